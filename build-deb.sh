@@ -15,7 +15,7 @@ mkdir -p $folder_name
 cp -r DEBIAN/ $folder_name
 bin_dir="$folder_name/usr/bin"
 mkdir -p $bin_dir
-go build -ldflags "-linkmode external -extldflags -static" -o ducknetview cmd/ducknetview/main.go
+go build -ldflags "-linkmode external -extldflags -static -X github.com/nexusriot/ducknetview/internal/version.Version=$version" -o ducknetview ./cmd/ducknetview
 
 mv ducknetview $bin_dir
 sed -i "s/_version_/$version/g" $folder_name/DEBIAN/control
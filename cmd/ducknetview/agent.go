@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+
+	"github.com/nexusriot/ducknetview/internal/agent"
+	"github.com/nexusriot/ducknetview/internal/metrics"
+	"github.com/nexusriot/ducknetview/pkg/probe/demo"
+)
+
+// installAgent implements `ducknetview install-agent [flags] -- AGENT ARGS...`.
+func installAgent(args []string) error {
+	fs := flag.NewFlagSet("install-agent", flag.ExitOnError)
+	launchd := fs.Bool("launchd", runtime.GOOS == "darwin", "write a launchd plist instead of a systemd unit")
+	out := fs.String("o", "", "output path (default: system location for the service manager)")
+	printOnly := fs.Bool("print", false, "print the service definition to stdout instead of writing it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: ducknetview install-agent [flags] -- AGENT ARGS...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	agentArgs := fs.Args()
+	if len(agentArgs) == 0 {
+		return errors.New("install-agent: no agent arguments given after --")
+	}
+	if !hasFlag(agentArgs, "metrics-addr") && !hasFlag(agentArgs, "config") {
+		return errors.New("install-agent: the agent only serves metrics; give it --metrics-addr ADDR (or a --config setting metrics_addr)")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+
+	content := agent.SystemdUnit(exe, agentArgs)
+	path := filepath.Join("/etc/systemd/system", agent.ServiceName+".service")
+	next := fmt.Sprintf("systemctl daemon-reload && systemctl enable --now %s", agent.ServiceName)
+	if *launchd {
+		content = agent.LaunchdPlist(exe, agentArgs)
+		path = filepath.Join("/Library/LaunchDaemons", agent.LaunchdName+".plist")
+		next = "launchctl load -w " + path
+	}
+	if *out != "" {
+		path = *out
+	}
+
+	if *printOnly {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s\nenable with: %s\n", path, next)
+	return nil
+}
+
+// hasFlag reports whether args set the flag name, as -name or --name, with
+// its value after "=" or as the next argument.
+func hasFlag(args []string, name string) bool {
+	for _, a := range args {
+		if a == "--" {
+			break
+		}
+		a = strings.TrimLeft(a, "-")
+		if a == name || strings.HasPrefix(a, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// runAgent implements --agent: it serves metrics at addr, without the UI,
+// until interrupted or stopped by the service manager.
+func runAgent(addr string, d *demo.Host) error {
+	if addr == "" {
+		return errors.New("--agent: no --metrics-addr given, and the config sets no metrics_addr")
+	}
+	e := metrics.New()
+	if d != nil {
+		e.Net, e.Ports, e.Procs = d, d, d
+	}
+	if err := e.ListenAndServe(addr); err != nil {
+		return err
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+	return nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestHasFlag(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"--metrics-addr", ":9187"}, true},
+		{[]string{"-metrics-addr=:9187"}, true},
+		{[]string{"--kiosk", "--metrics-addr=127.0.0.1:9187"}, true},
+		{[]string{"--kiosk"}, false},
+		{[]string{"--metrics-address", ":9187"}, false},
+		{[]string{"--", "--metrics-addr"}, false},
+	}
+	for _, tt := range tests {
+		if got := hasFlag(tt.args, "metrics-addr"); got != tt.want {
+			t.Errorf("hasFlag(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
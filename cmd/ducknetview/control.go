@@ -0,0 +1,470 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/netip"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const controlHelp = `commands:
+  iface [NAME]                    select the interface later commands are about; "iface -" drops it
+  filter [TEXT]                   keep only rows containing TEXT, any case; "filter -" drops it
+  show TABLE [csv|json]           print a table: ifaces, ports, procs, conns or flows
+  export TABLE [csv|json] [PATH]  write a table to PATH, by default ducknetview-TABLE-TIME.csv
+  help
+  quit`
+
+// controlProbes are what a control session reads; the host's, or those of
+// --demo.
+type controlProbes interface {
+	probe.Sampler
+	probe.PortLister
+	probe.ProcLister
+	probe.ConnLister
+	probe.FlowLister
+}
+
+// hostProbes are this host's probes, sampling interfaces with a sampler of
+// their own.
+type hostProbes struct {
+	*probe.NetSampler
+	probe.Host
+}
+
+// minRateWindow is the shortest time interface rates are taken over: a
+// sample right behind another has rates over microseconds, which is noise.
+const minRateWindow = time.Second
+
+// snapshotMaxAge is how long a sample serves to read the interfaces'
+// addresses from.
+const snapshotMaxAge = 5 * time.Second
+
+// controlSession is one reader of commands: what it selected and filtered
+// on stays until it changes them, as in the UI.
+type controlSession struct {
+	probes controlProbes
+	iface  string
+	filter string
+	now    func() time.Time
+
+	snap   probe.NetSnapshot // the last sample, taken at snapAt
+	snapAt time.Time
+}
+
+// controlTable is the rows of one table, with the UI's export columns.
+type controlTable struct {
+	name   string
+	header []string
+	rows   [][]string
+}
+
+// runControl reads commands from stdin when where is "-", or else from each
+// client of a Unix socket at where in turn, and answers each with its
+// output and a line starting "ok", or a line starting "error:". Closing
+// the input or sending quit ends a session; the socket is served until
+// interrupted.
+func runControl(where string, p controlProbes) error {
+	if where == "-" {
+		return newControlSession(p).serve(os.Stdin, os.Stdout)
+	}
+
+	ln, err := listenPrivate(where)
+	if err != nil {
+		return err
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		ln.Close()
+	}()
+	defer os.Remove(where)
+
+	for {
+		c, err := ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		newControlSession(p).serve(c, c)
+		c.Close()
+	}
+}
+
+// listenPrivate listens on a Unix socket at where that only this user can
+// connect to, whatever the umask: it is made in a directory of its own,
+// given mode 0600 there and only then moved into place. A socket already
+// at where is replaced; anything else there is an error.
+func listenPrivate(where string) (*net.UnixListener, error) {
+	if fi, err := os.Lstat(where); err == nil {
+		if fi.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("--control: %s exists and is not a socket", where)
+		}
+		// left behind by an earlier run that was killed
+		os.Remove(where)
+	}
+	dir, err := os.MkdirTemp(filepath.Dir(where), ".ducknetview-control-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, "sock")
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// runControl removes it at where
+	ln.SetUnlinkOnClose(false)
+	if err = os.Chmod(tmp, 0o600); err == nil {
+		err = os.Rename(tmp, where)
+	}
+	if err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+func newControlSession(p controlProbes) *controlSession {
+	s := &controlSession{probes: p, now: time.Now}
+	// a first sample, so that the next one has rates
+	s.sample()
+	return s
+}
+
+// sample takes a fresh sample and keeps it.
+func (s *controlSession) sample() (probe.NetSnapshot, error) {
+	snap, err := s.probes.Sample()
+	if err != nil {
+		return snap, err
+	}
+	s.snap, s.snapAt = snap, s.now()
+	return snap, nil
+}
+
+// snapshot is the kept sample while recent enough to read the interfaces
+// from, or else a fresh one.
+func (s *controlSession) snapshot() (probe.NetSnapshot, error) {
+	if !s.snapAt.IsZero() && s.now().Sub(s.snapAt) < snapshotMaxAge {
+		return s.snap, nil
+	}
+	return s.sample()
+}
+
+// rates is a fresh sample with rates over at least minRateWindow, waiting
+// out the rest of it after a recent one.
+func (s *controlSession) rates() (probe.NetSnapshot, error) {
+	if d := minRateWindow - s.now().Sub(s.snapAt); d > 0 {
+		time.Sleep(d)
+	}
+	return s.sample()
+}
+
+func (s *controlSession) serve(r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) == 0 || strings.HasPrefix(f[0], "#") {
+			continue
+		}
+		if f[0] == "quit" {
+			fmt.Fprintln(w, "ok")
+			return nil
+		}
+		out, err := s.do(f[0], f[1:])
+		if err != nil {
+			fmt.Fprintln(w, "error:", err)
+			continue
+		}
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// do runs one command, returning its output up to and with the "ok" line.
+func (s *controlSession) do(cmd string, args []string) (string, error) {
+	switch cmd {
+	case "help":
+		return controlHelp + "\nok\n", nil
+
+	case "iface":
+		switch {
+		case len(args) == 0:
+			return "ok " + s.iface + "\n", nil
+		case args[0] == "-":
+			s.iface = ""
+			return "ok\n", nil
+		}
+		snap, err := s.snapshot()
+		if err != nil {
+			return "", err
+		}
+		if _, err := ifacePrefixes(snap, args[0]); err != nil {
+			return "", err
+		}
+		s.iface = args[0]
+		return "ok " + s.iface + "\n", nil
+
+	case "filter":
+		switch {
+		case len(args) == 0:
+			return "ok " + s.filter + "\n", nil
+		case args[0] == "-":
+			s.filter = ""
+			return "ok\n", nil
+		}
+		s.filter = strings.Join(args, " ")
+		return "ok " + s.filter + "\n", nil
+
+	case "show", "export":
+		if len(args) == 0 {
+			return "", fmt.Errorf("%s: which table? ifaces, ports, procs, conns or flows", cmd)
+		}
+		t, err := s.table(args[0])
+		if err != nil {
+			return "", err
+		}
+		format, path := "csv", ""
+		if cmd == "show" {
+			format = "json"
+		}
+		for _, a := range args[1:] {
+			if a == "csv" || a == "json" {
+				format = a
+			} else if cmd == "export" && path == "" {
+				path = a
+			} else {
+				return "", fmt.Errorf("%s: unexpected %q", cmd, a)
+			}
+		}
+		data := t.csv()
+		if format == "json" {
+			data = t.json()
+		}
+		if cmd == "show" {
+			return string(data) + "ok\n", nil
+		}
+		if path == "" {
+			path = fmt.Sprintf("ducknetview-%s-%s.%s", t.name, s.now().Format("20060102-150405"), format)
+		}
+		if err := exportFile(path, data); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("ok exported %d rows to %s\n", len(t.rows), path), nil
+	}
+	return "", fmt.Errorf("unknown command %q; try help", cmd)
+}
+
+// table reads the named table afresh, narrowed to the selected interface
+// and the filter.
+func (s *controlSession) table(name string) (controlTable, error) {
+	// one sample for both the interface's networks and the ifaces table
+	var snap probe.NetSnapshot
+	var err error
+	switch {
+	case name == "ifaces":
+		snap, err = s.rates()
+	case s.iface != "":
+		snap, err = s.snapshot()
+	}
+	if err != nil {
+		return controlTable{}, err
+	}
+	var on []netip.Prefix
+	if s.iface != "" {
+		if on, err = ifacePrefixes(snap, s.iface); err != nil {
+			return controlTable{}, err
+		}
+	}
+	onIface := func(addrs ...string) bool {
+		if s.iface == "" {
+			return true
+		}
+		for _, a := range addrs {
+			ip, err := netip.ParseAddr(a)
+			if err != nil {
+				continue
+			}
+			for _, p := range on {
+				if p.Contains(ip.Unmap()) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	host := func(local string) string {
+		ip, _ := probe.SplitLocal(local)
+		return ip
+	}
+	// flows of protocols without ports have bare addresses, which for
+	// IPv6 SplitLocal would cut at the last colon
+	flowHost := func(f probe.Flow, addr string) string {
+		switch f.Proto {
+		case "tcp", "udp", "udplite", "sctp", "dccp":
+			return host(addr)
+		}
+		return addr
+	}
+
+	var t controlTable
+	switch name {
+	case "ifaces":
+		t = controlTable{name: name, header: []string{"name", "kind", "up", "mtu", "mac", "addrs", "rx_bps", "tx_bps", "rx_bytes", "tx_bytes"}}
+		for _, ii := range snap.Ifaces {
+			if s.iface != "" && ii.Name != s.iface {
+				continue
+			}
+			t.add(s.filter, ii.Name, ii.Kind.String(), fmt.Sprint(ii.IsUp), fmt.Sprint(ii.MTU), ii.Hardware, strings.Join(ii.Addrs, " "),
+				fmt.Sprintf("%.0f", ii.RxBps), fmt.Sprintf("%.0f", ii.TxBps), fmt.Sprint(ii.RxTotal), fmt.Sprint(ii.TxTotal))
+		}
+
+	case "ports":
+		ports, err := s.probes.ListListening()
+		if err != nil {
+			return t, err
+		}
+		t = controlTable{name: name, header: []string{"proto", "local", "pid", "process"}}
+		for _, p := range ports {
+			// wildcard listeners are reachable on every interface
+			if !p.IsWildcard() && !onIface(host(p.Local)) {
+				continue
+			}
+			t.add(s.filter, p.Proto, p.Local, fmt.Sprint(p.PID), p.Process)
+		}
+
+	case "procs":
+		// processes aren't tied to an interface, so only the filter applies
+		procs, err := s.probes.TopProcsByConnections(0)
+		if err != nil {
+			return t, err
+		}
+		t = controlTable{name: name, header: []string{"pid", "name", "conns", "listen"}}
+		for _, p := range procs {
+			t.add(s.filter, fmt.Sprint(p.PID), p.Name, fmt.Sprint(p.ConnCount), fmt.Sprint(p.ListenCount))
+		}
+
+	case "conns":
+		conns, err := s.probes.ListConnections()
+		if err != nil {
+			return t, err
+		}
+		t = controlTable{name: name, header: []string{"proto", "local", "remote", "state", "pid", "process"}}
+		for _, c := range conns {
+			if !onIface(host(c.Local)) {
+				continue
+			}
+			t.add(s.filter, c.Proto, c.Local, c.Remote, c.Status, fmt.Sprint(c.PID), c.Process)
+		}
+
+	case "flows":
+		flows, err := s.probes.ListFlows()
+		if err != nil {
+			return t, err
+		}
+		t = controlTable{name: name, header: []string{"family", "proto", "state", "src", "dst", "reply_src", "reply_dst", "packets", "bytes", "reply_packets", "reply_bytes"}}
+		for _, f := range flows {
+			// forwarded flows pass the interface without its address
+			if !onIface(flowHost(f, f.Src), flowHost(f, f.Dst), flowHost(f, f.ReplySrc), flowHost(f, f.ReplyDst)) {
+				continue
+			}
+			t.add(s.filter, f.Family, f.Proto, f.State, f.Src, f.Dst, f.ReplySrc, f.ReplyDst,
+				fmt.Sprint(f.Packets), fmt.Sprint(f.Bytes), fmt.Sprint(f.ReplyPackets), fmt.Sprint(f.ReplyBytes))
+		}
+
+	default:
+		return t, fmt.Errorf("unknown table %q: ifaces, ports, procs, conns or flows", name)
+	}
+	return t, nil
+}
+
+// ifacePrefixes returns the networks of the interface's addresses in snap,
+// or an error when there is no such interface.
+func ifacePrefixes(snap probe.NetSnapshot, name string) ([]netip.Prefix, error) {
+	for _, ii := range snap.Ifaces {
+		if ii.Name != name {
+			continue
+		}
+		var out []netip.Prefix
+		for _, a := range ii.Addrs {
+			if p, err := netip.ParsePrefix(a); err == nil {
+				out = append(out, p.Masked())
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("no interface %q", name)
+}
+
+// exportFile writes data to a new file at path, which has to stay inside
+// the working directory, symlinks included: the socket may be served as
+// root to clients that shouldn't write just anywhere, or over a file.
+func exportFile(path string, data []byte) error {
+	if !filepath.IsLocal(path) {
+		return fmt.Errorf("export: %s is not a path inside the working directory", path)
+	}
+	root, err := os.OpenRoot(".")
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+	f, err := root.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// add appends a row when one of its cells contains filter.
+func (t *controlTable) add(filter string, row ...string) {
+	if filter != "" && !strings.Contains(strings.ToLower(strings.Join(row, "\x00")), strings.ToLower(filter)) {
+		return
+	}
+	t.rows = append(t.rows, row)
+}
+
+func (t controlTable) csv() []byte {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write(t.header)
+	w.WriteAll(t.rows)
+	return b.Bytes()
+}
+
+// json writes the rows as an array of objects keyed by column, on one line
+// so that a script reads a reply line by line.
+func (t controlTable) json() []byte {
+	objs := make([]map[string]string, 0, len(t.rows))
+	for _, r := range t.rows {
+		o := make(map[string]string, len(t.header))
+		for i, h := range t.header {
+			o[h] = r[i]
+		}
+		objs = append(objs, o)
+	}
+	b, _ := json.Marshal(objs)
+	return append(b, '\n')
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+	"github.com/nexusriot/ducknetview/pkg/probe/probetest"
+)
+
+// countingProbes counts the samples taken.
+type countingProbes struct {
+	*probetest.Probes
+	samples int
+}
+
+func (p *countingProbes) Sample() (probe.NetSnapshot, error) {
+	p.samples++
+	return p.Probes.Sample()
+}
+
+// newTestSession is a session on the probetest fixture whose clock moves a
+// second per reading, so that rates never wait.
+func newTestSession(t *testing.T) (*controlSession, *countingProbes) {
+	t.Helper()
+	f := probetest.Fixture()
+	f.FlowList = append(f.FlowList,
+		probe.Flow{Family: "ipv6", Proto: "icmpv6", Src: "fe80::5054:ff:fe12:3456", Dst: "fe80::1", ReplySrc: "fe80::1", ReplyDst: "fe80::5054:ff:fe12:3456"},
+		probe.Flow{Family: "ipv6", Proto: "icmpv6", Src: "2001:db8:9::1", Dst: "2001:db8:9::2", ReplySrc: "2001:db8:9::2", ReplyDst: "2001:db8:9::1"},
+	)
+	p := &countingProbes{Probes: f}
+	now := probetest.Epoch
+	s := &controlSession{probes: p, now: func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}}
+	s.sample()
+	return s, p
+}
+
+func TestControlOneSamplePerCommand(t *testing.T) {
+	s, p := newTestSession(t)
+	if _, err := s.do("iface", []string{"eth0"}); err != nil {
+		t.Fatal(err)
+	}
+	p.samples = 0
+	out, err := s.do("show", []string{"ifaces", "csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.samples != 1 {
+		t.Errorf("show ifaces took %d samples, want 1", p.samples)
+	}
+	if !strings.Contains(out, "\neth0,") || strings.Contains(out, "wlan0") {
+		t.Errorf("show ifaces on eth0:\n%s", out)
+	}
+}
+
+func TestControlFlowsOnIface(t *testing.T) {
+	s, _ := newTestSession(t)
+	s.do("iface", []string{"eth0"})
+	tab, err := s.table("flows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var srcs []string
+	for _, r := range tab.rows {
+		srcs = append(srcs, r[3])
+	}
+	got := strings.Join(srcs, " ")
+	for _, want := range []string{"192.168.1.10:51234", "fe80::5054:ff:fe12:3456"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("flows on eth0 %q lack %s", got, want)
+		}
+	}
+	if strings.Contains(got, "2001:db8:9::1") {
+		t.Errorf("flows on eth0 %q have one of another network", got)
+	}
+}
+
+func TestControlExportPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Symlink(os.TempDir(), "out"); err != nil {
+		t.Fatal(err)
+	}
+	s, _ := newTestSession(t)
+
+	if _, err := s.do("export", []string{"ports", "csv", "ports.csv"}); err != nil {
+		t.Fatalf("export to the working directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ports.csv")); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"ports.csv", "../ports.csv", filepath.Join(dir, "abs.csv"), "out/ports.csv"} {
+		if _, err := s.do("export", []string{"ports", "csv", path}); err == nil {
+			t.Errorf("export to %s: no error", path)
+		}
+	}
+}
+
+func TestListenPrivate(t *testing.T) {
+	where := filepath.Join(t.TempDir(), "ctl.sock")
+	ln, err := listenPrivate(where)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	fi, err := os.Stat(where)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode %v, want 0600", perm)
+	}
+}
+
+func TestListenPrivateKeepsFiles(t *testing.T) {
+	where := filepath.Join(t.TempDir(), "notes")
+	if err := os.WriteFile(where, []byte("keep me\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if ln, err := listenPrivate(where); err == nil {
+		ln.Close()
+		t.Fatal("listened over a regular file")
+	}
+	if b, err := os.ReadFile(where); err != nil || string(b) != "keep me\n" {
+		t.Errorf("the file is now %q, %v", b, err)
+	}
+
+	// a socket left by a killed run is replaced
+	sock := filepath.Join(t.TempDir(), "ctl.sock")
+	old, err := listenPrivate(sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old.Close()
+	ln, err := listenPrivate(sock)
+	if err != nil {
+		t.Fatalf("stale socket: %v", err)
+	}
+	ln.Close()
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/nexusriot/ducknetview/internal/extip"
+	"github.com/nexusriot/ducknetview/internal/ui"
+	"github.com/nexusriot/ducknetview/pkg/probe/demo"
+)
+
+// demoOptions turns opts into those of --demo: every probe fed from d, a
+// made-up host, and nothing that reads or writes this one's files or asks
+// the network.
+func demoOptions(opts ui.Options, d *demo.Host) ui.Options {
+	opts.Probes = ui.Probes{
+		Net: d, Ports: d, Inspect: d, Procs: d, Stop: d, Block: d, Detail: d,
+		ConnRate: d, ICMP: d, Routes: d, Rules: d, RA: d, Conns: d, ConnOpts: d,
+		Metered: d, BGP: d, ProcBW: d, PeerBW: d, Tunnels: d, Firewall: d,
+		Casts: d, Softnet: d, Flows: d, Queues: d, Wifi: d, Link: d,
+		DHCP: d, Eyeballs: d, Neigh: d, RDNS: d, Geo: d, TimeSync: d, Ping: d,
+		Trace: d, DNSCache: d, DNSConf: d, Sniff: d, Ephemeral: d,
+	}
+	opts.ExternalIP = extip.Static{V4: "198.51.100.23", V6: "2001:db8:5::23"}
+	opts.CheckUpdate = false
+	opts.GeoIP = nil
+	opts.Reputation = nil
+	opts.IPHistory = nil
+	opts.PortHistory = nil
+	opts.Bandwidth = nil
+	opts.Notes = nil
+	opts.ExecProbes = nil
+	return opts
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/version"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// report is the document printed by --json.
+type report struct {
+	Version    string            `json:"version"`
+	TakenAt    time.Time         `json:"taken_at"`
+	Hostname   string            `json:"hostname"`
+	Uptime     int64             `json:"uptime_seconds"`
+	Interfaces []reportIface     `json:"interfaces"`
+	Listening  []reportPort      `json:"listening"`
+	Processes  []reportProc      `json:"processes"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+type reportIface struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Up      bool     `json:"up"`
+	MTU     int      `json:"mtu"`
+	MAC     string   `json:"mac,omitempty"`
+	Addrs   []string `json:"addrs"`
+	RxBps   float64  `json:"rx_bps"`
+	TxBps   float64  `json:"tx_bps"`
+	RxPps   float64  `json:"rx_pps"`
+	TxPps   float64  `json:"tx_pps"`
+	RxBytes uint64   `json:"rx_bytes"`
+	TxBytes uint64   `json:"tx_bytes"`
+	Speed   int      `json:"speed_mbps,omitempty"`
+	RxErrs  uint64   `json:"rx_errors"`
+	TxErrs  uint64   `json:"tx_errors"`
+	RxDrops uint64   `json:"rx_dropped"`
+	TxDrops uint64   `json:"tx_dropped"`
+}
+
+type reportPort struct {
+	Proto   string `json:"proto"`
+	Local   string `json:"local"`
+	PID     int32  `json:"pid"`
+	Process string `json:"process"`
+}
+
+type reportProc struct {
+	PID         int32  `json:"pid"`
+	Name        string `json:"name"`
+	Connections int    `json:"connections"`
+	Listening   int    `json:"listening"`
+}
+
+// headlessProbes are what --json reads.
+type headlessProbes interface {
+	probe.Sampler
+	probe.PortLister
+	probe.ProcLister
+}
+
+// runHeadless runs the probes once, without the UI, and writes a JSON
+// report to w, leaving out interfaces of the hidden kinds. Interfaces are
+// sampled twice, a second apart, so the rates are real; a probe that fails
+// is reported under "errors" and the rest of the document is still written.
+func runHeadless(w io.Writer, p headlessProbes, hidden []probe.IfaceKind) error {
+	r := report{
+		Version:    version.Version,
+		Interfaces: []reportIface{},
+		Listening:  []reportPort{},
+		Processes:  []reportProc{},
+		Errors:     map[string]string{},
+	}
+
+	snap, err := p.Sample()
+	if err == nil {
+		time.Sleep(time.Second)
+		snap, err = p.Sample()
+	}
+	if err != nil {
+		r.Errors["interfaces"] = err.Error()
+	}
+	r.TakenAt, r.Hostname, r.Uptime = snap.TakenAt, snap.Hostname, int64(snap.Uptime.Seconds())
+	if r.TakenAt.IsZero() {
+		r.TakenAt = time.Now()
+	}
+	for _, ii := range snap.Ifaces {
+		if slices.Contains(hidden, ii.Kind) {
+			continue
+		}
+		addrs := ii.Addrs
+		if addrs == nil {
+			addrs = []string{}
+		}
+		r.Interfaces = append(r.Interfaces, reportIface{
+			Name: ii.Name, Kind: ii.Kind.String(), Up: ii.IsUp, MTU: ii.MTU, MAC: ii.Hardware, Addrs: addrs,
+			RxBps: ii.RxBps, TxBps: ii.TxBps, RxPps: ii.RxPps, TxPps: ii.TxPps, RxBytes: ii.RxTotal, TxBytes: ii.TxTotal, Speed: ii.Speed,
+			RxErrs: ii.Errin, TxErrs: ii.Errout, RxDrops: ii.Dropin, TxDrops: ii.Dropout,
+		})
+	}
+
+	ports, err := p.ListListening()
+	if err != nil {
+		r.Errors["listening"] = err.Error()
+	}
+	for _, lp := range ports {
+		r.Listening = append(r.Listening, reportPort{Proto: lp.Proto, Local: lp.Local, PID: lp.PID, Process: lp.Process})
+	}
+
+	procs, err := p.TopProcsByConnections(0)
+	if err != nil {
+		r.Errors["processes"] = err.Error()
+	}
+	for _, pn := range procs {
+		r.Processes = append(r.Processes, reportProc{PID: pn.PID, Name: pn.Name, Connections: pn.ConnCount, Listening: pn.ListenCount})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+	"github.com/nexusriot/ducknetview/pkg/probe/probetest"
+)
+
+func TestRunHeadless(t *testing.T) {
+	f := probetest.Fixture()
+	var buf bytes.Buffer
+	if err := runHeadless(&buf, f, []probe.IfaceKind{probe.IfaceLoopback, probe.IfaceVeth}); err != nil {
+		t.Fatal(err)
+	}
+	var r report
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Hostname != f.Snapshot.Hostname {
+		t.Errorf("host %q, want the probes' %q", r.Hostname, f.Snapshot.Hostname)
+	}
+	var names []string
+	for _, ii := range r.Interfaces {
+		names = append(names, ii.Name)
+	}
+	if want := []string{"eth0", "wlan0", "docker0"}; !slices.Equal(names, want) {
+		t.Errorf("interfaces %v, want %v", names, want)
+	}
+	if len(r.Listening) != len(f.Ports) || len(r.Processes) != len(f.Procs) || len(r.Errors) > 0 {
+		t.Errorf("%d ports, %d processes, errors %v; the probes have %d, %d",
+			len(r.Listening), len(r.Processes), r.Errors, len(f.Ports), len(f.Procs))
+	}
+}
@@ -1,15 +1,289 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	_ "github.com/gdamore/tcell/v2" // keep tcell in the build; Bubble Tea already owns the terminal
+	"github.com/nexusriot/ducknetview/internal/blocklist"
+	"github.com/nexusriot/ducknetview/internal/config"
+	"github.com/nexusriot/ducknetview/internal/execprobe"
+	"github.com/nexusriot/ducknetview/internal/extip"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/iphistory"
+	"github.com/nexusriot/ducknetview/internal/metrics"
+	"github.com/nexusriot/ducknetview/internal/notes"
+	"github.com/nexusriot/ducknetview/internal/porthistory"
+	"github.com/nexusriot/ducknetview/internal/reputation"
+	"github.com/nexusriot/ducknetview/internal/store"
 	"github.com/nexusriot/ducknetview/internal/ui"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+	"github.com/nexusriot/ducknetview/pkg/probe/demo"
 )
 
 func main() {
-	m := ui.NewModel()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "install-agent":
+			if err := installAgent(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		case "self-update":
+			if err := selfUpdate(); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	lang := flag.String("lang", "", "UI language (en, de, ru); defaults to $LANG")
+	theme := flag.String("theme", "", "colours: "+strings.Join(ui.ThemeNames(), ", ")+" (default: the config's, else dark; mono if $NO_COLOR is set)")
+	summary := flag.Bool("summary", false, "print a session summary to stdout on exit")
+	quit := flag.String("quit", "off", "what q does: off, immediate or confirm")
+	idleDim := flag.Duration("idle-dim", 0, "dim the UI and slow refreshes after this long without input (e.g. 5m); 0 disables")
+	checkUpdate := flag.Bool("check-update", false, "check GitHub releases for a newer version")
+	extIP := flag.String("ext-ip", extip.DefaultProviders, "external IP providers, tried in order: comma-separated http(s) URLs returning the bare address, ipify, icanhazip, ifconfig.me, dns:google, dns:opendns, stun or stun:HOST:PORT")
+	metered := flag.String("metered", "auto", "hold back external IP, update and reputation lookups: auto (ask NetworkManager), on or off")
+	kiosk := flag.Bool("kiosk", false, "read-only dashboard mode: no mutating actions, filters reset on tab change")
+	var blocklists stringList
+	flag.Var(&blocklists, "blocklist", "hosts-format or domain/IP list to flag connections against (repeatable)")
+	var execProbes stringList
+	flag.Var(&execProbes, "exec-probe", "name=interval:command run via sh, printing JSON objects one per line; shown on the Custom tab (repeatable)")
+	var pings stringList
+	flag.Var(&pings, "ping", "host to ping on the Latency tab, next to the default gateway and 1.1.1.1 (repeatable)")
+	ipHistoryPath := flag.String("ip-history", "", "append external IP changes to this file and list earlier ones on Overview")
+	portHistoryPath := flag.String("port-history", "", "remember the ports each program listens on in this file and list earlier ones in the process details")
+	bandwidthPath := flag.String("bandwidth-db", "", `file keeping per-interface traffic totals across runs, shown on the History tab (default: bandwidth.json in the user config dir); "off" disables it`)
+	notesPath := flag.String("notes", "", "file for host/port notes (default: notes.json in the user config dir)")
+	repProvider := flag.String("reputation", "", "enable IP reputation lookups (r) with this provider: abuseipdb (key in $ABUSEIPDB_API_KEY)")
+	watchLAN := flag.Bool("watch-lan", false, "log every MAC address newly seen in the ARP/NDP neighbor table to the Events tab")
+	captureDNS := flag.Bool("capture-dns", false, "list the DNS queries seen on the selected interface on the Connections tab; runs tcpdump, needs root or CAP_NET_RAW")
+	metricsAddr := flag.String("metrics-addr", "", "serve Prometheus metrics at http://ADDR/metrics (e.g. :9187) while the UI runs")
+	agentMode := flag.Bool("agent", false, "serve --metrics-addr only, without the UI, until interrupted; how install-agent's service runs")
+	cfgPath := flag.String("config", "", "config file (default: config.toml in the user config dir, if present)")
+	jsonOut := flag.Bool("json", false, "print interfaces, listening ports and processes once as JSON and exit, without the UI")
+	once := flag.Bool("once", false, "same as --json")
+	control := flag.String("control", "", `read commands (select an interface, filter, show or export a table) from stdin with "-", or from clients of a Unix socket at this path, without the UI; "help" lists them`)
+	demoMode := flag.Bool("demo", false, "show a made-up host with lively traffic instead of this one, for screenshots and trying the UI out")
+	flag.Parse()
+
+	cfg, err := loadConfig(*cfgPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// flags given on the command line win over the file
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if cfg.ExtIP != "" && !set["ext-ip"] {
+		*extIP = cfg.ExtIP
+	}
+	if cfg.ExtIPHistory != "" && !set["ip-history"] {
+		*ipHistoryPath = cfg.ExtIPHistory
+	}
+	if cfg.PortHistory != "" && !set["port-history"] {
+		*portHistoryPath = cfg.PortHistory
+	}
+	if cfg.BandwidthDB != "" && !set["bandwidth-db"] {
+		*bandwidthPath = cfg.BandwidthDB
+	}
+	if cfg.WatchLAN && !set["watch-lan"] {
+		*watchLAN = true
+	}
+	if cfg.CaptureDNS && !set["capture-dns"] {
+		*captureDNS = true
+	}
+	if cfg.MetricsAddr != "" && !set["metrics-addr"] {
+		*metricsAddr = cfg.MetricsAddr
+	}
+	var demoHost *demo.Host
+	if *demoMode {
+		demoHost = demo.New()
+		// made-up traffic stays out of the files kept across sessions
+		*bandwidthPath, *ipHistoryPath, *portHistoryPath = "off", "", ""
+	}
+	for name, cc := range cfg.Caches {
+		if err := probe.ConfigureCache(name, cc); err != nil {
+			log.Fatal(fmt.Errorf("config: %w", err))
+		}
+	}
+	var hideKinds []probe.IfaceKind
+	for _, k := range cfg.HideKinds {
+		kind, err := probe.ParseIfaceKind(k)
+		if err != nil {
+			log.Fatal(fmt.Errorf("config: hide_kinds: %w", err))
+		}
+		hideKinds = append(hideKinds, kind)
+	}
+	// what --json and --control read: this host, or the made-up one
+	var host controlProbes = hostProbes{probe.NewNetSampler(), probe.Host{}}
+	if demoHost != nil {
+		host = demoHost
+	}
+	if *jsonOut || *once {
+		if err := runHeadless(os.Stdout, host, hideKinds); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *agentMode {
+		if err := runAgent(*metricsAddr, demoHost); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *control != "" {
+		if err := runControl(*control, host); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if !set["theme"] {
+		*theme = cfg.Theme
+		// https://no-color.org: only the flag asks for colours over it
+		if os.Getenv("NO_COLOR") != "" {
+			*theme = "mono"
+		}
+	}
+	if err := ui.SetTheme(*theme, cfg.Colors); err != nil {
+		if set["theme"] {
+			log.Fatal(err)
+		}
+		log.Fatal(fmt.Errorf("config: %w", err))
+	}
+	units, err := ui.ParseUnits(cfg.Units)
+	if err != nil {
+		log.Fatal(fmt.Errorf("config: %w", err))
+	}
+	if cfg.DefaultTab != "" {
+		if err := ui.CheckTab(cfg.DefaultTab); err != nil {
+			log.Fatal(fmt.Errorf("config: default_tab: %w", err))
+		}
+	}
+
+	quitMode, err := ui.ParseQuitMode(*quit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	meteredMode, err := ui.ParseMeteredMode(*metered)
+	if err != nil {
+		log.Fatal(err)
+	}
+	extIPProvider, err := extip.New(*extIP, cfg.ExtIPTimeout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *lang == "" {
+		*lang = i18n.Detect()
+	}
+	i18n.Set(*lang)
+
+	var bl *blocklist.List
+	if len(blocklists) > 0 {
+		if bl, err = blocklist.Load(blocklists...); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *notesPath == "" {
+		if *notesPath, err = notes.DefaultPath(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	ns, err := notes.Open(*notesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var bandwidth *store.DB
+	if *bandwidthPath != "off" {
+		if *bandwidthPath == "" {
+			if *bandwidthPath, err = store.DefaultPath(); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if bandwidth, err = store.Open(*bandwidthPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var ipHistory *iphistory.Log
+	if *ipHistoryPath != "" {
+		if ipHistory, err = iphistory.Open(*ipHistoryPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var portHistory *porthistory.Log
+	if *portHistoryPath != "" {
+		if portHistory, err = porthistory.Open(*portHistoryPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	specs := cfg.ExecProbes
+	for _, e := range execProbes {
+		sp, err := execprobe.ParseSpec(e)
+		if err != nil {
+			log.Fatal(err)
+		}
+		specs = append(specs, sp)
+	}
+
+	var rep reputation.Checker
+	if *repProvider != "" {
+		if rep, err = reputation.New(*repProvider, os.Getenv); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *metricsAddr != "" {
+		e := metrics.New()
+		if demoHost != nil {
+			e.Net, e.Ports, e.Procs = demoHost, demoHost, demoHost
+		}
+		if err := e.ListenAndServe(*metricsAddr); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	opts := ui.Options{
+		Quit:         quitMode,
+		IdleDim:      *idleDim,
+		Kiosk:        *kiosk,
+		CheckUpdate:  *checkUpdate,
+		Metered:      meteredMode,
+		ExternalIP:   extIPProvider,
+		IPHistory:    ipHistory,
+		PortHistory:  portHistory,
+		Bandwidth:    bandwidth,
+		Blocklist:    bl,
+		Reputation:   rep,
+		Notes:        ns,
+		ExecProbes:   specs,
+		Refresh:      cfg.Refresh,
+		SlowRefresh:  cfg.SlowRefresh,
+		PortsRefresh: cfg.PortsRefresh,
+		ProcsRefresh: cfg.ProcsRefresh,
+		ExtIPEvery:   cfg.ExtIPEvery,
+		StartTab:     cfg.DefaultTab,
+		HideKinds:    hideKinds,
+		HideVirtual:  cfg.HideVirtual,
+		Units:        units,
+		GeoIP:        cfg.GeoIP,
+		WatchLAN:     *watchLAN,
+		CaptureDNS:   *captureDNS,
+		PingTargets:  append(cfg.Ping, pings...),
+	}
+	if demoHost != nil {
+		opts = demoOptions(opts, demoHost)
+	}
+	m := ui.NewModel(opts)
 
 	p := tea.NewProgram(
 		m,
@@ -17,7 +291,36 @@ func main() {
 		tea.WithMouseAllMotion(),
 	)
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		log.Fatal(err)
 	}
+	// the UI saves it once a minute
+	if err := bandwidth.Save(); err != nil {
+		log.Print(err)
+	}
+
+	if *summary {
+		if fm, ok := final.(ui.Model); ok {
+			fmt.Print(fm.Summary())
+		}
+	}
+}
+
+// loadConfig reads the --config file, or the default one if it exists.
+func loadConfig(path string) (config.Config, error) {
+	if path == "" {
+		return config.LoadDefault()
+	}
+	return config.Load(path)
+}
+
+// stringList collects a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
 }
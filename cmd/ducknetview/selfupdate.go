@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/nexusriot/ducknetview/internal/update"
+	"github.com/nexusriot/ducknetview/internal/version"
+)
+
+// selfUpdate implements `ducknetview self-update`.
+func selfUpdate() error {
+	rel, err := update.Latest()
+	if err != nil {
+		return err
+	}
+	if !update.Newer(rel.Version(), version.Version) {
+		fmt.Printf("ducknetview %s is up to date\n", version.Version)
+		return nil
+	}
+
+	asset, ok := rel.BinaryAsset()
+	if !ok {
+		return fmt.Errorf("release %s has no binary for %s/%s", rel.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+
+	fmt.Printf("updating %s -> %s (%s)\n", version.Version, rel.Version(), asset.Name)
+	if err := update.Apply(rel, asset, exe); err != nil {
+		return err
+	}
+	fmt.Println("done")
+	return nil
+}
@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ServiceName = "ducknetview-agent"
+	LaunchdName = "io.github.nexusriot.ducknetview-agent"
+
+	// AgentFlag runs ducknetview without the UI, serving metrics only: a
+	// service has no terminal to draw on.
+	AgentFlag = "--agent"
+)
+
+// command is the service's argv: exe in agent mode, then args.
+func command(exe string, args []string) []string {
+	return append([]string{exe, AgentFlag}, args...)
+}
+
+// SystemdUnit renders a hardened systemd service running exe with args in
+// agent mode.
+// The service gets only the capabilities needed to see other processes'
+// sockets; everything else on the filesystem is read-only.
+func SystemdUnit(exe string, args []string) string {
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=ducknetview network monitoring agent\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("Wants=network-online.target\n\n")
+
+	b.WriteString("[Service]\n")
+	b.WriteString("ExecStart=" + systemdQuote(command(exe, args)) + "\n")
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString("RestartSec=5s\n")
+	b.WriteString("DynamicUser=yes\n")
+	b.WriteString("CapabilityBoundingSet=CAP_NET_RAW CAP_DAC_READ_SEARCH CAP_SYS_PTRACE\n")
+	b.WriteString("AmbientCapabilities=CAP_NET_RAW CAP_DAC_READ_SEARCH CAP_SYS_PTRACE\n")
+	b.WriteString("NoNewPrivileges=yes\n")
+	b.WriteString("ProtectSystem=strict\n")
+	b.WriteString("ProtectHome=yes\n")
+	b.WriteString("PrivateTmp=yes\n")
+	b.WriteString("PrivateDevices=yes\n")
+	b.WriteString("ProtectKernelTunables=yes\n")
+	b.WriteString("ProtectKernelModules=yes\n")
+	b.WriteString("ProtectControlGroups=yes\n")
+	b.WriteString("RestrictAddressFamilies=AF_INET AF_INET6 AF_UNIX AF_NETLINK\n")
+	b.WriteString("RestrictNamespaces=yes\n")
+	b.WriteString("LockPersonality=yes\n")
+	b.WriteString("MemoryDenyWriteExecute=yes\n")
+	b.WriteString("SystemCallArchitectures=native\n\n")
+
+	b.WriteString("[Install]\n")
+	b.WriteString("WantedBy=multi-user.target\n")
+	return b.String()
+}
+
+// LaunchdPlist renders a launchd job definition running exe with args in
+// agent mode.
+func LaunchdPlist(exe string, args []string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString(`<plist version="1.0">` + "\n<dict>\n")
+	b.WriteString("\t<key>Label</key>\n\t<string>" + LaunchdName + "</string>\n")
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, a := range command(exe, args) {
+		b.WriteString("\t\t<string>" + xmlEscape(a) + "</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	b.WriteString("\t<key>ProcessType</key>\n\t<string>Background</string>\n")
+	b.WriteString("\t<key>LowPriorityIO</key>\n\t<true/>\n")
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+func systemdQuote(argv []string) string {
+	out := make([]string, len(argv))
+	for i, a := range argv {
+		if a != "" && !strings.ContainsAny(a, " \t\"'\\$%") {
+			out[i] = a
+			continue
+		}
+		a = strings.ReplaceAll(a, `\`, `\\`)
+		a = strings.ReplaceAll(a, `"`, `\"`)
+		a = strings.ReplaceAll(a, `$`, `$$`)
+		a = strings.ReplaceAll(a, `%`, `%%`)
+		out[i] = fmt.Sprintf(`"%s"`, a)
+	}
+	return strings.Join(out, " ")
+}
+
+func xmlEscape(s string) string {
+	r := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+	return r.Replace(s)
+}
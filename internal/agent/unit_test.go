@@ -0,0 +1,23 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestServiceRunsAgentMode(t *testing.T) {
+	args := []string{"--metrics-addr", ":9187", "--config", "/etc/duck net.toml"}
+	tests := []struct {
+		name, got, want string
+	}{
+		{"systemd", SystemdUnit("/usr/bin/ducknetview", args),
+			"\nExecStart=/usr/bin/ducknetview --agent --metrics-addr :9187 --config \"/etc/duck net.toml\"\n"},
+		{"launchd", LaunchdPlist("/usr/bin/ducknetview", args),
+			"<string>/usr/bin/ducknetview</string>\n\t\t<string>--agent</string>\n\t\t<string>--metrics-addr</string>\n"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(tt.got, tt.want) {
+			t.Errorf("%s: no %q in\n%s", tt.name, tt.want, tt.got)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Package blocklist loads hosts-format and plain domain/IP blocklists and
+// matches remote endpoints against them.
+package blocklist
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// List is a merged set of blocked addresses, networks and domains. Each
+// entry remembers which file it came from.
+type List struct {
+	ips     map[string]string
+	nets    []blockedNet
+	domains map[string]string
+}
+
+type blockedNet struct {
+	n      *net.IPNet
+	source string
+}
+
+// Load reads and merges the given files. Lines may be
+//
+//	0.0.0.0 ads.example.com tracker.example.com   (hosts format)
+//	ads.example.com                               (domain list)
+//	203.0.113.7 or 198.51.100.0/24                (addresses)
+//
+// with # comments. Subdomains of a listed domain match as well.
+func Load(paths ...string) (*List, error) {
+	l := &List{ips: map[string]string{}, domains: map[string]string{}}
+	for _, p := range paths {
+		if err := l.load(p); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func (l *List) load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("blocklist: %w", err)
+	}
+	defer f.Close()
+
+	src := filepath.Base(path)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fs := strings.Fields(line)
+		switch {
+		case len(fs) == 0:
+		case len(fs) == 1:
+			l.addEntry(fs[0], src)
+		case net.ParseIP(fs[0]) != nil:
+			// hosts format: the address is just the sinkhole
+			for _, d := range fs[1:] {
+				l.addDomain(d, src)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("blocklist: %s: %w", path, err)
+	}
+	return nil
+}
+
+func (l *List) addEntry(s, src string) {
+	if ip := net.ParseIP(s); ip != nil {
+		l.ips[ip.String()] = src
+		return
+	}
+	if _, n, err := net.ParseCIDR(s); err == nil {
+		l.nets = append(l.nets, blockedNet{n: n, source: src})
+		return
+	}
+	l.addDomain(s, src)
+}
+
+func (l *List) addDomain(d, src string) {
+	d = normalize(d)
+	switch d {
+	case "", "localhost", "localhost.localdomain", "local", "broadcasthost":
+		return
+	}
+	l.domains[d] = src
+}
+
+func normalize(d string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+}
+
+// Len returns the number of entries.
+func (l *List) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.ips) + len(l.nets) + len(l.domains)
+}
+
+// HasDomains reports whether matching needs host names, i.e. whether
+// callers should bother with reverse lookups.
+func (l *List) HasDomains() bool {
+	return l != nil && len(l.domains) > 0
+}
+
+// Match checks ip and its host names. It returns the matching entry and the
+// file it came from.
+func (l *List) Match(ip string, names []string) (entry, source string, ok bool) {
+	if l == nil {
+		return "", "", false
+	}
+	if parsed := net.ParseIP(ip); parsed != nil {
+		if src, ok := l.ips[parsed.String()]; ok {
+			return parsed.String(), src, true
+		}
+		for _, bn := range l.nets {
+			if bn.n.Contains(parsed) {
+				return bn.n.String(), bn.source, true
+			}
+		}
+	}
+	for _, n := range names {
+		n = normalize(n)
+		// walk up the labels: a.ads.example.com, ads.example.com, example.com
+		for d := n; d != ""; {
+			if src, ok := l.domains[d]; ok {
+				return d, src, true
+			}
+			i := strings.IndexByte(d, '.')
+			if i < 0 {
+				break
+			}
+			d = d[i+1:]
+		}
+	}
+	return "", "", false
+}
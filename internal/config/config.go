@@ -0,0 +1,346 @@
+// Package config loads the optional config file,
+// ~/.config/ducknetview/config.toml on Linux:
+//
+//	refresh = "1s"          # interface rates, charts; 0.5s to 30s, + and - change it
+//	slow_refresh = "5s"     # routes, connections and such
+//	ports_refresh = "5s"    # listening ports (default: slow_refresh)
+//	procs_refresh = "5s"    # processes (default: slow_refresh)
+//	default_tab = "ports"   # a tab name or number
+//	hide_kinds = ["veth"]   # loopback, docker, bridge, veth, tuntap, wireguard, physical, unknown
+//	hide_virtual = true     # start with loopback, veth, docker and bridges hidden (v)
+//	theme = "dark"          # dark, light, high-contrast or mono
+//	units = "bits"          # rates in bytes (the default) or bits per second (u)
+//	watch_lan = true        # log new MAC addresses on the LAN to Events
+//	capture_dns = true      # list DNS queries seen on the selected interface (tcpdump)
+//	metrics_addr = ":9187"  # serve Prometheus metrics
+//	ping = ["nas.lan"]      # pinged on the Latency tab, with any --ping hosts
+//	bandwidth_db = "/var/lib/ducknetview/bandwidth.json" # traffic totals for the History tab, or "off"
+//	port_history = "/var/tmp/port-history.jsonl" # ports each program listened on, kept across sessions
+//
+//	[external_ip]
+//	providers = ["ipify", "stun"] # tried in order: http(s) URLs, ipify, icanhazip, ifconfig.me, dns:google, dns:opendns, stun, stun:HOST:PORT
+//	timeout = "4s"          # per provider
+//	every = "30s"
+//	history = "/var/tmp/ip-history.jsonl" # log of changes, kept across sessions
+//
+//	[geoip]
+//	license_key = "…"       # MaxMind license key; without it the file is used as is
+//	edition = "GeoLite2-City"
+//	path = "/var/lib/GeoIP/GeoLite2-City.mmdb"
+//	max_age = "168h"        # refresh when older
+//	asn_path = "/var/lib/GeoIP/GeoLite2-ASN.mmdb"
+//
+//	[colors]                # over the theme's: subtle, ok, warn, error, accent, selected_fg/_bg, marked_fg/_bg
+//	accent = "#5f87ff"      # a 256-colour number or #rrggbb
+//
+//	[cache]                 # lookups kept between refreshes: rdns, geoip, procname
+//	rdns_ttl = "1h"
+//	rdns_size = 4096
+//
+//	[[exec_probe]]
+//	name = "bird"
+//	every = "10s"
+//	command = "birdc -r show protocols | bird2jsonl"
+//
+// Command-line flags override the file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/execprobe"
+	"github.com/nexusriot/ducknetview/internal/geoip"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Config is the file's content; zero values mean "not set".
+type Config struct {
+	Refresh      time.Duration
+	SlowRefresh  time.Duration
+	PortsRefresh time.Duration
+	ProcsRefresh time.Duration
+	DefaultTab   string
+	HideKinds    []string
+	HideVirtual  bool
+	Theme        string
+	Units        string
+	WatchLAN     bool
+	CaptureDNS   bool
+	MetricsAddr  string
+	Ping         []string
+	BandwidthDB  string
+	PortHistory  string
+
+	ExtIP        string // comma-separated, as for extip.New
+	ExtIPEvery   time.Duration
+	ExtIPTimeout time.Duration
+	ExtIPHistory string
+
+	GeoIP *geoip.DB // nil without a [geoip] table
+
+	ExecProbes []execprobe.Spec
+
+	Caches map[string]probe.CacheConfig // by probe.CacheNames, from [cache]
+
+	Colors map[string]string // [colors], checked by ui.SetTheme
+}
+
+// DefaultPath is config.toml in the user's config directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ducknetview", "config.toml"), nil
+}
+
+// Load reads the file at path. A missing file is an error wrapping
+// fs.ErrNotExist, so callers can ignore it for the default path only.
+func Load(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %w", err)
+	}
+	defer f.Close()
+
+	doc, err := parseTOML(f)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %s: %w", path, err)
+	}
+	c, err := decode(doc)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// LoadDefault loads the file at DefaultPath, if there is one.
+func LoadDefault() (Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return Config{}, nil
+	}
+	c, err := Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	return c, err
+}
+
+func decode(doc *document) (Config, error) {
+	var c Config
+	d := decoder{}
+
+	c.Refresh = d.duration(doc.root, "refresh")
+	c.SlowRefresh = d.duration(doc.root, "slow_refresh")
+	c.PortsRefresh = d.duration(doc.root, "ports_refresh")
+	c.ProcsRefresh = d.duration(doc.root, "procs_refresh")
+	c.DefaultTab = d.str(doc.root, "default_tab")
+	c.HideKinds = d.strs(doc.root, "hide_kinds")
+	c.HideVirtual = d.boolean(doc.root, "hide_virtual")
+	c.Theme = d.str(doc.root, "theme")
+	c.Units = d.str(doc.root, "units")
+	c.WatchLAN = d.boolean(doc.root, "watch_lan")
+	c.CaptureDNS = d.boolean(doc.root, "capture_dns")
+	c.MetricsAddr = d.str(doc.root, "metrics_addr")
+	c.Ping = d.strs(doc.root, "ping")
+	c.BandwidthDB = d.str(doc.root, "bandwidth_db")
+	c.PortHistory = d.str(doc.root, "port_history")
+	d.unknown("", doc.root, "refresh", "slow_refresh", "ports_refresh", "procs_refresh", "default_tab", "hide_kinds", "hide_virtual", "theme", "units", "watch_lan", "capture_dns", "metrics_addr", "ping", "bandwidth_db", "port_history")
+
+	for name, t := range doc.tables {
+		switch name {
+		case "external_ip":
+			// provider, a single one, is the older spelling
+			c.ExtIP = d.str(t, "provider")
+			if ps := d.strs(t, "providers"); len(ps) > 0 {
+				c.ExtIP = strings.Join(ps, ",")
+			}
+			c.ExtIPEvery = d.duration(t, "every")
+			c.ExtIPTimeout = d.duration(t, "timeout")
+			c.ExtIPHistory = d.str(t, "history")
+			d.unknown(name, t, "provider", "providers", "every", "timeout", "history")
+		case "geoip":
+			c.GeoIP = &geoip.DB{
+				LicenseKey: d.str(t, "license_key"),
+				Edition:    d.str(t, "edition"),
+				Path:       d.str(t, "path"),
+				MaxAge:     d.duration(t, "max_age"),
+				ASNPath:    d.str(t, "asn_path"),
+			}
+			d.unknown(name, t, "license_key", "edition", "path", "max_age", "asn_path")
+		case "cache":
+			var known []string
+			for _, n := range probe.CacheNames {
+				cc := probe.CacheConfig{TTL: d.duration(t, n+"_ttl"), Size: d.integer(t, n+"_size")}
+				if cc != (probe.CacheConfig{}) {
+					if c.Caches == nil {
+						c.Caches = map[string]probe.CacheConfig{}
+					}
+					c.Caches[n] = cc
+				}
+				known = append(known, n+"_ttl", n+"_size")
+			}
+			d.unknown(name, t, known...)
+		case "colors":
+			c.Colors = map[string]string{}
+			for k := range t {
+				c.Colors[k] = d.str(t, k)
+			}
+		default:
+			d.fail(fmt.Errorf("unknown table [%s]", name))
+		}
+	}
+	for name, ts := range doc.arrays {
+		if name != "exec_probe" {
+			d.fail(fmt.Errorf("unknown table [[%s]]", name))
+			continue
+		}
+		for _, t := range ts {
+			d.unknown(name, t, "name", "every", "command")
+			// the flag syntax, so both are validated alike
+			sp, err := execprobe.ParseSpec(fmt.Sprintf("%s=%s:%s", d.str(t, "name"), d.duration(t, "every"), d.str(t, "command")))
+			if err != nil {
+				d.fail(err)
+				continue
+			}
+			c.ExecProbes = append(c.ExecProbes, sp)
+		}
+	}
+
+	if c.Refresh != 0 && (c.Refresh < 500*time.Millisecond || c.Refresh > 30*time.Second) {
+		d.fail(fmt.Errorf("refresh: want 0.5s to 30s"))
+	}
+	for _, r := range []struct {
+		key string
+		v   time.Duration
+	}{{"slow_refresh", c.SlowRefresh}, {"ports_refresh", c.PortsRefresh}, {"procs_refresh", c.ProcsRefresh}} {
+		if r.v != 0 && r.v < max(c.Refresh, time.Second) {
+			d.fail(fmt.Errorf("%s: below refresh or 1s", r.key))
+		}
+	}
+	if c.GeoIP != nil && c.GeoIP.MaxAge != 0 && c.GeoIP.MaxAge < 24*time.Hour {
+		// MaxMind limits daily downloads per account
+		d.fail(fmt.Errorf("[geoip] max_age: below 24h"))
+	}
+	if c.ExtIPEvery != 0 && c.ExtIPEvery < 10*time.Second {
+		// public lookup services rate-limit
+		d.fail(fmt.Errorf("[external_ip] every: below 10s"))
+	}
+	return c, d.err
+}
+
+// decoder reads typed values, keeping the first error.
+type decoder struct{ err error }
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) str(t table, key string) string {
+	v, ok := t[key]
+	if !ok {
+		return ""
+	}
+	s, ok := v.(string)
+	if !ok {
+		d.fail(fmt.Errorf("%s: want a string", key))
+	}
+	return s
+}
+
+func (d *decoder) boolean(t table, key string) bool {
+	v, ok := t[key]
+	if !ok {
+		return false
+	}
+	b, ok := v.(bool)
+	if !ok {
+		d.fail(fmt.Errorf("%s: want true or false", key))
+	}
+	return b
+}
+
+func (d *decoder) strs(t table, key string) []string {
+	v, ok := t[key]
+	if !ok {
+		return nil
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		d.fail(fmt.Errorf("%s: want an array of strings", key))
+		return nil
+	}
+	out := make([]string, 0, len(arr))
+	for _, e := range arr {
+		s, ok := e.(string)
+		if !ok {
+			d.fail(fmt.Errorf("%s: want an array of strings", key))
+			return nil
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func (d *decoder) integer(t table, key string) int {
+	v, ok := t[key]
+	if !ok {
+		return 0
+	}
+	n, ok := v.(int64)
+	if !ok || n < 0 {
+		d.fail(fmt.Errorf("%s: want a whole number", key))
+	}
+	return int(n)
+}
+
+// duration accepts a Go duration string ("1s", "1m30s") or whole seconds.
+func (d *decoder) duration(t table, key string) time.Duration {
+	switch v := t[key].(type) {
+	case nil:
+		return 0
+	case int64:
+		return time.Duration(v) * time.Second
+	case string:
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			d.fail(fmt.Errorf("%s: %w", key, err))
+		}
+		return dur
+	default:
+		d.fail(fmt.Errorf("%s: want a duration like \"5s\"", key))
+		return 0
+	}
+}
+
+// unknown rejects keys outside known, so typos don't go unnoticed.
+func (d *decoder) unknown(section string, t table, known ...string) {
+	var extra []string
+	for k := range t {
+		found := false
+		for _, kk := range known {
+			found = found || k == kk
+		}
+		if !found {
+			extra = append(extra, k)
+		}
+	}
+	if len(extra) == 0 {
+		return
+	}
+	sort.Strings(extra)
+	if section != "" {
+		section = "[" + section + "] "
+	}
+	d.fail(fmt.Errorf("%sunknown key %s", section, strings.Join(extra, ", ")))
+}
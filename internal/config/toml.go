@@ -0,0 +1,241 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// table is one TOML table: keys to string, int64, bool or []any values.
+type table map[string]any
+
+// document is a parsed file: the root table, named tables and arrays of
+// tables.
+type document struct {
+	root   table
+	tables map[string]table
+	arrays map[string][]table
+}
+
+// parseTOML reads the subset of TOML a config file needs: comments, bare
+// keys, [table] and [[array]] headers, basic and literal strings, integers,
+// booleans and arrays of those (which may span lines). Dotted keys, inline
+// tables, floats and dates are rejected.
+func parseTOML(r io.Reader) (*document, error) {
+	doc := &document{root: table{}, tables: map[string]table{}, arrays: map[string][]table{}}
+	cur := doc.root
+
+	sc := bufio.NewScanner(r)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(stripComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+		if name, ok := header(line, "[[", "]]"); ok {
+			t := table{}
+			doc.arrays[name] = append(doc.arrays[name], t)
+			cur = t
+			continue
+		}
+		if name, ok := header(line, "[", "]"); ok {
+			if _, dup := doc.tables[name]; dup {
+				return nil, fmt.Errorf("line %d: table [%s] defined twice", n, name)
+			}
+			cur = table{}
+			doc.tables[name] = cur
+			continue
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !bareKey(key) {
+			return nil, fmt.Errorf("line %d: want key = value", n)
+		}
+		val = strings.TrimSpace(val)
+		// arrays may continue on the following lines
+		for strings.HasPrefix(val, "[") && !balanced(val) && sc.Scan() {
+			n++
+			val += " " + strings.TrimSpace(stripComment(sc.Text()))
+		}
+		v, err := parseValue(val)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", n, key, err)
+		}
+		if _, dup := cur[key]; dup {
+			return nil, fmt.Errorf("line %d: %s set twice", n, key)
+		}
+		cur[key] = v
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func header(line, open, close string) (string, bool) {
+	if !strings.HasPrefix(line, open) || !strings.HasSuffix(line, close) {
+		return "", false
+	}
+	name := strings.TrimSpace(line[len(open) : len(line)-len(close)])
+	return name, bareKey(name)
+}
+
+func bareKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, c := range k {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// stripComment drops a # comment that isn't inside a string.
+func stripComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// balanced reports whether the brackets of an array value are closed,
+// ignoring brackets inside strings.
+func balanced(s string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '[':
+			depth++
+		case quote == 0 && c == ']':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+func parseValue(s string) (any, error) {
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("missing value")
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("bad string %s", s)
+		}
+		return v, nil
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") || strings.Contains(s[1:len(s)-1], "'") {
+			return nil, fmt.Errorf("bad string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	case strings.HasPrefix(s, "["):
+		return parseArray(s)
+	}
+	n, ok := parseInt(s)
+	if !ok {
+		return nil, fmt.Errorf("unsupported value %s", s)
+	}
+	return n, nil
+}
+
+// parseInt reads a TOML integer: decimal with an optional sign and no
+// leading zeros, or unsigned hex, octal or binary with a 0x, 0o or 0b
+// prefix; underscores only between digits.
+func parseInt(s string) (int64, bool) {
+	base, digits := 10, s
+	switch {
+	case strings.HasPrefix(s, "0x"):
+		base, digits = 16, s[2:]
+	case strings.HasPrefix(s, "0o"):
+		base, digits = 8, s[2:]
+	case strings.HasPrefix(s, "0b"):
+		base, digits = 2, s[2:]
+	default:
+		digits = strings.TrimLeft(s, "+-")
+		if len(s)-len(digits) > 1 || len(digits) > 1 && digits[0] == '0' {
+			return 0, false
+		}
+	}
+	if digits == "" || strings.ContainsAny(digits[:1], "+-_") || digits[len(digits)-1] == '_' || strings.Contains(digits, "__") {
+		return 0, false
+	}
+	if base == 10 {
+		digits = s
+	}
+	n, err := strconv.ParseInt(strings.ReplaceAll(digits, "_", ""), base, 64)
+	return n, err == nil
+}
+
+func parseArray(s string) ([]any, error) {
+	if !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("unterminated array")
+	}
+	body := strings.TrimSpace(s[1 : len(s)-1])
+	var out []any
+	for body != "" {
+		end := elemEnd(body)
+		v, err := parseValue(strings.TrimSpace(body[:end]))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+		body = strings.TrimSpace(body[end:])
+		// a trailing comma is allowed
+		body = strings.TrimSpace(strings.TrimPrefix(body, ","))
+	}
+	return out, nil
+}
+
+// elemEnd returns the index of the comma ending the first array element,
+// or len(s).
+func elemEnd(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '[':
+			depth++
+		case quote == 0 && c == ']':
+			depth--
+		case quote == 0 && c == ',' && depth == 0:
+			return i
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"0", 0, true},
+		{"42", 42, true},
+		{"+42", 42, true},
+		{"-42", -42, true},
+		{"1_000", 1000, true},
+		{"0x1F", 31, true},
+		{"0xdead_beef", 0xdeadbeef, true},
+		{"0o755", 0o755, true},
+		{"0b1010", 10, true},
+		{"010", 0, false}, // leading zero, not octal
+		{"00", 0, false},
+		{"+0x1F", 0, false},
+		{"-0b1", 0, false},
+		{"0X1F", 0, false},
+		{"1__000", 0, false},
+		{"_1", 0, false},
+		{"1_", 0, false},
+		{"0x_1", 0, false},
+		{"--1", 0, false},
+		{"+", 0, false},
+		{"0x", 0, false},
+		{"1.5", 0, false},
+		{"0b102", 0, false},
+		{"9223372036854775808", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseInt(tt.in)
+		if ok != tt.ok || ok && got != tt.want {
+			t.Errorf("parseInt(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+		ok   bool
+	}{
+		{`"eth0"`, "eth0", true},
+		{`"tab\there"`, "tab\there", true},
+		{`'C:\path'`, `C:\path`, true},
+		{`true`, true, true},
+		{`false`, false, true},
+		{`7`, int64(7), true},
+		{`["a", 'b', 3]`, []any{"a", "b", int64(3)}, true},
+		{`["a", ["b"],]`, []any{"a", []any{"b"}}, true},
+		{`[]`, []any(nil), true},
+		{``, nil, false},
+		{`"open`, nil, false},
+		{`'it's'`, nil, false},
+		{`["a"`, nil, false},
+		{`yes`, nil, false},
+		{`1.5`, nil, false},
+		{`1979-05-27`, nil, false},
+		{`{ a = 1 }`, nil, false},
+	}
+	for _, tt := range tests {
+		got, err := parseValue(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("parseValue(%q) error %v, want ok %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseValue(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name, in string
+		err      string // part of the error, "" when it parses
+	}{
+		{"comments and tables", "# top\nrefresh = \"2s\" # trailing\n\n[external_ip]\nproviders = [\n  \"ipify\", # first\n  \"stun\",\n]\n[[exec_probe]]\nname = \"a#b\"\n", ""},
+		{"table twice", "[geoip]\n[geoip]\n", "line 2: table [geoip] defined twice"},
+		{"key twice", "theme = \"dark\"\ntheme = \"mono\"\n", "line 2: theme set twice"},
+		{"dotted key", "geoip.path = \"x\"\n", "line 1: want key = value"},
+		{"no value", "theme =\n", "line 1: theme: missing value"},
+		{"bad line", "just words\n", "line 1: want key = value"},
+		{"unclosed array", "ping = [\"a\",\n\"b\"\n", "unterminated array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTOML(strings.NewReader(tt.in))
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("error %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Fatalf("error %v, want one with %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	doc, err := parseTOML(strings.NewReader(`
+refresh = "2s"
+hide_kinds = ["veth", "docker"]
+port_history = "/var/lib/ducknetview/ports.jsonl"
+[external_ip]
+providers = ["ipify", "stun"]
+[cache]
+rdns_size = 0x400
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := decode(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Refresh != 2*time.Second || c.ExtIP != "ipify,stun" || c.PortHistory != "/var/lib/ducknetview/ports.jsonl" ||
+		!reflect.DeepEqual(c.HideKinds, []string{"veth", "docker"}) || c.Caches["rdns"].Size != 1024 {
+		t.Errorf("decoded %+v", c)
+	}
+
+	for in, want := range map[string]string{
+		"refresh = true":                "refresh: want a duration",
+		"theme = 1":                     "theme",
+		"refresh = \"100ms\"":           "refresh: want 0.5s to 30s",
+		"colour = \"red\"":              "colour",
+		"[nope]":                        "unknown table [nope]",
+		"[external_ip]\nevery = \"1s\"": "[external_ip] every: below 10s",
+	} {
+		doc, err := parseTOML(strings.NewReader(in))
+		if err != nil {
+			t.Fatalf("%q: %v", in, err)
+		}
+		if _, err := decode(doc); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: error %v, want one with %q", in, err, want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Package desktop hands things off to the user's desktop session.
+package desktop
+
+import (
+	"os/exec"
+	"runtime"
+
+	"github.com/atotto/clipboard"
+)
+
+// OpenURL opens url in the default browser without waiting for it.
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// reap the launcher in the background
+	go cmd.Wait()
+	return nil
+}
+
+// Copy puts text on the system clipboard.
+func Copy(text string) error {
+	return clipboard.WriteAll(text)
+}
@@ -0,0 +1,160 @@
+// Package execprobe runs user-defined commands on an interval and reads
+// their output as a table: one JSON object per line, one row per object.
+// It lets site-specific data (routing daemons, VPN status, …) show up next
+// to the built-in probes.
+package execprobe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// MinEvery keeps a typo like "1ms" from turning into a fork bomb.
+const MinEvery = time.Second
+
+// Spec is one configured probe.
+type Spec struct {
+	Name    string
+	Every   time.Duration
+	Command string // run with sh -c
+}
+
+// ParseSpec parses "name=every:command", e.g.
+// "bird=10s:birdc -r show protocols | bird2jsonl".
+func ParseSpec(s string) (Spec, error) {
+	name, rest, ok := strings.Cut(s, "=")
+	every, cmd, ok2 := strings.Cut(rest, ":")
+	if !ok || !ok2 || strings.TrimSpace(name) == "" || strings.TrimSpace(cmd) == "" {
+		return Spec{}, fmt.Errorf("exec probe %q: want name=interval:command", s)
+	}
+	d, err := time.ParseDuration(every)
+	if err != nil {
+		return Spec{}, fmt.Errorf("exec probe %q: %w", s, err)
+	}
+	if d < MinEvery {
+		return Spec{}, fmt.Errorf("exec probe %q: interval below %s", s, MinEvery)
+	}
+	return Spec{Name: strings.TrimSpace(name), Every: d, Command: strings.TrimSpace(cmd)}, nil
+}
+
+// Result is the table one run produced. Columns are the object keys in the
+// order they were first seen.
+type Result struct {
+	Columns []string
+	Rows    [][]string
+	At      time.Time
+}
+
+// Run executes the probe once; it is killed after its interval.
+func Run(s Spec) (Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.Every)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, firstLine(msg))
+		}
+		return Result{}, fmt.Errorf("%s: %w", s.Name, err)
+	}
+	res, err := Parse(bytes.NewReader(out))
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", s.Name, err)
+	}
+	res.At = time.Now()
+	return res, nil
+}
+
+// Parse reads JSON lines into a table. Blank lines are skipped; anything
+// that is not a JSON object is an error.
+func Parse(r io.Reader) (Result, error) {
+	var res Result
+	col := map[string]int{}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		keys, vals, err := object(line)
+		if err != nil {
+			return Result{}, fmt.Errorf("line %d: %w", n, err)
+		}
+		row := make([]string, len(res.Columns))
+		for i, k := range keys {
+			c, ok := col[k]
+			if !ok {
+				c = len(res.Columns)
+				col[k] = c
+				res.Columns = append(res.Columns, k)
+				row = append(row, "")
+			}
+			row[c] = vals[i]
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	if err := sc.Err(); err != nil {
+		return Result{}, err
+	}
+	// rows read before a column first appeared are short
+	for i, row := range res.Rows {
+		for len(row) < len(res.Columns) {
+			row = append(row, "")
+		}
+		res.Rows[i] = row
+	}
+	return res, nil
+}
+
+// object decodes one JSON object, keeping key order. Nested values are
+// shown as their JSON text.
+func object(line []byte) (keys, vals []string, err error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, nil, errors.New("not a JSON object")
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		k, _ := t.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, k)
+		vals = append(vals, value(raw))
+	}
+	return keys, vals, nil
+}
+
+func value(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+func firstLine(s string) string {
+	l, _, _ := strings.Cut(s, "\n")
+	return l
+}
@@ -0,0 +1,280 @@
+// Package extip finds the host's public IP address by asking an outside
+// service, over HTTPS or, where outbound HTTP is filtered, plain DNS or
+// STUN.
+package extip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Provider looks up the public address as seen by some remote service.
+type Provider interface {
+	Name() string
+	Lookup(ctx context.Context) (string, error)
+}
+
+// DefaultURL is the first provider of DefaultProviders.
+const DefaultURL = "https://api.ipify.org"
+
+// DefaultProviders is the chain used when nothing else is configured.
+const DefaultProviders = "ipify,icanhazip,ifconfig.me,stun"
+
+// DefaultTimeout is how long a Chain waits for each provider.
+const DefaultTimeout = 4 * time.Second
+
+// New returns the chain described by spec, a comma-separated list of
+// providers tried in order, each allowed timeout (0: DefaultTimeout). A
+// provider is an http(s) URL answering with the bare address, one of the
+// shorthands ipify, icanhazip and ifconfig.me, dns:google, dns:opendns,
+// stun (Google's STUN server) or stun:HOST:PORT. Empty means
+// DefaultProviders.
+func New(spec string, timeout time.Duration) (Chain, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultProviders
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	c := Chain{Timeout: timeout}
+	for _, s := range strings.Split(spec, ",") {
+		p, err := newProvider(strings.TrimSpace(s))
+		if err != nil {
+			return Chain{}, err
+		}
+		c.Providers = append(c.Providers, p)
+	}
+	return c, nil
+}
+
+func newProvider(spec string) (Provider, error) {
+	switch {
+	case strings.HasPrefix(spec, "https://") || strings.HasPrefix(spec, "http://"):
+		return HTTPS{URL: spec}, nil
+	case spec == "ipify":
+		return HTTPS{URL: DefaultURL}, nil
+	case spec == "icanhazip":
+		return HTTPS{URL: "https://icanhazip.com"}, nil
+	case spec == "ifconfig.me":
+		return HTTPS{URL: "https://ifconfig.me/ip"}, nil
+	case spec == "dns:google":
+		return GoogleDNS{}, nil
+	case spec == "dns:opendns":
+		return OpenDNS{}, nil
+	case spec == "stun":
+		return STUN{Server: DefaultSTUN}, nil
+	case strings.HasPrefix(spec, "stun:"):
+		server := strings.TrimPrefix(spec, "stun:")
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			return nil, fmt.Errorf("extip: %q: want stun:HOST:PORT", spec)
+		}
+		return STUN{Server: server}, nil
+	}
+	return nil, fmt.Errorf("extip: unknown provider %q (want an http(s) URL, ipify, icanhazip, ifconfig.me, dns:google, dns:opendns, stun or stun:HOST:PORT)", spec)
+}
+
+// Chain asks its providers in turn until one answers, giving each up to
+// Timeout.
+type Chain struct {
+	Providers []Provider
+	Timeout   time.Duration
+}
+
+func (c Chain) Name() string {
+	names := make([]string, len(c.Providers))
+	for i, p := range c.Providers {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// Lookup returns the first answer. When all providers fail, the error is
+// the last one's; the others are most likely the same outage.
+func (c Chain) Lookup(ctx context.Context) (string, error) {
+	err := errors.New("extip: no providers")
+	for _, p := range c.Providers {
+		pctx, cancel := context.WithTimeout(ctx, c.Timeout)
+		var ip string
+		ip, err = p.Lookup(pctx)
+		cancel()
+		if err == nil {
+			return ip, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+	}
+	return "", err
+}
+
+// HTTPS fetches URL and expects the address as the whole response body.
+type HTTPS struct {
+	URL string
+}
+
+func (h HTTPS) Name() string { return h.URL }
+
+func (h HTTPS) Lookup(ctx context.Context) (string, error) {
+	// Fresh transport each time avoids stale keep-alive sockets after VPN / route changes.
+	tr := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dial,
+		DisableKeepAlives:   true,
+		TLSHandshakeTimeout: 3 * time.Second,
+	}
+	if source(ctx) != nil {
+		// a proxy would answer with its own exit, the same for every path
+		tr.Proxy = nil
+	}
+	c := &http.Client{
+		Timeout:   4 * time.Second,
+		Transport: tr,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("external ip: http %d", resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return "", err
+	}
+	return parseIP(string(b))
+}
+
+// Google's and OpenDNS's own name servers answer with the address the
+// query came from, so they are asked directly rather than through the
+// local resolver (which would report its own address).
+const (
+	googleNS  = "216.239.32.10:53"  // ns1.google.com
+	openDNSNS = "208.67.222.222:53" // resolver1.opendns.com
+)
+
+// GoogleDNS reads the TXT record o-o.myaddr.l.google.com from Google's
+// name server.
+type GoogleDNS struct{}
+
+func (GoogleDNS) Name() string { return "dns:google" }
+
+func (GoogleDNS) Lookup(ctx context.Context) (string, error) {
+	txts, err := resolver(googleNS).LookupTXT(ctx, "o-o.myaddr.l.google.com")
+	if err != nil {
+		return "", err
+	}
+	for _, t := range txts {
+		// an "edns0-client-subnet …" record may come along; skip it
+		if ip, err := parseIP(t); err == nil {
+			return ip, nil
+		}
+	}
+	return "", errors.New("external ip: no address in TXT answer")
+}
+
+// OpenDNS resolves myip.opendns.com at OpenDNS.
+type OpenDNS struct{}
+
+func (OpenDNS) Name() string { return "dns:opendns" }
+
+func (OpenDNS) Lookup(ctx context.Context) (string, error) {
+	ips, err := resolver(openDNSNS).LookupHost(ctx, "myip.opendns.com")
+	if err != nil {
+		return "", err
+	}
+	if len(ips) == 0 {
+		return "", errors.New("external ip: empty answer")
+	}
+	return parseIP(ips[0])
+}
+
+// Static answers with fixed addresses without asking anyone; for --demo.
+type Static struct {
+	V4, V6 string
+}
+
+func (Static) Name() string { return "static" }
+
+func (s Static) Lookup(ctx context.Context) (string, error) {
+	v, _ := ctx.Value(familyKey{}).(int)
+	if src := source(ctx); v == 6 || src != nil && src.To4() == nil {
+		return s.V6, nil
+	}
+	return s.V4, nil
+}
+
+// resolver sends every query to server instead of the system's resolvers.
+func resolver(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dial(ctx, network, server)
+		},
+	}
+}
+
+type sourceKey struct{}
+
+type familyKey struct{}
+
+// WithFamily keeps lookups under ctx to IPv4 (4) or IPv6 (6), so dual-stack
+// services report the address of that family.
+func WithFamily(ctx context.Context, v int) context.Context {
+	return context.WithValue(ctx, familyKey{}, v)
+}
+
+// WithSource makes lookups under ctx leave from the local address src, so
+// they take whatever path the routing picks for it: a second uplink or a
+// VPN with source-based rules. The answer is then that path's exit.
+func WithSource(ctx context.Context, src net.IP) context.Context {
+	return context.WithValue(ctx, sourceKey{}, src)
+}
+
+func source(ctx context.Context) net.IP {
+	ip, _ := ctx.Value(sourceKey{}).(net.IP)
+	return ip
+}
+
+// dial connects from the WithSource address, if any, which also keeps the
+// destination to that address's family, or else in the WithFamily one.
+func dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	if v, _ := ctx.Value(familyKey{}).(int); (v == 4 || v == 6) && (network == "tcp" || network == "udp") {
+		network += strconv.Itoa(v)
+	}
+	var d net.Dialer
+	if src := source(ctx); src != nil {
+		if strings.HasPrefix(network, "udp") {
+			d.LocalAddr = &net.UDPAddr{IP: src}
+		} else {
+			d.LocalAddr = &net.TCPAddr{IP: src}
+		}
+	}
+	return d.DialContext(ctx, network, addr)
+}
+
+func parseIP(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", errors.New("external ip: empty response")
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return "", fmt.Errorf("external ip: %q is not an address", s)
+	}
+	return ip.String(), nil
+}
@@ -0,0 +1,120 @@
+package extip
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultSTUN is the server the "stun" provider asks.
+const DefaultSTUN = "stun.l.google.com:19302"
+
+// STUN sends a Binding request (RFC 5389) to Server over UDP and reads
+// the address the server saw it come from. It gets through where only
+// UDP is open, e.g. to a VoIP or video call service.
+type STUN struct {
+	Server string
+}
+
+func (s STUN) Name() string { return "stun:" + s.Server }
+
+const (
+	stunMagic        = 0x2112A442
+	stunBindRequest  = 0x0001
+	stunBindSuccess  = 0x0101
+	stunMappedAddr   = 0x0001
+	stunXorMapped    = 0x0020
+	stunHeaderLength = 20
+)
+
+func (s STUN) Lookup(ctx context.Context) (string, error) {
+	conn, err := dial(ctx, "udp", s.Server)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	req := make([]byte, stunHeaderLength)
+	binary.BigEndian.PutUint16(req[0:], stunBindRequest)
+	binary.BigEndian.PutUint32(req[4:], stunMagic)
+	if _, err := rand.Read(req[8:20]); err != nil {
+		return "", err
+	}
+	// UDP may drop the request; resend until the deadline
+	buf := make([]byte, 1500)
+	for {
+		if _, err := conn.Write(req); err != nil {
+			return "", err
+		}
+		conn.SetReadDeadline(minTime(deadline, time.Now().Add(time.Second)))
+		n, err := conn.Read(buf)
+		if err != nil {
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() && time.Now().Before(deadline) {
+				continue
+			}
+			return "", err
+		}
+		if n < stunHeaderLength || !bytes.Equal(buf[8:20], req[8:20]) {
+			continue // not the answer to this request
+		}
+		return parseSTUN(buf[:n])
+	}
+}
+
+// parseSTUN reads the (XOR-)MAPPED-ADDRESS of a Binding success response.
+func parseSTUN(b []byte) (string, error) {
+	if binary.BigEndian.Uint16(b[0:]) != stunBindSuccess {
+		return "", fmt.Errorf("external ip: stun response type %#04x", binary.BigEndian.Uint16(b[0:]))
+	}
+	end := min(len(b), stunHeaderLength+int(binary.BigEndian.Uint16(b[2:])))
+	var mapped net.IP
+	for off := stunHeaderLength; off+4 <= end; {
+		typ, l := binary.BigEndian.Uint16(b[off:]), int(binary.BigEndian.Uint16(b[off+2:]))
+		v := b[off+4 : min(end, off+4+l)]
+		off += 4 + (l+3)&^3 // attributes are padded to 4 bytes
+		if len(v) < 8 {
+			continue
+		}
+		var ip net.IP
+		switch family := v[1]; {
+		case family == 1:
+			ip = append(net.IP(nil), v[4:8]...)
+		case family == 2 && len(v) >= 20:
+			ip = append(net.IP(nil), v[4:20]...)
+		default:
+			continue
+		}
+		switch typ {
+		case stunXorMapped:
+			// XORed with the magic cookie, then the transaction ID
+			for i := range ip {
+				ip[i] ^= b[4+i]
+			}
+			return ip.String(), nil
+		case stunMappedAddr:
+			mapped = ip
+		}
+	}
+	if mapped == nil {
+		return "", errors.New("external ip: no address in stun response")
+	}
+	return mapped.String(), nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
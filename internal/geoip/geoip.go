@@ -0,0 +1,236 @@
+// Package geoip keeps a MaxMind GeoLite2 / GeoIP2 database on disk up to
+// date. Downloads need a MaxMind license key; without one a database
+// placed at Path by hand (or by geoipupdate) is used as is.
+package geoip
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultEdition is the free city-level database.
+	DefaultEdition = "GeoLite2-City"
+	// DefaultMaxAge is how old the database may get before it is
+	// refreshed; MaxMind publishes new builds twice a week.
+	DefaultMaxAge = 7 * 24 * time.Hour
+)
+
+var downloadURL = "https://download.maxmind.com/app/geoip_download"
+
+// mmdbMarker starts the metadata section at the end of every mmdb file.
+var mmdbMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// ErrNoDatabase means there is no database at Path yet.
+var ErrNoDatabase = errors.New("geoip: no database")
+
+// DB is one managed database file.
+type DB struct {
+	Edition    string        // e.g. "GeoLite2-City"; empty means DefaultEdition
+	LicenseKey string        // empty: never download
+	Path       string        // empty: <edition>.mmdb in the user cache dir
+	MaxAge     time.Duration // zero means DefaultMaxAge
+
+	// ASNPath is an optional GeoLite2-ASN file read alongside for AS
+	// numbers, which the City and Country editions lack. It is not
+	// downloaded.
+	ASNPath string
+}
+
+func (db DB) edition() string {
+	if db.Edition == "" {
+		return DefaultEdition
+	}
+	return db.Edition
+}
+
+func (db DB) maxAge() time.Duration {
+	if db.MaxAge == 0 {
+		return DefaultMaxAge
+	}
+	return db.MaxAge
+}
+
+// File is the database path.
+func (db DB) File() (string, error) {
+	if db.Path != "" {
+		return db.Path, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ducknetview", db.edition()+".mmdb"), nil
+}
+
+// Status describes the database on disk.
+type Status struct {
+	Edition string
+	Built   time.Time // zero when there is no database
+}
+
+// Age is how old the database build is at now.
+func (s Status) Age(now time.Time) time.Duration {
+	return now.Sub(s.Built)
+}
+
+// Stat reports the build time of the database on disk, which downloads
+// record as the file's modification time. A missing file is ErrNoDatabase.
+func (db DB) Stat() (Status, error) {
+	st := Status{Edition: db.edition()}
+	path, err := db.File()
+	if err != nil {
+		return st, err
+	}
+	fi, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return st, ErrNoDatabase
+	}
+	if err != nil {
+		return st, err
+	}
+	st.Built = fi.ModTime()
+	return st, nil
+}
+
+// Stale reports whether s calls for a download: there is a license key,
+// and the database is missing or older than MaxAge.
+func (db DB) Stale(s Status, now time.Time) bool {
+	return db.LicenseKey != "" && (s.Built.IsZero() || s.Age(now) > db.maxAge())
+}
+
+// Update downloads the current build and atomically replaces the file.
+func (db DB) Update() (Status, error) {
+	if db.LicenseKey == "" {
+		return Status{}, errors.New("geoip: no license key")
+	}
+	path, err := db.File()
+	if err != nil {
+		return Status{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Status{}, err
+	}
+
+	q := url.Values{"edition_id": {db.edition()}, "license_key": {db.LicenseKey}, "suffix": {"tar.gz"}}
+	c := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := c.Get(downloadURL + "?" + q.Encode())
+	if err != nil {
+		// the URL carries the key; keep it out of the message
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			err = ue.Err
+		}
+		return Status{}, fmt.Errorf("geoip: download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return Status{}, errors.New("geoip: download: license key rejected")
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return Status{}, fmt.Errorf("geoip: download: http %d", resp.StatusCode)
+	}
+
+	// temp file next to the database so the final rename stays on one filesystem
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".geoip-*")
+	if err != nil {
+		return Status{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	built, err := extract(resp.Body, tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Status{}, fmt.Errorf("geoip: %w", err)
+	}
+	if err := os.Chtimes(tmp.Name(), built, built); err != nil {
+		return Status{}, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return Status{}, err
+	}
+	return Status{Edition: db.edition(), Built: built}, nil
+}
+
+// Open loads the database, and the ASN one when set, for lookups.
+func (db DB) Open() (Readers, error) {
+	path, err := db.File()
+	if err != nil {
+		return nil, err
+	}
+	var rs Readers
+	for _, p := range []string{path, db.ASNPath} {
+		if p == "" {
+			continue
+		}
+		r, err := Open(p)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
+
+// extract copies the .mmdb file out of a MaxMind tar.gz to w and returns
+// its build time, taken from the archive entry.
+func extract(r io.Reader, w io.Writer) (time.Time, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return time.Time{}, errors.New("archive has no .mmdb file")
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		if h.Typeflag != tar.TypeReg || !strings.HasSuffix(h.Name, ".mmdb") {
+			continue
+		}
+
+		// the metadata sits in the last 128KiB; keep that much to check it
+		tail := &tailBuffer{max: 128 << 10}
+		if _, err := io.Copy(io.MultiWriter(w, tail), tr); err != nil {
+			return time.Time{}, err
+		}
+		if !bytes.Contains(tail.buf, mmdbMarker) {
+			return time.Time{}, fmt.Errorf("%s is not an mmdb database", h.Name)
+		}
+		if h.ModTime.IsZero() {
+			return time.Now(), nil
+		}
+		return h.ModTime, nil
+	}
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	buf []byte
+	max int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+	return len(p), nil
+}
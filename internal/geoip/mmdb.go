@@ -0,0 +1,368 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Reader looks addresses up in an mmdb file (MaxMind DB format 2), which
+// is read into memory whole: GeoLite2 City is about 60 MB, Country and
+// ASN a few.
+type Reader struct {
+	buf        []byte
+	data       []byte // data section
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	v4Start    uint // node reached after the 96 zero bits of ::/96
+
+	Type string // from the metadata, e.g. "GeoLite2-City"
+}
+
+// Open reads the database at path.
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newReader(buf)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: %s: %w", path, err)
+	}
+	return r, nil
+}
+
+func newReader(buf []byte) (*Reader, error) {
+	i := bytes.LastIndex(buf, mmdbMarker)
+	if i < 0 {
+		return nil, errors.New("not an mmdb database")
+	}
+	meta := decoder{buf: buf[i+len(mmdbMarker):]}
+	v, _, err := meta.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("metadata: %w", err)
+	}
+	md, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.New("metadata: not a map")
+	}
+
+	r := &Reader{buf: buf}
+	r.nodeCount, _ = asUint(md["node_count"])
+	r.recordSize, _ = asUint(md["record_size"])
+	r.ipVersion, _ = asUint(md["ip_version"])
+	r.Type, _ = md["database_type"].(string)
+	switch r.recordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("unsupported record size %d", r.recordSize)
+	}
+
+	treeSize := r.nodeCount * r.recordSize / 4
+	if treeSize+16 > uint(i) {
+		return nil, errors.New("search tree runs past the data")
+	}
+	r.data = buf[treeSize+16 : i]
+
+	if r.ipVersion == 6 {
+		node := uint(0)
+		for n := 0; n < 96 && node < r.nodeCount; n++ {
+			node = r.record(node, 0)
+		}
+		r.v4Start = node
+	}
+	return r, nil
+}
+
+// record returns the left (bit 0) or right (bit 1) record of node.
+func (r *Reader) record(node, bit uint) uint {
+	b := r.buf[node*r.recordSize/4:]
+	switch r.recordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xf0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0f)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(b[bit*4:]))
+	}
+}
+
+// lookup returns the record stored for ip, nil when there is none.
+func (r *Reader) lookup(ip net.IP) (map[string]any, error) {
+	bits := ip.To4()
+	node := uint(0)
+	switch {
+	case bits != nil && r.ipVersion == 6:
+		node = r.v4Start
+	case bits == nil:
+		if r.ipVersion == 4 {
+			return nil, nil
+		}
+		bits = ip.To16()
+		if bits == nil {
+			return nil, fmt.Errorf("geoip: bad address %v", ip)
+		}
+	}
+
+	for i := 0; i < len(bits)*8 && node < r.nodeCount; i++ {
+		bit := uint(bits[i/8]>>(7-i%8)) & 1
+		node = r.record(node, bit)
+	}
+	switch {
+	case node == r.nodeCount:
+		return nil, nil
+	case node < r.nodeCount:
+		return nil, errors.New("geoip: search tree ends inside a node")
+	}
+
+	d := decoder{buf: r.data}
+	v, _, err := d.decode(node - r.nodeCount - 16)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: %w", err)
+	}
+	rec, _ := v.(map[string]any)
+	return rec, nil
+}
+
+// GeoLookup reads the country and AS of ip from what the database has.
+// An address it doesn't cover is no error, just a zero GeoInfo.
+func (r *Reader) GeoLookup(ip string) (probe.GeoInfo, error) {
+	var g probe.GeoInfo
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return g, fmt.Errorf("geoip: bad address %q", ip)
+	}
+	rec, err := r.lookup(parsed)
+	if err != nil || rec == nil {
+		return g, err
+	}
+	// City and Country databases; anycast and EU-wide ranges only have
+	// the registered country
+	for _, k := range []string{"country", "registered_country"} {
+		if c, ok := rec[k].(map[string]any); ok {
+			if g.Country, _ = c["iso_code"].(string); g.Country != "" {
+				break
+			}
+		}
+	}
+	// ASN database
+	g.ASN, _ = asUint(rec["autonomous_system_number"])
+	g.Org, _ = rec["autonomous_system_organization"].(string)
+	return g, nil
+}
+
+// Readers combines databases, e.g. City for the country and ASN for the
+// AS: each field comes from the first one that has it.
+type Readers []*Reader
+
+func (rs Readers) GeoLookup(ip string) (probe.GeoInfo, error) {
+	var g probe.GeoInfo
+	for _, r := range rs {
+		one, err := r.GeoLookup(ip)
+		if err != nil {
+			return g, err
+		}
+		if g.Country == "" {
+			g.Country = one.Country
+		}
+		if g.ASN == 0 {
+			g.ASN, g.Org = one.ASN, one.Org
+		}
+	}
+	return g, nil
+}
+
+// decoder reads values of the mmdb data section format. Pointers are
+// offsets into buf.
+type decoder struct {
+	buf []byte
+}
+
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEnd
+	typeBool
+	typeFloat
+)
+
+var errTruncated = errors.New("data section truncated")
+
+// decode returns the value at off and the offset just past it.
+func (d decoder) decode(off uint) (any, uint, error) {
+	if off >= uint(len(d.buf)) {
+		return nil, 0, errTruncated
+	}
+	ctrl := d.buf[off]
+	off++
+	typ := uint(ctrl >> 5)
+
+	if typ == typePointer {
+		ptr, next, err := d.pointer(ctrl, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ptr < uint(len(d.buf)) && d.buf[ptr]>>5 == typePointer {
+			// not allowed, and a loop in a broken file
+			return nil, 0, errors.New("pointer to a pointer")
+		}
+		v, _, err := d.decode(ptr)
+		return v, next, err
+	}
+
+	if typ == typeExtended {
+		if off >= uint(len(d.buf)) {
+			return nil, 0, errTruncated
+		}
+		typ = 7 + uint(d.buf[off])
+		off++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28
+		if off+n > uint(len(d.buf)) {
+			return nil, 0, errTruncated
+		}
+		v := uint(0)
+		for _, b := range d.buf[off : off+n] {
+			v = v<<8 | uint(b)
+		}
+		off += n
+		switch size {
+		case 29:
+			size = 29 + v
+		case 30:
+			size = 285 + v
+		default:
+			size = 65821 + v
+		}
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for i := uint(0); i < size; i++ {
+			k, next, err := d.decode(off)
+			if err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, errors.New("map key is not a string")
+			}
+			v, next, err := d.decode(next)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key], off = v, next
+		}
+		return m, off, nil
+	case typeArray:
+		a := make([]any, 0, size)
+		for i := uint(0); i < size; i++ {
+			v, next, err := d.decode(off)
+			if err != nil {
+				return nil, 0, err
+			}
+			a, off = append(a, v), next
+		}
+		return a, off, nil
+	case typeBool:
+		return size != 0, off, nil
+	case typeEnd, typeContainer:
+		return nil, off, nil
+	}
+
+	if off+size > uint(len(d.buf)) {
+		return nil, 0, errTruncated
+	}
+	b := d.buf[off : off+size]
+	off += size
+	switch typ {
+	case typeString:
+		return string(b), off, nil
+	case typeBytes:
+		return append([]byte(nil), b...), off, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.New("bad double")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), off, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.New("bad float")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), off, nil
+	case typeUint16, typeUint32, typeUint64, typeInt32:
+		if size > 8 {
+			return nil, 0, errors.New("integer too long")
+		}
+		v := uint64(0)
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+		if typ == typeInt32 {
+			return int64(int32(uint32(v))), off, nil
+		}
+		return v, off, nil
+	case typeUint128:
+		// nothing we read is this wide; keep the bytes
+		return append([]byte(nil), b...), off, nil
+	}
+	return nil, 0, fmt.Errorf("unknown data type %d", typ)
+}
+
+// pointer reads the pointer whose control byte is ctrl, with the rest of
+// it at off, and returns its target and the offset past it.
+func (d decoder) pointer(ctrl byte, off uint) (uint, uint, error) {
+	n := uint(ctrl>>3&0x3) + 1
+	if off+n > uint(len(d.buf)) {
+		return 0, 0, errTruncated
+	}
+	v := uint(0)
+	for _, b := range d.buf[off : off+n] {
+		v = v<<8 | uint(b)
+	}
+	switch n {
+	case 1:
+		v |= uint(ctrl&0x7) << 8
+	case 2:
+		v = (uint(ctrl&0x7)<<16 | v) + 2048
+	case 3:
+		v = (uint(ctrl&0x7)<<24 | v) + 526336
+	}
+	return v, off + n, nil
+}
+
+func asUint(v any) (uint, bool) {
+	switch n := v.(type) {
+	case uint64:
+		return uint(n), true
+	case int64:
+		return uint(n), n >= 0
+	}
+	return 0, false
+}
@@ -0,0 +1,209 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Encoders of the data section format, enough for the test database.
+
+func encStr(s string) []byte {
+	if len(s) >= 29 {
+		return append([]byte{typeString<<5 | 29, byte(len(s) - 29)}, s...)
+	}
+	return append([]byte{byte(typeString<<5 | len(s))}, s...)
+}
+
+func encUint(typ byte, v uint32) []byte {
+	b := binary.BigEndian.AppendUint32(nil, v)
+	return append([]byte{typ<<5 | 4}, b...)
+}
+
+func encMap(kv ...[]byte) []byte {
+	out := []byte{byte(typeMap<<5 | len(kv)/2)}
+	for _, x := range kv {
+		out = append(out, x...)
+	}
+	return out
+}
+
+// buildDB writes an IPv6 database with 24-bit records that maps each
+// network to the record at its offset in data.
+func buildDB(data []byte, nets map[string]int) []byte {
+	type rec struct {
+		node, data bool
+		val        int
+	}
+	nodes := [][2]rec{{}}
+	for cidr, off := range nets {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		plen, _ := n.Mask.Size()
+		bits, node := n.IP.To16(), 0
+		if v4 := n.IP.To4(); v4 != nil {
+			// IPv4 lives at ::/96 of an IPv6 tree
+			bits, plen = append(make(net.IP, 12), v4...), plen+96
+		}
+		for i := 0; i < plen; i++ {
+			bit := int(bits[i/8]>>(7-i%8)) & 1
+			if i == plen-1 {
+				nodes[node][bit] = rec{data: true, val: off}
+				break
+			}
+			if !nodes[node][bit].node {
+				nodes = append(nodes, [2]rec{})
+				nodes[node][bit] = rec{node: true, val: len(nodes) - 1}
+			}
+			node = nodes[node][bit].val
+		}
+	}
+
+	count := len(nodes)
+	var buf []byte
+	for _, nd := range nodes {
+		for _, r := range nd {
+			v := count // nothing here
+			switch {
+			case r.node:
+				v = r.val
+			case r.data:
+				v = count + 16 + r.val
+			}
+			buf = append(buf, byte(v>>16), byte(v>>8), byte(v))
+		}
+	}
+	buf = append(buf, make([]byte, 16)...)
+	buf = append(buf, data...)
+	buf = append(buf, mmdbMarker...)
+	return append(buf, encMap(
+		encStr("node_count"), encUint(typeUint32, uint32(count)),
+		encStr("record_size"), encUint(typeUint16, 24),
+		encStr("ip_version"), encUint(typeUint16, 6),
+		encStr("database_type"), encStr("Test-City"),
+	)...)
+}
+
+func TestGeoLookup(t *testing.T) {
+	var data []byte
+	de := len(data)
+	data = append(data, encMap(encStr("country"), encMap(encStr("iso_code"), encStr("DE")))...)
+	asn := len(data)
+	data = append(data, encMap(
+		encStr("autonomous_system_number"), encUint(typeUint32, 64500),
+		encStr("autonomous_system_organization"), encStr("Example Net"),
+	)...)
+	ptr := len(data)
+	data = append(data, typePointer<<5, byte(de)) // to the DE record
+	reg := len(data)
+	data = append(data, encMap(encStr("registered_country"), encMap(encStr("iso_code"), encStr("NL")))...)
+
+	r, err := newReader(buildDB(data, map[string]int{
+		"1.2.3.0/24":    de,
+		"5.6.7.0/24":    ptr,
+		"9.9.9.0/24":    reg,
+		"2001:db8::/32": asn,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Type != "Test-City" {
+		t.Errorf("Type = %q", r.Type)
+	}
+
+	tests := []struct {
+		ip   string
+		want probe.GeoInfo
+	}{
+		{"1.2.3.4", probe.GeoInfo{Country: "DE"}},
+		{"::ffff:1.2.3.4", probe.GeoInfo{Country: "DE"}},
+		{"5.6.7.8", probe.GeoInfo{Country: "DE"}},
+		{"9.9.9.9", probe.GeoInfo{Country: "NL"}},
+		{"2001:db8::1", probe.GeoInfo{ASN: 64500, Org: "Example Net"}},
+		{"8.8.8.8", probe.GeoInfo{}},
+		{"2001:db9::1", probe.GeoInfo{}},
+	}
+	for _, tt := range tests {
+		got, err := r.GeoLookup(tt.ip)
+		if err != nil {
+			t.Errorf("%s: %v", tt.ip, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.ip, got, tt.want)
+		}
+	}
+	if _, err := r.GeoLookup("not-an-ip"); err == nil {
+		t.Error("a bad address: no error")
+	}
+}
+
+func TestNewReaderRejects(t *testing.T) {
+	good := buildDB(encMap(), nil)
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		{"no marker", []byte("just some bytes")},
+		{"metadata truncated", append(append([]byte{}, mmdbMarker...), typeMap<<5|1)},
+		{"metadata not a map", append(append([]byte{}, mmdbMarker...), encStr("x")...)},
+		{"record size", append(append([]byte{}, mmdbMarker...), encMap(encStr("record_size"), encUint(typeUint16, 20))...)},
+		{"tree past the data", append(append([]byte{}, mmdbMarker...), encMap(
+			encStr("node_count"), encUint(typeUint32, 1000), encStr("record_size"), encUint(typeUint16, 24))...)},
+	}
+	for _, tt := range tests {
+		if _, err := newReader(tt.buf); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+	if _, err := newReader(good); err != nil {
+		t.Errorf("an empty database: %v", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  []byte
+		want any
+		err  error
+	}{
+		{"string", encStr("hello"), "hello", nil},
+		{"long string", encStr("a string of more than twenty-nine bytes"), "a string of more than twenty-nine bytes", nil},
+		{"uint16", []byte{typeUint16<<5 | 2, 0x01, 0xbb}, uint64(443), nil},
+		{"uint32 short", []byte{typeUint32<<5 | 1, 0x2a}, uint64(42), nil},
+		{"int32", []byte{typeExtended<<5 | 4, typeInt32 - 7, 0xff, 0xff, 0xff, 0xfe}, int64(-2), nil},
+		{"bool", []byte{typeExtended<<5 | 1, typeBool - 7}, true, nil},
+		{"double", []byte{typeDouble<<5 | 8, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, 1.5, nil},
+		{"array", append([]byte{typeExtended<<5 | 2, typeArray - 7}, append(encStr("a"), encStr("b")...)...), []any{"a", "b"}, nil},
+		{"map", encMap(encStr("k"), encStr("v")), map[string]any{"k": "v"}, nil},
+		{"empty", nil, nil, errTruncated},
+		{"string truncated", []byte{typeString<<5 | 5, 'a'}, nil, errTruncated},
+		{"map truncated", []byte{typeMap<<5 | 1}, nil, errTruncated},
+		{"map key not a string", encMap([]byte{typeUint16<<5 | 1, 1}, encStr("v")), nil, errors.New("")},
+		{"pointer to a pointer", []byte{typePointer << 5, 0}, nil, errors.New("")},
+		{"bad double", []byte{typeDouble<<5 | 4, 0, 0, 0, 0}, nil, errors.New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := decoder{buf: tt.buf}.decode(0)
+			switch {
+			case tt.err == nil && err != nil:
+				t.Fatalf("error %v", err)
+			case tt.err != nil && err == nil:
+				t.Fatalf("%v, want an error", got)
+			case tt.err == errTruncated && err != errTruncated:
+				t.Fatalf("error %v, want %v", err, errTruncated)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("= %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,740 @@
+package i18n
+
+var deMessages = map[string]string{
+	// tabs / chrome
+	"Overview":   "Übersicht",
+	"Interfaces": "Schnittstellen",
+	"Ports":      "Ports",
+	"Processes":  "Prozesse",
+	"Ovw":        "Übs",
+	"If":         "If",
+	"Procs":      "Proz",
+	"Keys: tab/shift+tab • ←/→ • / search • Ctrl+u clear • ctrl+e ext-ip": "Tasten: tab/shift+tab • ←/→ • / Suche • Ctrl+u leeren • ctrl+e ext-IP",
+	"Error: ": "Fehler: ",
+
+	// overview
+	"Collecting data…":                   "Sammle Daten…",
+	"Host: %s":                           "Host: %s",
+	"Uptime: %s":                         "Laufzeit: %s",
+	"Time: %s":                           "Zeit: %s",
+	"Ifaces: %d total  (%s up, %s down)": "Schnittstellen: %d gesamt  (%s aktiv, %s inaktiv)",
+	"Selected interface":                 "Ausgewählte Schnittstelle",
+	"External IP: %s":                    "Externe IP: %s",
+	"(updated %s)":                       "(aktualisiert %s)",
+	"External IP error: %s":              "Fehler externe IP: %s",
+
+	// interfaces
+	"MAC %s  RX %s  TX %s": "MAC %s  RX %s  TX %s",
+	"Select an interface…": "Schnittstelle auswählen…",
+	"UP":                   "AKTIV",
+	"DOWN":                 "INAKTIV",
+	"Addrs: ":              "Adressen: ",
+
+	// search
+	"Press / to search":                    "/ drücken zum Suchen",
+	"Filter: ":                             "Filter: ",
+	"(press / to change, ctrl+u to clear)": "(/ zum Ändern, ctrl+u zum Leeren)",
+	"(/ change, ^u clear)":                 "(/ ändern, ^u leeren)",
+	"search port / address / process":      "Port / Adresse / Prozess suchen",
+	"search process name":                  "Prozessname suchen",
+	"No data (yet)…":                       "(Noch) keine Daten…",
+
+	// ports
+	"Open listening ports": "Offene lauschende Ports",
+	"Listening ports":      "Lauschende Ports",
+	"PR":                   "PR",
+	"LOCAL":                "LOKAL",
+	"PID":                  "PID",
+	"PROCESS":              "PROZESS",
+	"PROC":                 "PROZ",
+
+	// processes
+	"Processes by network connections (proxy)": "Prozesse nach Netzwerkverbindungen (Näherung)",
+	"Processes by connections":                 "Prozesse nach Verbindungen",
+	"NAME":                                     "NAME",
+	"CONNS":                                    "VERB",
+	"LISTEN":                                   "HÖRT",
+	"CON":                                      "VRB",
+	"LSN":                                      "LSN",
+
+	// session summary
+	"ducknetview session summary":     "ducknetview Sitzungsübersicht",
+	"Duration: %s":                    "Dauer: %s",
+	"Interfaces:":                     "Schnittstellen:",
+	"peak":                            "Spitze",
+	"Listeners: %d added, %d removed": "Lauschende Ports: %d hinzugekommen, %d entfernt",
+	"External IP changes: %d":         "Wechsel der externen IP: %d",
+
+	// quit
+	"quit":                    "beenden",
+	"Quit ducknetview? (y/n)": "ducknetview beenden? (y/n)",
+
+	// kiosk
+	"KIOSK": "KIOSK",
+
+	// updates
+	"update available: %s": "Update verfügbar: %s",
+
+	// process groups
+	"Processes grouped by name":          "Prozesse nach Name gruppiert",
+	"g ungroup • e expand/collapse PIDs": "g Gruppierung aus • e PIDs auf-/zuklappen",
+	"g group":                            "g gruppieren",
+
+	// wildcard expansion
+	"w expand wildcards":   "w Wildcards aufklappen",
+	"w collapse wildcards": "w Wildcards zuklappen",
+
+	// local URLs
+	"opened %s":                       "%s geöffnet",
+	"copied %s":                       "%s kopiert",
+	"Open local URL":                  "Lokale URL öffnen",
+	"enter open • c copy • esc close": "enter öffnen • c kopieren • esc schließen",
+	"no HTTP-looking listeners":       "keine HTTP-artigen Ports",
+	"o open URL":                      "o URL öffnen",
+
+	// port timeline
+	"SEEN":        "GESEHEN",
+	"gone %s ago": "weg vor %s",
+	"new %s ago":  "neu vor %s",
+
+	// connection rate
+	"New connections":       "Neue Verbindungen",
+	"n/a":                   "n. v.",
+	"%s/s  (out %s, in %s)": "%s/s  (ausgehend %s, eingehend %s)",
+	"Top: ":                 "Top: ",
+
+	// stats tab
+	"Stats":                              "Statistik",
+	"ICMP messages":                      "ICMP-Nachrichten",
+	"PROTO":                              "PROTO",
+	"COUNTER":                            "ZÄHLER",
+	"TOTAL":                              "GESAMT",
+	"RATE/s":                             "RATE/s",
+	"ICMP redirect burst: %s/s received": "ICMP-Redirect-Schwall: %s/s empfangen",
+
+	// Events
+	"Events":     "Ereignisse",
+	"Ev":         "Erg",
+	"routes n/a": "Routen n. v.",
+	"No events yet. Route changes, rogue router advertisements and blocklist hits are recorded here.": "Noch keine Ereignisse. Routenänderungen, fremde Router-Advertisements und Blocklisten-Treffer werden hier protokolliert.",
+	"default route (%s) removed: %s":      "Standardroute (%s) entfernt: %s",
+	"default route (%s) added: %s":        "Standardroute (%s) hinzugefügt: %s",
+	"default route (%s) changed: %s → %s": "Standardroute (%s) geändert: %s → %s",
+
+	// Routing
+	"Routing":            "Routing",
+	"Rt":                 "Rt",
+	"Main routing table": "Haupt-Routingtabelle",
+	"Policy rules":       "Richtlinienregeln",
+	"DESTINATION":        "ZIEL",
+	"GATEWAY":            "GATEWAY",
+	"DEV":                "GERÄT",
+	"METRIC":             "METRIK",
+	"PRIO":               "PRIO",
+	"FAM":                "FAM",
+	"SELECTOR":           "SELEKTOR",
+	"TABLE":              "TABELLE",
+	"ROUTES":             "ROUTEN",
+	"DEFAULT":            "STANDARD",
+
+	// Router advertisements
+	"Router advertisements": "Router-Advertisements",
+	"none seen":             "keine gesehen",
+	"unexpected":            "unerwartet",
+	"unexpected router advertisement on %s from %s": "unerwartetes Router-Advertisement auf %s von %s",
+	"lifetime %s  pref %s  flags %s  seen %s ago":   "Lebensdauer %s  Präf. %s  Flags %s  vor %s gesehen",
+	"valid %s  preferred %s":                        "gültig %s  bevorzugt %s",
+
+	// Blocklist
+	"blocklisted connection to %s by %s: %s in %s": "Verbindung zu %s auf Blockliste (%s): %s in %s",
+
+	// Reputation
+	"IP: ":                            "IP: ",
+	"not an IP address: %q":           "keine IP-Adresse: %q",
+	"IP reputation (%s)":              "IP-Reputation (%s)",
+	"enter check • esc close":         "Enter prüfen • Esc schließen",
+	"checking %s…":                    "prüfe %s…",
+	"enter check another • esc close": "Enter weitere prüfen • Esc schließen",
+	"abuse score":                     "Missbrauchswert",
+	"%d reports from %d users":        "%d Meldungen von %d Nutzern",
+	"last reported %s":                "zuletzt gemeldet %s",
+	"Categories: ":                    "Kategorien: ",
+	"reputation lookups are off (start with --reputation)": "Reputationsabfragen sind aus (mit --reputation starten)",
+
+	// Notes
+	"note: ": "Notiz: ",
+	"8080 dev server  •  203.0.113.5 backup box  •  empty note removes": "8080 Dev-Server  •  203.0.113.5 Backup-Box  •  leere Notiz löscht",
+	"note for %s removed":               "Notiz für %s entfernt",
+	"note for %s saved":                 "Notiz für %s gespeichert",
+	"notes are read-only in kiosk mode": "Notizen sind im Kiosk-Modus schreibgeschützt",
+	"notes are unavailable":             "Notizen sind nicht verfügbar",
+
+	// Export
+	"copied %d rows":                   "%d Zeilen kopiert",
+	"exported %d rows to %s":           "%d Zeilen nach %s exportiert",
+	"Export %d rows":                   "%d Zeilen exportieren",
+	"enter export • esc close":         "Enter exportieren • Esc schließen",
+	"CSV file":                         "CSV-Datei",
+	"JSON file":                        "JSON-Datei",
+	"Clipboard (CSV)":                  "Zwischenablage (CSV)",
+	"export is disabled in kiosk mode": "Export ist im Kiosk-Modus deaktiviert",
+
+	// freeze
+	"tab is live again":                  "Tab ist wieder live",
+	"tab frozen; f resumes auto-refresh": "Tab eingefroren; f setzt die Aktualisierung fort",
+	"FROZEN %s":                          "EINGEFROREN %s",
+	"LIVE":                               "LIVE",
+
+	// link gauge
+	"%.1f%% of %s": "%.1f%% von %s",
+
+	// kind breakdown
+	"Throughput by kind": "Durchsatz nach Art",
+	"native":             "nativ",
+	"tunnelled":          "getunnelt",
+	"bridges":            "Bridges",
+	"other":              "sonstige",
+
+	// session totals
+	"This session (%s)":              "Diese Sitzung (%s)",
+	"RX %s  TX %s on physical links": "RX %s  TX %s über physische Links",
+
+	// metered
+	"reputation lookups are paused on a metered connection (m)": "Reputationsabfragen pausieren bei getakteter Verbindung (m)",
+	"METERED":                             "GETAKTET",
+	"not checked on a metered connection": "bei getakteter Verbindung nicht abgefragt",
+	"metered connection detected; external lookups paused":       "getaktete Verbindung erkannt; externe Abfragen pausiert",
+	"metered: external IP, update and reputation lookups paused": "getaktet: externe IP-, Update- und Reputationsabfragen pausiert",
+	"metered mode off": "getakteter Modus aus",
+
+	// extip backoff
+	"(retry %s, ctrl+e now)": "(nächster Versuch %s, ctrl+e sofort)",
+
+	// conns tab
+	"REMOTE":         "ENTFERNT",
+	"STATE":          "STATUS",
+	"Connections":    "Verbindungen",
+	"Conns":          "Verb",
+	"%d connections": "%d Verbindungen",
+
+	// exec probes
+	"Custom":   "Eigene",
+	"Cust":     "Eig",
+	"every %s": "alle %s",
+	"no rows":  "keine Zeilen",
+
+	// bgp
+	"BGP session %s (%s) established": "BGP-Sitzung %s (%s) aufgebaut",
+	"BGP session %s (%s) down: %s":    "BGP-Sitzung %s (%s) getrennt: %s",
+	"BGP sessions":                    "BGP-Sitzungen",
+	"no BGP sessions configured":      "keine BGP-Sitzungen konfiguriert",
+	"PEER":                            "PEER",
+	"NEIGHBOR":                        "NACHBAR",
+	"PFX IN/OUT":                      "PFX EIN/AUS",
+	"SINCE":                           "SEIT",
+
+	// Processes
+	"Processes by network connections, with TCP throughput": "Prozesse nach Netzwerkverbindungen, mit TCP-Durchsatz",
+
+	// Tunnels
+	"Tunnels":         "Tunnel",
+	"any (SOCKS)":     "beliebig (SOCKS)",
+	"(not listening)": "(lauscht nicht)",
+
+	// Firewall
+	"Firewall (nft)":                      "Firewall (nft)",
+	"%d pkts":                             "%d Pakete",
+	"no counted rules for this interface": "keine gezählten Regeln für diese Schnittstelle",
+	"dropped by firewall: %s":             "von der Firewall verworfen: %s",
+	"(%.1f%% of RX)":                      "(%.1f%% von RX)",
+
+	// Kill switch
+	"VPN %s is up again":                                     "VPN %s ist wieder aktiv",
+	"VPN %s went down":                                       "VPN %s ist ausgefallen",
+	"traffic on %s stopped while VPN is down":                "Verkehr auf %s bei ausgefallenem VPN gestoppt",
+	"KILL SWITCH: VPN %s is down but %s is still sending %s": "KILL SWITCH: VPN %s ist aus, aber %s sendet weiter %s",
+	"VPN LEAK": "VPN-LECK",
+
+	// Split tunnel audit
+	"VPN split":     "VPN-Aufteilung",
+	"full tunnel":   "voller Tunnel",
+	"split tunnel":  "Split-Tunnel",
+	"not tunnelled": "nicht getunnelt",
+	"bypassing:":    "am Tunnel vorbei:",
+	"through:":      "durch den Tunnel:",
+	"(+%d more)":    "(+%d weitere)",
+	"%d prefixes through, everything else around": "%d Präfixe durch den Tunnel, alles andere daran vorbei",
+	"%s + %s via %s":                                    "%s + %s über %s",
+	"default route via %s":                              "Standardroute über %s",
+	"policy rule %d → table %s via %s":                  "Richtlinienregel %d → Tabelle %s über %s",
+	"nothing goes through the VPN; all traffic uses %s": "nichts läuft durch das VPN; der gesamte Verkehr nutzt %s",
+	"IPv4 is fully tunnelled but IPv6 is not: IPv6 traffic bypasses the VPN": "IPv4 ist voll getunnelt, IPv6 nicht: IPv6-Verkehr umgeht das VPN",
+
+	// Happy Eyeballs
+	"Host: ":                               "Host: ",
+	"not a host name: %q":                  "kein Hostname: %q",
+	"Happy Eyeballs: IPv4 vs IPv6":         "Happy Eyeballs: IPv4 gegen IPv6",
+	"enter race • esc close":               "Enter verbinden • Esc schließen",
+	"enter race another • esc close":       "Enter weiterer Host • Esc schließen",
+	"connecting to %s over IPv4 and IPv6…": "verbinde mit %s über IPv4 und IPv6…",
+	"ADDRESS":                              "ADRESSE",
+	"DNS":                                  "DNS",
+	"CONNECT":                              "VERBINDUNG",
+	"no address":                           "keine Adresse",
+	"failed":                               "fehlgeschlagen",
+	"first":                                "zuerst",
+	"%s connected %s before %s (DNS included).":                            "%s war %s vor %s verbunden (inkl. DNS).",
+	"Neither IPv4 nor IPv6 connected.":                                     "Weder IPv4 noch IPv6 hat verbunden.",
+	"Clients use IPv6: IPv4 did not connect.":                              "Clients nutzen IPv6: IPv4 hat nicht verbunden.",
+	"Clients use IPv6: it connected first.":                                "Clients nutzen IPv6: es war zuerst verbunden.",
+	"Clients use IPv6: it was slower, but by less than its %s head start.": "Clients nutzen IPv6: es war langsamer, aber um weniger als seinen Vorsprung von %s.",
+	"the name has no AAAA record":                                          "der Name hat keinen AAAA-Eintrag",
+	"the IPv6 connection failed":                                           "die IPv6-Verbindung ist fehlgeschlagen",
+	"the AAAA answer came %s after the A answer, so IPv4 started first":    "die AAAA-Antwort kam %s nach der A-Antwort, daher startete IPv4 zuerst",
+	"the IPv6 handshake took %s longer, more than its %s head start":       "der IPv6-Handshake dauerte %s länger, mehr als sein Vorsprung von %s",
+	"Clients use IPv4: %s.":                                                "Clients nutzen IPv4: %s.",
+
+	// Table sorting
+	"s sort column • S reverse": "s Sortierspalte • S umkehren",
+
+	// Neighbor table
+	"Neighbors (ARP/NDP)":                        "Nachbarn (ARP/NDP)",
+	"watching for new devices • %d seen, %d new": "Überwachung neuer Geräte • %d gesehen, %d neu",
+	"no neighbors":                               "keine Nachbarn",
+	"MAC":                                        "MAC",
+	"new":                                        "neu",
+	"new device on %s: %s (%s)":                  "neues Gerät an %s: %s (%s)",
+
+	// Virtual interface filter
+	"virtual interfaces shown":                                    "virtuelle Schnittstellen eingeblendet",
+	"virtual interfaces hidden (loopback, veth, Docker, bridges)": "virtuelle Schnittstellen ausgeblendet (Loopback, veth, Docker, Bridges)",
+	"v: virtual ifaces hidden":                                    "v: virtuelle ausgeblendet",
+
+	// GeoIP database
+	"GeoIP download failed: %v":            "GeoIP-Download fehlgeschlagen: %v",
+	"GeoIP database %s updated (built %s)": "GeoIP-Datenbank %s aktualisiert (erstellt %s)",
+	"GeoIP: %s":                            "GeoIP: %s",
+	"downloading…":                         "wird heruntergeladen…",
+	"no database; set license_key under [geoip] to download it": "keine Datenbank; license_key unter [geoip] setzen, um sie herunterzuladen",
+	"no database; download paused on a metered connection (m)":  "keine Datenbank; Download auf getakteter Verbindung pausiert (m)",
+	"no database yet":  "noch keine Datenbank",
+	"built %s, %s old": "erstellt %s, %s alt",
+
+	// GeoIP
+	"GEO":                          "GEO",
+	"external IP changed: %s → %s": "externe IP geändert: %s → %s",
+	"changed %s, was %s":           "geändert %s, vorher %s",
+
+	// Format
+	"%s ago": "vor %s",
+
+	// Clock sync
+	"Clock sync: %s":                        "Uhrzeit-Sync: %s",
+	"no time daemon":                        "kein Zeitdienst",
+	"synchronized":                          "synchronisiert",
+	"not synchronized":                      "nicht synchronisiert",
+	"offset %s":                             "Abweichung %s",
+	"per %s":                                "laut %s",
+	"TLS, TOTP and Kerberos may fail":       "TLS, TOTP und Kerberos können scheitern",
+	"system clock synchronized again":       "Systemuhr wieder synchronisiert",
+	"system clock lost NTP synchronization": "Systemuhr hat die NTP-Synchronisation verloren",
+
+	// Latency
+	"Latency":                     "Latenz",
+	"Lat":                         "Lat",
+	"ping every %s, last %d kept": "Ping alle %s, die letzten %d werden gezählt",
+	"TARGET":                      "ZIEL",
+	"LAST":                        "LETZTE",
+	"AVG":                         "MITTEL",
+	"MIN":                         "MIN",
+	"MAX":                         "MAX",
+	"LOSS":                        "VERLUST",
+	"gateway":                     "Gateway",
+	"gateway (IPv6)":              "Gateway (IPv6)",
+	"(TCP): no permission for ICMP, timing a TCP handshake to port 443 instead": "(TCP): keine Berechtigung für ICMP, stattdessen wird der TCP-Handshake zu Port 443 gemessen",
+
+	// Interface picker
+	"no interfaces (yet)":      "(noch) keine Schnittstellen",
+	"Select interface":         "Schnittstelle wählen",
+	"enter select • esc close": "Enter wählen • Esc schließen",
+	"i change":                 "i wechseln",
+
+	// Overview glance
+	"… %d more":              "… %d weitere",
+	"(v hides virtual ones)": "(v blendet virtuelle aus)",
+
+	// Traceroute
+	"HOP":                     "HOP",
+	"BEST":                    "BESTE",
+	"WORST":                   "SCHLECHTESTE",
+	"Traceroute":              "Traceroute",
+	"Trace":                   "Trace",
+	"enter new trace":         "Enter neuer Trace",
+	"done in %s":              "fertig nach %s",
+	"tracing %s… (esc stops)": "verfolge %s… (Esc bricht ab)",
+	"Press enter, type a host and press enter again to trace the route to it.": "Enter drücken, einen Host eingeben und erneut Enter drücken, um die Route dorthin zu verfolgen.",
+
+	// Stopping processes
+	"select a single process (e lists the PIDs of a group)":              "einen einzelnen Prozess wählen (e listet die PIDs einer Gruppe)",
+	"no permission to signal %s (PID %d), it belongs to another user":    "keine Berechtigung, %s (PID %d) ein Signal zu senden, er gehört einem anderen Benutzer",
+	"sent %s to %s (PID %d)":                                             "%s an %s (PID %d) gesendet",
+	"Kill process":                                                       "Prozess abschießen",
+	"Send SIGKILL to %s (PID %d)? It ends at once, without cleaning up.": "SIGKILL an %s (PID %d) senden? Er endet sofort, ohne aufzuräumen.",
+	"Stop process":                                             "Prozess beenden",
+	"Send SIGTERM to %s (PID %d)?":                             "SIGTERM an %s (PID %d) senden?",
+	"y/enter send • any other key cancels":                     "y/Enter senden • jede andere Taste bricht ab",
+	"stopping processes is disabled in kiosk mode":             "Prozesse beenden ist im Kiosk-Modus deaktiviert",
+	"↑↓ select • space mark • enter details • X stop • K kill": "↑↓ wählen • Leertaste markieren • Enter Details • X beenden • K abschießen",
+
+	// External IP per interface
+	"Exits as: %s":                     "Ausgang als: %s",
+	"(same as the default route)":      "(wie die Standardroute)",
+	"(differs from the default route)": "(anders als die Standardroute)",
+	"from %s, %s":                      "von %s, %s",
+
+	// DNS cache
+	"%s cache flushed": "Cache von %s geleert",
+	"DNS cache":        "DNS-Cache",
+	"no local caching resolver (systemd-resolved, dnsmasq)": "kein lokaler cachender Resolver (systemd-resolved, dnsmasq)",
+	"C flush":         "C leeren",
+	"%d entries":      "%d Einträge",
+	"room for %d":     "Platz für %d",
+	"hits %d":         "Treffer %d",
+	"misses %d":       "Fehlschläge %d",
+	"hit rate %.1f%%": "Trefferquote %.1f%%",
+	"flushing the DNS cache is disabled in kiosk mode": "DNS-Cache leeren ist im Kiosk-Modus deaktiviert",
+
+	// Process details
+	"User: %s    Open files: %s":  "Benutzer: %s    Offene Dateien: %s",
+	"(not readable, try as root)": "(nicht lesbar, als root versuchen)",
+	"Command: ":                   "Befehl: ",
+	"Sockets (%d)":                "Sockets (%d)",
+	"none":                        "keine",
+	"esc back • X stop • K kill":  "Esc zurück • X beenden • K abschießen",
+
+	// Port details
+	"↑↓ select • space mark • enter details": "↑↓ auswählen • Leertaste markieren • Enter Details",
+	"esc close": "Esc schließen",
+	"This listener is gone; only what was last seen is left.": "Dieser Listener ist weg; übrig ist nur, was zuletzt zu sehen war.",
+	"Family":                        "Familie",
+	"Process":                       "Prozess",
+	"Command":                       "Befehl",
+	"Owner":                         "Besitzer",
+	"Accept queue":                  "Accept-Queue",
+	"%s waiting, backlog %s":        "%s wartend, Backlog %s",
+	"Queues":                        "Queues",
+	"%s bytes received, %s to send": "%s Bytes empfangen, %s zu senden",
+	"Socket inode":                  "Socket-Inode",
+	"Note":                          "Notiz",
+
+	// DNS query log
+	"Recently resolved":      "Zuletzt aufgelöst",
+	"DNS queries seen on %s": "DNS-Anfragen auf %s",
+	"Capture failed: ":       "Mitschnitt fehlgeschlagen: ",
+	"capture stopped":        "Mitschnitt beendet",
+	"no queries yet":         "noch keine Anfragen",
+
+	// Bandwidth by domain
+	"Bandwidth by domain": "Bandbreite nach Domain",
+	"TCP, approximate":    "TCP, ungefähr",
+	"no traffic":          "kein Verkehr",
+	"%d hosts":            "%d Hosts",
+
+	// External IPv6
+	"External IPv6: %s":        "Externe IPv6: %s",
+	"none (no IPv6 route out)": "keine (keine IPv6-Route nach außen)",
+
+	// session timeline
+	"interface %s went down":       "Schnittstelle %s ist ausgefallen",
+	"interface %s is up again":     "Schnittstelle %s ist wieder aktiv",
+	"listener opened: %s":          "Listener geöffnet: %s",
+	"listener closed: %s":          "Listener geschlossen: %s",
+	"now":                          "jetzt",
+	"↓ %s  ↑ %s on physical links": "↓ %s  ↑ %s auf physischen Verbindungen",
+	"[ ] step • esc back to live":  "[ ] blättern • esc zurück zu live",
+	"! alert  @ external IP  ↕ interface  + listener  • other": "! Warnung  @ externe IP  ↕ Schnittstelle  + Listener  • Sonstiges",
+	"[ ] step through events":                                  "[ ] durch Ereignisse blättern",
+
+	// bulk actions
+	"%d marked": "%d markiert",
+	"x export • t tag • X/K stop/kill • esc unmark":                        "x exportieren • t Notiz • X/K beenden/abschießen • esc Markierung aufheben",
+	"x export • X/K stop/kill • esc unmark":                                "x exportieren • X/K beenden/abschießen • esc Markierung aufheben",
+	"x export • t tag • b block • X/K stop/kill • esc unmark":              "x exportieren • t Notiz • b sperren • X/K beenden/abschießen • esc Markierung aufheben",
+	"no process owns the marked rows":                                      "keine der markierten Zeilen gehört zu einem Prozess",
+	"sent %s to %d of %d processes: %w":                                    "%s an %d von %d Prozessen gesendet: %w",
+	"sent %s to %d processes":                                              "%s an %d Prozesse gesendet",
+	"Kill processes":                                                       "Prozesse abschießen",
+	"Send SIGKILL to %d processes? They end at once, without cleaning up.": "SIGKILL an %d Prozesse senden? Sie enden sofort, ohne aufzuräumen.",
+	"Stop processes":                                                       "Prozesse beenden",
+	"Send SIGTERM to %d processes?":                                        "SIGTERM an %d Prozesse senden?",
+	"nothing to tag here; mark listeners or connections with space":        "hier gibt es nichts zu notieren; Listener oder Verbindungen mit der Leertaste markieren",
+	"tag %d: ":                    "Notiz für %d: ",
+	"notes of %d targets removed": "Notizen von %d Zielen entfernt",
+	"%d targets tagged":           "%d Ziele notiert",
+	"no remote host to block; mark connections with space": "kein entfernter Host zum Sperren; Verbindungen mit der Leertaste markieren",
+	"blocked %d hosts; nft delete table %s lifts it":       "%d Hosts gesperrt; nft delete table %s hebt das auf",
+	"Block hosts": "Hosts sperren",
+	"Drop all traffic to and from %d hosts? They are added to the nftables table %s.": "Jeglichen Verkehr zu und von %d Hosts verwerfen? Sie werden der nftables-Tabelle %s hinzugefügt.",
+	"y/enter block • any other key cancels":                                           "y/Enter sperren • jede andere Taste bricht ab",
+	"blocking hosts is disabled in kiosk mode":                                        "Sperren von Hosts ist im Kiosk-Modus deaktiviert",
+
+	// Link errors
+	"Errors: ":  "Fehler: ",
+	"Drops: ":   "Verworfen: ",
+	"(+%.1f/s)": "(+%.1f/s)",
+	"in":        "ein",
+	"out":       "aus",
+
+	// Interface resets
+	"interface %s disappeared":                              "Schnittstelle %s ist verschwunden",
+	"interface %s is back after %s; its counters restarted": "Schnittstelle %s ist nach %s zurück; ihre Zähler beginnen neu",
+	"interface %s counters were reset":                      "Zähler der Schnittstelle %s wurden zurückgesetzt",
+
+	// Packet rates
+	"p shows packets/s":              "p zeigt Pakete/s",
+	"packets/s • p shows data rates": "Pakete/s • p zeigt Datenraten",
+
+	// Units
+	"rates in bytes per second": "Raten in Bytes pro Sekunde",
+	"rates in bits per second":  "Raten in Bit pro Sekunde",
+
+	// Bandwidth history
+	"History":                              "Verlauf",
+	"Hist":                                 "Verl",
+	"hourly":                               "stündlich",
+	"daily":                                "täglich",
+	"monthly":                              "monatlich",
+	"p hourly/daily/monthly • i interface": "p stündlich/täglich/monatlich • i Schnittstelle",
+	"PERIOD":                               "ZEITRAUM",
+
+	// Help overlay
+	"Keys":                                                        "Tasten",
+	"help":                                                        "Hilfe",
+	"Everywhere":                                                  "Überall",
+	"esc close • ↑↓ scroll":                                       "Esc schließen • ↑↓ blättern",
+	"Switch tabs":                                                 "Tab wechseln",
+	"Cycle tabs":                                                  "Tabs durchgehen",
+	"This help":                                                   "Diese Hilfe",
+	"Refresh the external IP":                                     "Externe IP neu abfragen",
+	"Pick the selected interface":                                 "Gewählte Schnittstelle festlegen",
+	"Hide / show virtual interfaces":                              "Virtuelle Schnittstellen aus-/einblenden",
+	"Rates in bits or bytes per second":                           "Raten in Bit oder Byte pro Sekunde",
+	"Toggle metered mode":                                         "Getakteten Modus umschalten",
+	"Freeze / resume the current tab":                             "Aktuellen Tab einfrieren / fortsetzen",
+	"Export the marked or shown rows":                             "Markierte oder angezeigte Zeilen exportieren",
+	"Add a note to a host or port":                                "Notiz zu Host oder Port hinzufügen",
+	"Check an IP's abuse reputation":                              "Missbrauchs-Reputation einer IP prüfen",
+	"Race IPv4 against IPv6 to a host":                            "IPv4 gegen IPv6 zu einem Host messen",
+	"Scroll":                                                      "Blättern",
+	"Jump to the top / bottom":                                    "Zum Anfang / Ende springen",
+	"Select an interface":                                         "Schnittstelle wählen",
+	"Filter the list":                                             "Liste filtern",
+	"Charts in bytes or packets per second":                       "Diagramme in Byte oder Paketen pro Sekunde",
+	"Select a listener":                                           "Listener wählen",
+	"Socket details; esc closes them":                             "Socket-Details; Esc schließt sie",
+	"Mark / unmark; esc clears the marks":                         "Markieren / Markierung aufheben; Esc hebt alle auf",
+	"Note the ports of the marked or selected listeners":          "Ports der markierten oder gewählten Listener notieren",
+	"Stop / kill their processes":                                 "Ihre Prozesse beenden / abschießen",
+	"Expand / collapse wildcard addresses":                        "Wildcard-Adressen auf-/zuklappen",
+	"Open an HTTP listener in the browser":                        "HTTP-Listener im Browser öffnen",
+	"Search / clear the search":                                   "Suchen / Suche löschen",
+	"Next sort column / reverse the order":                        "Nächste Sortierspalte / Reihenfolge umkehren",
+	"Select a process":                                            "Prozess wählen",
+	"Process details; esc closes them":                            "Prozessdetails; Esc schließt sie",
+	"Send SIGTERM to the marked or selected processes":            "SIGTERM an markierte oder gewählte Prozesse senden",
+	"Send SIGKILL to the marked or selected processes":            "SIGKILL an markierte oder gewählte Prozesse senden",
+	"Group by name / expand the PIDs of groups":                   "Nach Name gruppieren / PIDs der Gruppen aufklappen",
+	"Select a connection":                                         "Verbindung wählen",
+	"Note the remote hosts of the marked or selected connections": "Gegenstellen der markierten oder gewählten Verbindungen notieren",
+	"Block their remote hosts with nftables":                      "Ihre Gegenstellen mit nftables sperren",
+	"Flush the DNS cache":                                         "DNS-Cache leeren",
+	"Step through the events on the timeline":                     "Ereignisse auf der Zeitleiste durchgehen",
+	"Back to live":                                                "Zurück zu live",
+	"Enter a host to trace":                                       "Host für Traceroute eingeben",
+	"Stop the trace":                                              "Traceroute anhalten",
+	"Cycle hourly, daily and monthly totals":                      "Stündliche, tägliche und monatliche Summen durchgehen",
+
+	// Bar charts
+	"c bar charts": "c Balkendiagramme",
+	"c sparklines": "c Sparklines",
+
+	// Pause
+	"paused; P resumes":            "angehalten; P setzt fort",
+	"resumed":                      "fortgesetzt",
+	"PAUSED":                       "ANGEHALTEN",
+	"Pause / resume all refreshes": "Alle Aktualisierungen anhalten / fortsetzen",
+
+	// Refresh interval
+	"refreshing every %s":      "Aktualisierung alle %s",
+	"Sample less / more often": "Seltener / öfter abtasten",
+
+	// Braille charts
+	"c braille charts":                  "c Braille-Diagramme",
+	"Sparklines, bar or braille charts": "Sparklines, Balken- oder Braille-Diagramme",
+
+	// Chart windows
+	"w window %s": "w Zeitraum %s",
+	"session":     "Sitzung",
+	"Chart window: 30s, 5m, 30m or the session": "Diagrammzeitraum: 30s, 5m, 30m oder die Sitzung",
+
+	// Top talkers
+	"Top talkers":                               "Top-Gesprächspartner",
+	"established connections by remote host":    "bestehende Verbindungen nach Gegenstelle",
+	"no byte counts here":                       "hier ohne Bytezähler",
+	"g all connections":                         "g alle Verbindungen",
+	"no established connections to other hosts": "keine bestehenden Verbindungen zu anderen Hosts",
+	"g top talkers":                             "g Top-Gesprächspartner",
+	"Top talkers: connections by remote host":   "Top-Gesprächspartner: Verbindungen nach Gegenstelle",
+
+	// Socket options
+	"Socket options; esc closes them":     "Socket-Optionen; esc schließt sie",
+	"Options":                             "Optionen",
+	"? (needs ss from iproute2)":          "? (braucht ss aus iproute2)",
+	"receive %s, send %s":                 "Empfang %s, Senden %s",
+	"%d dropped":                          "%d verworfen",
+	"Buffers":                             "Puffer",
+	"yes, %d sockets share the address":   "ja, %d Sockets teilen die Adresse",
+	"IPv6 only":                           "Nur IPv6",
+	"no, IPv4 too":                        "nein, auch IPv4",
+	"no timer running":                    "kein Timer aktiv",
+	"on, next probe in %s":                "an, nächste Probe in %s",
+	"system: %s idle, then every %s × %d": "System: %s Leerlauf, dann alle %s × %d",
+	"Keepalive":                           "Keepalive",
+	"SO_REUSEADDR and TCP_NODELAY can't be seen from outside the program.": "SO_REUSEADDR und TCP_NODELAY sind von außerhalb des Programms nicht sichtbar.",
+	"State": "Zustand",
+	"yes":   "ja",
+	"no":    "nein",
+
+	// Firewall tab
+	"Firewall":                   "Firewall",
+	"Fw":                         "Fw",
+	"%d chains, %d rules":        "%d Ketten, %d Regeln",
+	"Firewall ruleset":           "Firewall-Regelwerk",
+	"needs nft or iptables-save": "braucht nft oder iptables-save",
+	"Reading the ruleset needs root or CAP_NET_ADMIN.": "Das Regelwerk zu lesen braucht root oder CAP_NET_ADMIN.",
+	"The ruleset is empty.":                            "Das Regelwerk ist leer.",
+	"table":                                            "Tabelle",
+	"PKTS":                                             "PAKETE",
+	"BYTES":                                            "BYTES",
+	"RATE":                                             "RATE",
+	"RULE":                                             "REGEL",
+	"chain":                                            "Kette",
+	"hook %s priority %d":                              "Hook %s Priorität %d",
+	"policy":                                           "Policy",
+	"no rules":                                         "keine Regeln",
+
+	// Multicast and broadcast
+	"Multicast: ":       "Multicast: ",
+	"Broadcast: ":       "Broadcast: ",
+	"+%.1f/s":           "+%.1f/s",
+	"%.1f%% of packets": "%.1f%% der Pakete",
+	"broadcast storm? %.0f%% of the packets received are broadcasts":        "Broadcast-Sturm? %.0f%% der empfangenen Pakete sind Broadcasts",
+	"broadcasts are counted only by drivers that report them to ethtool -S": "Broadcasts zählen nur Treiber, die sie an ethtool -S melden",
+
+	// Network stack
+	"Network stack": "Netzwerk-Stack",
+	"ok":            "ok",
+	"dropping":      "verwirft",
+	"busy":          "ausgelastet",
+	"%s  softirqs NET_RX %s/s, NET_TX %s/s • %s packets/s": "%s  Softirqs NET_RX %s/s, NET_TX %s/s • %s Pakete/s",
+	"busiest CPU %d runs %.0f%% of NET_RX":                 "CPU %d trägt %.0f%% von NET_RX",
+	"Backlog drops: ":                                      "Backlog-Verluste: ",
+	"budget squeezes: ":                                    "Budget erschöpft: ",
+	"The NICs delivered these packets but the CPU's backlog queue was full: raise net.core.netdev_max_backlog, or spread the load with RPS.": "Die Netzwerkkarten haben diese Pakete geliefert, aber die Backlog-Warteschlange der CPU war voll: net.core.netdev_max_backlog erhöhen oder die Last mit RPS verteilen.",
+	"Softirqs ran out of budget with packets left: net.core.netdev_budget may be too low for this rate.":                                     "Softirqs hatten kein Budget mehr, obwohl Pakete warteten: net.core.netdev_budget ist für diese Rate vielleicht zu niedrig.",
+
+	// Flows tab
+	"Flows": "Flows",
+	"search address / protocol / state / NAT": "Adresse / Protokoll / Zustand / NAT suchen",
+	"SOURCE":                             "QUELLE",
+	"NAT":                                "NAT",
+	"unreplied":                          "ohne Antwort",
+	"no flows match":                     "keine passenden Flows",
+	"no tracked flows":                   "keine verfolgten Flows",
+	"… and %d more; / narrows them down": "… und %d weitere; / grenzt sie ein",
+	"Tracked flows":                      "Verfolgte Flows",
+	"%d flows, %d translated":            "%d Flows, %d übersetzt",
+	"byte counts need net.netfilter.nf_conntrack_acct=1":  "Bytezähler brauchen net.netfilter.nf_conntrack_acct=1",
+	"connection tracking is off (no nf_conntrack module)": "Verbindungsverfolgung ist aus (kein nf_conntrack-Modul)",
+	"Reading the conntrack table needs root.":             "Das Lesen der Conntrack-Tabelle braucht root.",
+
+	// NIC queues
+	"Queues: %d RX, %d TX": "Queues: %d RX, %d TX",
+	"%d interrupts":        "%d Interrupts",
+	"IRQ":                  "IRQ",
+	"CPUS":                 "CPUS",
+	"every busy queue interrupts CPU %s: spread them with irqbalance or /proc/irq/N/smp_affinity_list": "jede aktive Queue unterbricht CPU %s: mit irqbalance oder /proc/irq/N/smp_affinity_list verteilen",
+
+	// Wi-Fi
+	"Wi-Fi":                      "WLAN",
+	"excellent":                  "hervorragend",
+	"good":                       "gut",
+	"fair":                       "mäßig",
+	"weak":                       "schwach",
+	"install iw to see the link": "iw installieren, um die Verbindung zu sehen",
+	"not connected":              "nicht verbunden",
+	"(hidden)":                   "(versteckt)",
+	"%d MHz, %s":                 "%d MHz, %s",
+	"channel %d":                 "Kanal %d",
+	"signal %d dBm":              "Signal %d dBm",
+	"rate":                       "Rate",
+	"%.0f to %.0f dBm":           "%.0f bis %.0f dBm",
+
+	// Link details
+	"full duplex":  "Vollduplex",
+	"half duplex":  "Halbduplex",
+	"carrier up":   "Träger da",
+	"no carrier":   "kein Träger",
+	"(%d changes)": "(%d Wechsel)",
+	"Driver: ":     "Treiber: ",
+	"autoneg":      "Autoneg",
+	"Link: ":       "Link: ",
+	"no cable, or nothing answering at its other end":                   "kein Kabel, oder am anderen Ende antwortet nichts",
+	"negotiated %s, the NIC can do %s: check the cable and switch port": "%s ausgehandelt, die Karte kann %s: Kabel und Switch-Port prüfen",
+	"half duplex: a mismatch with the switch costs throughput":          "Halbduplex: eine Abweichung zum Switch kostet Durchsatz",
+
+	// DHCP lease
+	"DHCP: ":         "DHCP: ",
+	"from %s":        "von %s",
+	"obtained":       "bezogen",
+	"expired %s ago": "vor %s abgelaufen",
+	"expires in %s":  "läuft in %s ab",
+	"router":         "Router",
+	"domain":         "Domain",
+	"the lease ran out: the address may be handed to another host":    "die Lease ist abgelaufen: die Adresse kann an einen anderen Host gehen",
+	"not renewed at half its lifetime: is the DHCP server answering?": "zur Hälfte der Laufzeit nicht erneuert: antwortet der DHCP-Server?",
+
+	// DNS configuration
+	"DNS over TLS":          "DNS über TLS",
+	"DNS over HTTPS":        "DNS über HTTPS",
+	"d time a lookup of %s": "d misst eine Abfrage von %s",
+	"search":                "Suche",
+	"no nameservers configured: lookups go to 127.0.0.1": "keine Nameserver eingetragen: Abfragen gehen an 127.0.0.1",
+	"global":                         "global",
+	"timeout":                        "Zeitüberschreitung",
+	"no resolvers to test":           "keine Resolver zum Testen",
+	"Time a lookup on each resolver": "Eine Abfrage bei jedem Resolver messen",
+
+	// Port history
+	"Ports listened on, this session": "Belegte Ports in dieser Sitzung",
+	"Ports listened on":               "Belegte Ports",
+	"since %s":                        "seit %s",
+	"listening":                       "lauscht",
+	"first %s, not this session":      "zuerst %s, nicht in dieser Sitzung",
+	"%d different ports: it likely binds a new one each start; match it by name, not port": "%d verschiedene Ports: belegt wohl bei jedem Start einen neuen; nach Name statt Port zuordnen",
+
+	// Port classes
+	"well-known": "well-known",
+	"registered": "registriert",
+	"ephemeral":  "ephemer",
+	"Port class": "Portklasse",
+	"Local port": "Lokaler Port",
+	"an outgoing connection can take it while the service is down": "eine ausgehende Verbindung kann ihn belegen, solange der Dienst nicht läuft",
+	"%d from ephemeral ports hidden, e shows them":                 "%d von ephemeren Ports ausgeblendet, e zeigt sie",
+	"e hides ephemeral sources":                                    "e blendet ephemere Quellen aus",
+	"Hide / show connections from ephemeral local ports":           "Verbindungen von ephemeren lokalen Ports aus- / einblenden",
+
+	// Help
+	"Quit":                "Beenden",
+	"Quit (q asks first)": "Beenden (q fragt vorher)",
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// Locale describes how messages, numbers and dates are rendered.
+type Locale struct {
+	Lang     string
+	Decimal  string // decimal separator
+	DateTime string // time.Format layout for full timestamps
+	Clock    string // time.Format layout for wall clock time
+	messages map[string]string
+}
+
+var english = Locale{
+	Lang:     "en",
+	Decimal:  ".",
+	DateTime: "2006-01-02 15:04:05 -07:00",
+	Clock:    "15:04:05",
+}
+
+var locales = map[string]Locale{
+	"en": english,
+	"de": {
+		Lang:     "de",
+		Decimal:  ",",
+		DateTime: "02.01.2006 15:04:05 -07:00",
+		Clock:    "15:04:05",
+		messages: deMessages,
+	},
+	"ru": {
+		Lang:     "ru",
+		Decimal:  ",",
+		DateTime: "02.01.2006 15:04:05 -07:00",
+		Clock:    "15:04:05",
+		messages: ruMessages,
+	},
+}
+
+var current = english
+
+// Available returns the supported language codes.
+func Available() []string {
+	return []string{"en", "de", "ru"}
+}
+
+// Set switches the active locale. Unknown languages fall back to English.
+// Accepts POSIX style values like "de_DE.UTF-8".
+func Set(lang string) {
+	current = lookup(lang)
+}
+
+// Current returns the active locale.
+func Current() Locale {
+	return current
+}
+
+// Detect picks a language from LC_ALL / LC_MESSAGES / LANG.
+func Detect() string {
+	for _, k := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(k); v != "" {
+			return normalize(v)
+		}
+	}
+	return "en"
+}
+
+// T translates a message. The English text is the key, so missing
+// translations simply render in English.
+func T(msg string) string {
+	if s, ok := current.messages[msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Number localizes the decimal separator of an already formatted number,
+// e.g. "1.5 MiB/s" -> "1,5 MiB/s".
+func Number(s string) string {
+	if current.Decimal == "." {
+		return s
+	}
+	return strings.ReplaceAll(s, ".", current.Decimal)
+}
+
+// DateTime formats a full timestamp for the active locale.
+func DateTime(t time.Time) string {
+	return t.Format(current.DateTime)
+}
+
+// Clock formats a wall clock time for the active locale.
+func Clock(t time.Time) string {
+	return t.Format(current.Clock)
+}
+
+func lookup(lang string) Locale {
+	if l, ok := locales[normalize(lang)]; ok {
+		return l
+	}
+	return english
+}
+
+func normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" || lang == "c" || lang == "posix" {
+		return "en"
+	}
+	return lang
+}
@@ -0,0 +1,740 @@
+package i18n
+
+var ruMessages = map[string]string{
+	// tabs / chrome
+	"Overview":   "Обзор",
+	"Interfaces": "Интерфейсы",
+	"Ports":      "Порты",
+	"Processes":  "Процессы",
+	"Ovw":        "Обз",
+	"If":         "Инт",
+	"Procs":      "Проц",
+	"Keys: tab/shift+tab • ←/→ • / search • Ctrl+u clear • ctrl+e ext-ip": "Клавиши: tab/shift+tab • ←/→ • / поиск • Ctrl+u сброс • ctrl+e внеш. IP",
+	"Error: ": "Ошибка: ",
+
+	// overview
+	"Collecting data…":                   "Сбор данных…",
+	"Host: %s":                           "Хост: %s",
+	"Uptime: %s":                         "Аптайм: %s",
+	"Time: %s":                           "Время: %s",
+	"Ifaces: %d total  (%s up, %s down)": "Интерфейсы: всего %d  (%s активны, %s отключены)",
+	"Selected interface":                 "Выбранный интерфейс",
+	"External IP: %s":                    "Внешний IP: %s",
+	"(updated %s)":                       "(обновлён %s)",
+	"External IP error: %s":              "Ошибка внешнего IP: %s",
+
+	// interfaces
+	"MAC %s  RX %s  TX %s": "MAC %s  RX %s  TX %s",
+	"Select an interface…": "Выберите интерфейс…",
+	"UP":                   "ВКЛ",
+	"DOWN":                 "ВЫКЛ",
+	"Addrs: ":              "Адреса: ",
+
+	// search
+	"Press / to search":                    "Нажмите / для поиска",
+	"Filter: ":                             "Фильтр: ",
+	"(press / to change, ctrl+u to clear)": "(/ изменить, ctrl+u сбросить)",
+	"(/ change, ^u clear)":                 "(/ изменить, ^u сброс)",
+	"search port / address / process":      "поиск порта / адреса / процесса",
+	"search process name":                  "поиск по имени процесса",
+	"No data (yet)…":                       "Данных (пока) нет…",
+
+	// ports
+	"Open listening ports": "Открытые слушающие порты",
+	"Listening ports":      "Слушающие порты",
+	"PR":                   "ПР",
+	"LOCAL":                "ЛОКАЛЬНЫЙ",
+	"PID":                  "PID",
+	"PROCESS":              "ПРОЦЕСС",
+	"PROC":                 "ПРОЦ",
+
+	// processes
+	"Processes by network connections (proxy)": "Процессы по сетевым соединениям (приблизительно)",
+	"Processes by connections":                 "Процессы по соединениям",
+	"NAME":                                     "ИМЯ",
+	"CONNS":                                    "СОЕД",
+	"LISTEN":                                   "СЛУШ",
+	"CON":                                      "СОЕ",
+	"LSN":                                      "СЛШ",
+
+	// session summary
+	"ducknetview session summary":     "Итоги сеанса ducknetview",
+	"Duration: %s":                    "Длительность: %s",
+	"Interfaces:":                     "Интерфейсы:",
+	"peak":                            "пик",
+	"Listeners: %d added, %d removed": "Слушающие порты: %d добавлено, %d удалено",
+	"External IP changes: %d":         "Смен внешнего IP: %d",
+
+	// quit
+	"quit":                    "выход",
+	"Quit ducknetview? (y/n)": "Выйти из ducknetview? (y/n)",
+
+	// kiosk
+	"KIOSK": "КИОСК",
+
+	// updates
+	"update available: %s": "доступно обновление: %s",
+
+	// process groups
+	"Processes grouped by name":          "Процессы, сгруппированные по имени",
+	"g ungroup • e expand/collapse PIDs": "g разгруппировать • e показать/скрыть PID",
+	"g group":                            "g группировать",
+
+	// wildcard expansion
+	"w expand wildcards":   "w раскрыть 0.0.0.0/::",
+	"w collapse wildcards": "w свернуть 0.0.0.0/::",
+
+	// local URLs
+	"opened %s":                       "открыто %s",
+	"copied %s":                       "скопировано %s",
+	"Open local URL":                  "Открыть локальный URL",
+	"enter open • c copy • esc close": "enter открыть • c копировать • esc закрыть",
+	"no HTTP-looking listeners":       "нет портов, похожих на HTTP",
+	"o open URL":                      "o открыть URL",
+
+	// port timeline
+	"SEEN":        "ВИДЕН",
+	"gone %s ago": "пропал %s назад",
+	"new %s ago":  "новый %s назад",
+
+	// connection rate
+	"New connections":       "Новые соединения",
+	"n/a":                   "н/д",
+	"%s/s  (out %s, in %s)": "%s/с  (исх. %s, вх. %s)",
+	"Top: ":                 "Топ: ",
+
+	// stats tab
+	"Stats":                              "Статистика",
+	"ICMP messages":                      "ICMP-сообщения",
+	"PROTO":                              "ПРОТО",
+	"COUNTER":                            "СЧЁТЧИК",
+	"TOTAL":                              "ВСЕГО",
+	"RATE/s":                             "В СЕК",
+	"ICMP redirect burst: %s/s received": "Всплеск ICMP redirect: %s/с",
+
+	// Events
+	"Events":     "События",
+	"Ev":         "Соб",
+	"routes n/a": "маршруты н/д",
+	"No events yet. Route changes, rogue router advertisements and blocklist hits are recorded here.": "Событий пока нет. Здесь записываются изменения маршрутов, чужие объявления маршрутизаторов и совпадения с блок-листами.",
+	"default route (%s) removed: %s":      "маршрут по умолчанию (%s) удалён: %s",
+	"default route (%s) added: %s":        "маршрут по умолчанию (%s) добавлен: %s",
+	"default route (%s) changed: %s → %s": "маршрут по умолчанию (%s) изменён: %s → %s",
+
+	// Routing
+	"Routing":            "Маршрутизация",
+	"Rt":                 "Мрш",
+	"Main routing table": "Основная таблица маршрутов",
+	"Policy rules":       "Правила маршрутизации",
+	"DESTINATION":        "НАЗНАЧЕНИЕ",
+	"GATEWAY":            "ШЛЮЗ",
+	"DEV":                "УСТР",
+	"METRIC":             "МЕТРИКА",
+	"PRIO":               "ПРИО",
+	"FAM":                "СЕМ",
+	"SELECTOR":           "СЕЛЕКТОР",
+	"TABLE":              "ТАБЛИЦА",
+	"ROUTES":             "МАРШР",
+	"DEFAULT":            "ПО УМОЛЧ",
+
+	// Router advertisements
+	"Router advertisements": "Объявления маршрутизаторов",
+	"none seen":             "не обнаружено",
+	"unexpected":            "неожиданный",
+	"unexpected router advertisement on %s from %s": "неожиданное объявление маршрутизатора на %s от %s",
+	"lifetime %s  pref %s  flags %s  seen %s ago":   "время жизни %s  приор. %s  флаги %s  получено %s назад",
+	"valid %s  preferred %s":                        "действует %s  предпочтителен %s",
+
+	// Blocklist
+	"blocklisted connection to %s by %s: %s in %s": "соединение с %s из блок-листа (%s): %s в %s",
+
+	// Reputation
+	"IP: ":                            "IP: ",
+	"not an IP address: %q":           "не IP-адрес: %q",
+	"IP reputation (%s)":              "Репутация IP (%s)",
+	"enter check • esc close":         "enter проверить • esc закрыть",
+	"checking %s…":                    "проверка %s…",
+	"enter check another • esc close": "enter проверить другой • esc закрыть",
+	"abuse score":                     "оценка злоупотреблений",
+	"%d reports from %d users":        "%d жалоб от %d пользователей",
+	"last reported %s":                "последняя жалоба %s",
+	"Categories: ":                    "Категории: ",
+	"reputation lookups are off (start with --reputation)": "проверка репутации выключена (запустите с --reputation)",
+
+	// Notes
+	"note: ": "заметка: ",
+	"8080 dev server  •  203.0.113.5 backup box  •  empty note removes": "8080 dev-сервер  •  203.0.113.5 бэкап  •  пустая заметка удаляет",
+	"note for %s removed":               "заметка для %s удалена",
+	"note for %s saved":                 "заметка для %s сохранена",
+	"notes are read-only in kiosk mode": "в режиме киоска заметки только для чтения",
+	"notes are unavailable":             "заметки недоступны",
+
+	// Export
+	"copied %d rows":                   "скопировано строк: %d",
+	"exported %d rows to %s":           "экспортировано строк: %d в %s",
+	"Export %d rows":                   "Экспорт строк: %d",
+	"enter export • esc close":         "enter экспорт • esc закрыть",
+	"CSV file":                         "Файл CSV",
+	"JSON file":                        "Файл JSON",
+	"Clipboard (CSV)":                  "Буфер обмена (CSV)",
+	"export is disabled in kiosk mode": "экспорт отключён в режиме киоска",
+
+	// freeze
+	"tab is live again":                  "вкладка снова обновляется",
+	"tab frozen; f resumes auto-refresh": "вкладка заморожена; f возобновит обновление",
+	"FROZEN %s":                          "ЗАМОРОЖЕНО %s",
+	"LIVE":                               "ОНЛАЙН",
+
+	// link gauge
+	"%.1f%% of %s": "%.1f%% из %s",
+
+	// kind breakdown
+	"Throughput by kind": "Трафик по типам",
+	"native":             "напрямую",
+	"tunnelled":          "через туннели",
+	"bridges":            "мосты",
+	"other":              "прочее",
+
+	// session totals
+	"This session (%s)":              "За сеанс (%s)",
+	"RX %s  TX %s on physical links": "RX %s  TX %s по физическим линкам",
+
+	// metered
+	"reputation lookups are paused on a metered connection (m)": "проверки репутации приостановлены на лимитном подключении (m)",
+	"METERED":                             "ЛИМИТ",
+	"not checked on a metered connection": "не проверяется на лимитном подключении",
+	"metered connection detected; external lookups paused":       "обнаружено лимитное подключение; внешние запросы приостановлены",
+	"metered: external IP, update and reputation lookups paused": "лимит: запросы внешнего IP, обновлений и репутации приостановлены",
+	"metered mode off": "лимитный режим выключен",
+
+	// extip backoff
+	"(retry %s, ctrl+e now)": "(повтор в %s, ctrl+e сейчас)",
+
+	// conns tab
+	"REMOTE":         "УДАЛЁННЫЙ",
+	"STATE":          "СОСТ.",
+	"Connections":    "Соединения",
+	"Conns":          "Соед",
+	"%d connections": "соединений: %d",
+
+	// exec probes
+	"Custom":   "Свои",
+	"Cust":     "Свои",
+	"every %s": "каждые %s",
+	"no rows":  "нет строк",
+
+	// bgp
+	"BGP session %s (%s) established": "BGP-сессия %s (%s) установлена",
+	"BGP session %s (%s) down: %s":    "BGP-сессия %s (%s) упала: %s",
+	"BGP sessions":                    "BGP-сессии",
+	"no BGP sessions configured":      "BGP-сессии не настроены",
+	"PEER":                            "ПИР",
+	"NEIGHBOR":                        "СОСЕД",
+	"PFX IN/OUT":                      "ПРЕФ ВХ/ИСХ",
+	"SINCE":                           "С",
+
+	// Processes
+	"Processes by network connections, with TCP throughput": "Процессы по сетевым соединениям, с TCP-трафиком",
+
+	// Tunnels
+	"Tunnels":         "Туннели",
+	"any (SOCKS)":     "любой (SOCKS)",
+	"(not listening)": "(не слушает)",
+
+	// Firewall
+	"Firewall (nft)":                      "Брандмауэр (nft)",
+	"%d pkts":                             "%d пакетов",
+	"no counted rules for this interface": "нет правил со счётчиками для этого интерфейса",
+	"dropped by firewall: %s":             "отброшено брандмауэром: %s",
+	"(%.1f%% of RX)":                      "(%.1f%% от RX)",
+
+	// Kill switch
+	"VPN %s is up again":                                     "VPN %s снова поднят",
+	"VPN %s went down":                                       "VPN %s упал",
+	"traffic on %s stopped while VPN is down":                "трафик на %s прекратился, пока VPN недоступен",
+	"KILL SWITCH: VPN %s is down but %s is still sending %s": "KILL SWITCH: VPN %s недоступен, но %s продолжает отправлять %s",
+	"VPN LEAK": "УТЕЧКА VPN",
+
+	// Split tunnel audit
+	"VPN split":     "Разделение VPN",
+	"full tunnel":   "полный туннель",
+	"split tunnel":  "раздельный туннель",
+	"not tunnelled": "не туннелируется",
+	"bypassing:":    "в обход:",
+	"through:":      "через туннель:",
+	"(+%d more)":    "(+%d ещё)",
+	"%d prefixes through, everything else around": "%d префиксов через туннель, остальное в обход",
+	"%s + %s via %s":                                    "%s + %s через %s",
+	"default route via %s":                              "маршрут по умолчанию через %s",
+	"policy rule %d → table %s via %s":                  "правило %d → таблица %s через %s",
+	"nothing goes through the VPN; all traffic uses %s": "через VPN ничего не идёт; весь трафик идёт через %s",
+	"IPv4 is fully tunnelled but IPv6 is not: IPv6 traffic bypasses the VPN": "IPv4 полностью туннелируется, а IPv6 нет: трафик IPv6 идёт в обход VPN",
+
+	// Happy Eyeballs
+	"Host: ":                               "Хост: ",
+	"not a host name: %q":                  "не имя хоста: %q",
+	"Happy Eyeballs: IPv4 vs IPv6":         "Happy Eyeballs: IPv4 против IPv6",
+	"enter race • esc close":               "enter проверить • esc закрыть",
+	"enter race another • esc close":       "enter другой хост • esc закрыть",
+	"connecting to %s over IPv4 and IPv6…": "подключение к %s по IPv4 и IPv6…",
+	"ADDRESS":                              "АДРЕС",
+	"DNS":                                  "DNS",
+	"CONNECT":                              "ПОДКЛЮЧЕНИЕ",
+	"no address":                           "нет адреса",
+	"failed":                               "ошибка",
+	"first":                                "первым",
+	"%s connected %s before %s (DNS included).":                            "%s подключился на %s раньше %s (с учётом DNS).",
+	"Neither IPv4 nor IPv6 connected.":                                     "Не удалось подключиться ни по IPv4, ни по IPv6.",
+	"Clients use IPv6: IPv4 did not connect.":                              "Клиенты используют IPv6: IPv4 не подключился.",
+	"Clients use IPv6: it connected first.":                                "Клиенты используют IPv6: он подключился первым.",
+	"Clients use IPv6: it was slower, but by less than its %s head start.": "Клиенты используют IPv6: он медленнее, но меньше чем на фору в %s.",
+	"the name has no AAAA record":                                          "у имени нет записи AAAA",
+	"the IPv6 connection failed":                                           "подключение по IPv6 не удалось",
+	"the AAAA answer came %s after the A answer, so IPv4 started first":    "ответ AAAA пришёл на %s позже ответа A, поэтому IPv4 начал первым",
+	"the IPv6 handshake took %s longer, more than its %s head start":       "рукопожатие IPv6 заняло на %s дольше — больше форы в %s",
+	"Clients use IPv4: %s.":                                                "Клиенты используют IPv4: %s.",
+
+	// Table sorting
+	"s sort column • S reverse": "s столбец сортировки • S обратный порядок",
+
+	// Neighbor table
+	"Neighbors (ARP/NDP)":                        "Соседи (ARP/NDP)",
+	"watching for new devices • %d seen, %d new": "отслеживание новых устройств • видно %d, новых %d",
+	"no neighbors":                               "нет соседей",
+	"MAC":                                        "MAC",
+	"new":                                        "новый",
+	"new device on %s: %s (%s)":                  "новое устройство на %s: %s (%s)",
+
+	// Virtual interface filter
+	"virtual interfaces shown":                                    "виртуальные интерфейсы показаны",
+	"virtual interfaces hidden (loopback, veth, Docker, bridges)": "виртуальные интерфейсы скрыты (loopback, veth, Docker, мосты)",
+	"v: virtual ifaces hidden":                                    "v: виртуальные скрыты",
+
+	// GeoIP database
+	"GeoIP download failed: %v":            "ошибка загрузки GeoIP: %v",
+	"GeoIP database %s updated (built %s)": "база GeoIP %s обновлена (собрана %s)",
+	"GeoIP: %s":                            "GeoIP: %s",
+	"downloading…":                         "загрузка…",
+	"no database; set license_key under [geoip] to download it": "нет базы; укажите license_key в [geoip] для загрузки",
+	"no database; download paused on a metered connection (m)":  "нет базы; загрузка приостановлена на лимитном подключении (m)",
+	"no database yet":  "базы пока нет",
+	"built %s, %s old": "собрана %s, возраст %s",
+
+	// GeoIP
+	"GEO":                          "ГЕО",
+	"external IP changed: %s → %s": "внешний IP изменился: %s → %s",
+	"changed %s, was %s":           "изменён %s, был %s",
+
+	// Format
+	"%s ago": "%s назад",
+
+	// Clock sync
+	"Clock sync: %s":                        "Синхронизация часов: %s",
+	"no time daemon":                        "нет службы времени",
+	"synchronized":                          "синхронизированы",
+	"not synchronized":                      "не синхронизированы",
+	"offset %s":                             "смещение %s",
+	"per %s":                                "по %s",
+	"TLS, TOTP and Kerberos may fail":       "TLS, TOTP и Kerberos могут не работать",
+	"system clock synchronized again":       "системные часы снова синхронизированы",
+	"system clock lost NTP synchronization": "системные часы потеряли синхронизацию NTP",
+
+	// Latency
+	"Latency":                     "Задержка",
+	"Lat":                         "Зад",
+	"ping every %s, last %d kept": "пинг каждые %s, учитываются последние %d",
+	"TARGET":                      "ЦЕЛЬ",
+	"LAST":                        "ПОСЛ",
+	"AVG":                         "СРЕД",
+	"MIN":                         "МИН",
+	"MAX":                         "МАКС",
+	"LOSS":                        "ПОТЕРИ",
+	"gateway":                     "шлюз",
+	"gateway (IPv6)":              "шлюз (IPv6)",
+	"(TCP): no permission for ICMP, timing a TCP handshake to port 443 instead": "(TCP): нет прав на ICMP, вместо этого измеряется TCP-рукопожатие с портом 443",
+
+	// Interface picker
+	"no interfaces (yet)":      "интерфейсов (пока) нет",
+	"Select interface":         "Выбор интерфейса",
+	"enter select • esc close": "enter выбрать • esc закрыть",
+	"i change":                 "i сменить",
+
+	// Overview glance
+	"… %d more":              "… ещё %d",
+	"(v hides virtual ones)": "(v скрывает виртуальные)",
+
+	// Traceroute
+	"HOP":                     "ХОП",
+	"BEST":                    "ЛУЧШ",
+	"WORST":                   "ХУДШ",
+	"Traceroute":              "Трассировка",
+	"Trace":                   "Трасс",
+	"enter new trace":         "enter новая трассировка",
+	"done in %s":              "готово за %s",
+	"tracing %s… (esc stops)": "трассировка %s… (esc — стоп)",
+	"Press enter, type a host and press enter again to trace the route to it.": "Нажмите enter, введите хост и снова нажмите enter, чтобы проследить маршрут до него.",
+
+	// Stopping processes
+	"select a single process (e lists the PIDs of a group)":              "выберите отдельный процесс (e показывает PID группы)",
+	"no permission to signal %s (PID %d), it belongs to another user":    "нет прав на отправку сигнала %s (PID %d), он принадлежит другому пользователю",
+	"sent %s to %s (PID %d)":                                             "%s отправлен %s (PID %d)",
+	"Kill process":                                                       "Убить процесс",
+	"Send SIGKILL to %s (PID %d)? It ends at once, without cleaning up.": "Отправить SIGKILL %s (PID %d)? Процесс завершится сразу, без очистки.",
+	"Stop process":                                             "Остановить процесс",
+	"Send SIGTERM to %s (PID %d)?":                             "Отправить SIGTERM %s (PID %d)?",
+	"y/enter send • any other key cancels":                     "y/enter отправить • любая другая клавиша — отмена",
+	"stopping processes is disabled in kiosk mode":             "остановка процессов отключена в режиме киоска",
+	"↑↓ select • space mark • enter details • X stop • K kill": "↑↓ выбор • пробел отметить • enter подробно • X остановить • K убить",
+
+	// External IP per interface
+	"Exits as: %s":                     "Выход как: %s",
+	"(same as the default route)":      "(как у маршрута по умолчанию)",
+	"(differs from the default route)": "(отличается от маршрута по умолчанию)",
+	"from %s, %s":                      "с %s, %s",
+
+	// DNS cache
+	"%s cache flushed": "кэш %s очищен",
+	"DNS cache":        "DNS-кэш",
+	"no local caching resolver (systemd-resolved, dnsmasq)": "нет локального кэширующего резолвера (systemd-resolved, dnsmasq)",
+	"C flush":         "C очистить",
+	"%d entries":      "записей: %d",
+	"room for %d":     "вмещает %d",
+	"hits %d":         "попаданий %d",
+	"misses %d":       "промахов %d",
+	"hit rate %.1f%%": "доля попаданий %.1f%%",
+	"flushing the DNS cache is disabled in kiosk mode": "очистка DNS-кэша отключена в режиме киоска",
+
+	// Process details
+	"User: %s    Open files: %s":  "Пользователь: %s    Открытых файлов: %s",
+	"(not readable, try as root)": "(недоступно, попробуйте от root)",
+	"Command: ":                   "Команда: ",
+	"Sockets (%d)":                "Сокеты (%d)",
+	"none":                        "нет",
+	"esc back • X stop • K kill":  "esc назад • X остановить • K убить",
+
+	// Port details
+	"↑↓ select • space mark • enter details": "↑↓ выбор • пробел отметить • Enter подробности",
+	"esc close": "Esc закрыть",
+	"This listener is gone; only what was last seen is left.": "Этот порт больше не слушается; осталось только то, что было видно последним.",
+	"Family":                        "Семейство",
+	"Process":                       "Процесс",
+	"Command":                       "Команда",
+	"Owner":                         "Владелец",
+	"Accept queue":                  "Очередь accept",
+	"%s waiting, backlog %s":        "%s в ожидании, backlog %s",
+	"Queues":                        "Очереди",
+	"%s bytes received, %s to send": "%s байт получено, %s к отправке",
+	"Socket inode":                  "Inode сокета",
+	"Note":                          "Заметка",
+
+	// DNS query log
+	"Recently resolved":      "Недавно разрешённые имена",
+	"DNS queries seen on %s": "DNS-запросы на %s",
+	"Capture failed: ":       "Ошибка захвата: ",
+	"capture stopped":        "захват остановлен",
+	"no queries yet":         "запросов пока нет",
+
+	// Bandwidth by domain
+	"Bandwidth by domain": "Трафик по доменам",
+	"TCP, approximate":    "TCP, приблизительно",
+	"no traffic":          "трафика нет",
+	"%d hosts":            "хостов: %d",
+
+	// External IPv6
+	"External IPv6: %s":        "Внешний IPv6: %s",
+	"none (no IPv6 route out)": "нет (нет маршрута IPv6 наружу)",
+
+	// session timeline
+	"interface %s went down":       "интерфейс %s отключился",
+	"interface %s is up again":     "интерфейс %s снова включён",
+	"listener opened: %s":          "открыт слушающий сокет: %s",
+	"listener closed: %s":          "закрыт слушающий сокет: %s",
+	"now":                          "сейчас",
+	"↓ %s  ↑ %s on physical links": "↓ %s  ↑ %s на физических интерфейсах",
+	"[ ] step • esc back to live":  "[ ] листать • esc вернуться к текущему",
+	"! alert  @ external IP  ↕ interface  + listener  • other": "! тревога  @ внешний IP  ↕ интерфейс  + сокет  • прочее",
+	"[ ] step through events":                                  "[ ] листать события",
+
+	// bulk actions
+	"%d marked": "отмечено: %d",
+	"x export • t tag • X/K stop/kill • esc unmark":                        "x экспорт • t заметка • X/K остановить/убить • esc снять отметки",
+	"x export • X/K stop/kill • esc unmark":                                "x экспорт • X/K остановить/убить • esc снять отметки",
+	"x export • t tag • b block • X/K stop/kill • esc unmark":              "x экспорт • t заметка • b заблокировать • X/K остановить/убить • esc снять отметки",
+	"no process owns the marked rows":                                      "ни одна отмеченная строка не принадлежит процессу",
+	"sent %s to %d of %d processes: %w":                                    "%s отправлен %d из %d процессов: %w",
+	"sent %s to %d processes":                                              "%s отправлен %d процессам",
+	"Kill processes":                                                       "Убить процессы",
+	"Send SIGKILL to %d processes? They end at once, without cleaning up.": "Отправить SIGKILL %d процессам? Они завершатся сразу, без очистки.",
+	"Stop processes":                                                       "Остановить процессы",
+	"Send SIGTERM to %d processes?":                                        "Отправить SIGTERM %d процессам?",
+	"nothing to tag here; mark listeners or connections with space":        "здесь нечего помечать; отметьте сокеты или соединения пробелом",
+	"tag %d: ":                    "заметка для %d: ",
+	"notes of %d targets removed": "заметки для %d целей удалены",
+	"%d targets tagged":           "заметка добавлена к %d целям",
+	"no remote host to block; mark connections with space": "нет удалённых хостов для блокировки; отметьте соединения пробелом",
+	"blocked %d hosts; nft delete table %s lifts it":       "заблокировано хостов: %d; nft delete table %s снимает блокировку",
+	"Block hosts": "Заблокировать хосты",
+	"Drop all traffic to and from %d hosts? They are added to the nftables table %s.": "Отбрасывать весь трафик к %d хостам и от них? Они будут добавлены в таблицу nftables %s.",
+	"y/enter block • any other key cancels":                                           "y/enter заблокировать • любая другая клавиша отменяет",
+	"blocking hosts is disabled in kiosk mode":                                        "блокировка хостов отключена в режиме киоска",
+
+	// Link errors
+	"Errors: ":  "Ошибки: ",
+	"Drops: ":   "Отброшено: ",
+	"(+%.1f/s)": "(+%.1f/с)",
+	"in":        "вх",
+	"out":       "исх",
+
+	// Interface resets
+	"interface %s disappeared":                              "интерфейс %s исчез",
+	"interface %s is back after %s; its counters restarted": "интерфейс %s вернулся через %s; его счётчики начались заново",
+	"interface %s counters were reset":                      "счётчики интерфейса %s были сброшены",
+
+	// Packet rates
+	"p shows packets/s":              "p — пакеты/с",
+	"packets/s • p shows data rates": "пакеты/с • p — скорость данных",
+
+	// Units
+	"rates in bytes per second": "скорости в байтах в секунду",
+	"rates in bits per second":  "скорости в битах в секунду",
+
+	// Bandwidth history
+	"History":                              "История",
+	"Hist":                                 "Ист",
+	"hourly":                               "по часам",
+	"daily":                                "по дням",
+	"monthly":                              "по месяцам",
+	"p hourly/daily/monthly • i interface": "p по часам/дням/месяцам • i интерфейс",
+	"PERIOD":                               "ПЕРИОД",
+
+	// Help overlay
+	"Keys":                                                        "Клавиши",
+	"help":                                                        "справка",
+	"Everywhere":                                                  "Везде",
+	"esc close • ↑↓ scroll":                                       "esc закрыть • ↑↓ прокрутка",
+	"Switch tabs":                                                 "Переключить вкладку",
+	"Cycle tabs":                                                  "Перебрать вкладки",
+	"This help":                                                   "Эта справка",
+	"Refresh the external IP":                                     "Обновить внешний IP",
+	"Pick the selected interface":                                 "Выбрать интерфейс",
+	"Hide / show virtual interfaces":                              "Скрыть / показать виртуальные интерфейсы",
+	"Rates in bits or bytes per second":                           "Скорости в битах или байтах в секунду",
+	"Toggle metered mode":                                         "Переключить лимитный режим",
+	"Freeze / resume the current tab":                             "Заморозить / продолжить текущую вкладку",
+	"Export the marked or shown rows":                             "Экспортировать отмеченные или показанные строки",
+	"Add a note to a host or port":                                "Добавить заметку к хосту или порту",
+	"Check an IP's abuse reputation":                              "Проверить репутацию IP",
+	"Race IPv4 against IPv6 to a host":                            "Сравнить IPv4 и IPv6 до хоста",
+	"Scroll":                                                      "Прокрутка",
+	"Jump to the top / bottom":                                    "В начало / в конец",
+	"Select an interface":                                         "Выбрать интерфейс",
+	"Filter the list":                                             "Фильтровать список",
+	"Charts in bytes or packets per second":                       "Графики в байтах или пакетах в секунду",
+	"Select a listener":                                           "Выбрать слушающий сокет",
+	"Socket details; esc closes them":                             "Сведения о сокете; esc закрывает",
+	"Mark / unmark; esc clears the marks":                         "Отметить / снять отметку; esc снимает все",
+	"Note the ports of the marked or selected listeners":          "Заметка к портам отмеченных или выбранного сокета",
+	"Stop / kill their processes":                                 "Остановить / убить их процессы",
+	"Expand / collapse wildcard addresses":                        "Развернуть / свернуть адреса-шаблоны",
+	"Open an HTTP listener in the browser":                        "Открыть HTTP-сокет в браузере",
+	"Search / clear the search":                                   "Поиск / сбросить поиск",
+	"Next sort column / reverse the order":                        "Следующий столбец сортировки / обратный порядок",
+	"Select a process":                                            "Выбрать процесс",
+	"Process details; esc closes them":                            "Сведения о процессе; esc закрывает",
+	"Send SIGTERM to the marked or selected processes":            "Послать SIGTERM отмеченным или выбранному процессу",
+	"Send SIGKILL to the marked or selected processes":            "Послать SIGKILL отмеченным или выбранному процессу",
+	"Group by name / expand the PIDs of groups":                   "Группировать по имени / развернуть PID групп",
+	"Select a connection":                                         "Выбрать соединение",
+	"Note the remote hosts of the marked or selected connections": "Заметка к удалённым хостам отмеченных или выбранного соединения",
+	"Block their remote hosts with nftables":                      "Заблокировать их удалённые хосты через nftables",
+	"Flush the DNS cache":                                         "Очистить кэш DNS",
+	"Step through the events on the timeline":                     "Переходить по событиям на шкале времени",
+	"Back to live":                                                "Вернуться к текущему",
+	"Enter a host to trace":                                       "Ввести хост для трассировки",
+	"Stop the trace":                                              "Остановить трассировку",
+	"Cycle hourly, daily and monthly totals":                      "Почасовые, дневные и месячные итоги по кругу",
+
+	// Bar charts
+	"c bar charts": "c гистограммы",
+	"c sparklines": "c спарклайны",
+
+	// Pause
+	"paused; P resumes":            "пауза; P продолжает",
+	"resumed":                      "продолжено",
+	"PAUSED":                       "ПАУЗА",
+	"Pause / resume all refreshes": "Приостановить / продолжить все обновления",
+
+	// Refresh interval
+	"refreshing every %s":      "обновление каждые %s",
+	"Sample less / more often": "Опрашивать реже / чаще",
+
+	// Braille charts
+	"c braille charts":                  "c диаграммы Брайля",
+	"Sparklines, bar or braille charts": "Спарклайны, гистограммы или диаграммы Брайля",
+
+	// Chart windows
+	"w window %s": "w окно %s",
+	"session":     "сеанс",
+	"Chart window: 30s, 5m, 30m or the session": "Окно графиков: 30s, 5m, 30m или весь сеанс",
+
+	// Top talkers
+	"Top talkers":                               "Главные собеседники",
+	"established connections by remote host":    "установленные соединения по удалённому хосту",
+	"no byte counts here":                       "без счётчиков байт",
+	"g all connections":                         "g все соединения",
+	"no established connections to other hosts": "нет установленных соединений с другими хостами",
+	"g top talkers":                             "g главные собеседники",
+	"Top talkers: connections by remote host":   "Главные собеседники: соединения по удалённому хосту",
+
+	// Socket options
+	"Socket options; esc closes them":     "Параметры сокета; esc закрывает",
+	"Options":                             "Параметры",
+	"? (needs ss from iproute2)":          "? (нужен ss из iproute2)",
+	"receive %s, send %s":                 "приём %s, отправка %s",
+	"%d dropped":                          "%d отброшено",
+	"Buffers":                             "Буферы",
+	"yes, %d sockets share the address":   "да, адрес делят %d сокетов",
+	"IPv6 only":                           "Только IPv6",
+	"no, IPv4 too":                        "нет, и IPv4",
+	"no timer running":                    "таймер не запущен",
+	"on, next probe in %s":                "вкл., следующая проба через %s",
+	"system: %s idle, then every %s × %d": "система: %s простоя, затем каждые %s × %d",
+	"Keepalive":                           "Keepalive",
+	"SO_REUSEADDR and TCP_NODELAY can't be seen from outside the program.": "SO_REUSEADDR и TCP_NODELAY не видны снаружи программы.",
+	"State": "Состояние",
+	"yes":   "да",
+	"no":    "нет",
+
+	// Firewall tab
+	"Firewall":                   "Брандмауэр",
+	"Fw":                         "Бр",
+	"%d chains, %d rules":        "цепочек: %d, правил: %d",
+	"Firewall ruleset":           "Правила брандмауэра",
+	"needs nft or iptables-save": "нужен nft или iptables-save",
+	"Reading the ruleset needs root or CAP_NET_ADMIN.": "Для чтения правил нужен root или CAP_NET_ADMIN.",
+	"The ruleset is empty.":                            "Правил нет.",
+	"table":                                            "таблица",
+	"PKTS":                                             "ПАКЕТЫ",
+	"BYTES":                                            "БАЙТЫ",
+	"RATE":                                             "СКОРОСТЬ",
+	"RULE":                                             "ПРАВИЛО",
+	"chain":                                            "цепочка",
+	"hook %s priority %d":                              "хук %s приоритет %d",
+	"policy":                                           "политика",
+	"no rules":                                         "нет правил",
+
+	// Multicast and broadcast
+	"Multicast: ":       "Multicast: ",
+	"Broadcast: ":       "Broadcast: ",
+	"+%.1f/s":           "+%.1f/с",
+	"%.1f%% of packets": "%.1f%% пакетов",
+	"broadcast storm? %.0f%% of the packets received are broadcasts":        "широковещательный шторм? %.0f%% принятых пакетов — broadcast",
+	"broadcasts are counted only by drivers that report them to ethtool -S": "broadcast считают только драйверы, которые сообщают их в ethtool -S",
+
+	// Network stack
+	"Network stack": "Сетевой стек",
+	"ok":            "норма",
+	"dropping":      "теряет",
+	"busy":          "загружен",
+	"%s  softirqs NET_RX %s/s, NET_TX %s/s • %s packets/s": "%s  softirq NET_RX %s/с, NET_TX %s/с • %s пакетов/с",
+	"busiest CPU %d runs %.0f%% of NET_RX":                 "CPU %d выполняет %.0f%% NET_RX",
+	"Backlog drops: ":                                      "Потери backlog: ",
+	"budget squeezes: ":                                    "исчерпан бюджет: ",
+	"The NICs delivered these packets but the CPU's backlog queue was full: raise net.core.netdev_max_backlog, or spread the load with RPS.": "Сетевые карты доставили эти пакеты, но очередь backlog процессора была полна: увеличьте net.core.netdev_max_backlog или распределите нагрузку через RPS.",
+	"Softirqs ran out of budget with packets left: net.core.netdev_budget may be too low for this rate.":                                     "Softirq исчерпали бюджет, а пакеты ещё оставались: net.core.netdev_budget может быть мал для такой скорости.",
+
+	// Flows tab
+	"Flows": "Потоки",
+	"search address / protocol / state / NAT": "поиск адреса / протокола / состояния / NAT",
+	"SOURCE":                             "ИСТОЧНИК",
+	"NAT":                                "NAT",
+	"unreplied":                          "без ответа",
+	"no flows match":                     "нет подходящих потоков",
+	"no tracked flows":                   "нет отслеживаемых потоков",
+	"… and %d more; / narrows them down": "… и ещё %d; / сузит список",
+	"Tracked flows":                      "Отслеживаемые потоки",
+	"%d flows, %d translated":            "потоков: %d, с трансляцией: %d",
+	"byte counts need net.netfilter.nf_conntrack_acct=1":  "для счётчиков байт нужен net.netfilter.nf_conntrack_acct=1",
+	"connection tracking is off (no nf_conntrack module)": "отслеживание соединений выключено (нет модуля nf_conntrack)",
+	"Reading the conntrack table needs root.":             "Чтение таблицы conntrack требует root.",
+
+	// NIC queues
+	"Queues: %d RX, %d TX": "Очереди: %d RX, %d TX",
+	"%d interrupts":        "прерываний: %d",
+	"IRQ":                  "IRQ",
+	"CPUS":                 "ЦП",
+	"every busy queue interrupts CPU %s: spread them with irqbalance or /proc/irq/N/smp_affinity_list": "все активные очереди прерывают ЦП %s: распределите их через irqbalance или /proc/irq/N/smp_affinity_list",
+
+	// Wi-Fi
+	"Wi-Fi":                      "Wi-Fi",
+	"excellent":                  "отличный",
+	"good":                       "хороший",
+	"fair":                       "средний",
+	"weak":                       "слабый",
+	"install iw to see the link": "установите iw, чтобы видеть связь",
+	"not connected":              "не подключено",
+	"(hidden)":                   "(скрыта)",
+	"%d MHz, %s":                 "%d МГц, %s",
+	"channel %d":                 "канал %d",
+	"signal %d dBm":              "сигнал %d дБм",
+	"rate":                       "скорость",
+	"%.0f to %.0f dBm":           "от %.0f до %.0f дБм",
+
+	// Link details
+	"full duplex":  "полный дуплекс",
+	"half duplex":  "полудуплекс",
+	"carrier up":   "несущая есть",
+	"no carrier":   "нет несущей",
+	"(%d changes)": "(смен: %d)",
+	"Driver: ":     "Драйвер: ",
+	"autoneg":      "автосогласование",
+	"Link: ":       "Линк: ",
+	"no cable, or nothing answering at its other end":                   "нет кабеля или на другом конце никто не отвечает",
+	"negotiated %s, the NIC can do %s: check the cable and switch port": "согласовано %s, карта умеет %s: проверьте кабель и порт коммутатора",
+	"half duplex: a mismatch with the switch costs throughput":          "полудуплекс: несовпадение с коммутатором снижает пропускную способность",
+
+	// DHCP lease
+	"DHCP: ":         "DHCP: ",
+	"from %s":        "от %s",
+	"obtained":       "получена",
+	"expired %s ago": "истекла %s назад",
+	"expires in %s":  "истекает через %s",
+	"router":         "маршрутизатор",
+	"domain":         "домен",
+	"the lease ran out: the address may be handed to another host":    "аренда истекла: адрес могут выдать другому хосту",
+	"not renewed at half its lifetime: is the DHCP server answering?": "не продлена к половине срока: отвечает ли DHCP-сервер?",
+
+	// DNS configuration
+	"DNS over TLS":          "DNS поверх TLS",
+	"DNS over HTTPS":        "DNS поверх HTTPS",
+	"d time a lookup of %s": "d замерить запрос %s",
+	"search":                "поиск",
+	"no nameservers configured: lookups go to 127.0.0.1": "серверы имён не заданы: запросы идут на 127.0.0.1",
+	"global":                         "глобальный",
+	"timeout":                        "тайм-аут",
+	"no resolvers to test":           "нет резолверов для проверки",
+	"Time a lookup on each resolver": "Замерить запрос к каждому резолверу",
+
+	// Port history
+	"Ports listened on, this session": "Прослушиваемые порты за сеанс",
+	"Ports listened on":               "Прослушиваемые порты",
+	"since %s":                        "с %s",
+	"listening":                       "слушает",
+	"first %s, not this session":      "впервые %s, не в этом сеансе",
+	"%d different ports: it likely binds a new one each start; match it by name, not port": "%d разных портов: похоже, при каждом запуске берёт новый; сопоставляйте по имени, а не по порту",
+
+	// Port classes
+	"well-known": "общеизвестный",
+	"registered": "зарегистрированный",
+	"ephemeral":  "эфемерный",
+	"Port class": "Класс порта",
+	"Local port": "Локальный порт",
+	"an outgoing connection can take it while the service is down": "исходящее соединение может занять его, пока служба остановлена",
+	"%d from ephemeral ports hidden, e shows them":                 "%d с эфемерных портов скрыто, e показывает их",
+	"e hides ephemeral sources":                                    "e скрывает эфемерные источники",
+	"Hide / show connections from ephemeral local ports":           "Скрыть / показать соединения с эфемерных локальных портов",
+
+	// Help
+	"Quit":                "Выход",
+	"Quit (q asks first)": "Выход (q спрашивает подтверждение)",
+}
@@ -0,0 +1,102 @@
+// Package iphistory keeps a file of the host's external IP changes, so
+// they can be looked back on across sessions.
+package iphistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Change is one switch of the external IP.
+type Change struct {
+	At   time.Time `json:"at"`
+	From string    `json:"from"`
+	To   string    `json:"to"`
+}
+
+// Log is a JSON lines file of changes, oldest first. New ones are appended,
+// so the file can be followed with tail -f. It is safe for concurrent use.
+type Log struct {
+	path string
+
+	mu      sync.Mutex
+	changes []Change
+}
+
+// Open loads the changes at path. A missing file is an empty log.
+func Open(path string) (*Log, error) {
+	l := &Log{path: path}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("iphistory: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var c Change
+		if err := json.Unmarshal(sc.Bytes(), &c); err != nil {
+			return nil, fmt.Errorf("iphistory: %s:%d: %w", path, n, err)
+		}
+		l.changes = append(l.changes, c)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("iphistory: %w", err)
+	}
+	return l, nil
+}
+
+// Add records c and appends it to the file.
+func (l *Log) Add(c Change) error {
+	if l == nil {
+		return errors.New("iphistory: no log")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.changes = append(l.changes, c)
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("iphistory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("iphistory: %w", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("iphistory: %w", err)
+	}
+	return f.Close()
+}
+
+// Last returns up to n changes, newest first.
+func (l *Log) Last(n int) []Change {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	out := make([]Change, 0, min(n, len(l.changes)))
+	for i := len(l.changes) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, l.changes[i])
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+// Package metrics serves the probe data in the Prometheus text exposition
+// format, for --metrics-addr. It samples on its own, next to the UI.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/version"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Exporter answers scrapes. Interfaces are sampled in the background every
+// Every so the rates cover a fixed window rather than the time between
+// scrapes; ports and processes are read on each scrape.
+type Exporter struct {
+	Net   probe.Sampler
+	Ports probe.PortLister
+	Procs probe.ProcLister
+	Every time.Duration
+
+	mu      sync.Mutex
+	snap    probe.NetSnapshot
+	snapErr error
+}
+
+// New returns an Exporter reading the live system.
+func New() *Exporter {
+	// only counters are exported: no kind needs its addresses read
+	s := probe.NewNetSampler()
+	s.SetBrief(probe.IfaceUnknown, probe.IfaceLoopback, probe.IfaceDockerBridge, probe.IfaceLinuxBridge,
+		probe.IfaceVeth, probe.IfaceTunTap, probe.IfaceVirt, probe.IfacePhysical)
+	return &Exporter{Net: s, Ports: probe.Host{}, Procs: probe.Host{}, Every: time.Second}
+}
+
+// ListenAndServe binds addr right away, so a bad address fails before the
+// UI starts, and serves /metrics in the background until the program
+// exits.
+func (e *Exporter) ListenAndServe(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	go e.sample()
+
+	// read-only: nothing served here can act on the host, and any method
+	// but GET and HEAD gets 405 Method Not Allowed
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", e)
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ducknetview: metrics at /metrics\n")
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	return nil
+}
+
+func (e *Exporter) sample() {
+	for {
+		snap, err := e.Net.Sample()
+		e.mu.Lock()
+		e.snap, e.snapErr = snap, err
+		e.mu.Unlock()
+		time.Sleep(e.Every)
+	}
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	e.Write(w)
+}
+
+// Write renders every metric. A probe that fails leaves its metrics out
+// and sets ducknetview_probe_up{probe} to 0.
+func (e *Exporter) Write(w io.Writer) error {
+	e.mu.Lock()
+	snap, snapErr := e.snap, e.snapErr
+	e.mu.Unlock()
+	ports, portsErr := e.Ports.ListListening()
+	procs, procsErr := e.Procs.TopProcsByConnections(0)
+
+	var b strings.Builder
+	family(&b, "ducknetview_build_info", "gauge", "Version of the running ducknetview.")
+	sample(&b, "ducknetview_build_info", 1, "version", version.Version)
+
+	family(&b, "ducknetview_probe_up", "gauge", "Whether the probe behind a group of metrics worked on the last read.")
+	sample(&b, "ducknetview_probe_up", up(snapErr == nil && !snap.TakenAt.IsZero()), "probe", "interfaces")
+	sample(&b, "ducknetview_probe_up", up(portsErr == nil), "probe", "ports")
+	sample(&b, "ducknetview_probe_up", up(procsErr == nil), "probe", "processes")
+
+	if snapErr == nil {
+		ifaces := snap.Ifaces
+		for _, m := range []struct {
+			name, typ, help string
+			value           func(probe.IfaceInfo) float64
+		}{
+			{"ducknetview_interface_up", "gauge", "Whether the interface is up.", func(ii probe.IfaceInfo) float64 { return up(ii.IsUp) }},
+			{"ducknetview_interface_receive_bytes_total", "counter", "Bytes received on the interface.", func(ii probe.IfaceInfo) float64 { return float64(ii.RxTotal) }},
+			{"ducknetview_interface_transmit_bytes_total", "counter", "Bytes sent on the interface.", func(ii probe.IfaceInfo) float64 { return float64(ii.TxTotal) }},
+			{"ducknetview_interface_receive_bytes_per_second", "gauge", "Receive rate over the last sampling interval.", func(ii probe.IfaceInfo) float64 { return ii.RxBps }},
+			{"ducknetview_interface_transmit_bytes_per_second", "gauge", "Send rate over the last sampling interval.", func(ii probe.IfaceInfo) float64 { return ii.TxBps }},
+			{"ducknetview_interface_receive_errors_total", "counter", "Receive errors on the interface.", func(ii probe.IfaceInfo) float64 { return float64(ii.Errin) }},
+			{"ducknetview_interface_transmit_errors_total", "counter", "Send errors on the interface.", func(ii probe.IfaceInfo) float64 { return float64(ii.Errout) }},
+			{"ducknetview_interface_receive_drops_total", "counter", "Incoming packets dropped on the interface.", func(ii probe.IfaceInfo) float64 { return float64(ii.Dropin) }},
+			{"ducknetview_interface_transmit_drops_total", "counter", "Outgoing packets dropped on the interface.", func(ii probe.IfaceInfo) float64 { return float64(ii.Dropout) }},
+		} {
+			family(&b, m.name, m.typ, m.help)
+			for _, ii := range ifaces {
+				sample(&b, m.name, m.value(ii), "iface", ii.Name, "kind", ii.Kind.String())
+			}
+		}
+	}
+
+	if portsErr == nil {
+		byProto := map[string]int{}
+		for _, p := range ports {
+			byProto[p.Proto]++
+		}
+		family(&b, "ducknetview_listening_ports", "gauge", "Listening sockets by protocol.")
+		for _, proto := range sortedKeys(byProto) {
+			sample(&b, "ducknetview_listening_ports", float64(byProto[proto]), "proto", proto)
+		}
+	}
+
+	if procsErr == nil {
+		family(&b, "ducknetview_process_connections", "gauge", "Sockets with a remote peer, per process.")
+		for _, p := range procs {
+			sample(&b, "ducknetview_process_connections", float64(p.ConnCount), "pid", fmt.Sprint(p.PID), "process", p.Name)
+		}
+		family(&b, "ducknetview_process_listening", "gauge", "Listening sockets per process.")
+		for _, p := range procs {
+			sample(&b, "ducknetview_process_listening", float64(p.ListenCount), "pid", fmt.Sprint(p.PID), "process", p.Name)
+		}
+	}
+
+	if cs := probe.CacheStats(); len(cs) > 0 {
+		for _, m := range []struct {
+			name, typ, help string
+			value           func(probe.CacheStat) float64
+		}{
+			{"ducknetview_cache_hits_total", "counter", "Lookups answered from the cache.", func(c probe.CacheStat) float64 { return float64(c.Hits) }},
+			{"ducknetview_cache_misses_total", "counter", "Lookups the cache could not answer.", func(c probe.CacheStat) float64 { return float64(c.Misses) }},
+			{"ducknetview_cache_entries", "gauge", "Entries in the cache.", func(c probe.CacheStat) float64 { return float64(c.Len) }},
+			{"ducknetview_cache_size", "gauge", "Most entries the cache keeps.", func(c probe.CacheStat) float64 { return float64(c.Size) }},
+		} {
+			family(&b, m.name, m.typ, m.help)
+			for _, c := range cs {
+				sample(&b, m.name, m.value(c), "cache", c.Name)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func family(b *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one line; labels are name, value pairs.
+func sample(b *strings.Builder, name string, v float64, labels ...string) {
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteString("{")
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			fmt.Fprintf(b, "%s=\"%s\"", labels[i], escape(labels[i+1]))
+		}
+		b.WriteString("}")
+	}
+	b.WriteString(" " + strconv.FormatFloat(v, 'f', -1, 64) + "\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(s string) string { return escaper.Replace(s) }
+
+func up(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
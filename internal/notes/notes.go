@@ -0,0 +1,136 @@
+// Package notes keeps short user notes about remote hosts and ports, so known
+// traffic is recognised when it shows up again.
+package notes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Store is a JSON file of notes keyed by target. It is safe for concurrent
+// use; every change is written back immediately.
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	notes map[string]string
+}
+
+// DefaultPath is notes.json in the user's config directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ducknetview", "notes.json"), nil
+}
+
+// Open loads the notes at path. A missing file is an empty store.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, notes: map[string]string{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("notes: %w", err)
+	}
+	if err := json.Unmarshal(b, &s.notes); err != nil {
+		return nil, fmt.Errorf("notes: %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseTarget turns user input into a key: an IP address ("203.0.113.5"),
+// a port ("8080") or a port with protocol ("udp/53").
+func ParseTarget(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if ip := net.ParseIP(s); ip != nil {
+		return hostKey(ip.String()), nil
+	}
+	proto, port, ok := strings.Cut(s, "/")
+	if !ok {
+		proto, port = "", s
+	}
+	if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		return "", fmt.Errorf("notes: %q is neither an IP address nor a port", s)
+	}
+	if proto != "" && proto != "tcp" && proto != "udp" {
+		return "", fmt.Errorf("notes: unknown protocol %q", proto)
+	}
+	return portKey(proto, port), nil
+}
+
+func hostKey(ip string) string { return "host " + ip }
+
+func portKey(proto, port string) string {
+	if proto == "" {
+		return "port " + port
+	}
+	return "port " + proto + "/" + port
+}
+
+// Set stores note under key and saves the file; an empty note removes it.
+func (s *Store) Set(key, note string) error {
+	if s == nil {
+		return errors.New("notes: no store")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if note = strings.TrimSpace(note); note == "" {
+		delete(s.notes, key)
+	} else {
+		s.notes[key] = note
+	}
+	return s.save()
+}
+
+func (s *Store) save() error {
+	b, err := json.MarshalIndent(s.notes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("notes: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o600); err != nil {
+		return fmt.Errorf("notes: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *Store) get(key string) string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notes[key]
+}
+
+// Host returns the note for a remote address.
+func (s *Store) Host(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		ip = parsed.String()
+	}
+	return s.get(hostKey(ip))
+}
+
+// Port returns the note for proto/port, falling back to a note on the bare
+// port number.
+func (s *Store) Port(proto, port string) string {
+	if n := s.get(portKey(proto, port)); n != "" {
+		return n
+	}
+	return s.get(portKey("", port))
+}
@@ -0,0 +1,123 @@
+// Package porthistory keeps a file of the ports each program has listened
+// on, so that a service rebinding to a new port on every start can be
+// told apart from a new service across sessions.
+package porthistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Entry is a port a program was first seen listening on.
+type Entry struct {
+	Process string    `json:"process"`
+	Proto   string    `json:"proto"`
+	Port    string    `json:"port"`
+	First   time.Time `json:"first"`
+}
+
+func (e Entry) key() string { return e.Process + " " + e.Proto + "/" + e.Port }
+
+// Log is a JSON lines file of entries, one per program and port, oldest
+// first. New ones are appended, so the file can be followed with tail -f.
+// It is safe for concurrent use.
+type Log struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// Open loads the entries at path. A missing file is an empty log.
+func Open(path string) (*Log, error) {
+	l := &Log{path: path, entries: map[string]Entry{}}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("porthistory: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("porthistory: %s:%d: %w", path, n, err)
+		}
+		if _, ok := l.entries[e.key()]; !ok {
+			l.entries[e.key()] = e
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("porthistory: %w", err)
+	}
+	return l, nil
+}
+
+// Add records the entries not known yet and appends them to the file.
+func (l *Log) Add(es ...Entry) error {
+	if l == nil {
+		return errors.New("porthistory: no log")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var buf []byte
+	for _, e := range es {
+		if _, ok := l.entries[e.key()]; ok {
+			continue
+		}
+		l.entries[e.key()] = e
+		b, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, b...), '\n')
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("porthistory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("porthistory: %w", err)
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return fmt.Errorf("porthistory: %w", err)
+	}
+	return f.Close()
+}
+
+// Of returns the ports process was ever seen listening on, earliest first.
+func (l *Log) Of(process string) []Entry {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []Entry
+	for _, e := range l.entries {
+		if e.Process == process {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].First.Before(out[j].First) })
+	return out
+}
@@ -0,0 +1,164 @@
+// Package reputation looks up the abuse reputation of remote IP addresses.
+// Lookups send the address to a third-party service, so callers only do
+// this on explicit user request.
+package reputation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Report is the reputation of one address.
+type Report struct {
+	IP       string
+	Provider string
+	Score    int // 0 (clean) .. 100 (certainly abusive)
+
+	Reports      int
+	Reporters    int
+	LastReported time.Time
+	Categories   []string // most frequent first
+
+	Country string
+	ISP     string
+	Usage   string
+}
+
+// Checker is a reputation provider.
+type Checker interface {
+	Name() string
+	Check(ip string) (Report, error)
+}
+
+// New returns the checker called name; the API key comes from the
+// provider's environment variable.
+func New(name string, getenv func(string) string) (Checker, error) {
+	switch name {
+	case "abuseipdb":
+		key := getenv("ABUSEIPDB_API_KEY")
+		if key == "" {
+			return nil, errors.New("reputation: ABUSEIPDB_API_KEY is not set")
+		}
+		return &AbuseIPDB{Key: key}, nil
+	}
+	return nil, fmt.Errorf("reputation: unknown provider %q (want abuseipdb)", name)
+}
+
+const abuseIPDBURL = "https://api.abuseipdb.com/api/v2/check"
+
+// AbuseIPDB queries the AbuseIPDB v2 API.
+type AbuseIPDB struct {
+	Key string
+
+	// MaxAge limits reports to this many days; 0 means 90.
+	MaxAge int
+}
+
+func (a *AbuseIPDB) Name() string { return "AbuseIPDB" }
+
+func (a *AbuseIPDB) Check(ip string) (Report, error) {
+	age := a.MaxAge
+	if age <= 0 {
+		age = 90
+	}
+	q := url.Values{}
+	q.Set("ipAddress", ip)
+	q.Set("maxAgeInDays", fmt.Sprint(age))
+	q.Set("verbose", "")
+
+	req, err := http.NewRequest(http.MethodGet, abuseIPDBURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return Report{}, err
+	}
+	req.Header.Set("Key", a.Key)
+	req.Header.Set("Accept", "application/json")
+
+	c := &http.Client{Timeout: 5 * time.Second}
+	resp, err := c.Do(req)
+	if err != nil {
+		return Report{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Report{}, fmt.Errorf("abuseipdb: http %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			IPAddress            string    `json:"ipAddress"`
+			AbuseConfidenceScore int       `json:"abuseConfidenceScore"`
+			CountryCode          string    `json:"countryCode"`
+			UsageType            string    `json:"usageType"`
+			ISP                  string    `json:"isp"`
+			TotalReports         int       `json:"totalReports"`
+			NumDistinctUsers     int       `json:"numDistinctUsers"`
+			LastReportedAt       time.Time `json:"lastReportedAt"`
+			Reports              []struct {
+				Categories []int `json:"categories"`
+			} `json:"reports"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4<<20)).Decode(&body); err != nil {
+		return Report{}, fmt.Errorf("abuseipdb: %w", err)
+	}
+
+	d := body.Data
+	counts := map[int]int{}
+	for _, r := range d.Reports {
+		for _, c := range r.Categories {
+			counts[c]++
+		}
+	}
+
+	return Report{
+		IP:           d.IPAddress,
+		Provider:     a.Name(),
+		Score:        d.AbuseConfidenceScore,
+		Reports:      d.TotalReports,
+		Reporters:    d.NumDistinctUsers,
+		LastReported: d.LastReportedAt,
+		Categories:   topCategories(counts),
+		Country:      d.CountryCode,
+		ISP:          d.ISP,
+		Usage:        d.UsageType,
+	}, nil
+}
+
+func topCategories(counts map[int]int) []string {
+	ids := make([]int, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		name, ok := abuseCategories[id]
+		if !ok {
+			name = fmt.Sprintf("category %d", id)
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// https://www.abuseipdb.com/categories
+var abuseCategories = map[int]string{
+	1: "DNS Compromise", 2: "DNS Poisoning", 3: "Fraud Orders", 4: "DDoS Attack",
+	5: "FTP Brute-Force", 6: "Ping of Death", 7: "Phishing", 8: "Fraud VoIP",
+	9: "Open Proxy", 10: "Web Spam", 11: "Email Spam", 12: "Blog Spam",
+	13: "VPN IP", 14: "Port Scan", 15: "Hacking", 16: "SQL Injection",
+	17: "Spoofing", 18: "Brute-Force", 19: "Bad Web Bot", 20: "Exploited Host",
+	21: "Web App Attack", 22: "SSH", 23: "IoT Targeted",
+}
@@ -0,0 +1,216 @@
+// Package store keeps per-interface traffic totals by hour, day and month,
+// vnstat style, so usage against a data cap can be followed across
+// restarts.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Period is the span of a bucket.
+type Period int
+
+const (
+	Hourly Period = iota
+	Daily
+	Monthly
+)
+
+func (p Period) String() string {
+	return [...]string{"hourly", "daily", "monthly"}[p]
+}
+
+// Kept is how many buckets of each period are kept per interface.
+var Kept = [...]int{Hourly: 48, Daily: 62, Monthly: 36}
+
+// start is the local start of the period that t falls in.
+func (p Period) start(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	switch p {
+	case Hourly:
+		return time.Date(y, mo, d, t.Hour(), 0, 0, 0, t.Location())
+	case Daily:
+		return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(y, mo, 1, 0, 0, 0, 0, t.Location())
+}
+
+// Bucket is the traffic of one interface in one period.
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Rx    uint64    `json:"rx"`
+	Tx    uint64    `json:"tx"`
+}
+
+type iface struct {
+	Buckets [len(Kept)][]Bucket `json:"buckets"`
+	// the counters at the last sample and the boot they count from, so
+	// traffic while ducknetview wasn't running is counted on the next start
+	LastRx uint64    `json:"last_rx"`
+	LastTx uint64    `json:"last_tx"`
+	Boot   time.Time `json:"boot"`
+}
+
+// DB is a JSON file of buckets by interface. Add only updates memory; Save
+// writes the file. It is safe for concurrent use.
+type DB struct {
+	path string
+
+	mu     sync.Mutex
+	ifaces map[string]*iface
+	dirty  bool
+}
+
+// DefaultPath is bandwidth.json in the user's config directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ducknetview", "bandwidth.json"), nil
+}
+
+// Open loads the totals at path. A missing file is an empty database.
+func Open(path string) (*DB, error) {
+	db := &DB{path: path, ifaces: map[string]*iface{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return db, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+	if err := json.Unmarshal(b, &db.ifaces); err != nil {
+		return nil, fmt.Errorf("store: %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// sameBoot allows for the jitter of a boot time worked out from the uptime.
+// A zero time is an unknown boot, taken to be the same one.
+func sameBoot(a, b time.Time) bool {
+	if a.IsZero() || b.IsZero() {
+		return true
+	}
+	d := a.Sub(b)
+	return d > -5*time.Minute && d < 5*time.Minute
+}
+
+// Add counts a sample of interface name: its byte counters at time at, on
+// a host booted at boot. The first sample of a new interface only sets the
+// baseline. Counters that went back, or a new boot, count from zero.
+// Traffic between two runs goes into the bucket of the sample.
+func (db *DB) Add(name string, rx, tx uint64, boot, at time.Time) {
+	if db == nil {
+		return
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	f := db.ifaces[name]
+	if f == nil {
+		db.ifaces[name] = &iface{LastRx: rx, LastTx: tx, Boot: boot}
+		db.dirty = true
+		return
+	}
+	drx, dtx := rx, tx
+	if sameBoot(f.Boot, boot) && rx >= f.LastRx && tx >= f.LastTx {
+		drx, dtx = rx-f.LastRx, tx-f.LastTx
+	}
+	f.LastRx, f.LastTx = rx, tx
+	if !boot.IsZero() {
+		f.Boot = boot
+	}
+	db.dirty = true
+	if drx == 0 && dtx == 0 {
+		return
+	}
+
+	for p := range f.Buckets {
+		start := Period(p).start(at)
+		bs := f.Buckets[p]
+		// the clock may have gone back
+		i, found := slices.BinarySearchFunc(bs, start, func(b Bucket, t time.Time) int { return b.Start.Compare(t) })
+		if !found {
+			bs = slices.Insert(bs, i, Bucket{Start: start})
+		}
+		bs[i].Rx += drx
+		bs[i].Tx += dtx
+		if len(bs) > Kept[p] {
+			bs = slices.Clone(bs[len(bs)-Kept[p]:])
+		}
+		f.Buckets[p] = bs
+	}
+}
+
+// Save writes the file if anything changed since the last Save.
+func (db *DB) Save() error {
+	if db == nil {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if !db.dirty {
+		return nil
+	}
+
+	b, err := json.Marshal(db.ifaces)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(db.path), 0o755); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	tmp := db.path + ".tmp"
+	if err := os.WriteFile(tmp, append(b, '\n'), 0o600); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	if err := os.Rename(tmp, db.path); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	db.dirty = false
+	return nil
+}
+
+// Names lists the interfaces with any traffic recorded, sorted.
+func (db *DB) Names() []string {
+	if db == nil {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var out []string
+	for name, f := range db.ifaces {
+		if len(f.Buckets[Monthly]) > 0 {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Buckets returns the buckets of name for period p, newest first.
+func (db *DB) Buckets(name string, p Period) []Bucket {
+	if db == nil {
+		return nil
+	}
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	f := db.ifaces[name]
+	if f == nil {
+		return nil
+	}
+	out := slices.Clone(f.Buckets[p])
+	slices.Reverse(out)
+	return out
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type bgpMsg struct {
+	peers []probe.BGPPeer
+	err   error
+}
+
+func (m Model) fetchBGPCmd() tea.Cmd {
+	return func() tea.Msg {
+		ps, err := m.bgpReader.BGPPeers()
+		return bgpMsg{peers: ps, err: err}
+	}
+}
+
+// applyBGP stores the sessions and logs established sessions that dropped
+// (and came back) since the previous poll.
+func (m *Model) applyBGP(msg bgpMsg) {
+	m.bgp, m.bgpErr = msg.peers, msg.err
+	if msg.err != nil {
+		return
+	}
+	if m.bgp == nil {
+		m.bgp = []probe.BGPPeer{}
+	}
+
+	logged := false
+	up := make(map[string]bool, len(m.bgp))
+	for _, p := range m.bgp {
+		key := p.Daemon + " " + p.Name
+		up[key] = p.Up()
+		was, seen := m.bgpUp[key]
+		if !seen || was == p.Up() {
+			continue
+		}
+		text, kind := fmt.Sprintf(i18n.T("BGP session %s (%s) established"), p.Name, p.Neighbor), evNote
+		if !p.Up() {
+			text, kind = fmt.Sprintf(i18n.T("BGP session %s (%s) down: %s"), p.Name, p.Neighbor, p.State), evAlert
+			m.alert, m.alertAt = text, m.now()
+		}
+		m.events.add(m.now(), kind, text)
+		logged = true
+	}
+	m.bgpUp = up
+	if logged {
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+}
+
+// renderBGPText is the BGP section of the Routing tab; empty when no
+// routing daemon runs here.
+func (m Model) renderBGPText(w int) string {
+	if errors.Is(m.bgpErr, probe.ErrNoRoutingDaemon) || (m.bgp == nil && m.bgpErr == nil) {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n" + titleStyle.Render(i18n.T("BGP sessions")) + "\n")
+	if m.bgpErr != nil {
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.bgpErr.Error()) + "\n")
+		return b.String()
+	}
+	if len(m.bgp) == 0 {
+		b.WriteString(i18n.T("no BGP sessions configured") + "\n")
+		return b.String()
+	}
+
+	const colName, colNbr, colAS, colState, colPfx = 16, 26, 8, 12, 13
+	b.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s\n",
+		padRight(i18n.T("PEER"), colName),
+		padRight(i18n.T("NEIGHBOR"), colNbr),
+		padRight("AS", colAS),
+		padRight(i18n.T("STATE"), colState),
+		padRight(i18n.T("PFX IN/OUT"), colPfx),
+		i18n.T("SINCE"),
+	))
+	b.WriteString(strings.Repeat("─", min(w, colName+2+colNbr+2+colAS+2+colState+2+colPfx+2+19)) + "\n")
+	for _, p := range m.bgp {
+		state := padRight(trunc(p.State, colState), colState)
+		if p.Up() {
+			state = okStyle.Render(state)
+		} else {
+			state = errStyle.Render(state)
+		}
+		out := "?"
+		if p.Exported >= 0 {
+			out = fmt.Sprint(p.Exported)
+		}
+		as := "-"
+		if p.AS > 0 {
+			as = fmt.Sprint(p.AS)
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s\n",
+			padRight(trunc(p.Name, colName), colName),
+			padRight(trunc(p.Neighbor, colNbr), colNbr),
+			padRight(as, colAS),
+			state,
+			padRight(fmt.Sprintf("%d/%s", p.Imported, out), colPfx),
+			p.Since,
+		))
+	}
+	return b.String()
+}
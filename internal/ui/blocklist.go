@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// reverse lookups started per check; the rest wait for the next round
+const rdnsBatch = 32
+
+// blockHit is a connection whose remote end is on a loaded blocklist.
+type blockHit struct {
+	conn   probe.Conn
+	name   string // host name that matched, if any
+	entry  string
+	source string
+}
+
+type blockedMsg struct {
+	hits []blockHit
+	err  error
+}
+
+// fetchBlockedCmd cross-checks current connections against the blocklist.
+func (m Model) fetchBlockedCmd() tea.Cmd {
+	bl := m.opts.Blocklist
+	if bl.Len() == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		conns, err := m.connLister.ListConnections()
+		if err != nil {
+			return blockedMsg{err: err}
+		}
+
+		var hits []blockHit
+		lookups := 0
+		for _, c := range conns {
+			ip := c.RemoteIP()
+			var names []string
+			if bl.HasDomains() {
+				var ok bool
+				if names, ok = m.rdns.Cached(ip); !ok && lookups < rdnsBatch {
+					lookups++
+					names = m.rdns.Resolve(ip)
+				}
+			}
+			if entry, src, ok := bl.Match(ip, names); ok {
+				h := blockHit{conn: c, entry: entry, source: src}
+				if len(names) > 0 {
+					h.name = names[0]
+				}
+				hits = append(hits, h)
+			}
+		}
+		return blockedMsg{hits: hits}
+	}
+}
+
+// applyBlocked logs each blocklisted remote once per process.
+func (m *Model) applyBlocked(msg blockedMsg) {
+	if msg.err != nil {
+		return
+	}
+	m.blocked = msg.hits
+
+	logged := false
+	for _, h := range msg.hits {
+		key := h.conn.RemoteIP() + " " + fmt.Sprint(h.conn.PID)
+		if m.blockSeen[key] {
+			continue
+		}
+		m.blockSeen[key] = true
+
+		remote := h.conn.Remote
+		if h.name != "" {
+			remote += " (" + h.name + ")"
+		}
+		proc := h.conn.Process
+		if proc == "" {
+			proc = fmt.Sprintf("pid %d", h.conn.PID)
+		}
+		text := fmt.Sprintf(i18n.T("blocklisted connection to %s by %s: %s in %s"), remote, proc, h.entry, h.source)
+		if n := m.opts.Notes.Host(h.conn.RemoteIP()); n != "" {
+			text += "  # " + n
+		}
+		m.events.add(m.now(), evAlert, text)
+		m.alert, m.alertAt = text, m.now()
+		logged = true
+	}
+	if logged {
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+}
@@ -0,0 +1,351 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/notes"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// bulkListed is how many targets a bulk confirmation lists.
+const bulkListed = 10
+
+// marks are the rows marked with space on Ports (by listener key),
+// Processes (by PID) and Connections (by connection key). A new set is
+// made on every change, so the copy behind a frozen tab keeps its own.
+type marks map[string]bool
+
+func (s marks) any() bool { return len(s) > 0 }
+
+func (s marks) toggle(keys ...string) marks {
+	out := maps.Clone(s)
+	if out == nil {
+		out = marks{}
+	}
+	// a group row turns on unless all its PIDs are marked already
+	on := !s.all(keys)
+	for _, k := range keys {
+		if on {
+			out[k] = true
+		} else {
+			delete(out, k)
+		}
+	}
+	return out
+}
+
+func (s marks) all(keys []string) bool {
+	for _, k := range keys {
+		if !s[k] {
+			return false
+		}
+	}
+	return len(keys) > 0
+}
+
+// kept drops the marks of rows no longer listed.
+func (s marks) kept(listed func(string) bool) marks {
+	if !s.any() {
+		return s
+	}
+	out := marks{}
+	for k := range s {
+		if listed(k) {
+			out[k] = true
+		}
+	}
+	return out
+}
+
+func (m *Model) pruneProcMarks() {
+	if !m.procsMarked.any() {
+		return
+	}
+	pids := make(map[string]bool, len(m.procs))
+	for _, p := range m.procs {
+		pids[strconv.Itoa(int(p.PID))] = true
+	}
+	m.procsMarked = m.procsMarked.kept(func(k string) bool { return pids[k] })
+}
+
+func (m *Model) pruneConnMarks() {
+	if !m.connsMarked.any() {
+		return
+	}
+	keys := make(map[string]bool, len(m.conns))
+	for _, c := range m.conns {
+		keys[connKey(c)] = true
+	}
+	m.connsMarked = m.connsMarked.kept(func(k string) bool { return keys[k] })
+}
+
+func (m Model) marked(t tab) marks {
+	switch t {
+	case tabPorts:
+		return m.portsMarked
+	case tabProcs:
+		return m.procsMarked
+	case tabConns:
+		return m.connsMarked
+	}
+	return nil
+}
+
+func (m *Model) clearMarks(t tab) {
+	switch t {
+	case tabPorts:
+		m.portsMarked = nil
+		m.setPortsContent()
+	case tabProcs:
+		m.procsMarked = nil
+		m.setProcsContent()
+	case tabConns:
+		m.connsMarked = nil
+		m.setConnsContent()
+	}
+}
+
+// toggleMark marks or unmarks the selected row of the active tab; on a
+// process group row, all of its PIDs, on a Top talkers row, all of its
+// connections.
+func (m *Model) toggleMark() {
+	switch m.activeTab {
+	case tabPorts:
+		if m.portsSel != "" {
+			m.portsMarked = m.portsMarked.toggle(m.portsSel)
+			m.setPortsContent()
+		}
+	case tabProcs:
+		if pids := m.selectedPIDs(); len(pids) > 0 {
+			m.procsMarked = m.procsMarked.toggle(pids...)
+			m.setProcsContent()
+		}
+	case tabConns:
+		if t, ok := m.selectedTalker(); ok {
+			m.connsMarked = m.connsMarked.toggle(t.conns...)
+			m.setConnsContent()
+		} else if m.connsSel != "" {
+			m.connsMarked = m.connsMarked.toggle(m.connsSel)
+			m.setConnsContent()
+		}
+	}
+}
+
+// selectedPIDs are the PIDs of the selected Processes row: one, or those
+// of a group.
+func (m Model) selectedPIDs() []string {
+	if pid, _, ok := m.selectedPID(); ok {
+		return []string{strconv.Itoa(int(pid))}
+	}
+	_, name, _ := strings.Cut(m.procsSel, " ")
+	return m.groupPIDs(name)
+}
+
+// groupPIDs are the PIDs of the processes called name.
+func (m Model) groupPIDs(name string) []string {
+	var pids []string
+	for _, p := range m.procs {
+		if procName(p.Name) == name {
+			pids = append(pids, strconv.Itoa(int(p.PID)))
+		}
+	}
+	return pids
+}
+
+// markedNote is the count indicator for a filter line, "" without marks.
+func (m Model) markedNote(t tab) string {
+	n := len(m.marked(t))
+	if n == 0 {
+		return ""
+	}
+	return "  •  " + accentStyle.Render(fmt.Sprintf(i18n.T("%d marked"), n)) +
+		subtleStyle.Render(" ("+i18n.T(bulkHints[t])+")")
+}
+
+var bulkHints = map[tab]string{
+	tabPorts: "x export • t tag • X/K stop/kill • esc unmark",
+	tabProcs: "x export • X/K stop/kill • esc unmark",
+	tabConns: "x export • t tag • b block • X/K stop/kill • esc unmark",
+}
+
+// markedProcs are the processes behind the marked rows of the active tab,
+// each once.
+func (m Model) markedProcs() []procTarget {
+	var out []procTarget
+	seen := map[int32]bool{}
+	add := func(pid int32, name string) {
+		if pid > 0 && !seen[pid] {
+			seen[pid] = true
+			out = append(out, procTarget{pid: pid, name: procName(name)})
+		}
+	}
+	switch m.activeTab {
+	case tabPorts:
+		for _, p := range m.ports {
+			if m.portsMarked[listenerKey(p)] {
+				add(p.PID, p.Process)
+			}
+		}
+	case tabProcs:
+		for _, p := range m.procs {
+			if m.procsMarked[strconv.Itoa(int(p.PID))] {
+				add(p.PID, p.Name)
+			}
+		}
+	case tabConns:
+		for _, c := range m.conns {
+			if m.connsMarked[connKey(c)] {
+				add(c.PID, c.Process)
+			}
+		}
+	}
+	return out
+}
+
+// markedHosts are the remote addresses of the marked connections, or of
+// the selected one or Top talkers row, each once; loopback and unspecified
+// ones are left out.
+func (m Model) markedHosts() []string {
+	var out []string
+	for _, c := range m.conns {
+		k := connKey(c)
+		selected := k == m.connsSel || talkerKey(c.RemoteIP()) == m.connsSel
+		if m.connsMarked.any() && !m.connsMarked[k] || !m.connsMarked.any() && !selected {
+			continue
+		}
+		ip := net.ParseIP(c.RemoteIP())
+		if ip == nil || ip.IsLoopback() || ip.IsUnspecified() {
+			continue
+		}
+		if s := ip.String(); !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// tagTargets are the note targets of the marked rows, or of the selected
+// one: the port of a listener, the remote host of a connection.
+func (m Model) tagTargets() []string {
+	var out []string
+	add := func(t string) {
+		if t != "" && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	switch m.activeTab {
+	case tabPorts:
+		for _, p := range m.ports {
+			k := listenerKey(p)
+			if m.portsMarked.any() && !m.portsMarked[k] || !m.portsMarked.any() && k != m.portsSel {
+				continue
+			}
+			if _, port := probe.SplitLocal(p.Local); port != "" {
+				add(strings.TrimSuffix(p.Proto, "6") + "/" + port)
+			}
+		}
+	case tabConns:
+		for _, ip := range m.markedHosts() {
+			add(ip)
+		}
+	}
+	return out
+}
+
+// openTagPrompt asks for one note for all tagTargets, in the note prompt.
+func (m *Model) openTagPrompt() {
+	ts := m.tagTargets()
+	if len(ts) == 0 {
+		m.notice = i18n.T("nothing to tag here; mark listeners or connections with space")
+		return
+	}
+	in := textinput.New()
+	in.Prompt = fmt.Sprintf(i18n.T("tag %d: "), len(ts))
+	in.Placeholder = strings.Join(ts, ", ")
+	in.CharLimit = 120
+	in.Focus()
+	m.notePrompt, m.noting, m.noteTargets = in, true, ts
+}
+
+func saveNotesCmd(s *notes.Store, targets []string, note string) tea.Cmd {
+	return func() tea.Msg {
+		for _, t := range targets {
+			key, err := notes.ParseTarget(t)
+			if err == nil {
+				err = s.Set(key, note)
+			}
+			if err != nil {
+				return noticeMsg{err: err}
+			}
+		}
+		if strings.TrimSpace(note) == "" {
+			return noteSavedMsg(fmt.Sprintf(i18n.T("notes of %d targets removed"), len(targets)))
+		}
+		return noteSavedMsg(fmt.Sprintf(i18n.T("%d targets tagged"), len(targets)))
+	}
+}
+
+// hostBlock is the confirmation before blocking remote hosts.
+type hostBlock struct {
+	open  bool
+	hosts []string
+}
+
+func (m *Model) openHostBlock() {
+	hs := m.markedHosts()
+	if len(hs) == 0 {
+		m.notice = i18n.T("no remote host to block; mark connections with space")
+		return
+	}
+	m.hostBlock = hostBlock{open: true, hosts: hs}
+}
+
+func (m Model) updateHostBlock(km tea.KeyMsg) (tea.Model, tea.Cmd) {
+	hs := m.hostBlock.hosts
+	m.hostBlock = hostBlock{}
+	switch km.String() {
+	case "y", "Y", "enter":
+		m.clearMarks(tabConns)
+		return m, m.blockHostsCmd(hs)
+	}
+	return m, nil
+}
+
+func (m Model) blockHostsCmd(hosts []string) tea.Cmd {
+	b := m.hostBlocker
+	return func() tea.Msg {
+		if err := b.BlockHosts(hosts); err != nil {
+			return noticeMsg{err: err}
+		}
+		return noticeMsg{text: fmt.Sprintf(i18n.T("blocked %d hosts; nft delete table %s lifts it"), len(hosts), probe.BlockTable)}
+	}
+}
+
+func (m Model) viewHostBlock() string {
+	hs := m.hostBlock.hosts
+	var b strings.Builder
+	b.WriteString(errStyle.Render(i18n.T("Block hosts")) + "\n\n")
+	b.WriteString(fmt.Sprintf(i18n.T("Drop all traffic to and from %d hosts? They are added to the nftables table %s."), len(hs), probe.BlockTable) + "\n\n")
+	for i, h := range hs {
+		if i == bulkListed {
+			b.WriteString(subtleStyle.Render(fmt.Sprintf(i18n.T("… %d more"), len(hs)-bulkListed)) + "\n")
+			break
+		}
+		line := "  " + h
+		if n := m.hostName(h); n != "" {
+			line += "  " + subtleStyle.Render(n)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + subtleStyle.Render(i18n.T("y/enter block • any other key cancels")))
+	return b.String()
+}
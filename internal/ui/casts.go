@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// a broadcast storm, or something close: this much of what an interface
+// receives is broadcast, at this many packets a second at least
+const (
+	castStormShare = 0.2
+	castStormPps   = 50
+)
+
+type castsMsg struct {
+	iface string
+	c     probe.CastStats
+	err   error
+	at    time.Time
+}
+
+// castSample is the last reading of the multicast and broadcast counters
+// of the selected interface, with their rates since the one before; -1 for
+// those not counted or not known yet.
+type castSample struct {
+	iface string
+	c     probe.CastStats
+	at    time.Time
+	rates [4]float64 // rx multicast, tx multicast, rx broadcast, tx broadcast
+}
+
+// fetchCastsCmd reads the counters of the selected interface; only done
+// while the Interfaces tab is open.
+func (m Model) fetchCastsCmd() tea.Cmd {
+	iface := m.selectedIface
+	if iface == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		c, err := m.castReader.CastCounters(iface)
+		return castsMsg{iface: iface, c: c, err: err, at: m.now()}
+	}
+}
+
+func (m *Model) applyCasts(msg castsMsg) {
+	if msg.iface != m.selectedIface {
+		return
+	}
+	if msg.err != nil {
+		m.casts = castSample{}
+		return
+	}
+	prev := m.casts
+	s := castSample{iface: msg.iface, c: msg.c, at: msg.at, rates: [4]float64{-1, -1, -1, -1}}
+	if dt := msg.at.Sub(prev.at).Seconds(); prev.iface == msg.iface && dt > 0 {
+		cur, old := castCounts(msg.c), castCounts(prev.c)
+		for i := range cur {
+			if cur[i] >= 0 && old[i] >= 0 && cur[i] >= old[i] {
+				s.rates[i] = float64(cur[i]-old[i]) / dt
+			}
+		}
+	}
+	m.casts = s
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+func castCounts(c probe.CastStats) [4]int64 {
+	return [4]int64{c.RxMulticast, c.TxMulticast, c.RxBroadcast, c.TxBroadcast}
+}
+
+// renderCastText shows the multicast and broadcast counters of ii for the
+// interface details pane, with each rate's share of the packets received
+// or sent, and a warning when broadcasts flood in.
+func (m Model) renderCastText(ii *probe.IfaceInfo) string {
+	s := m.casts
+	if s.iface != ii.Name {
+		return ""
+	}
+	n := castCounts(s.c)
+	if n == [4]int64{-1, -1, -1, -1} {
+		return ""
+	}
+	cell := func(label string, i int, pps float64) string {
+		if n[i] < 0 {
+			return subtleStyle.Render(label + " ?")
+		}
+		out := fmt.Sprintf("%s %d", label, n[i])
+		if r := s.rates[i]; r > 0 {
+			extra := fmt.Sprintf(i18n.T("+%.1f/s"), r)
+			if pps > 0 {
+				extra += ", " + fmt.Sprintf(i18n.T("%.1f%% of packets"), math.Min(r/pps, 1)*100)
+			}
+			out += " " + i18n.Number("("+extra+")")
+		}
+		return out
+	}
+	line := func(title string, rx, tx int) string {
+		return i18n.T(title) + cell(i18n.T("in"), rx, ii.RxPps) + "  " + cell(i18n.T("out"), tx, ii.TxPps) + "\n"
+	}
+	out := line("Multicast: ", 0, 1) + line("Broadcast: ", 2, 3)
+	if r := s.rates[2]; r >= castStormPps && ii.RxPps > 0 && r/ii.RxPps >= castStormShare {
+		out += warnStyle.Render(i18n.Number(fmt.Sprintf(i18n.T("broadcast storm? %.0f%% of the packets received are broadcasts"), r/ii.RxPps*100))) + "\n"
+	}
+	if n[2] < 0 && n[3] < 0 {
+		out += subtleStyle.Render(i18n.T("broadcasts are counted only by drivers that report them to ethtool -S")) + "\n"
+	}
+	return out
+}
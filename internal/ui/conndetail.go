@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// connDetail is the modal with the socket options of the selected
+// connection, opened with enter on the Connections tab.
+type connDetail struct {
+	open   bool
+	key    string
+	c      probe.Conn
+	o      probe.SockOpts
+	err    error
+	loaded bool
+}
+
+type connDetailMsg struct {
+	key string
+	o   probe.SockOpts
+	err error
+}
+
+// selectedConn is the connection of the selected Connections row; a Top
+// talkers row is none.
+func (m Model) selectedConn() (probe.Conn, bool) {
+	for _, c := range m.conns {
+		if connKey(c) == m.connsSel {
+			return c, true
+		}
+	}
+	return probe.Conn{}, false
+}
+
+func (m *Model) openConnDetail() tea.Cmd {
+	c, ok := m.selectedConn()
+	if !ok {
+		return nil
+	}
+	m.connDetail = connDetail{open: true, key: m.connsSel, c: c}
+	ci, key := m.connInspector, m.connsSel
+	return func() tea.Msg {
+		o, err := ci.InspectConn(c)
+		return connDetailMsg{key: key, o: o, err: err}
+	}
+}
+
+func (m *Model) applyConnDetail(msg connDetailMsg) {
+	if !m.connDetail.open || msg.key != m.connDetail.key {
+		return
+	}
+	m.connDetail.o, m.connDetail.err, m.connDetail.loaded = msg.o, msg.err, true
+}
+
+func (m Model) updateConnDetail(km tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch km.String() {
+	case "esc", "enter", "q", "backspace":
+		m.connDetail.open = false
+	}
+	return m, nil
+}
+
+func (m Model) viewConnDetail() string {
+	cd := m.connDetail
+	c := cd.c
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(c.Proto+" "+c.Local+" → "+c.Remote) + "  " + subtleStyle.Render(i18n.T("esc close")) + "\n\n")
+	switch {
+	case !cd.loaded:
+		return b.String() + i18n.T("No data (yet)…") + "\n"
+	case cd.err != nil:
+		return b.String() + errStyle.Render(i18n.T("Error: ")+cd.err.Error()) + "\n"
+	}
+
+	row := func(label, value string) {
+		b.WriteString(padRight(i18n.T(label), 14) + value + "\n")
+	}
+	row("State", c.Status)
+	if class := m.portClassLabel(c.Local); class != "" {
+		row("Local port", class)
+	}
+	proc := c.Process
+	if proc == "" {
+		proc = subtleStyle.Render("?")
+	}
+	if c.PID > 0 {
+		proc += fmt.Sprintf("  (PID %d)", c.PID)
+	}
+	row("Process", proc)
+	b.WriteString("\n")
+	writeSockOpts(&b, cd.o, c.Proto, true)
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type connRateMsg struct {
+	rate probe.ConnRate
+	err  error
+}
+
+type procConnRatesMsg map[int32]float64
+
+func (m Model) fetchConnRateCmd() tea.Cmd {
+	return func() tea.Msg {
+		r, err := m.connRater.Rate()
+		return connRateMsg{rate: r, err: err}
+	}
+}
+
+func (m Model) fetchProcConnRatesCmd() tea.Cmd {
+	return func() tea.Msg {
+		r, err := m.connRater.ProcRates()
+		if err != nil {
+			// per-process rates are best-effort, like process names
+			return nil
+		}
+		return procConnRatesMsg(r)
+	}
+}
+
+type procBWMsg map[int32]probe.ProcBandwidth
+
+// fetchProcBWCmd samples per-process throughput. Failures yield nil, which
+// hides the RX/TX columns: connection counts remain as the proxy.
+func (m Model) fetchProcBWCmd() tea.Cmd {
+	return func() tea.Msg {
+		bw, err := m.procBWer.ProcBandwidth()
+		if err != nil {
+			return procBWMsg(nil)
+		}
+		return procBWMsg(bw)
+	}
+}
+
+// procRate formats a per-process rate; idle processes get a dash so the
+// busy ones stand out.
+func procRate(bps float64, u Units) string {
+	if bps < 1 {
+		return "-"
+	}
+	return humanRate(bps, u)
+}
+
+// renderConnRate renders the Overview section with the new-connection rate
+// chart and the processes opening the most connections.
+func (m Model) renderConnRate(width int) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("New connections")) + "\n")
+	if m.connRateErr != nil {
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.connRateErr.Error()) + "\n")
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf(i18n.T("%s/s  (out %s, in %s)")+"\n",
+		i18n.Number(fmt.Sprintf("%.1f", m.connRate.Total())),
+		i18n.Number(fmt.Sprintf("%.1f", m.connRate.Active)),
+		i18n.Number(fmt.Sprintf("%.1f", m.connRate.Passive)),
+	))
+	b.WriteString(Spark(m.connHist, max(10, width-6)) + "\n")
+
+	type pr struct {
+		name string
+		rate float64
+	}
+	var top []pr
+	for pid, r := range m.procConnRates {
+		if r <= 0 {
+			continue
+		}
+		top = append(top, pr{name: fmt.Sprintf("%s[%d]", m.procNameByPID(pid), pid), rate: r})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].rate != top[j].rate {
+			return top[i].rate > top[j].rate
+		}
+		return top[i].name < top[j].name
+	})
+	if len(top) > 3 {
+		top = top[:3]
+	}
+	if len(top) > 0 {
+		parts := make([]string, len(top))
+		for i, p := range top {
+			parts[i] = fmt.Sprintf("%s %s/s", p.name, i18n.Number(fmt.Sprintf("%.1f", p.rate)))
+		}
+		b.WriteString(subtleStyle.Render(i18n.T("Top: ")) + strings.Join(parts, ", ") + "\n")
+	}
+	return b.String()
+}
+
+func (m Model) procNameByPID(pid int32) string {
+	for _, p := range m.procs {
+		if p.PID == pid && p.Name != "" {
+			return p.Name
+		}
+	}
+	return "?"
+}
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type connsMsg struct {
+	conns []probe.Conn
+	err   error
+}
+
+func (m Model) fetchConnsCmd() tea.Cmd {
+	return func() tea.Msg {
+		cs, err := m.connLister.ListConnections()
+		return connsMsg{conns: cs, err: err}
+	}
+}
+
+func connKey(c probe.Conn) string {
+	return c.Proto + " " + c.Local + " " + c.Remote
+}
+
+// setConnsContent re-renders the Connections table, keeping the top row
+// in place and a row selected like setPortsContent.
+func (m *Model) setConnsContent() {
+	anchor, off := anchorKey(m.connsKeys, m.connsVP)
+	text, keys := m.renderConns()
+	if sel := nearestRow(m.connsKeys, keys, m.connsSel); sel != m.connsSel {
+		m.connsSel = sel
+		text, keys = m.renderConns()
+	}
+	m.connsVP.SetContent(hardClipLinesToWidth(text, m.connsVP.Width))
+	m.connsKeys = keys
+	restoreAnchor(&m.connsVP, keys, anchor, off)
+}
+
+// moveConnSel selects the row delta rows up or down, scrolling it into view.
+func (m *Model) moveConnSel(delta int) {
+	m.connsSel = stepRow(m.connsKeys, m.connsSel, delta, &m.connsVP, -1)
+	m.setConnsContent()
+}
+
+// keepConnSelInView is keepPortSelInView for the Connections tab.
+func (m *Model) keepConnSelInView() {
+	if sel := rowInView(m.connsKeys, m.connsSel, m.connsVP, -1); sel != m.connsSel {
+		m.connsSel = sel
+		m.setConnsContent()
+	}
+}
+
+// renderConns renders the table and returns the connection key of every
+// line, "" for lines that aren't connection rows.
+func (m Model) renderConns() (string, []string) {
+	var b strings.Builder
+	var keys []string
+	line := func(s, key string) {
+		b.WriteString(s + "\n")
+		keys = append(keys, key)
+	}
+	m.renderDomainBW(line, m.connsVP.Width)
+	m.renderDNSLog(line, m.connsVP.Width)
+
+	if m.connsErr != nil {
+		line(subtleStyle.Render(i18n.T("n/a")+": "+m.connsErr.Error()), "")
+		return b.String(), keys
+	}
+	if m.conns == nil {
+		line(i18n.T("No data (yet)…"), "")
+		return b.String(), keys
+	}
+
+	states := map[string]int{}
+	for _, c := range m.conns {
+		states[c.Status]++
+	}
+	names := make([]string, 0, len(states))
+	for s := range states {
+		names = append(names, s)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if states[names[i]] != states[names[j]] {
+			return states[names[i]] > states[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, s := range names {
+		parts[i] = fmt.Sprintf("%s %d", s, states[s])
+	}
+	listening := m.listeningPorts()
+	hint := ""
+	if !m.connsGrouped {
+		hint = subtleStyle.Render("  •  " + i18n.T("g top talkers"))
+		if m.connsHideEph {
+			n := 0
+			for _, c := range m.conns {
+				if m.ephemeralSource(c, listening) {
+					n++
+				}
+			}
+			hint += subtleStyle.Render("  •  " + fmt.Sprintf(i18n.T("%d from ephemeral ports hidden, e shows them"), n))
+		} else {
+			hint += subtleStyle.Render("  •  " + i18n.T("e hides ephemeral sources"))
+		}
+	}
+	line(fmt.Sprintf(i18n.T("%d connections"), len(m.conns))+"  "+subtleStyle.Render(strings.Join(parts, "  "))+hint+m.markedNote(tabConns), "")
+	line("", "")
+	if m.connsGrouped {
+		m.renderTalkers(line, m.connsVP.Width)
+		return b.String(), keys
+	}
+
+	blocked := map[string]blockHit{}
+	for _, h := range m.blocked {
+		blocked[connKey(h.conn)] = h
+	}
+
+	colAddr, colState, colPID, colGeo := 26, 12, 7, 12
+	if m.compact() {
+		colAddr, colState, colPID = 21, 6, 6
+	}
+	// the GEO column only with a database to fill it and room to spare
+	geoHdr := ""
+	if m.geo.loc == nil || m.compact() {
+		colGeo = 0
+	} else {
+		geoHdr = padRight(i18n.T("GEO"), colGeo) + "  "
+	}
+	line(fmt.Sprintf("%s  %s  %s  %s%s  %s  %s",
+		padRight(i18n.T("PR"), 4),
+		padRight(i18n.T("LOCAL"), colAddr),
+		padRight(sorted(i18n.T("REMOTE"), false), colAddr),
+		geoHdr,
+		padRight(i18n.T("STATE"), colState),
+		padRight(i18n.T("PID"), colPID),
+		i18n.T("PROCESS"),
+	), "")
+	line(strings.Repeat("─", 4+2+colAddr+2+colAddr+2+len([]rune(geoHdr))+colState+2+colPID+2+16), "")
+
+	for _, c := range m.conns {
+		ephemeral := m.ephemeralSource(c, listening)
+		if ephemeral && m.connsHideEph {
+			continue
+		}
+		pid := "-"
+		if c.PID > 0 {
+			pid = fmt.Sprint(c.PID)
+		}
+		state := padRight(trunc(c.Status, colState), colState)
+		if c.Status == "ESTABLISHED" {
+			state = okStyle.Render(state)
+		}
+		geo := ""
+		if colGeo > 0 {
+			geo = padRight(trunc(m.geoColumn(c.RemoteIP(), colGeo), colGeo), colGeo) + "  "
+		}
+		local := padRight(trunc(c.Local, colAddr), colAddr)
+		if !ephemeral {
+			// a service's port, set apart from the many the kernel hands out
+			local = accentStyle.Render(local)
+		}
+		row := fmt.Sprintf("%s  %s  %s  %s%s  %s  %s",
+			padRight(c.Proto, 4),
+			local,
+			padRight(m.withHostName(c.Remote, colAddr), colAddr),
+			geo,
+			state,
+			padRight(pid, colPID),
+			c.Process,
+		)
+		if h, ok := blocked[connKey(c)]; ok {
+			row += "  " + errStyle.Render("⚠ "+h.entry)
+		}
+		if n := m.opts.Notes.Host(c.RemoteIP()); n != "" {
+			row += "  " + subtleStyle.Render("# "+n)
+		}
+		switch k := connKey(c); {
+		case k == m.connsSel:
+			row = selectedStyle.Render(row)
+		case m.connsMarked[k]:
+			row = markedStyle.Render(row)
+		}
+		line(row, connKey(c))
+	}
+	return b.String(), keys
+}
+
+// geoColumn is the country and AS number of ip, the country alone when
+// that is all that fits.
+func (m Model) geoColumn(ip string, width int) string {
+	g := m.geoOf(ip)
+	if g.ASN == 0 {
+		return g.Country
+	}
+	full := strings.TrimSpace(fmt.Sprintf("%s AS%d", g.Country, g.ASN))
+	if len(full) > width && g.Country != "" {
+		return g.Country
+	}
+	return full
+}
+
+// connsTable returns the connections for export: the marked ones, or else
+// those shown.
+func (m Model) connsTable() table {
+	t := table{name: "conns", header: []string{"proto", "local", "local_class", "remote", "host", "geo", "state", "pid", "process", "note"}}
+	listening := m.listeningPorts()
+	for _, c := range m.conns {
+		if m.connsMarked.any() && !m.connsMarked[connKey(c)] {
+			continue
+		}
+		if !m.connsMarked.any() && m.connsHideEph && m.ephemeralSource(c, listening) {
+			continue
+		}
+		class := ""
+		if cl, ok := m.ephemeralRange().ClassOf(c.Local); ok {
+			class = cl.String()
+		}
+		t.rows = append(t.rows, []string{c.Proto, c.Local, class, c.Remote, m.hostName(c.RemoteIP()), m.geoOf(c.RemoteIP()).String(), c.Status, fmt.Sprint(c.PID), c.Process, m.opts.Notes.Host(c.RemoteIP())})
+	}
+	return t
+}
+
+func (m Model) viewConns() string {
+	w := min(m.w-2, 120)
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(m.connsVP.View())
+}
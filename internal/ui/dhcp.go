@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type leaseMsg struct {
+	iface string
+	l     probe.DHCPLease
+	err   error
+}
+
+// fetchLeaseCmd reads the DHCP lease of the selected interface; done on
+// selection and on the slow refresh while the Interfaces tab is open,
+// which is often enough to see it renewed.
+func (m Model) fetchLeaseCmd() tea.Cmd {
+	iface := m.selectedIface
+	if iface == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		l, err := m.leaseReader.Lease(iface)
+		return leaseMsg{iface: iface, l: l, err: err}
+	}
+}
+
+func (m *Model) applyLease(msg leaseMsg) {
+	if msg.iface != m.selectedIface {
+		return
+	}
+	m.lease = msg
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+// renderLeaseText shows the DHCP lease of the interface for the interface
+// details pane: who leased the address, when, until when, and the
+// resolvers offered with it. A lease past half its lifetime should have
+// been renewed, so that is warned about. Nothing for interfaces without
+// one.
+func (m Model) renderLeaseText(ii *probe.IfaceInfo) string {
+	s := m.lease
+	if s.iface != ii.Name || errors.Is(s.err, probe.ErrNoLease) {
+		return ""
+	}
+	if s.err != nil {
+		return i18n.T("DHCP: ") + subtleStyle.Render(i18n.T("n/a")+": "+s.err.Error()) + "\n"
+	}
+	l := s.l
+	head := l.Addr
+	if l.Server != "" {
+		head += " " + fmt.Sprintf(i18n.T("from %s"), l.Server)
+	}
+	out := i18n.T("DHCP: ") + head + subtleStyle.Render(" ("+l.Source+")") + "\n"
+
+	var times []string
+	if !l.Obtained.IsZero() {
+		times = append(times, i18n.T("obtained")+" "+m.ago(l.Obtained))
+	}
+	warn := ""
+	if !l.Expires.IsZero() {
+		left := l.Expires.Sub(m.now())
+		switch {
+		case left <= 0:
+			times = append(times, errStyle.Render(fmt.Sprintf(i18n.T("expired %s ago"), shortAgo(-left))))
+			warn = i18n.T("the lease ran out: the address may be handed to another host")
+		case !l.Obtained.IsZero() && left < l.Expires.Sub(l.Obtained)*45/100:
+			times = append(times, warnStyle.Render(fmt.Sprintf(i18n.T("expires in %s"), shortAgo(left))))
+			warn = i18n.T("not renewed at half its lifetime: is the DHCP server answering?")
+		default:
+			times = append(times, fmt.Sprintf(i18n.T("expires in %s"), shortAgo(left)))
+		}
+	}
+	if len(times) > 0 {
+		out += "  " + strings.Join(times, " • ") + "\n"
+	}
+
+	var offered []string
+	if len(l.DNS) > 0 {
+		offered = append(offered, i18n.T("DNS")+" "+strings.Join(l.DNS, ", "))
+	}
+	if l.Router != "" {
+		offered = append(offered, i18n.T("router")+" "+l.Router)
+	}
+	if l.Domain != "" {
+		offered = append(offered, i18n.T("domain")+" "+l.Domain)
+	}
+	if len(offered) > 0 {
+		out += "  " + strings.Join(offered, " • ") + "\n"
+	}
+	if warn != "" {
+		out += "  " + warnStyle.Render(warn) + "\n"
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type dnsCacheMsg struct {
+	cache probe.DNSCache
+	err   error
+}
+
+// fetchDNSCacheCmd reads the local resolver's cache counters; they are
+// only shown on the Stats tab, so only polled there.
+func (m Model) fetchDNSCacheCmd() tea.Cmd {
+	c := m.dnsCacher
+	return func() tea.Msg {
+		dc, err := c.DNSCache()
+		return dnsCacheMsg{cache: dc, err: err}
+	}
+}
+
+// flushDNSCacheCmd empties the cache, then reads the counters again.
+func (m Model) flushDNSCacheCmd() tea.Cmd {
+	c, daemon := m.dnsCacher, m.dnsCache.Daemon
+	flush := func() tea.Msg {
+		if err := c.FlushDNSCache(); err != nil {
+			return noticeMsg{err: err}
+		}
+		return noticeMsg{text: fmt.Sprintf(i18n.T("%s cache flushed"), daemon)}
+	}
+	return tea.Sequence(flush, m.fetchDNSCacheCmd())
+}
+
+// renderDNSCache is the DNS cache section at the top of the Stats tab.
+func (m Model) renderDNSCache() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("DNS cache")))
+	switch {
+	case errors.Is(m.dnsCacheErr, probe.ErrNoDNSCache):
+		return b.String() + "  " + subtleStyle.Render(i18n.T("no local caching resolver (systemd-resolved, dnsmasq)")) + "\n\n"
+	case m.dnsCacheErr != nil:
+		return b.String() + "  " + subtleStyle.Render(i18n.T("n/a")+": "+m.dnsCacheErr.Error()) + "\n\n"
+	case m.dnsCache.Daemon == "":
+		return b.String() + "  …\n\n"
+	}
+	c := m.dnsCache
+	b.WriteString("  " + c.Daemon)
+	if !m.readOnly() {
+		b.WriteString("  " + subtleStyle.Render(i18n.T("C flush")))
+	}
+	b.WriteString("\n")
+
+	var parts []string
+	if c.Size >= 0 {
+		parts = append(parts, fmt.Sprintf(i18n.T("%d entries"), c.Size))
+	}
+	if c.Capacity > 0 {
+		parts = append(parts, fmt.Sprintf(i18n.T("room for %d"), c.Capacity))
+	}
+	parts = append(parts,
+		fmt.Sprintf(i18n.T("hits %d"), c.Hits),
+		fmt.Sprintf(i18n.T("misses %d"), c.Misses))
+	if c.Hits+c.Misses > 0 {
+		parts = append(parts, i18n.Number(fmt.Sprintf(i18n.T("hit rate %.1f%%"), c.HitRate())))
+	}
+	b.WriteString(strings.Join(parts, "  ") + "\n\n")
+	return b.String()
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// dnsTestTimeout bounds one timed lookup; a resolver slower than that is
+// as good as down.
+const dnsTestTimeout = 3 * time.Second
+
+type dnsConfigMsg struct {
+	c   probe.DNSConfig
+	err error
+}
+
+// dnsTestMsg is the timed lookup of one resolver, by its target address.
+type dnsTestMsg struct {
+	gen    int
+	target string
+	rtt    time.Duration
+	err    error
+}
+
+// dnsTest is a timed lookup; pending until its answer is in.
+type dnsTest struct {
+	pending bool
+	rtt     time.Duration
+	err     error
+}
+
+// fetchDNSConfigCmd reads the resolver configuration; at start and on the
+// slow refresh while the Overview is open.
+func (m Model) fetchDNSConfigCmd() tea.Cmd {
+	r := m.dnsConfReader
+	return func() tea.Msg {
+		c, err := r.DNSConfig()
+		return dnsConfigMsg{c: c, err: err}
+	}
+}
+
+// testDNSCmd times a lookup of probe.DNSTestName on every resolver at once.
+func (m *Model) testDNSCmd() tea.Cmd {
+	m.dnsTestGen++
+	gen, r := m.dnsTestGen, m.dnsConfReader
+	// a fresh map: frozen copies share the old one
+	m.dnsTests = map[string]dnsTest{}
+	var cmds []tea.Cmd
+	for _, s := range m.dnsConf.Servers {
+		target := s.Target()
+		if _, ok := m.dnsTests[target]; ok {
+			continue
+		}
+		m.dnsTests[target] = dnsTest{pending: true}
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), dnsTestTimeout)
+			defer cancel()
+			rtt, err := r.TimeLookup(ctx, target, probe.DNSTestName)
+			return dnsTestMsg{gen: gen, target: target, rtt: rtt, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) applyDNSTest(msg dnsTestMsg) {
+	if msg.gen != m.dnsTestGen {
+		return
+	}
+	tests := make(map[string]dnsTest, len(m.dnsTests))
+	for k, v := range m.dnsTests {
+		tests[k] = v
+	}
+	tests[msg.target] = dnsTest{rtt: msg.rtt, err: msg.err}
+	m.dnsTests = tests
+}
+
+// dnsTestError says briefly why a lookup failed: "timeout", "no such host",
+// "connection refused".
+func dnsTestError(err error) string {
+	var de *net.DNSError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return i18n.T("timeout")
+	case errors.As(err, &de):
+		if de.IsTimeout {
+			return i18n.T("timeout")
+		}
+		return de.Err
+	}
+	return err.Error()
+}
+
+// renderDNSConfig is the DNS section of the Overview: what resolves names
+// for the host, the domains searched, and each resolver with its time to
+// answer once d has run the test.
+func (m Model) renderDNSConfig(width int) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("DNS")))
+	switch {
+	case m.dnsConfErr != nil:
+		return b.String() + "  " + subtleStyle.Render(i18n.T("n/a")+": "+m.dnsConfErr.Error()) + "\n"
+	case m.dnsConf.Servers == nil && m.dnsConf.Search == nil:
+		return b.String() + "  …\n"
+	}
+	c := m.dnsConf
+
+	via := "resolv.conf"
+	switch {
+	case c.Resolved:
+		via = "systemd-resolved"
+		var opts []string
+		if c.DoT != "" {
+			opts = append(opts, i18n.T("DNS over TLS")+" "+c.DoT)
+		}
+		if c.DNSSEC != "" {
+			opts = append(opts, "DNSSEC "+c.DNSSEC)
+		}
+		if len(opts) > 0 {
+			via += " " + subtleStyle.Render("("+strings.Join(opts, ", ")+")")
+		}
+	case c.Proxy != "":
+		via = c.Proxy
+		if c.DoH {
+			via += " " + okStyle.Render("("+i18n.T("DNS over HTTPS")+")")
+		}
+	}
+	b.WriteString("  " + via + "  " + subtleStyle.Render(fmt.Sprintf(i18n.T("d time a lookup of %s"), probe.DNSTestName)) + "\n")
+	if len(c.Search) > 0 {
+		b.WriteString("  " + i18n.T("search") + " " + strings.Join(c.Search, " ") + "\n")
+	}
+	if len(c.Servers) == 0 {
+		b.WriteString("  " + warnStyle.Render(i18n.T("no nameservers configured: lookups go to 127.0.0.1")) + "\n")
+		return b.String()
+	}
+
+	addrW := max(16, min(36, width-30))
+	for _, s := range c.Servers {
+		addr := s.Addr
+		if s.Name != "" {
+			addr += " (" + s.Name + ")"
+		}
+		where := s.Iface
+		switch {
+		case s.From == "resolv.conf":
+			where = "resolv.conf"
+		case where == "":
+			where = i18n.T("global")
+		}
+		line := padRight(trunc(addr, addrW), addrW) + "  " + padRight(trunc(where, 12), 12)
+		if t, ok := m.dnsTests[s.Target()]; ok {
+			switch {
+			case t.pending:
+				line += "  …"
+			case t.err != nil:
+				line += "  " + errStyle.Render(dnsTestError(t.err))
+			case t.rtt >= 200*time.Millisecond:
+				line += "  " + warnStyle.Render(humanRTT(t.rtt))
+			default:
+				line += "  " + okStyle.Render(humanRTT(t.rtt))
+			}
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	// dnsLogKeep is how many distinct names the log remembers.
+	dnsLogKeep = 50
+	// dnsLogShown is how many of them the Connections tab lists.
+	dnsLogShown = 8
+	// dnsAddrsKeep bounds the answered addresses remembered; past it the
+	// map starts over.
+	dnsAddrsKeep = 10000
+)
+
+// dnsLog is the passive DNS query log of the selected interface, kept
+// with Options.CaptureDNS. names has the most recently asked first; addrs
+// maps the addresses in the answers to the name asked for.
+type dnsLog struct {
+	iface   string
+	run     *dnsLogRun
+	running bool
+	names   []askedName
+	addrs   map[string]string
+	err     error
+}
+
+type askedName struct {
+	name  string
+	types []string
+	count int
+	last  time.Time
+}
+
+// dnsLogRun connects one capture to the UI, like traceRun.
+type dnsLogRun struct {
+	id      int
+	queries chan probe.DNSQuery
+	done    chan error
+	cancel  context.CancelFunc
+}
+
+type dnsQueryMsg struct {
+	id int
+	q  probe.DNSQuery
+}
+
+type dnsLogDoneMsg struct {
+	id  int
+	err error
+}
+
+// followDNSLog (re)starts the capture when the selected interface has
+// changed; the log starts over with it.
+func (m *Model) followDNSLog() tea.Cmd {
+	if !m.opts.CaptureDNS || m.selectedIface == "" || m.selectedIface == m.dnsLog.iface {
+		return nil
+	}
+	if m.dnsLog.running {
+		m.dnsLog.run.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	id := 1
+	if m.dnsLog.run != nil {
+		id = m.dnsLog.run.id + 1
+	}
+	r := &dnsLogRun{id: id, queries: make(chan probe.DNSQuery), done: make(chan error, 1), cancel: cancel}
+	iface := m.selectedIface
+	m.dnsLog = dnsLog{iface: iface, run: r, running: true}
+	m.setConnsContent()
+
+	sniffer := m.sniffer
+	return func() tea.Msg {
+		go func() { r.done <- sniffer.SniffDNS(ctx, iface, r.queries) }()
+		return waitDNSLogCmd(r)()
+	}
+}
+
+func waitDNSLogCmd(r *dnsLogRun) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case q := <-r.queries:
+			return dnsQueryMsg{id: r.id, q: q}
+		case err := <-r.done:
+			return dnsLogDoneMsg{id: r.id, err: err}
+		}
+	}
+}
+
+func (m *Model) applyDNSQuery(msg dnsQueryMsg) tea.Cmd {
+	if m.dnsLog.run == nil || msg.id != m.dnsLog.run.id {
+		return nil
+	}
+	if len(msg.q.Addrs) > 0 {
+		// updated in place: a frozen Connections tab may as well learn
+		// the names too
+		if m.dnsLog.addrs == nil || len(m.dnsLog.addrs) > dnsAddrsKeep {
+			m.dnsLog.addrs = map[string]string{}
+		}
+		for _, ip := range msg.q.Addrs {
+			m.dnsLog.addrs[ip] = msg.q.Name
+		}
+		return waitDNSLogCmd(m.dnsLog.run)
+	}
+	n := askedName{name: msg.q.Name}
+	names := make([]askedName, 0, len(m.dnsLog.names)+1)
+	for _, old := range m.dnsLog.names {
+		if old.name == n.name {
+			n = old
+			continue
+		}
+		names = append(names, old)
+	}
+	n.count++
+	n.last = m.now()
+	if indexOf(n.types, msg.q.Type) < 0 {
+		n.types = append(append([]string(nil), n.types...), msg.q.Type)
+	}
+	names = append([]askedName{n}, names...)
+	if len(names) > dnsLogKeep {
+		names = names[:dnsLogKeep]
+	}
+	m.dnsLog.names = names
+	m.setConnsContent()
+	return waitDNSLogCmd(m.dnsLog.run)
+}
+
+func (m *Model) applyDNSLogDone(msg dnsLogDoneMsg) {
+	if m.dnsLog.run == nil || msg.id != m.dnsLog.run.id {
+		return
+	}
+	m.dnsLog.running = false
+	if !errors.Is(msg.err, context.Canceled) {
+		m.dnsLog.err = msg.err
+	}
+	m.setConnsContent()
+}
+
+// renderDNSLog lists the names looked up most recently, through line as
+// in renderConns; nothing without Options.CaptureDNS.
+func (m Model) renderDNSLog(line func(s, key string), w int) {
+	if !m.opts.CaptureDNS {
+		return
+	}
+	l := m.dnsLog
+	title := titleStyle.Render(i18n.T("Recently resolved"))
+	if l.iface != "" {
+		title += "  " + subtleStyle.Render(fmt.Sprintf(i18n.T("DNS queries seen on %s"), l.iface))
+	}
+	line(title, "")
+	switch {
+	case l.err != nil:
+		line(errStyle.Render(i18n.T("Capture failed: ")+l.err.Error()), "")
+	case !l.running && l.run != nil:
+		line(subtleStyle.Render(i18n.T("capture stopped")), "")
+	case len(l.names) == 0:
+		line(subtleStyle.Render(i18n.T("no queries yet")), "")
+	}
+
+	colName := min(40, max(20, w-34))
+	for i, n := range l.names {
+		if i == dnsLogShown {
+			line(subtleStyle.Render(fmt.Sprintf(i18n.T("… %d more"), len(l.names)-dnsLogShown)), "")
+			break
+		}
+		name := padRight(trunc(n.name, colName), colName)
+		if _, _, ok := m.opts.Blocklist.Match("", []string{n.name}); ok {
+			name = errStyle.Render(name)
+		}
+		line(fmt.Sprintf("%s  %s  %s  %s", name,
+			padRight(trunc(strings.Join(n.types, " "), 12), 12),
+			padRight(fmt.Sprintf("×%d", n.count), 5),
+			subtleStyle.Render(m.ago(n.last))), "")
+	}
+	line("", "")
+}
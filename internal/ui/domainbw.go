@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// domainBWShown is how many domains the Connections tab ranks.
+const domainBWShown = 8
+
+type peerBWMsg map[string]probe.ProcBandwidth
+
+// fetchPeerBWCmd samples throughput per remote address. Like
+// fetchProcBWCmd, failures yield nil, which hides the ranking.
+func (m Model) fetchPeerBWCmd() tea.Cmd {
+	return func() tea.Msg {
+		bw, err := m.peerBWer.PeerBandwidth()
+		if err != nil {
+			return peerBWMsg(nil)
+		}
+		return peerBWMsg(bw)
+	}
+}
+
+type domainBW struct {
+	domain string
+	hosts  int
+	bw     probe.ProcBandwidth
+}
+
+// peerDomain names the site behind a remote address: the name it was
+// looked up by, when the DNS log saw the answer, else its reverse DNS
+// name, cut down to the registered domain ("rr3.googlevideo.com" →
+// "googlevideo.com"). Addresses without either stay as they are.
+func (m Model) peerDomain(ip string) string {
+	name := m.dnsLog.addrs[ip]
+	if name == "" {
+		name = m.hostName(ip)
+	}
+	if name == "" {
+		return ip
+	}
+	return baseDomain(name)
+}
+
+// baseDomain keeps the last two labels of name, or three under a
+// two-letter country code with a short second level ("bbc.co.uk").
+func baseDomain(name string) string {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	n := 2
+	if k := len(labels); k >= 3 && len(labels[k-1]) == 2 && len(labels[k-2]) <= 3 {
+		n = 3
+	}
+	if len(labels) <= n {
+		return strings.Join(labels, ".")
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+// domainBandwidth sums peerBW by peerDomain, busiest first.
+func (m Model) domainBandwidth() []domainBW {
+	byDomain := map[string]*domainBW{}
+	for ip, bw := range m.peerBW {
+		if bw.RxBps+bw.TxBps < 1 {
+			continue
+		}
+		d := m.peerDomain(ip)
+		e := byDomain[d]
+		if e == nil {
+			e = &domainBW{domain: d}
+			byDomain[d] = e
+		}
+		e.hosts++
+		e.bw.RxBps += bw.RxBps
+		e.bw.TxBps += bw.TxBps
+	}
+	out := make([]domainBW, 0, len(byDomain))
+	for _, e := range byDomain {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ti, tj := out[i].bw.RxBps+out[i].bw.TxBps, out[j].bw.RxBps+out[j].bw.TxBps
+		if ti != tj {
+			return ti > tj
+		}
+		return out[i].domain < out[j].domain
+	})
+	return out
+}
+
+// renderDomainBW ranks the domains by throughput, through line as in
+// renderConns; nothing where peer bandwidth is unavailable.
+func (m Model) renderDomainBW(line func(s, key string), w int) {
+	if m.peerBW == nil {
+		return
+	}
+	line(titleStyle.Render(i18n.T("Bandwidth by domain"))+"  "+subtleStyle.Render(i18n.T("TCP, approximate")), "")
+	ds := m.domainBandwidth()
+	if len(ds) == 0 {
+		line(subtleStyle.Render(i18n.T("no traffic")), "")
+	}
+	colName := min(40, max(20, w-34))
+	for i, d := range ds {
+		if i == domainBWShown {
+			line(subtleStyle.Render(fmt.Sprintf(i18n.T("… %d more"), len(ds)-domainBWShown)), "")
+			break
+		}
+		hosts := ""
+		if d.hosts > 1 {
+			hosts = subtleStyle.Render(fmt.Sprintf(i18n.T("%d hosts"), d.hosts))
+		}
+		line(fmt.Sprintf("%s  ↓ %s  ↑ %s  %s", padRight(trunc(d.domain, colName), colName),
+			padRight(procRate(d.bw.RxBps, m.units), 11), padRight(procRate(d.bw.TxBps, m.units), 11), hosts), "")
+	}
+	line("", "")
+}
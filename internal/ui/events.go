@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// oldest events are dropped beyond this
+const maxEvents = 500
+
+// eventLog is a bounded, timestamped record of notable changes. It is
+// shared by pointer so copies of Model append to the same log.
+type eventLog struct {
+	entries []event
+	dropped int // entries dropped from the front, so entries[i] is event number dropped+i
+}
+
+type event struct {
+	at   time.Time
+	kind eventKind
+	text string
+}
+
+// eventKind groups events on the session timeline.
+type eventKind int
+
+const (
+	evNote     eventKind = iota // anything not below
+	evListener                  // a listener opened or closed
+	evIface                     // an interface or VPN went down or up
+	evIP                        // the external IP changed
+	evAlert                     // also raised in the footer
+)
+
+func newEventLog() *eventLog {
+	return &eventLog{}
+}
+
+func (l *eventLog) add(at time.Time, kind eventKind, text string) {
+	l.entries = append(l.entries, event{at: at, kind: kind, text: text})
+	if n := len(l.entries); n > maxEvents {
+		l.entries = append([]event(nil), l.entries[n-maxEvents:]...)
+		l.dropped += n - maxEvents
+	}
+}
+
+type routesMsg struct {
+	routes []probe.Route
+	err    error
+}
+
+func (m Model) fetchRoutesCmd() tea.Cmd {
+	return func() tea.Msg {
+		rs, err := m.routeReader.Routes()
+		return routesMsg{routes: rs, err: err}
+	}
+}
+
+// applyRoutes stores the routing table and logs an event when the preferred
+// default route of a family moves to another gateway or interface.
+func (m *Model) applyRoutes(msg routesMsg) {
+	m.routes, m.routesErr = msg.routes, msg.err
+	if msg.err != nil {
+		return
+	}
+
+	cur := map[string]probe.Route{}
+	for _, r := range probe.DefaultRoutes(msg.routes) {
+		// routes are sorted by metric, so the first one per family wins
+		if _, ok := cur[r.Family]; !ok {
+			cur[r.Family] = r
+		}
+	}
+
+	if m.defRoutes != nil {
+		for _, fam := range []string{"inet", "inet6"} {
+			prev, had := m.defRoutes[fam]
+			next, has := cur[fam]
+			var text string
+			switch {
+			case had && !has:
+				text = fmt.Sprintf(i18n.T("default route (%s) removed: %s"), fam, routeVia(prev))
+			case !had && has:
+				text = fmt.Sprintf(i18n.T("default route (%s) added: %s"), fam, routeVia(next))
+			case had && has && (prev.Gateway != next.Gateway || prev.Iface != next.Iface):
+				text = fmt.Sprintf(i18n.T("default route (%s) changed: %s → %s"), fam, routeVia(prev), routeVia(next))
+			default:
+				continue
+			}
+			m.events.add(m.now(), evAlert, text)
+			m.alert, m.alertAt = text, m.now()
+		}
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+	m.defRoutes = cur
+	m.updatePingTargets()
+}
+
+// logIfaceFlaps logs the interfaces that went down or came up since the
+// previous snapshot. VPNs are left to checkKillSwitch.
+func (m *Model) logIfaceFlaps(prev []probe.IfaceInfo) {
+	was := make(map[string]bool, len(prev))
+	for _, ii := range prev {
+		was[ii.Name] = ii.IsUp
+	}
+	var logged bool
+	for _, ii := range m.lastSnap.Ifaces {
+		up, seen := was[ii.Name]
+		if !seen || up == ii.IsUp || isVPN(ii.Kind) {
+			continue
+		}
+		text := fmt.Sprintf(i18n.T("interface %s went down"), ii.Name)
+		if ii.IsUp {
+			text = fmt.Sprintf(i18n.T("interface %s is up again"), ii.Name)
+		}
+		m.events.add(m.now(), evIface, text)
+		logged = true
+	}
+	if logged {
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+}
+
+// logListeners logs listeners opened and closed between ports samples.
+func (m *Model) logListeners(added, removed []string) {
+	for _, k := range added {
+		m.events.add(m.now(), evListener, fmt.Sprintf(i18n.T("listener opened: %s"), k))
+	}
+	for _, k := range removed {
+		m.events.add(m.now(), evListener, fmt.Sprintf(i18n.T("listener closed: %s"), k))
+	}
+	if len(added)+len(removed) > 0 {
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+}
+
+func routeVia(r probe.Route) string {
+	if r.Gateway == "" {
+		return "dev " + r.Iface
+	}
+	return "via " + r.Gateway + " dev " + r.Iface
+}
+
+func (m Model) renderEventsText() string {
+	if len(m.events.entries) == 0 {
+		return subtleStyle.Render(i18n.T("No events yet. Route changes, rogue router advertisements and blocklist hits are recorded here."))
+	}
+
+	var b strings.Builder
+	sel, scrubbing := m.scrubbed()
+	// newest first
+	for i := len(m.events.entries) - 1; i >= 0; i-- {
+		e := m.events.entries[i]
+		text := e.text
+		if scrubbing && i == sel {
+			text = selectedStyle.Render(text)
+		}
+		b.WriteString(subtleStyle.Render(i18n.DateTime(e.at)+"  "+padRight(m.ago(e.at), 9)) + "  " + text + "\n")
+	}
+	return b.String()
+}
+
+func (m Model) viewEvents() string {
+	w := min(m.w-2, 120)
+
+	title := titleStyle.Render(i18n.T("Events"))
+	if m.routesErr != nil {
+		title += "  " + subtleStyle.Render(i18n.T("routes n/a")+": "+m.routesErr.Error())
+	}
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(title + "\n\n" + m.renderSessionTimeline() + "\n" + m.eventsVP.View())
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/execprobe"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// execState tracks the user's exec probes, indexed like Options.ExecProbes.
+// Shared by pointer like sessionStats.
+type execState struct {
+	results []execprobe.Result
+	errs    []error
+	nextAt  []time.Time
+	running []bool
+}
+
+func newExecState(n int) *execState {
+	return &execState{
+		results: make([]execprobe.Result, n),
+		errs:    make([]error, n),
+		nextAt:  make([]time.Time, n),
+		running: make([]bool, n),
+	}
+}
+
+type execMsg struct {
+	idx int
+	res execprobe.Result
+	err error
+}
+
+// dueExecCmds starts the probes whose interval has passed. A probe that is
+// still running is not started again.
+func (m Model) dueExecCmds(now time.Time) []tea.Cmd {
+	var cmds []tea.Cmd
+	for i, spec := range m.opts.ExecProbes {
+		st := m.execs
+		if st.running[i] || now.Before(st.nextAt[i]) {
+			continue
+		}
+		st.running[i] = true
+		st.nextAt[i] = now.Add(spec.Every)
+		i, spec := i, spec
+		cmds = append(cmds, func() tea.Msg {
+			res, err := execprobe.Run(spec)
+			return execMsg{idx: i, res: res, err: err}
+		})
+	}
+	return cmds
+}
+
+func (m *Model) applyExec(msg execMsg) {
+	st := m.execs
+	st.running[msg.idx] = false
+	st.errs[msg.idx] = msg.err
+	if msg.err == nil {
+		st.results[msg.idx] = msg.res
+	}
+	m.setExecContent()
+}
+
+// tabHidden reports tabs that have nothing to show in this configuration.
+func (m Model) tabHidden(t tab) bool {
+	return t == tabExec && len(m.opts.ExecProbes) == 0 ||
+		t == tabHistory && m.opts.Bandwidth == nil
+}
+
+// stepTab returns the next visible tab in direction d (+1 or -1).
+func (m Model) stepTab(d int) tab {
+	t := m.activeTab
+	for {
+		t = (t + tab(d) + tabCount) % tabCount
+		if !m.tabHidden(t) {
+			return t
+		}
+	}
+}
+
+func (m *Model) setExecContent() {
+	m.execVP.SetContent(hardClipLinesToWidth(m.renderExecText(), m.execVP.Width))
+}
+
+func (m Model) renderExecText() string {
+	const maxCol = 30
+
+	var b strings.Builder
+	for i, spec := range m.opts.ExecProbes {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		res, err := m.execs.results[i], m.execs.errs[i]
+		title := titleStyle.Render(spec.Name) + "  " + subtleStyle.Render(fmt.Sprintf(i18n.T("every %s"), humanDuration(spec.Every)))
+		if !res.At.IsZero() {
+			title += subtleStyle.Render("  " + fmt.Sprintf(i18n.T("(updated %s)"), m.ago(res.At)))
+		}
+		b.WriteString(title + "\n")
+		if err != nil {
+			b.WriteString(errStyle.Render(err.Error()) + "\n")
+		}
+		switch {
+		case res.At.IsZero() && err == nil:
+			b.WriteString(i18n.T("No data (yet)…") + "\n")
+			continue
+		case res.At.IsZero():
+			continue
+		case len(res.Rows) == 0:
+			b.WriteString(subtleStyle.Render(i18n.T("no rows")) + "\n")
+			continue
+		}
+
+		widths := make([]int, len(res.Columns))
+		for c, h := range res.Columns {
+			widths[c] = min(maxCol, len([]rune(h)))
+		}
+		for _, row := range res.Rows {
+			for c, v := range row {
+				widths[c] = min(maxCol, max(widths[c], len([]rune(v))))
+			}
+		}
+		cells := func(vals []string) string {
+			parts := make([]string, len(vals))
+			for c, v := range vals {
+				parts[c] = padRight(trunc(v, widths[c]), widths[c])
+			}
+			return strings.TrimRight(strings.Join(parts, "  "), " ")
+		}
+		head := make([]string, len(res.Columns))
+		for c, h := range res.Columns {
+			head[c] = strings.ToUpper(h)
+		}
+		b.WriteString(cells(head) + "\n")
+		total := 0
+		for _, w := range widths {
+			total += w + 2
+		}
+		b.WriteString(strings.Repeat("─", max(0, total-2)) + "\n")
+		for _, row := range res.Rows {
+			b.WriteString(cells(row) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (m Model) viewExec() string {
+	w := min(m.w-2, 120)
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(m.execVP.View())
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/desktop"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// table is a plain export of a tab's rows.
+type table struct {
+	name   string // used in file names
+	header []string
+	rows   [][]string
+}
+
+// portsTable returns the marked listeners, or else those passing the
+// current search.
+func (m Model) portsTable() table {
+	t := table{name: "ports", header: []string{"proto", "local", "pid", "process", "note"}}
+	for _, p := range m.ports {
+		var reach []string
+		if m.portsExpand {
+			reach = probe.ReachableAddrs(p, m.lastSnap.Ifaces)
+		}
+		if m.portsMarked.any() && !m.portsMarked[listenerKey(p)] || !m.portsMarked.any() && !m.portMatches(p, reach) {
+			continue
+		}
+		t.rows = append(t.rows, []string{p.Proto, p.Local, fmt.Sprint(p.PID), p.Process, m.portNote(p)})
+	}
+	return t
+}
+
+// procsTable returns the marked processes, or else those passing the
+// current search.
+func (m Model) procsTable() table {
+	t := table{name: "procs", header: []string{"pid", "name", "conns", "listen"}}
+	for _, p := range m.procs {
+		if m.procsMarked.any() && !m.procsMarked[fmt.Sprint(p.PID)] || !m.procsMarked.any() && !m.procMatches(p) {
+			continue
+		}
+		t.rows = append(t.rows, []string{fmt.Sprint(p.PID), p.Name, fmt.Sprint(p.ConnCount), fmt.Sprint(p.ListenCount)})
+	}
+	return t
+}
+
+func (t table) csv() []byte {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write(t.header)
+	w.WriteAll(t.rows)
+	return b.Bytes()
+}
+
+// json renders the rows as an array of objects keyed by column.
+func (t table) json() []byte {
+	objs := make([]map[string]string, 0, len(t.rows))
+	for _, r := range t.rows {
+		o := make(map[string]string, len(t.header))
+		for i, h := range t.header {
+			o[h] = r[i]
+		}
+		objs = append(objs, o)
+	}
+	b, _ := json.MarshalIndent(objs, "", "  ")
+	return append(b, '\n')
+}
+
+// exportPicker chooses where the active table goes.
+type exportPicker struct {
+	open   bool
+	table  table
+	cursor int
+}
+
+var exportTargets = []string{"CSV file", "JSON file", "Clipboard (CSV)"}
+
+func exportCmd(t table, target int, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		if target == 2 {
+			if err := desktop.Copy(string(t.csv())); err != nil {
+				return noticeMsg{err: err}
+			}
+			return noticeMsg{text: fmt.Sprintf(i18n.T("copied %d rows"), len(t.rows))}
+		}
+
+		ext, data := "csv", t.csv()
+		if target == 1 {
+			ext, data = "json", t.json()
+		}
+		path := fmt.Sprintf("ducknetview-%s-%s.%s", t.name, now.Format("20060102-150405"), ext)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return noticeMsg{err: err}
+		}
+		return noticeMsg{text: fmt.Sprintf(i18n.T("exported %d rows to %s"), len(t.rows), path)}
+	}
+}
+
+// openExport offers the rows currently shown, or the marked ones, on the
+// Ports, Processes or Connections tab, the neighbor table on Routing, the
+// ruleset on Firewall or the flows on Flows (after search).
+func (m *Model) openExport() bool {
+	var t table
+	switch m.activeTab {
+	case tabPorts:
+		t = m.portsTable()
+	case tabProcs:
+		t = m.procsTable()
+	case tabConns:
+		t = m.connsTable()
+	case tabRouting:
+		t = m.neighborsTable()
+	case tabFirewall:
+		t = m.firewallTable()
+	case tabFlows:
+		t = m.flowsTable()
+	default:
+		return false
+	}
+	m.export = exportPicker{open: true, table: t}
+	return true
+}
+
+func (m Model) updateExport(km tea.KeyMsg) (Model, tea.Cmd) {
+	p := &m.export
+	switch km.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(exportTargets)-1 {
+			p.cursor++
+		}
+	case "enter":
+		p.open = false
+		return m, exportCmd(p.table, p.cursor, m.now())
+	case "esc", "x", "q":
+		p.open = false
+	}
+	return m, nil
+}
+
+func (m Model) viewExport() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T("Export %d rows"), len(m.export.table.rows))) + "\n")
+	b.WriteString(subtleStyle.Render(i18n.T("enter export • esc close")) + "\n\n")
+	for i, t := range exportTargets {
+		line := "  " + i18n.T(t)
+		if i == m.export.cursor {
+			line = selectedStyle.Render("> " + i18n.T(t))
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
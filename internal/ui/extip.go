@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/extip"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/iphistory"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	extIPMaxBackoff = 10 * time.Minute
+	// extIPLookupTimeout bounds a lookup through all providers.
+	extIPLookupTimeout = 30 * time.Second
+)
+
+// extIPBackoff is the wait before retrying the external IP lookup after
+// fails consecutive failures: the normal interval, doubled per failure
+// after the first, capped so an outage is still noticed ending.
+func extIPBackoff(every time.Duration, fails int) time.Duration {
+	d := every
+	for i := 1; i < fails && d < extIPMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > extIPMaxBackoff {
+		d = extIPMaxBackoff
+	}
+	return d
+}
+
+// extIPDue reports whether the periodic external IP lookup should run now;
+// after a failure, retryExternalIPCmd runs it instead.
+func (m Model) extIPDue() bool {
+	return !m.metered && m.extIPFails == 0
+}
+
+// extIPRetryMsg is the retry of a failed external IP lookup; stale when
+// another lookup has come back since.
+type extIPRetryMsg struct{ gen int }
+
+// retryExternalIPCmd retries the lookup after backoff, on a timer of its
+// own: waiting for the periodic tick would add up to a whole interval.
+func (m *Model) retryExternalIPCmd(backoff time.Duration) tea.Cmd {
+	m.extIPRetryGen++
+	m.extIPRetryAt = m.now().Add(backoff)
+	gen := m.extIPRetryGen
+	return tea.Tick(backoff, func(time.Time) tea.Msg { return extIPRetryMsg{gen: gen} })
+}
+
+type externalIPv6Msg struct {
+	ip  string
+	err error
+}
+
+// fetchExternalIPv6Cmd looks up the public IPv6 address. Without IPv6
+// connectivity it simply fails, so it has no backoff of its own; it runs
+// with the IPv4 lookup.
+func (m Model) fetchExternalIPv6Cmd() tea.Cmd {
+	p := m.opts.ExternalIP
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), extIPLookupTimeout)
+		defer cancel()
+
+		ip, err := p.Lookup(extip.WithFamily(ctx, 6))
+		if err == nil && net.ParseIP(ip).To4() != nil {
+			// a provider without IPv6 that ignored the family, e.g. DNS
+			err = fmt.Errorf("external ip: %s is not IPv6", ip)
+			ip = ""
+		}
+		return externalIPv6Msg{ip: ip, err: err}
+	}
+}
+
+// renderExternalIPv6 is the Overview line below the external IP; empty
+// until the first lookup is back.
+func (m Model) renderExternalIPv6() string {
+	switch {
+	case m.externalIPv6 != "":
+		line := fmt.Sprintf(i18n.T("External IPv6: %s"), m.externalIPv6)
+		if g := m.geoOf(m.externalIPv6); !g.IsZero() {
+			line += "  " + okStyle.Render(g.String())
+		}
+		return line
+	case m.externalIPv6Err != nil:
+		return fmt.Sprintf(i18n.T("External IPv6: %s"), subtleStyle.Render(i18n.T("none (no IPv6 route out)")))
+	}
+	return ""
+}
+
+// ipChangesShown is how many external IP changes Overview lists.
+const ipChangesShown = 5
+
+// recordIPChangeCmd appends a change to Options.IPHistory; nil without one.
+func (m Model) recordIPChangeCmd(from, to string) tea.Cmd {
+	l := m.opts.IPHistory
+	if l == nil {
+		return nil
+	}
+	c := iphistory.Change{At: m.now(), From: from, To: to}
+	return func() tea.Msg {
+		if err := l.Add(c); err != nil {
+			return noticeMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// renderIPChanges lists the latest external IP changes below the external
+// IP, from Options.IPHistory when kept, else this session's. The newest is
+// highlighted: a different exit means ISP failover, a VPN coming or going.
+func (m Model) renderIPChanges() string {
+	var cs []iphistory.Change
+	if m.opts.IPHistory != nil {
+		cs = m.opts.IPHistory.Last(ipChangesShown)
+	} else {
+		for i := len(m.session.ipChanges) - 1; i >= 0 && len(cs) < ipChangesShown; i-- {
+			c := m.session.ipChanges[i]
+			cs = append(cs, iphistory.Change{At: c.at, From: c.from, To: c.to})
+		}
+	}
+	if len(cs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	from := cs[0].From
+	if g := m.geoOf(from); !g.IsZero() {
+		from += " (" + g.String() + ")"
+	}
+	b.WriteString(warnStyle.Render(fmt.Sprintf(i18n.T("changed %s, was %s"), m.ago(cs[0].At), from)) + "\n")
+	for _, c := range cs[1:] {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf("  %s  %s → %s", i18n.DateTime(c.At), c.From, c.To)) + "\n")
+	}
+	return b.String()
+}
+
+// ifaceExit is the external IP seen by a lookup sent from an interface's
+// own address: with a VPN and a physical uplink, what each path exits as.
+type ifaceExit struct {
+	src string
+	ip  string
+	err error
+	at  time.Time
+}
+
+type ifaceExitMsg struct {
+	iface, src, ip string
+	err            error
+}
+
+// exitSource is the address lookups via ii leave from: its first global
+// IPv4 address, or IPv6 when it has none. nil for interfaces that are
+// down or only have loopback and link-local addresses.
+func exitSource(ii probe.IfaceInfo) net.IP {
+	if !ii.IsUp {
+		return nil
+	}
+	var v6 net.IP
+	for _, a := range ii.Addrs {
+		ip, _, err := net.ParseCIDR(a)
+		if err != nil || !ip.IsGlobalUnicast() {
+			continue
+		}
+		if ip.To4() != nil {
+			return ip
+		}
+		if v6 == nil {
+			v6 = ip
+		}
+	}
+	return v6
+}
+
+// fetchIfaceExitCmd looks up the external IP via interface name; nil when
+// it has no address to send from.
+func (m Model) fetchIfaceExitCmd(name string) tea.Cmd {
+	var src net.IP
+	for _, ii := range m.lastSnap.Ifaces {
+		if ii.Name == name {
+			src = exitSource(ii)
+		}
+	}
+	if src == nil {
+		return nil
+	}
+	p := m.opts.ExternalIP
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), extIPLookupTimeout)
+		defer cancel()
+
+		ip, err := p.Lookup(extip.WithSource(ctx, src))
+		return ifaceExitMsg{iface: name, src: src.String(), ip: ip, err: err}
+	}
+}
+
+// applyIfaceExit stores a lookup result in a new map, so frozen copies
+// keep theirs.
+func (m *Model) applyIfaceExit(msg ifaceExitMsg) {
+	next := make(map[string]ifaceExit, len(m.ifaceExits)+1)
+	for k, v := range m.ifaceExits {
+		next[k] = v
+	}
+	next[msg.iface] = ifaceExit{src: msg.src, ip: msg.ip, err: msg.err, at: m.now()}
+	m.ifaceExits = next
+}
+
+// renderIfaceExit is the "Exits as" line of the interface details, empty
+// until a lookup via ii was made.
+func (m Model) renderIfaceExit(ii probe.IfaceInfo) string {
+	e, ok := m.ifaceExits[ii.Name]
+	if !ok || exitSource(ii) == nil {
+		return ""
+	}
+	ip, note := e.ip, ""
+	switch {
+	case e.err != nil:
+		ip = subtleStyle.Render(e.err.Error())
+	case m.externalIP == "":
+	case e.ip == m.externalIP:
+		note = i18n.T("(same as the default route)")
+	default:
+		ip = accentStyle.Render(ip)
+		note = i18n.T("(differs from the default route)")
+	}
+	line := fmt.Sprintf(i18n.T("Exits as: %s"), ip)
+	if note != "" {
+		line += "  " + subtleStyle.Render(note)
+	}
+	if g := m.geoOf(e.ip); e.err == nil && !g.IsZero() {
+		line += "  " + okStyle.Render(g.String())
+	}
+	return line + "  " + subtleStyle.Render(fmt.Sprintf(i18n.T("from %s, %s"), e.src, m.ago(e.at))) + "\n"
+}
@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// eyeballsPanel is the Happy Eyeballs overlay: a host prompt, then how
+// IPv4 and IPv6 connections to it compare.
+type eyeballsPanel struct {
+	open    bool
+	input   textinput.Model
+	loading bool
+	target  string
+	race    *probe.EyeballsRace
+	err     error
+}
+
+type eyeballsMsg struct {
+	target string
+	race   probe.EyeballsRace
+	err    error
+}
+
+func raceCmd(r probe.DualStackRacer, host, port string) tea.Cmd {
+	return func() tea.Msg {
+		race, err := r.RaceDualStack(host, port)
+		return eyeballsMsg{target: net.JoinHostPort(host, port), race: race, err: err}
+	}
+}
+
+// openEyeballs shows the host prompt, keeping the last host typed.
+func (m *Model) openEyeballs() {
+	in := textinput.New()
+	in.Prompt = i18n.T("Host: ")
+	in.Placeholder = "example.com:443"
+	in.CharLimit = 253
+	in.SetValue(m.eyeballs.input.Value())
+	in.CursorEnd()
+	in.Focus()
+	m.eyeballs = eyeballsPanel{open: true, input: in}
+}
+
+// splitTarget parses "host", "host:port" or "[v6]:port"; the port
+// defaults to 443.
+func splitTarget(s string) (host, port string, err error) {
+	s = strings.TrimSpace(s)
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		host, port = h, p
+	} else {
+		host, port = strings.Trim(s, "[]"), "443"
+	}
+	if host == "" || strings.ContainsAny(host, " /") {
+		return "", "", fmt.Errorf(i18n.T("not a host name: %q"), s)
+	}
+	return host, port, nil
+}
+
+func (m Model) updateEyeballs(km tea.KeyMsg) (Model, tea.Cmd) {
+	p := &m.eyeballs
+	if p.loading {
+		if km.String() == "esc" {
+			p.open = false
+		}
+		return m, nil
+	}
+
+	if !p.input.Focused() {
+		// showing a result
+		switch km.String() {
+		case "enter":
+			p.race, p.err = nil, nil
+			p.input.Focus()
+		case "esc", "H", "q":
+			p.open = false
+		}
+		return m, nil
+	}
+
+	switch km.String() {
+	case "esc":
+		p.open = false
+		return m, nil
+	case "enter":
+		host, port, err := splitTarget(p.input.Value())
+		if err != nil {
+			p.err = err
+			return m, nil
+		}
+		p.target, p.loading, p.err, p.race = net.JoinHostPort(host, port), true, nil, nil
+		p.input.Blur()
+		return m, raceCmd(m.racer, host, port)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(km)
+	return m, cmd
+}
+
+func (m *Model) applyEyeballs(msg eyeballsMsg) {
+	if !m.eyeballs.open || msg.target != m.eyeballs.target {
+		return
+	}
+	m.eyeballs.loading = false
+	if msg.err != nil {
+		m.eyeballs.err = msg.err
+		return
+	}
+	m.eyeballs.race = &msg.race
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%d ms", d.Milliseconds())
+}
+
+func (m Model) viewEyeballs() string {
+	p := m.eyeballs
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Happy Eyeballs: IPv4 vs IPv6")) + "\n")
+
+	switch {
+	case p.input.Focused():
+		b.WriteString(subtleStyle.Render(i18n.T("enter race • esc close")) + "\n\n")
+		b.WriteString(p.input.View() + "\n")
+		if p.err != nil {
+			b.WriteString("\n" + errStyle.Render(p.err.Error()) + "\n")
+		}
+		return b.String()
+	case p.loading:
+		b.WriteString("\n" + fmt.Sprintf(i18n.T("connecting to %s over IPv4 and IPv6…"), p.target) + "\n")
+		return b.String()
+	}
+
+	b.WriteString(subtleStyle.Render(i18n.T("enter race another • esc close")) + "\n\n")
+	if p.err != nil {
+		b.WriteString(p.target + "\n" + errStyle.Render(i18n.T("Error: ")+p.err.Error()) + "\n")
+		return b.String()
+	}
+
+	r := p.race
+	winner, margin := r.Winner()
+	b.WriteString(titleStyle.Render(p.target) + "\n\n")
+	const colFam, colAddr, colT = 6, 40, 9
+	b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n",
+		padRight("", colFam),
+		padRight(i18n.T("ADDRESS"), colAddr),
+		padRight(i18n.T("DNS"), colT),
+		i18n.T("CONNECT"),
+	))
+	for _, row := range []struct {
+		fam, name string
+		a         probe.EyeballsAttempt
+	}{{"inet6", "IPv6", r.V6}, {"inet", "IPv4", r.V4}} {
+		addr, conn := row.a.Addr, ms(row.a.Connect)
+		switch {
+		case row.a.Addr == "":
+			addr, conn = subtleStyle.Render(padRight(i18n.T("no address"), colAddr)), "-"
+		case row.a.Err != nil:
+			addr, conn = padRight(trunc(addr, colAddr), colAddr), errStyle.Render(i18n.T("failed"))
+		default:
+			addr = padRight(trunc(addr, colAddr), colAddr)
+			if row.fam == winner {
+				conn = okStyle.Render(conn + "  ← " + i18n.T("first"))
+			}
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n", padRight(row.name, colFam), addr, padRight(ms(row.a.Lookup), colT), conn))
+	}
+	for _, a := range []probe.EyeballsAttempt{r.V6, r.V4} {
+		if a.Err != nil {
+			b.WriteString(subtleStyle.Render(a.Err.Error()) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if r.V4.OK() && r.V6.OK() {
+		slower, faster := "IPv4", "IPv6"
+		if winner == "inet" {
+			slower, faster = faster, slower
+		}
+		b.WriteString(fmt.Sprintf(i18n.T("%s connected %s before %s (DNS included)."), faster, ms(margin), slower) + "\n")
+	}
+	b.WriteString(eyeballsVerdict(*r) + "\n")
+	return b.String()
+}
+
+// eyeballsVerdict explains which family a Happy Eyeballs client (browsers,
+// curl, Go) would end up on, and why.
+func eyeballsVerdict(r probe.EyeballsRace) string {
+	switch r.Preferred() {
+	case "":
+		return errStyle.Render(i18n.T("Neither IPv4 nor IPv6 connected."))
+	case "inet6":
+		if !r.V4.OK() {
+			return okStyle.Render(i18n.T("Clients use IPv6: IPv4 did not connect."))
+		}
+		if w, _ := r.Winner(); w == "inet6" {
+			return okStyle.Render(i18n.T("Clients use IPv6: it connected first."))
+		}
+		return okStyle.Render(fmt.Sprintf(i18n.T("Clients use IPv6: it was slower, but by less than its %s head start."), ms(probe.EyeballsDelay)))
+	}
+
+	// IPv4 it is; say what kept IPv6 out
+	var why string
+	switch {
+	case r.V6.Addr == "":
+		why = i18n.T("the name has no AAAA record")
+	case r.V6.Err != nil:
+		why = i18n.T("the IPv6 connection failed")
+	case r.V6.Lookup > r.V4.Lookup+probe.EyeballsResolutionDelay:
+		why = fmt.Sprintf(i18n.T("the AAAA answer came %s after the A answer, so IPv4 started first"), ms(r.V6.Lookup-r.V4.Lookup))
+	default:
+		why = fmt.Sprintf(i18n.T("the IPv6 handshake took %s longer, more than its %s head start"),
+			ms(r.V6.Connect-r.V4.Connect), ms(probe.EyeballsDelay))
+	}
+	return warnStyle.Render(fmt.Sprintf(i18n.T("Clients use IPv4: %s."), why))
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type firewallMsg struct {
+	counters []probe.FirewallCounter
+	err      error
+	at       time.Time
+}
+
+// fetchFirewallCmd reads the nftables counters; only done while the
+// Interfaces tab is open.
+func (m Model) fetchFirewallCmd() tea.Cmd {
+	return func() tea.Msg {
+		cs, err := m.fwReader.FirewallCounters()
+		return firewallMsg{counters: cs, err: err, at: m.now()}
+	}
+}
+
+// applyFirewall stores a sample and derives per-rule byte rates from the
+// previous one.
+func (m *Model) applyFirewall(msg firewallMsg) {
+	m.fwErr = msg.err
+	if msg.err != nil {
+		m.fw, m.fwRates = nil, nil
+		return
+	}
+	prev := map[string]probe.FirewallCounter{}
+	for _, c := range m.fw {
+		prev[c.Key()] = c
+	}
+	rates := map[string]float64{}
+	if dt := msg.at.Sub(m.fwAt).Seconds(); dt > 0 {
+		for _, c := range msg.counters {
+			// a reset or a replaced rule with a reused handle
+			if p, ok := prev[c.Key()]; ok && c.Bytes >= p.Bytes {
+				rates[c.Key()] = float64(c.Bytes-p.Bytes) / dt
+			}
+		}
+	}
+	m.fw, m.fwRates, m.fwAt = msg.counters, rates, msg.at
+
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+// renderFirewallText lists the counted nftables rules matching ii for the
+// interface details pane, with how much of the received traffic inbound
+// drop rules account for.
+func (m Model) renderFirewallText(ii *probe.IfaceInfo) string {
+	if errors.Is(m.fwErr, probe.ErrNoNft) || (m.fw == nil && m.fwErr == nil) {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Firewall (nft)")) + "\n")
+	if m.fwErr != nil {
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.fwErr.Error()) + "\n")
+		return b.String()
+	}
+
+	var rows []string
+	dropped := -1.0
+	for _, c := range m.fw {
+		if !c.Matches(ii.Name) {
+			continue
+		}
+		rate, known := m.fwRates[c.Key()]
+		if c.Dir == "in" && c.Dropped() && known {
+			if dropped < 0 {
+				dropped = 0
+			}
+			dropped += rate
+		}
+		verdict := c.Verdict
+		if verdict == "" {
+			verdict = "-"
+		}
+		row := fmt.Sprintf("%-3s %-7s %s/%s  %s  %s",
+			c.Dir, trunc(verdict, 7), c.Table, c.Chain,
+			fmt.Sprintf(i18n.T("%d pkts"), c.Packets),
+			i18n.Number(probe.HumanBytes(c.Bytes)))
+		if known {
+			row += "  " + humanRate(rate, m.units)
+		}
+		if c.Comment != "" {
+			row += "  " + subtleStyle.Render("# "+c.Comment)
+		}
+		if c.Dropped() && c.Packets > 0 {
+			row = errStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		b.WriteString(subtleStyle.Render(i18n.T("no counted rules for this interface")) + "\n")
+		return b.String()
+	}
+	if dropped >= 0 {
+		// the RX counters see packets before netfilter does, so this is
+		// the part of RX that arrived but never reached a socket
+		line := fmt.Sprintf(i18n.T("dropped by firewall: %s"), humanRate(dropped, m.units))
+		if ii.RxBps > 0 {
+			line += " " + i18n.Number(fmt.Sprintf(i18n.T("(%.1f%% of RX)"), dropped/ii.RxBps*100))
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(strings.Join(rows, "\n") + "\n")
+	return b.String()
+}
+
+type firewallRulesMsg struct {
+	chains []probe.FirewallChain
+	err    error
+	at     time.Time
+}
+
+// fetchFirewallRulesCmd reads the whole ruleset for the Firewall tab, on
+// every tick while it is open.
+func (m Model) fetchFirewallRulesCmd() tea.Cmd {
+	return func() tea.Msg {
+		cs, err := m.fwReader.ListFirewallRules()
+		return firewallRulesMsg{chains: cs, err: err, at: m.now()}
+	}
+}
+
+// applyFirewallRules is applyFirewall for the Firewall tab.
+func (m *Model) applyFirewallRules(msg firewallRulesMsg) {
+	m.fwChainsErr = msg.err
+	if msg.err != nil {
+		m.fwChains, m.fwRuleRates = nil, nil
+		m.setFirewallContent()
+		return
+	}
+	prev := map[string]probe.FirewallRule{}
+	for _, c := range m.fwChains {
+		for _, r := range c.Rules {
+			prev[r.Key(c)] = r
+		}
+	}
+	rates := map[string]float64{}
+	if dt := msg.at.Sub(m.fwChainsAt).Seconds(); dt > 0 {
+		for _, c := range msg.chains {
+			for _, r := range c.Rules {
+				if p, ok := prev[r.Key(c)]; ok && r.Counted && r.Bytes >= p.Bytes {
+					rates[r.Key(c)] = float64(r.Bytes-p.Bytes) / dt
+				}
+			}
+		}
+	}
+	m.fwChains, m.fwRuleRates, m.fwChainsAt = msg.chains, rates, msg.at
+	m.setFirewallContent()
+}
+
+func (m *Model) setFirewallContent() {
+	m.firewallVP.SetContent(hardClipLinesToWidth(m.renderFirewallRulesText(), m.firewallVP.Width))
+}
+
+// renderFirewallRulesText lists every chain, grouped by table, with the
+// counters of its rules and how fast they grow.
+func (m Model) renderFirewallRulesText() string {
+	w := m.firewallVP.Width
+	if w <= 0 {
+		w = 120
+	}
+	var b strings.Builder
+	rules := 0
+	for _, c := range m.fwChains {
+		rules += len(c.Rules)
+	}
+	sub := fmt.Sprintf(i18n.T("%d chains, %d rules"), len(m.fwChains), rules)
+	b.WriteString(titleStyle.Render(i18n.T("Firewall ruleset")) + "  " + subtleStyle.Render(sub) + "\n\n")
+	switch {
+	case errors.Is(m.fwChainsErr, probe.ErrNoFirewall):
+		return b.String() + subtleStyle.Render(i18n.T("n/a")+": "+i18n.T("needs nft or iptables-save")) + "\n"
+	case m.fwChainsErr != nil:
+		return b.String() + subtleStyle.Render(i18n.T("n/a")+": "+m.fwChainsErr.Error()) + "\n" +
+			subtleStyle.Render(i18n.T("Reading the ruleset needs root or CAP_NET_ADMIN.")) + "\n"
+	case m.fwChainsAt.IsZero():
+		return b.String() + i18n.T("No data (yet)…") + "\n"
+	case len(m.fwChains) == 0:
+		return b.String() + subtleStyle.Render(i18n.T("The ruleset is empty.")) + "\n"
+	}
+
+	colPkts, colBytes, colRate := 9, 10, 11
+	if m.compact() {
+		colRate = 0
+	}
+	counters := func(pkts, bytes, rate string) string {
+		s := padRight(pkts, colPkts) + "  " + padRight(bytes, colBytes)
+		if colRate > 0 {
+			s += "  " + padRight(rate, colRate)
+		}
+		return s
+	}
+	table := ""
+	for _, c := range m.fwChains {
+		if t := c.Family + " " + c.Table; t != table {
+			if table != "" {
+				b.WriteString("\n")
+			}
+			table = t
+			b.WriteString(accentStyle.Render(i18n.T("table")+" "+t) + "\n")
+			b.WriteString(counters(i18n.T("PKTS"), i18n.T("BYTES"), i18n.T("RATE")) + "  " + i18n.T("RULE") + "\n")
+			b.WriteString(strings.Repeat("─", w) + "\n")
+		}
+		head := i18n.T("chain") + " " + c.Name
+		if c.Hook != "" {
+			head += subtleStyle.Render(fmt.Sprintf("  "+i18n.T("hook %s priority %d"), c.Hook, c.Priority))
+			policy := i18n.T("policy") + " " + c.Policy
+			if c.Policy == "drop" {
+				policy = warnStyle.Render(policy)
+			}
+			head += "  " + policy
+		}
+		b.WriteString(titleStyle.Render(head) + "\n")
+		if len(c.Rules) == 0 {
+			b.WriteString(subtleStyle.Render(counters("", "", "")+"  "+i18n.T("no rules")) + "\n")
+		}
+		for _, r := range c.Rules {
+			rule := r.Text
+			if r.Comment != "" {
+				rule += "  " + subtleStyle.Render("# "+r.Comment)
+			}
+			if !r.Counted {
+				b.WriteString(subtleStyle.Render(counters("-", "-", "")) + "  " + rule + "\n")
+				continue
+			}
+			rate := ""
+			if v, ok := m.fwRuleRates[r.Key(c)]; ok {
+				rate = humanRate(v, m.units)
+			}
+			row := counters(fmt.Sprint(r.Packets), i18n.Number(probe.HumanBytes(r.Bytes)), rate)
+			switch {
+			case r.Dropped() && r.Packets > 0:
+				row = errStyle.Render(row)
+			case rate != "" && m.fwRuleRates[r.Key(c)] > 0:
+				row = okStyle.Render(row)
+			}
+			b.WriteString(row + "  " + rule + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (m Model) viewFirewall() string {
+	w := min(m.w-2, 120)
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(m.firewallVP.View())
+}
+
+func (m Model) firewallTable() table {
+	t := table{name: "firewall", header: []string{"family", "table", "chain", "handle", "rule", "verdict", "comment", "packets", "bytes"}}
+	for _, c := range m.fwChains {
+		for _, r := range c.Rules {
+			pkts, bytes := "", ""
+			if r.Counted {
+				pkts, bytes = fmt.Sprint(r.Packets), fmt.Sprint(r.Bytes)
+			}
+			t.rows = append(t.rows, []string{c.Family, c.Table, c.Name, fmt.Sprint(r.Handle), r.Text, r.Verdict, r.Comment, pkts, bytes})
+		}
+	}
+	return t
+}
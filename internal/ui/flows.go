@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// maxFlowRows bounds the Flows table; a busy NAT gateway tracks tens of
+// thousands, and a search narrows them down.
+const maxFlowRows = 1000
+
+type flowsMsg struct {
+	flows []probe.Flow
+	err   error
+}
+
+// fetchFlowsCmd reads the conntrack table; only done while the Flows tab
+// is open.
+func (m Model) fetchFlowsCmd() tea.Cmd {
+	return func() tea.Msg {
+		fs, err := m.flowLister.ListFlows()
+		return flowsMsg{flows: fs, err: err}
+	}
+}
+
+func (m *Model) applyFlows(msg flowsMsg) {
+	m.flows, m.flowsErr = msg.flows, msg.err
+	// busiest first; without accounting all are 0 and the order stays
+	sort.SliceStable(m.flows, func(i, j int) bool {
+		a, b := m.flows[i], m.flows[j]
+		return a.Bytes+a.ReplyBytes > b.Bytes+b.ReplyBytes
+	})
+	m.setFlowsContent()
+}
+
+func (m *Model) setFlowsContent() {
+	m.flowsVP.SetContent(hardClipLinesToWidth(m.renderFlowsText(), m.flowsVP.Width))
+}
+
+// flowNAT says how f was translated: the address the source was rewritten
+// to, or the one the destination was sent on to.
+func flowNAT(f probe.Flow) string {
+	var nat []string
+	if f.SNAT() {
+		nat = append(nat, "SNAT "+f.ReplyDst)
+	}
+	if f.DNAT() {
+		nat = append(nat, "DNAT "+f.ReplySrc)
+	}
+	return strings.Join(nat, ", ")
+}
+
+// flowMatches reports whether f has q in any of its columns.
+func flowMatches(f probe.Flow, q string) bool {
+	return q == "" || containsFold(strings.Join([]string{f.Proto, f.State, f.Src, f.Dst, flowNAT(f)}, " "), q)
+}
+
+// flowCols are the column widths of the Flows table; the NAT column only
+// where it fits.
+func (m Model) flowCols() (proto, state, addr, nat, bytes int) {
+	if m.compact() || m.flowsVP.Width < 114 {
+		return 5, 11, 21, 0, 9
+	}
+	return 5, 11, 22, 24, 9
+}
+
+// flowsHeader is the column header of the Flows table, which stays above
+// its viewport.
+func (m Model) flowsHeader() string {
+	colProto, colState, colAddr, colNAT, colBytes := m.flowCols()
+	h := padRight(i18n.T("PROTO"), colProto) + "  " + padRight(i18n.T("STATE"), colState) + "  " +
+		padRight(i18n.T("SOURCE"), colAddr) + "  " + padRight(i18n.T("DESTINATION"), colAddr) + "  "
+	if colNAT > 0 {
+		h += padRight(i18n.T("NAT"), colNAT) + "  "
+	}
+	h += padRight("→ "+i18n.T("BYTES"), colBytes) + "  " + padRight("← "+i18n.T("BYTES"), colBytes)
+	return hardClipLinesToWidth(h, m.flowsVP.Width) + "\n" + strings.Repeat("─", min(m.flowsVP.Width, lipgloss.Width(h))) + "\n"
+}
+
+func (m Model) renderFlowsText() string {
+	switch {
+	case errors.Is(m.flowsErr, probe.ErrNoConntrack):
+		return subtleStyle.Render(i18n.T("n/a")+": "+i18n.T("connection tracking is off (no nf_conntrack module)")) + "\n"
+	case m.flowsErr != nil:
+		return subtleStyle.Render(i18n.T("n/a")+": "+m.flowsErr.Error()) + "\n" +
+			subtleStyle.Render(i18n.T("Reading the conntrack table needs root.")) + "\n"
+	case m.flows == nil:
+		return i18n.T("No data (yet)…") + "\n"
+	}
+
+	colProto, colState, colAddr, colNAT, colBytes := m.flowCols()
+	q := m.flowsQuery
+	var b strings.Builder
+	shown, more := 0, 0
+	for _, f := range m.flows {
+		if !flowMatches(f, q) {
+			continue
+		}
+		if shown == maxFlowRows {
+			more++
+			continue
+		}
+		shown++
+		state := f.State
+		switch {
+		case f.Unreplied:
+			state = i18n.T("unreplied")
+		case state == "":
+			state = "-"
+		}
+		bytes := func(n uint64) string {
+			if n == 0 {
+				return "-"
+			}
+			return i18n.Number(probe.HumanBytes(n))
+		}
+		row := padRight(trunc(f.Proto, colProto), colProto) + "  " + padRight(trunc(state, colState), colState) + "  " +
+			padRight(trunc(f.Src, colAddr), colAddr) + "  " + padRight(trunc(f.Dst, colAddr), colAddr) + "  "
+		if colNAT > 0 {
+			row += padRight(trunc(flowNAT(f), colNAT), colNAT) + "  "
+		}
+		row += padRight(bytes(f.Bytes), colBytes) + "  " + padRight(bytes(f.ReplyBytes), colBytes)
+		row = highlightFold(row, q)
+		if f.Unreplied {
+			row = subtleStyle.Render(row)
+		}
+		b.WriteString(row + "\n")
+	}
+	if shown == 0 {
+		if q != "" {
+			return subtleStyle.Render(i18n.T("no flows match")) + "\n"
+		}
+		return subtleStyle.Render(i18n.T("no tracked flows")) + "\n"
+	}
+	if more > 0 {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf(i18n.T("… and %d more; / narrows them down"), more)) + "\n")
+	}
+	return b.String()
+}
+
+func (m Model) viewFlows() string {
+	nat, acct := 0, false
+	for _, f := range m.flows {
+		if f.SNAT() || f.DNAT() {
+			nat++
+		}
+		acct = acct || f.Bytes > 0
+	}
+	title := titleStyle.Render(i18n.T("Tracked flows")) + "  " +
+		subtleStyle.Render(fmt.Sprintf(i18n.T("%d flows, %d translated"), len(m.flows), nat))
+	if m.flows != nil && !acct {
+		title += subtleStyle.Render(" • " + i18n.T("byte counts need net.netfilter.nf_conntrack_acct=1"))
+	}
+
+	searchLine := subtleStyle.Render(i18n.T("Press / to search"))
+	if m.flowsQuery != "" {
+		searchLine = subtleStyle.Render(i18n.T("Filter: ")) + titleStyle.Render(m.flowsQuery) + subtleStyle.Render(m.filterHint())
+	}
+	if m.flowsSearching {
+		searchLine = m.flowsSearch.View()
+	}
+	content := title + "\n" + searchLine + "\n\n" + m.flowsHeader() + m.flowsVP.View()
+	return boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(content)
+}
+
+func (m Model) flowsTable() table {
+	t := table{name: "flows", header: []string{"family", "proto", "state", "src", "dst", "reply_src", "reply_dst", "packets", "bytes", "reply_packets", "reply_bytes"}}
+	for _, f := range m.flows {
+		if !flowMatches(f, m.flowsQuery) {
+			continue
+		}
+		t.rows = append(t.rows, []string{f.Family, f.Proto, f.State, f.Src, f.Dst, f.ReplySrc, f.ReplyDst,
+			fmt.Sprint(f.Packets), fmt.Sprint(f.Bytes), fmt.Sprint(f.ReplyPackets), fmt.Sprint(f.ReplyBytes)})
+	}
+	return t
+}
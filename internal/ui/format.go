@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// Durations and ages are written the same way everywhere: humanDuration
+// for how long something has been running ("2d 4h 13m"), shortAgo for a
+// compact age in a column ("12s", "5h"), ago for a timestamp relative to
+// now ("12s ago"); humanOffset for a signed clock offset and humanRTT for
+// a round-trip time.
+
+// humanDuration writes d with up to three units, dropping seconds once it
+// is an hour or more: "45s", "13m 5s", "4h 13m", "2d 4h 13m".
+func humanDuration(d time.Duration) string {
+	if d < time.Second {
+		return "0s"
+	}
+	d = d.Truncate(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	h := d / time.Hour
+	d -= h * time.Hour
+	mins := d / time.Minute
+	secs := (d - mins*time.Minute) / time.Second
+
+	var parts []string
+	add := func(n time.Duration, unit string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit))
+		}
+	}
+	add(days, "d")
+	add(h, "h")
+	add(mins, "m")
+	if days == 0 && h == 0 {
+		add(secs, "s")
+	}
+	return strings.Join(parts, " ")
+}
+
+// shortAgo writes d in its largest unit, for narrow columns.
+func shortAgo(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// ago is how long before now t was, e.g. "12s ago".
+func (m Model) ago(t time.Time) string {
+	return fmt.Sprintf(i18n.T("%s ago"), shortAgo(m.now().Sub(t)))
+}
+
+// humanOffset writes a signed offset in a unit that fits its size:
+// "+420 µs", "-3.1 ms", "+1.25 s", "-3m 12s".
+func humanOffset(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%s%d µs", sign, d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%s%.1f ms", sign, float64(d)/float64(time.Millisecond))
+	case d < time.Minute:
+		return fmt.Sprintf("%s%.2f s", sign, d.Seconds())
+	}
+	return sign + humanDuration(d)
+}
+
+// humanRTT writes a round-trip time: "420 µs", "12.3 ms", "1.25 s".
+func humanRTT(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%d µs", d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%.1f ms", float64(d)/float64(time.Millisecond))
+	}
+	return fmt.Sprintf("%.2f s", d.Seconds())
+}
+
+// humanPps writes a packet rate with SI prefixes: "340 pkt/s",
+// "12.5 kpkt/s", "1.2 Mpkt/s".
+func humanPps(pps float64) string {
+	var s string
+	switch {
+	case pps < 1e3:
+		s = fmt.Sprintf("%.0f pkt/s", pps)
+	case pps < 1e6:
+		s = fmt.Sprintf("%.1f kpkt/s", pps/1e3)
+	default:
+		s = fmt.Sprintf("%.1f Mpkt/s", pps/1e6)
+	}
+	return i18n.Number(s)
+}
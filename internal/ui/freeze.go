@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// A frozen tab is backed by a copy of the model taken when it was frozen.
+// Data keeps flowing into the live model (events, session totals, alerts),
+// while the copy renders the tab and takes its keys, so it can still be
+// scrolled, searched and exported, on the old data.
+
+// toggleFreeze freezes or resumes auto-refresh of the active tab.
+func (m *Model) toggleFreeze() {
+	t := m.activeTab
+	if m.frozen[t] != nil {
+		m.frozen[t] = nil
+		m.notice = i18n.T("tab is live again")
+		return
+	}
+	c := *m
+	c.frozen = [tabCount]*Model{}
+	c.notice, c.noticeErr = "", nil
+	c.frozenAt = m.now()
+	m.frozen[t] = &c
+	m.notice = i18n.T("tab frozen; f resumes auto-refresh")
+}
+
+// frozenKey reports whether km belongs to the frozen copy of the active tab
+// rather than to the live model.
+func (m Model) frozenKey(km tea.KeyMsg) bool {
+	f := m.frozen[m.activeTab]
+	if f == nil {
+		return false
+	}
+	switch km.String() {
+	case "ctrl+c", "tab", "shift+tab", "left", "right":
+		return false
+	case "f", "n", "r", "H", "v", "q", "ctrl+e", "?", "P", "+", "-":
+		return f.searching()
+	}
+	return true
+}
+
+// updateFrozen hands msg to the frozen copy of the active tab.
+func (m Model) updateFrozen(msg tea.Msg) (Model, tea.Cmd) {
+	nm, cmd := m.frozen[m.activeTab].Update(msg)
+	fm := nm.(Model)
+	m.frozen[m.activeTab] = &fm
+	return m, cmd
+}
+
+// resizeFrozen re-lays out every frozen copy for the new terminal size.
+func (m *Model) resizeFrozen(msg tea.WindowSizeMsg) {
+	for t, f := range m.frozen {
+		if f == nil {
+			continue
+		}
+		nm, _ := f.Update(msg)
+		fm := nm.(Model)
+		m.frozen[t] = &fm
+	}
+}
+
+func (m Model) anyFrozen() bool {
+	for _, f := range m.frozen {
+		if f != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// tabLabel translates a tab name and marks it when the tab is frozen.
+func (m Model) tabLabel(t tab, name string) string {
+	if m.frozen[t] != nil {
+		return i18n.T(name) + "❄"
+	}
+	return i18n.T(name)
+}
+
+// freezeBadge tells whether the active tab is live; it is only shown once
+// some tab has been frozen.
+func (m Model) freezeBadge() string {
+	if f := m.frozen[m.activeTab]; f != nil {
+		return warnStyle.Render(fmt.Sprintf(i18n.T("FROZEN %s"), f.frozenAt.Format("15:04:05")))
+	}
+	return okStyle.Render(i18n.T("LIVE"))
+}
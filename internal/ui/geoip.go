@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/geoip"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// geoipRetry is how long a failed download waits before the next attempt.
+const geoipRetry = time.Hour
+
+type geoipMsg struct {
+	status geoip.Status
+	err    error
+	update bool // a download finished, rather than a look at the file
+}
+
+// statGeoIPCmd checks the database on disk; nil when GeoIP is off.
+func (m Model) statGeoIPCmd() tea.Cmd {
+	db := m.opts.GeoIP
+	if db == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		st, err := db.Stat()
+		return geoipMsg{status: st, err: err}
+	}
+}
+
+func (m *Model) updateGeoIPCmd() tea.Cmd {
+	db := m.opts.GeoIP
+	m.geo.busy = true
+	return func() tea.Msg {
+		st, err := db.Update()
+		return geoipMsg{status: st, err: err, update: true}
+	}
+}
+
+type geoOpenMsg struct {
+	loc   probe.GeoLocator
+	built time.Time
+	err   error
+}
+
+// openGeoIPCmd loads the database for lookups once it is there, and again
+// after each download; nil when there is nothing new to load or lookups
+// come from Options.Probes.Geo.
+func (m *Model) openGeoIPCmd() tea.Cmd {
+	g := &m.geo
+	if m.opts.Probes.Geo != nil || g.opening || g.status.Built.IsZero() || g.status.Built.Equal(g.loaded) {
+		return nil
+	}
+	g.opening = true
+	db, built := *m.opts.GeoIP, g.status.Built
+	return func() tea.Msg {
+		rs, err := db.Open()
+		if err != nil {
+			return geoOpenMsg{built: built, err: err}
+		}
+		return geoOpenMsg{loc: rs, built: built}
+	}
+}
+
+// geoState is the managed GeoIP database as last seen.
+type geoState struct {
+	status  geoip.Status
+	err     error
+	busy    bool      // download running
+	pending bool      // download held back by metered mode
+	retryAt time.Time // after a failed download
+
+	loc     probe.GeoLocator // nil until a database is loaded
+	loaded  time.Time        // build of the database in loc
+	opening bool
+	openErr error
+}
+
+// applyGeoIP records a stat or download result and starts a download when
+// the database is missing or too old, unless one is running, failed
+// recently or metered mode holds it back.
+func (m *Model) applyGeoIP(msg geoipMsg) tea.Cmd {
+	g := &m.geo
+	now := m.now()
+	if msg.update {
+		g.busy = false
+		if msg.err != nil {
+			g.err, g.retryAt = msg.err, now.Add(geoipRetry)
+			m.events.add(now, evNote, fmt.Sprintf(i18n.T("GeoIP download failed: %v"), msg.err))
+		} else {
+			g.status, g.err = msg.status, nil
+			m.events.add(now, evNote, fmt.Sprintf(i18n.T("GeoIP database %s updated (built %s)"), msg.status.Edition, i18n.DateTime(msg.status.Built)))
+		}
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+		return m.openGeoIPCmd()
+	}
+
+	g.status = msg.status
+	if !now.Before(g.retryAt) {
+		// a failed download stays visible until the retry
+		g.err = msg.err
+	}
+	if msg.err != nil && !errors.Is(msg.err, geoip.ErrNoDatabase) {
+		return nil
+	}
+	open := m.openGeoIPCmd()
+	if g.busy || now.Before(g.retryAt) || !m.opts.GeoIP.Stale(g.status, now) {
+		return open
+	}
+	if m.metered || m.opts.Metered == MeteredAuto && !m.meteredChecked {
+		// optionalNetCmd starts it once the link may be used
+		g.pending = true
+		return open
+	}
+	return tea.Batch(open, m.updateGeoIPCmd())
+}
+
+// applyGeoOpen switches lookups to a newly loaded database. A file that
+// doesn't load is not retried until a new build turns up.
+func (m *Model) applyGeoOpen(msg geoOpenMsg) {
+	g := &m.geo
+	g.opening, g.loaded, g.openErr = false, msg.built, msg.err
+	if msg.err != nil {
+		return
+	}
+	g.loc = probe.CachedGeo(msg.loc)
+	m.setConnsContent()
+}
+
+// geoOf is what the GeoIP database knows about ip; zero without one.
+func (m Model) geoOf(ip string) probe.GeoInfo {
+	if m.geo.loc == nil {
+		return probe.GeoInfo{}
+	}
+	g, _ := m.geo.loc.GeoLookup(strings.Trim(ip, "[]"))
+	return g
+}
+
+// renderGeoIPLine is the Overview line about the database, "" when GeoIP
+// is off.
+func (m Model) renderGeoIPLine() string {
+	if m.opts.GeoIP == nil {
+		return ""
+	}
+	g := m.geo
+	label := fmt.Sprintf(i18n.T("GeoIP: %s"), g.status.Edition) + "  "
+	switch {
+	case g.busy:
+		return label + subtleStyle.Render(i18n.T("downloading…"))
+	case g.err != nil && !errors.Is(g.err, geoip.ErrNoDatabase):
+		return label + errStyle.Render(g.err.Error())
+	case g.openErr != nil:
+		return label + errStyle.Render(g.openErr.Error())
+	case g.status.Built.IsZero() && m.opts.GeoIP.LicenseKey == "":
+		return label + warnStyle.Render(i18n.T("no database; set license_key under [geoip] to download it"))
+	case g.status.Built.IsZero() && g.pending:
+		return label + warnStyle.Render(i18n.T("no database; download paused on a metered connection (m)"))
+	case g.status.Built.IsZero():
+		return label + subtleStyle.Render(i18n.T("no database yet"))
+	}
+	age := fmt.Sprintf(i18n.T("built %s, %s old"), i18n.DateTime(g.status.Built), shortAgo(g.status.Age(m.now())))
+	if m.opts.GeoIP.Stale(g.status, m.now()) || m.opts.GeoIP.LicenseKey == "" && g.status.Age(m.now()) > geoip.DefaultMaxAge {
+		return label + warnStyle.Render(age)
+	}
+	return label + okStyle.Render(age)
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	// glanceHist is how many samples the per-interface sparklines keep
+	glanceHist = 40
+	// glanceRows caps the Overview list; a host full of veths would push
+	// everything else off the screen
+	glanceRows = 8
+)
+
+// ifaceHist is the recent RX and TX rates of one interface.
+type ifaceHist struct {
+	rx, tx []float64
+}
+
+// addIfaceHists appends the latest rates of every interface. The map is
+// rebuilt rather than updated, so frozen copies keep the one they had, and
+// interfaces that went away drop out. Where the counters restarted (see
+// trackIfaceResets) a break goes in instead: the first rates after it are
+// not real.
+func (m *Model) addIfaceHists(breaks map[string]ifaceHist) {
+	next := make(map[string]ifaceHist, len(m.lastSnap.Ifaces))
+	for _, ii := range m.lastSnap.Ifaces {
+		h, brk := breaks[ii.Name]
+		rx, tx := ii.RxBps, ii.TxBps
+		if brk {
+			rx, tx = sparkBreak, sparkBreak
+		} else {
+			h = m.ifaceHists[ii.Name]
+		}
+		h.rx = probe.ClampHistory(append(h.rx, rx), glanceHist)
+		h.tx = probe.ClampHistory(append(h.tx, tx), glanceHist)
+		next[ii.Name] = h
+	}
+	m.ifaceHists = next
+}
+
+// renderIfaceGlance is one row per interface that is up, with a small RX
+// and TX sparkline each, nload style.
+func (m Model) renderIfaceGlance(width int) string {
+	var up []probe.IfaceInfo
+	for _, ii := range m.lastSnap.Ifaces {
+		if ii.IsUp {
+			up = append(up, ii)
+		}
+	}
+	if len(up) == 0 {
+		return ""
+	}
+
+	colName, colRate := 12, 13
+	if m.compact() {
+		colName = 8
+	}
+	sparkW := min(30, max(4, (width-colName-2*(colRate+5))/2))
+
+	var b strings.Builder
+	for i, ii := range up {
+		if i == glanceRows {
+			more := fmt.Sprintf(i18n.T("… %d more"), len(up)-glanceRows)
+			if !m.hideVirtual {
+				more += "  " + i18n.T("(v hides virtual ones)")
+			}
+			b.WriteString(subtleStyle.Render(more) + "\n")
+			break
+		}
+		h := m.ifaceHists[ii.Name]
+		name := padRight(trunc(ii.Name, colName), colName)
+		if ii.Name == m.selectedIface {
+			name = titleStyle.Render(name)
+		}
+		b.WriteString(fmt.Sprintf("%s  %s %s  %s %s\n", name,
+			okStyle.Render(Spark(h.rx, sparkW)), padRight("↓"+humanRate(ii.RxBps, m.units), colRate),
+			accentStyle.Render(Spark(h.tx, sparkW)), "↑"+humanRate(ii.TxBps, m.units)))
+	}
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of TestGolden")
+
+// ansiSeq matches the escape sequences of styling, which depend on the
+// terminal the test runs in.
+var ansiSeq = regexp.MustCompile("\x1b\\[[0-9;?]*[A-Za-z]")
+
+// TestGolden renders every tab of the probetest fixture at three terminal
+// sizes and compares it with testdata/golden. Run with -update after a
+// deliberate change of layout and review the diff of the files.
+func TestGolden(t *testing.T) {
+	for _, size := range [][2]int{{60, 20}, {80, 24}, {120, 40}} {
+		w, h := size[0], size[1]
+		m := newTestModel(t, w, h, Options{})
+		for tb := tab(0); tb < tabCount; tb++ {
+			name := fmt.Sprintf("%02d-%s-%dx%d", tb, tabNames[tb].short, w, h)
+			t.Run(name, func(t *testing.T) {
+				m.setTab(tb)
+				got := ansiSeq.ReplaceAllString(m.View(), "") + "\n"
+				path := filepath.Join("testdata", "golden", name+".golden")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+				want, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("%v; run go test -run TestGolden -update", err)
+				}
+				if !bytes.Equal([]byte(got), want) {
+					t.Errorf("View() differs from %s:\n%s", path, got)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// helpKey is one line of the help overlay.
+type helpKey struct{ keys, desc string }
+
+// helpGlobal and helpTabs are what the help overlay (?) lists; keep them in
+// step with the key tables in README.md. Tabs without keys of their own
+// are left out.
+var helpGlobal = []helpKey{
+	{"← →", "Switch tabs"},
+	{"tab shift+tab", "Cycle tabs"},
+	{"?", "This help"},
+	{"q ctrl+c", "Quit"}, // see globalHelp
+	{"ctrl+e", "Refresh the external IP"},
+	{"i", "Pick the selected interface"},
+	{"v", "Hide / show virtual interfaces"},
+	{"u", "Rates in bits or bytes per second"},
+	{"m", "Toggle metered mode"},
+	{"f", "Freeze / resume the current tab"},
+	{"P", "Pause / resume all refreshes"},
+	{"+ -", "Sample less / more often"},
+	{"x", "Export the marked or shown rows"},
+	{"n", "Add a note to a host or port"},
+	{"r", "Check an IP's abuse reputation"},
+	{"H", "Race IPv4 against IPv6 to a host"},
+	{"↑ ↓ PgUp PgDn", "Scroll"},
+	{"Home End", "Jump to the top / bottom"},
+}
+
+var helpTabs = [tabCount][]helpKey{
+	tabOverview: {
+		{"d", "Time a lookup on each resolver"},
+	},
+	tabIfaces: {
+		{"↑ ↓", "Select an interface"},
+		{"/", "Filter the list"},
+		{"p", "Charts in bytes or packets per second"},
+		{"c", "Sparklines, bar or braille charts"},
+		{"w", "Chart window: 30s, 5m, 30m or the session"},
+	},
+	tabPorts: {
+		{"↑ ↓", "Select a listener"},
+		{"enter", "Socket details; esc closes them"},
+		{"space", "Mark / unmark; esc clears the marks"},
+		{"t", "Note the ports of the marked or selected listeners"},
+		{"X K", "Stop / kill their processes"},
+		{"w", "Expand / collapse wildcard addresses"},
+		{"o", "Open an HTTP listener in the browser"},
+		{"/ ctrl+u", "Search / clear the search"},
+		{"s S", "Next sort column / reverse the order"},
+	},
+	tabProcs: {
+		{"↑ ↓", "Select a process"},
+		{"enter", "Process details; esc closes them"},
+		{"space", "Mark / unmark; esc clears the marks"},
+		{"X", "Send SIGTERM to the marked or selected processes"},
+		{"K", "Send SIGKILL to the marked or selected processes"},
+		{"g e", "Group by name / expand the PIDs of groups"},
+		{"/ ctrl+u", "Search / clear the search"},
+		{"s S", "Next sort column / reverse the order"},
+	},
+	tabConns: {
+		{"↑ ↓", "Select a connection"},
+		{"enter", "Socket options; esc closes them"},
+		{"space", "Mark / unmark; esc clears the marks"},
+		{"t", "Note the remote hosts of the marked or selected connections"},
+		{"b", "Block their remote hosts with nftables"},
+		{"X K", "Stop / kill their processes"},
+		{"g", "Top talkers: connections by remote host"},
+		{"e", "Hide / show connections from ephemeral local ports"},
+	},
+	tabStats: {
+		{"C", "Flush the DNS cache"},
+	},
+	tabFlows: {
+		{"/ ctrl+u", "Search / clear the search"},
+	},
+	tabEvents: {
+		{"[ ]", "Step through the events on the timeline"},
+		{"esc", "Back to live"},
+	},
+	tabTrace: {
+		{"enter /", "Enter a host to trace"},
+		{"esc", "Stop the trace"},
+	},
+	tabHistory: {
+		{"p", "Cycle hourly, daily and monthly totals"},
+	},
+}
+
+// globalHelp is helpGlobal with the quit keys --quit leaves in effect.
+func (m Model) globalHelp() []helpKey {
+	keys := make([]helpKey, len(helpGlobal))
+	copy(keys, helpGlobal)
+	for i, k := range keys {
+		if k.desc != "Quit" {
+			continue
+		}
+		keys[i].keys = strings.ReplaceAll(m.quitKeys(), "/", " ")
+		if m.opts.Quit == QuitConfirm {
+			keys[i].desc = "Quit (q asks first)"
+		}
+	}
+	return keys
+}
+
+// helpOverlay lists the keys of every tab, the active one first. The
+// footer has room for only a few.
+type helpOverlay struct {
+	open bool
+	vp   viewport.Model
+}
+
+func (m *Model) openHelp() {
+	m.help = helpOverlay{open: true, vp: viewport.New(0, 0)}
+}
+
+// helpViewport is the overlay's viewport sized and filled for the current
+// terminal.
+func (m Model) helpViewport() viewport.Model {
+	vp := m.help.vp
+	vp.Width, vp.Height = max(10, m.w-4), m.bodyHeight()
+	vp.SetContent(hardClipLinesToWidth(m.renderHelpText(), vp.Width))
+	return vp
+}
+
+func (m Model) updateHelp(km tea.KeyMsg) (Model, tea.Cmd) {
+	switch km.String() {
+	case "esc", "?", "q":
+		m.help.open = false
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.help.vp, cmd = m.helpViewport().Update(km)
+	return m, cmd
+}
+
+func (m Model) renderHelpText() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Keys")) + "  " + subtleStyle.Render(i18n.T("esc close • ↑↓ scroll")) + "\n")
+
+	section := func(title string, keys []helpKey) {
+		b.WriteString("\n" + accentStyle.Render(title) + "\n")
+		for _, k := range keys {
+			b.WriteString("  " + padRight(k.keys, 16) + "  " + i18n.T(k.desc) + "\n")
+		}
+	}
+	tabSection := func(t tab) {
+		if len(helpTabs[t]) > 0 && !m.tabHidden(t) {
+			section(i18n.T(tabNames[t].full), helpTabs[t])
+		}
+	}
+
+	tabSection(m.activeTab)
+	section(i18n.T("Everywhere"), m.globalHelp())
+	for t := range tabCount {
+		if t != m.activeTab {
+			tabSection(t)
+		}
+	}
+	return b.String()
+}
+
+func (m Model) viewHelp() string {
+	return boxStyle.Width(m.w - 2).Height(m.bodyHeight()).Render(m.helpViewport().View())
+}
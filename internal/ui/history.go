@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/store"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// bandwidthSaveEvery is how often the bandwidth history is written; the
+// counters are kept, so a crash loses no traffic, only where it goes.
+const bandwidthSaveEvery = time.Minute
+
+// recordBandwidth adds the counters of a snapshot, hidden interfaces
+// included, to the bandwidth history and returns a cmd saving it when due.
+// Loopback and veths are left out: the one isn't traffic on the wire, the
+// others come and go with containers.
+func (m *Model) recordBandwidth(snap probe.NetSnapshot) tea.Cmd {
+	db := m.opts.Bandwidth
+	if db == nil {
+		return nil
+	}
+	var boot time.Time
+	if snap.Uptime > 0 {
+		boot = snap.TakenAt.Add(-snap.Uptime)
+	}
+	for _, ii := range snap.Ifaces {
+		if ii.Kind == probe.IfaceLoopback || ii.Kind == probe.IfaceVeth {
+			continue
+		}
+		db.Add(ii.Name, ii.RxTotal, ii.TxTotal, boot, m.now())
+	}
+	if m.now().Sub(m.bandwidthSavedAt) < bandwidthSaveEvery {
+		return nil
+	}
+	m.bandwidthSavedAt = m.now()
+	return func() tea.Msg {
+		if err := db.Save(); err != nil {
+			return noticeMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// historyIface is the interface shown on the History tab: the selected
+// one if it has any history, or else the first that has.
+func (m Model) historyIface() string {
+	names := m.opts.Bandwidth.Names()
+	if slices.Contains(names, m.selectedIface) || len(names) == 0 {
+		return m.selectedIface
+	}
+	return names[0]
+}
+
+// historyLabel writes the start of a bucket for its period.
+func historyLabel(t time.Time, p store.Period) string {
+	switch p {
+	case store.Hourly:
+		return t.Format("2006-01-02 15:00")
+	case store.Daily:
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01")
+}
+
+func (m Model) viewHistory() string {
+	w := min(m.w-2, 120)
+	name, p := m.historyIface(), m.historyPeriod
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("History")) + "  " + accentStyle.Render(name) + "  " + i18n.T(p.String()) + "  " +
+		subtleStyle.Render(i18n.T("p hourly/daily/monthly • i interface")) + "\n\n")
+
+	buckets := m.opts.Bandwidth.Buckets(name, p)
+	if len(buckets) == 0 {
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+	}
+
+	colP, colB := 16, 11
+	h := padRight(i18n.T("PERIOD"), colP) + "  " + padRight("RX", colB) + "  " + padRight("TX", colB) + "  " + padRight(i18n.T("TOTAL"), colB)
+	b.WriteString(h + "\n")
+	b.WriteString(strings.Repeat("─", min(w-4, lipgloss.Width(h))) + "\n")
+
+	// header, rule, title and the box border
+	rows := max(1, m.bodyHeight()-6)
+	if len(buckets) > rows {
+		buckets = buckets[:rows]
+	}
+	var top uint64
+	for _, bk := range buckets {
+		if bk.Rx+bk.Tx > top {
+			top = bk.Rx + bk.Tx
+		}
+	}
+	barW := max(0, w-4-lipgloss.Width(h)-2)
+	for _, bk := range buckets {
+		row := padRight(historyLabel(bk.Start, p), colP) + "  " +
+			padRight(i18n.Number(probe.HumanBytes(bk.Rx)), colB) + "  " +
+			padRight(i18n.Number(probe.HumanBytes(bk.Tx)), colB) + "  " +
+			padRight(i18n.Number(probe.HumanBytes(bk.Rx+bk.Tx)), colB)
+		if barW > 0 && top > 0 {
+			row += "  " + subtleStyle.Render(strings.Repeat("█", int(float64(bk.Rx+bk.Tx)/float64(top)*float64(barW))))
+		}
+		b.WriteString(row + "\n")
+	}
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+}
+
+// stepHistoryPeriod cycles the History tab through hourly, daily and
+// monthly totals.
+func (m *Model) stepHistoryPeriod() {
+	m.historyPeriod = (m.historyPeriod + 1) % store.Period(len(store.Kept))
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// while idle, data is refreshed only every idleRefresh
+const idleRefresh = 5 * time.Second
+
+// noteInput records user activity. It reports true when the input woke the
+// UI from idle, in which case the caller should swallow it.
+func (m *Model) noteInput(msg tea.Msg) bool {
+	if m.opts.IdleDim <= 0 {
+		return false
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.lastInput = m.now()
+		if msg.String() == "ctrl+c" {
+			return false
+		}
+	case tea.MouseMsg:
+		m.lastInput = m.now()
+	default:
+		return false
+	}
+	if !m.idle {
+		return false
+	}
+	m.idle = false
+	return true
+}
+
+// checkIdle flips the model into idle mode once no input arrived for a while.
+func (m *Model) checkIdle(now time.Time) {
+	if m.opts.IdleDim > 0 && !m.idle && now.Sub(m.lastInput) >= m.opts.IdleDim {
+		m.idle = true
+	}
+}
+
+// dim renders s faint. SGR resets inside s would cancel the effect, so faint
+// is re-applied after each of them.
+func dim(s string) string {
+	const faint = "\x1b[2m"
+	return faint + strings.ReplaceAll(s, "\x1b[0m", "\x1b[0m"+faint)
+}
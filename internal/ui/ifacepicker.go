@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// ifacePicker switches the selected interface from tabs other than
+// Interfaces (i).
+type ifacePicker struct {
+	open   bool
+	names  []string
+	cursor int
+}
+
+func (m *Model) openIfacePicker() {
+	p := ifacePicker{open: true}
+	for _, ii := range m.lastSnap.Ifaces {
+		if ii.Name == m.selectedIface {
+			p.cursor = len(p.names)
+		}
+		p.names = append(p.names, ii.Name)
+	}
+	if len(p.names) == 0 {
+		m.notice = i18n.T("no interfaces (yet)")
+		return
+	}
+	m.ifacePicker = p
+}
+
+// selectIface makes name the selected interface, as picking it on the
+// Interfaces tab does; its charts start over.
+func (m *Model) selectIface(name string) {
+	if name == m.selectedIface {
+		return
+	}
+	m.selectedIface = name
+	m.rxHist, m.txHist = series{}, series{}
+	m.rxPktHist, m.txPktHist = series{}, series{}
+	for i, it := range m.ifaceList.Items() {
+		if it.(ifaceItem).name == name {
+			m.ifaceList.Select(i)
+			break
+		}
+	}
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+func (m Model) updateIfacePicker(km tea.KeyMsg) (Model, tea.Cmd) {
+	p := &m.ifacePicker
+	switch km.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.names)-1 {
+			p.cursor++
+		}
+	case "home":
+		p.cursor = 0
+	case "end":
+		p.cursor = len(p.names) - 1
+	case "enter":
+		p.open = false
+		if p.names[p.cursor] == m.selectedIface {
+			break
+		}
+		m.selectIface(p.names[p.cursor])
+		if !m.metered {
+			return m, m.fetchIfaceExitCmd(m.selectedIface)
+		}
+	case "esc", "i", "q":
+		p.open = false
+	}
+	return m, nil
+}
+
+func (m Model) viewIfacePicker() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Select interface")) + "\n")
+	b.WriteString(subtleStyle.Render(i18n.T("enter select • esc close")) + "\n\n")
+
+	colName := 16
+	for _, n := range m.ifacePicker.names {
+		colName = max(colName, len(n))
+	}
+	for i, n := range m.ifacePicker.names {
+		var desc string
+		for _, ii := range m.lastSnap.Ifaces {
+			if ii.Name != n {
+				continue
+			}
+			state := i18n.T("DOWN")
+			if ii.IsUp {
+				state = i18n.T("UP")
+			}
+			desc = fmt.Sprintf("%s  %s  RX %s  TX %s", padRight(state, 4), padRight(ii.Kind.String(), 9), padRight(humanRate(ii.RxBps, m.units), 11), humanRate(ii.TxBps, m.units))
+			break
+		}
+		line := padRight(n, colName) + "  " + desc
+		if i == m.ifacePicker.cursor {
+			b.WriteString(selectedStyle.Render("> "+line) + "\n")
+			continue
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	return b.String()
+}
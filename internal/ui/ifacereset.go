@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// ifaceGoneKept is how long an interface that disappeared is remembered,
+// so its charts can go on after a break when it comes back.
+const ifaceGoneKept = time.Hour
+
+// goneIface is an interface missing from the snapshots, with the rates it
+// had.
+type goneIface struct {
+	at   time.Time
+	hist ifaceHist
+}
+
+// trackIfaceResets compares all interfaces of a snapshot, including hidden
+// ones, with the previous one. It logs interfaces that disappeared, came
+// back (a USB NIC replugged, a VPN reconnecting) or had their counters
+// reset, and returns the ones whose rate history breaks here, with the
+// history to go on from. veths come and go with containers, so they are
+// not logged; VPNs going away are left to checkKillSwitch.
+func (m *Model) trackIfaceResets(ifaces []probe.IfaceInfo) map[string]ifaceHist {
+	now := m.now()
+	cur := make(map[string]bool, len(ifaces))
+	for _, ii := range ifaces {
+		cur[ii.Name] = true
+	}
+
+	gone := maps.Clone(m.ifaceGone)
+	if gone == nil {
+		gone = map[string]goneIface{}
+	}
+	var logged bool
+	log := func(text string) {
+		m.events.add(now, evIface, text)
+		logged = true
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(m.ifacePresent)) {
+		if cur[name] {
+			continue
+		}
+		gone[name] = goneIface{at: now, hist: m.ifaceHists[name]}
+		if k := probe.ClassifyIface(name); k != probe.IfaceVeth && !isVPN(k) {
+			log(fmt.Sprintf(i18n.T("interface %s disappeared"), name))
+		}
+	}
+
+	var breaks map[string]ifaceHist
+	brk := func(name string, h ifaceHist) {
+		if breaks == nil {
+			breaks = map[string]ifaceHist{}
+		}
+		breaks[name] = h
+	}
+	for _, ii := range ifaces {
+		g, back := gone[ii.Name]
+		switch {
+		case back:
+			delete(gone, ii.Name)
+			brk(ii.Name, g.hist)
+			if ii.Kind != probe.IfaceVeth {
+				log(fmt.Sprintf(i18n.T("interface %s is back after %s; its counters restarted"), ii.Name, humanDuration(now.Sub(g.at))))
+			}
+		case ii.Reset:
+			brk(ii.Name, m.ifaceHists[ii.Name])
+			log(fmt.Sprintf(i18n.T("interface %s counters were reset"), ii.Name))
+		}
+	}
+
+	for name, g := range gone {
+		if now.Sub(g.at) > ifaceGoneKept {
+			delete(gone, name)
+		}
+	}
+	m.ifaceGone, m.ifacePresent = gone, cur
+	if logged {
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+	return breaks
+}
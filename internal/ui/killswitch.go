@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	// physical TX above this while a VPN is down counts as leaking; the
+	// VPN client's own reconnect attempts stay well below it
+	leakBps = 2 * 1024
+	// snapshots in a row above leakBps before raising the alarm
+	leakSamples = 3
+)
+
+// killSwitch watches VPN interfaces (tun/tap/wg) that have been seen up.
+// When one goes down or disappears, traffic that keeps flowing on a
+// physical interface means the kill switch isn't doing its job. Shared by
+// pointer like sessionStats.
+type killSwitch struct {
+	seen    map[string]bool // VPN interfaces seen up → up now
+	streak  int
+	leaking string // physical interface carrying traffic, "" when none
+	text    string // the alert raised for it
+}
+
+func newKillSwitch() *killSwitch {
+	return &killSwitch{seen: map[string]bool{}}
+}
+
+func isVPN(k probe.IfaceKind) bool {
+	return k == probe.IfaceTunTap || k == probe.IfaceVirt
+}
+
+// downVPNs lists the VPN interfaces seen up earlier that aren't up now.
+func (ks *killSwitch) downVPNs() []string {
+	var out []string
+	for name, up := range ks.seen {
+		if !up {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// checkKillSwitch updates the VPN states from the latest snapshot, logs
+// them going down and up, and raises an alert, refreshed every snapshot,
+// while a physical interface keeps sending with a VPN down.
+func (m *Model) checkKillSwitch() {
+	ks := m.kill
+	var logged bool
+	log := func(kind eventKind, text string) {
+		m.events.add(m.now(), kind, text)
+		logged = true
+	}
+
+	upNow := map[string]bool{}
+	var (
+		phys string
+		tx   float64
+	)
+	for _, ii := range m.lastSnap.Ifaces {
+		switch {
+		case isVPN(ii.Kind) && ii.IsUp:
+			upNow[ii.Name] = true
+		case ii.Kind == probe.IfacePhysical && ii.TxBps > tx:
+			phys, tx = ii.Name, ii.TxBps
+		}
+	}
+	for name := range upNow {
+		if up, ok := ks.seen[name]; ok && !up {
+			log(evIface, fmt.Sprintf(i18n.T("VPN %s is up again"), name))
+		}
+		ks.seen[name] = true
+	}
+	for name, up := range ks.seen {
+		if up && !upNow[name] {
+			ks.seen[name] = false
+			log(evIface, fmt.Sprintf(i18n.T("VPN %s went down"), name))
+		}
+	}
+
+	down := ks.downVPNs()
+	if len(down) == 0 || tx < leakBps {
+		ks.streak = 0
+		if ks.leaking != "" {
+			if len(down) > 0 {
+				log(evNote, fmt.Sprintf(i18n.T("traffic on %s stopped while VPN is down"), ks.leaking))
+			}
+			// the alert would otherwise outlive the leak by alertHold
+			if m.alert == ks.text {
+				m.alert = ""
+			}
+			ks.leaking, ks.text = "", ""
+		}
+	} else {
+		ks.streak++
+		if ks.streak >= leakSamples {
+			text := fmt.Sprintf(i18n.T("KILL SWITCH: VPN %s is down but %s is still sending %s"),
+				strings.Join(down, ", "), phys, humanRate(tx, m.units))
+			if ks.leaking == "" {
+				log(evAlert, text)
+			}
+			ks.leaking, ks.text = phys, text
+			m.alert, m.alertAt = text, m.now()
+		}
+	}
+
+	if logged {
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// kindGroup buckets interface kinds for the Overview throughput breakdown.
+// Each group has its own fill so the bar reads without colours too.
+type kindGroup struct {
+	name  string
+	fill  string
+	style *lipgloss.Style // follows SetTheme
+	kinds []probe.IfaceKind
+}
+
+// Loopback never leaves the host and veth pairs carry the same bytes as the
+// bridge they hang off, so neither is counted.
+var kindGroups = []kindGroup{
+	{"native", "█", &okStyle, []probe.IfaceKind{probe.IfacePhysical}},
+	{"tunnelled", "▓", &warnStyle, []probe.IfaceKind{probe.IfaceTunTap, probe.IfaceVirt}},
+	{"bridges", "▒", &accentStyle, []probe.IfaceKind{probe.IfaceDockerBridge, probe.IfaceLinuxBridge}},
+	{"other", "░", &subtleStyle, []probe.IfaceKind{probe.IfaceUnknown}},
+}
+
+// kindThroughput sums RX+TX per kind group, indexed like kindGroups.
+func kindThroughput(ifaces []probe.IfaceInfo) []float64 {
+	out := make([]float64, len(kindGroups))
+	for _, ii := range ifaces {
+		for g, kg := range kindGroups {
+			for _, k := range kg.kinds {
+				if ii.Kind == k {
+					out[g] += ii.RxBps + ii.TxBps
+				}
+			}
+		}
+	}
+	return out
+}
+
+// renderKindBreakdown renders the Overview section splitting total
+// throughput by interface kind as one stacked bar plus a legend.
+func (m Model) renderKindBreakdown(width int) string {
+	rates := kindThroughput(m.lastSnap.Ifaces)
+	var total float64
+	for _, r := range rates {
+		total += r
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Throughput by kind")) + "  " + humanRate(total, m.units) + "\n")
+	barW := max(10, width-6)
+	if total <= 0 {
+		b.WriteString(subtleStyle.Render(strings.Repeat("·", barW)) + "\n")
+		return b.String()
+	}
+
+	// hand out cells by largest remainder so the bar always fills barW
+	cells := make([]int, len(rates))
+	rems := make([]float64, len(rates))
+	used := 0
+	for g, r := range rates {
+		exact := r / total * float64(barW)
+		cells[g] = int(exact)
+		rems[g] = exact - float64(cells[g])
+		used += cells[g]
+	}
+	for ; used < barW; used++ {
+		best := 0
+		for g := range rems {
+			if rems[g] > rems[best] {
+				best = g
+			}
+		}
+		cells[best]++
+		rems[best] = -1
+	}
+
+	legend := make([]string, 0, len(kindGroups))
+	for g, kg := range kindGroups {
+		b.WriteString(kg.style.Render(strings.Repeat(kg.fill, cells[g])))
+		if rates[g] <= 0 {
+			continue
+		}
+		legend = append(legend, fmt.Sprintf("%s %s %s (%s)",
+			kg.style.Render(kg.fill), i18n.T(kg.name), humanRate(rates[g], m.units),
+			i18n.Number(fmt.Sprintf("%.0f%%", rates[g]/total*100))))
+	}
+	b.WriteString("\n" + strings.Join(legend, "  ") + "\n")
+	return b.String()
+}
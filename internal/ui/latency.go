@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	// pingPublic is always pinged, as a stand-in for "the internet"
+	pingPublic = "1.1.1.1"
+
+	// loss in percent worth a warning, and one that makes a link unusable
+	lossWarn = 1.0
+	lossBad  = 20.0
+)
+
+// pingTargets are the default gateway of each family, pingPublic and the
+// hosts from Options.PingTargets.
+func (m Model) pingTargets() []probe.PingTarget {
+	var ts []probe.PingTarget
+	for _, fam := range []string{"inet", "inet6"} {
+		r, ok := m.defRoutes[fam]
+		if !ok || r.Gateway == "" {
+			continue
+		}
+		t := probe.PingTarget{Name: "gateway", Host: r.Gateway}
+		if fam == "inet6" {
+			t.Name = "gateway (IPv6)"
+			if strings.HasPrefix(r.Gateway, "fe80:") && r.Iface != "" {
+				// link-local: only reachable through its interface
+				t.Host += "%" + r.Iface
+			}
+		}
+		ts = append(ts, t)
+	}
+	ts = append(ts, probe.PingTarget{Host: pingPublic})
+	for _, h := range m.opts.PingTargets {
+		ts = append(ts, probe.PingTarget{Host: h})
+	}
+	return ts
+}
+
+// updatePingTargets follows the default gateways as routes change.
+func (m *Model) updatePingTargets() {
+	m.pinger.SetTargets(m.pingTargets())
+}
+
+func (m Model) viewLatency() string {
+	w := min(m.w-2, 120)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Latency")) + "  " +
+		subtleStyle.Render(fmt.Sprintf(i18n.T("ping every %s, last %d kept"), humanDuration(probe.PingInterval), probe.PingWindow)) + "\n\n")
+	if len(m.pings) == 0 {
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+	}
+
+	colName, colT, colLoss := 26, 9, 7
+	if m.compact() {
+		colName, colT = 16, 8
+	}
+	h := padRight(i18n.T("TARGET"), colName) + "  " + padRight(i18n.T("LAST"), colT) + "  " + padRight(i18n.T("AVG"), colT)
+	if !m.compact() {
+		h += "  " + padRight(i18n.T("MIN"), colT) + "  " + padRight(i18n.T("MAX"), colT)
+	}
+	h += "  " + padRight(i18n.T("LOSS"), colLoss)
+	b.WriteString(h + "\n")
+	b.WriteString(strings.Repeat("─", min(w-2, lipgloss.Width(h))) + "\n")
+
+	for _, s := range m.pings {
+		name := i18n.T(s.Label())
+		if s.Name != "" && !m.compact() {
+			name += " " + s.Host
+		}
+		row := padRight(trunc(name, colName), colName)
+		answered := len(s.RTTs) > 0
+		cell := func(ok bool, d time.Duration) string {
+			if !ok {
+				return "  " + padRight("-", colT)
+			}
+			return "  " + padRight(humanRTT(d), colT)
+		}
+		row += cell(answered && s.Err == nil, s.Last) + cell(answered, s.Avg)
+		if !m.compact() {
+			row += cell(answered, s.Min) + cell(answered, s.Max)
+		}
+		loss := padRight(i18n.Number(fmt.Sprintf("%.1f%%", s.Loss())), colLoss)
+		switch {
+		case s.Sent == 0:
+			loss = padRight("…", colLoss)
+		case s.Loss() >= lossBad:
+			loss = errStyle.Render(loss)
+		case s.Loss() >= lossWarn:
+			loss = warnStyle.Render(loss)
+		default:
+			loss = okStyle.Render(loss)
+		}
+		row += "  " + loss
+		switch {
+		case s.Err != nil:
+			row += " " + errStyle.Render(trunc(s.Err.Error(), max(5, w-2-lipgloss.Width(row)-1)))
+		case s.Method == "tcp":
+			row += " " + subtleStyle.Render("(TCP)")
+		}
+		b.WriteString(row + "\n")
+		b.WriteString("  " + subtleStyle.Render(Spark(s.RTTs, max(10, w-6))) + "\n")
+	}
+	if m.pingsTCP() {
+		b.WriteString("\n" + subtleStyle.Render(i18n.T("(TCP): no permission for ICMP, timing a TCP handshake to port 443 instead")) + "\n")
+	}
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+}
+
+func (m Model) pingsTCP() bool {
+	for _, s := range m.pings {
+		if s.Method == "tcp" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type linkMsg struct {
+	iface string
+	l     probe.LinkInfo
+	err   error
+}
+
+// fetchLinkCmd reads the link details of the selected interface; done on
+// selection and on the slow refresh while the Interfaces tab is open, as
+// they change only when a cable or port does.
+func (m Model) fetchLinkCmd() tea.Cmd {
+	iface := m.selectedIface
+	if iface == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		l, err := m.linkReader.Link(iface)
+		return linkMsg{iface: iface, l: l, err: err}
+	}
+}
+
+func (m *Model) applyLink(msg linkMsg) {
+	if msg.iface != m.selectedIface {
+		return
+	}
+	m.link = msg
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+// renderLinkText shows what the interface negotiated for the interface
+// details pane, and warns about a slower link than the NIC can do, half
+// duplex or a missing carrier. Nothing for interfaces without a driver,
+// loopback and other virtual ones.
+func (m Model) renderLinkText(ii *probe.IfaceInfo) string {
+	l := m.link.l
+	if m.link.iface != ii.Name || m.link.err != nil || l.Driver == "" {
+		return ""
+	}
+	var parts []string
+	if l.Speed > 0 {
+		s := linkSpeedLabel(l.Speed)
+		switch l.Duplex {
+		case "full":
+			s += " " + i18n.T("full duplex")
+		case "half":
+			s += " " + i18n.T("half duplex")
+		}
+		parts = append(parts, s)
+	}
+	carrier := ""
+	switch l.Carrier {
+	case "up":
+		carrier = okStyle.Render(i18n.T("carrier up"))
+	case "down":
+		carrier = errStyle.Render(i18n.T("no carrier"))
+	}
+	if carrier != "" && l.CarrierChanges > 0 {
+		carrier += " " + fmt.Sprintf(i18n.T("(%d changes)"), l.CarrierChanges)
+	}
+	if carrier != "" {
+		parts = append(parts, carrier)
+	}
+	drv := i18n.T("Driver: ") + l.Driver
+	if l.Autoneg != "" {
+		drv += " • " + i18n.T("autoneg") + " " + l.Autoneg
+	}
+
+	out := drv + "\n"
+	if len(parts) > 0 {
+		out = i18n.T("Link: ") + strings.Join(parts, " • ") + "\n" + out
+	}
+	switch {
+	case l.Carrier == "down":
+		out += warnStyle.Render(i18n.T("no cable, or nothing answering at its other end")) + "\n"
+	case l.Speed > 0 && l.MaxSpeed > l.Speed:
+		out += warnStyle.Render(fmt.Sprintf(i18n.T("negotiated %s, the NIC can do %s: check the cable and switch port"),
+			linkSpeedLabel(l.Speed), linkSpeedLabel(l.MaxSpeed))) + "\n"
+	case l.Duplex == "half":
+		out += warnStyle.Render(i18n.T("half duplex: a mismatch with the switch costs throughput")) + "\n"
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+type meteredMsg struct {
+	on  bool
+	err error
+}
+
+// detectMeteredCmd asks the system whether the uplink is metered. It is nil
+// unless the mode is auto, so a manual choice sticks.
+func (m Model) detectMeteredCmd() tea.Cmd {
+	if m.opts.Metered != MeteredAuto {
+		return nil
+	}
+	return func() tea.Msg {
+		on, err := m.meteredChecker.Metered()
+		return meteredMsg{on: on, err: err}
+	}
+}
+
+func (m Model) applyMetered(msg meteredMsg) (Model, tea.Cmd) {
+	if m.opts.Metered != MeteredAuto {
+		// toggled by hand while the check was running
+		return m, nil
+	}
+	if msg.err != nil {
+		// no NetworkManager: behave as before metered mode existed
+		msg.on = false
+	}
+	first, was := !m.meteredChecked, m.metered
+	m.metered, m.meteredChecked = msg.on, true
+	if m.metered && !was {
+		m.events.add(m.now(), evNote, i18n.T("metered connection detected; external lookups paused"))
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	}
+	if first || was != m.metered {
+		return m, m.optionalNetCmd()
+	}
+	return m, nil
+}
+
+// toggleMetered switches metered mode by hand, which also stops the
+// NetworkManager check from overriding it.
+func (m Model) toggleMetered() (Model, tea.Cmd) {
+	m.metered = !m.metered
+	if m.metered {
+		m.opts.Metered = MeteredOn
+		m.notice = i18n.T("metered: external IP, update and reputation lookups paused")
+		return m, nil
+	}
+	m.opts.Metered = MeteredOff
+	m.notice = i18n.T("metered mode off")
+	return m, m.optionalNetCmd()
+}
+
+// optionalNetCmd runs the network calls metered mode holds back: an
+// external IP refresh and, once, the pending update check and GeoIP
+// download.
+func (m *Model) optionalNetCmd() tea.Cmd {
+	if m.metered {
+		return nil
+	}
+	cmds := []tea.Cmd{m.fetchExternalIPCmd(), m.fetchIfaceExitCmd(m.selectedIface)}
+	if m.updatePending {
+		m.updatePending = false
+		cmds = append(cmds, checkUpdateCmd())
+	}
+	if m.geo.pending {
+		m.geo.pending = false
+		cmds = append(cmds, m.updateGeoIPCmd())
+	}
+	return tea.Batch(cmds...)
+}
@@ -1,10 +1,11 @@
 package ui
 
 import (
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -14,7 +15,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/nexusriot/ducknetview/internal/probe"
+	"github.com/nexusriot/ducknetview/internal/extip"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/store"
+	"github.com/nexusriot/ducknetview/internal/update"
+	"github.com/nexusriot/ducknetview/internal/version"
+	"github.com/nexusriot/ducknetview/pkg/probe"
 )
 
 type tab int
@@ -24,11 +30,29 @@ const (
 	tabIfaces
 	tabPorts
 	tabProcs
+	tabConns
+	tabStats
+	tabRouting
+	tabFirewall
+	tabFlows
+	tabEvents
+	tabLatency
+	tabTrace
+	tabHistory // bandwidth history, hidden without Options.Bandwidth
+	tabExec    // user exec probes, hidden unless configured
+	tabCount
 	headerH = 1
 	footerH = 1
+
+	// below this width the UI switches to the compact layout
+	compactW = 80
 )
 
-type tickMsg time.Time
+// tickMsg is a tick of the refresh loop; see Model.tickLoop.
+type tickMsg struct {
+	at   time.Time
+	loop int
+}
 type extIPTickMsg time.Time
 
 type externalIPMsg struct {
@@ -36,28 +60,64 @@ type externalIPMsg struct {
 	err error
 }
 
-func tickEvery(d time.Duration) tea.Cmd {
-	return tea.Tick(d, func(t time.Time) tea.Msg { return tickMsg(t) })
+func tickEvery(d time.Duration, loop int) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg { return tickMsg{at: t, loop: loop} })
 }
 
 func extIPTickEvery(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(t time.Time) tea.Msg { return extIPTickMsg(t) })
 }
 
+// ifaceItem is an interface list entry. Its description is only written
+// when the list draws it, which is one page of what may be thousands of
+// interfaces.
 type ifaceItem struct {
-	name string
-	desc string
+	name    string
+	mac     string
+	rx, tx  float64
+	units   Units
+	descMax int
 }
 
-func (i ifaceItem) Title() string       { return i.name }
-func (i ifaceItem) Description() string { return i.desc }
+func (i ifaceItem) Title() string { return i.name }
+func (i ifaceItem) Description() string {
+	return trunc(fmt.Sprintf(i18n.T("MAC %s  RX %s  TX %s"), i.mac, humanRate(i.rx, i.units), humanRate(i.tx, i.units)), i.descMax)
+}
 func (i ifaceItem) FilterValue() string { return i.name }
 
+// ifaceDelegate drops the description line in compact layout so more
+// interfaces fit into the shorter stacked list.
+func ifaceDelegate(compact bool) list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	if compact {
+		d.ShowDescription = false
+		d.SetSpacing(0)
+	}
+	return d
+}
+
 type Model struct {
 	w, h int
 
-	activeTab  tab
-	netSampler *probe.NetSampler
+	activeTab     tab
+	netSampler    probe.Sampler
+	sampling      *atomic.Bool // a sample is under way, see refreshCmd
+	portLister    probe.PortLister
+	portInspector probe.PortInspector
+	connInspector probe.ConnInspector
+	procLister    probe.ProcLister
+	procStop      probe.ProcSignaler
+	hostBlocker   probe.HostBlocker
+	procInspector probe.ProcInspector
+	connRater     probe.ConnRater
+	icmpReader    probe.ICMPReader
+	routeReader   probe.RouteReader
+	ruleReader    probe.RuleReader
+	raReader      probe.RAReader
+	connLister    probe.ConnLister
+	procBWer      probe.ProcBandwidthReader
+	peerBWer      probe.PeerBandwidthReader
+	bgpReader     probe.BGPReader
 
 	lastSnap probe.NetSnapshot
 	err      error
@@ -65,18 +125,185 @@ type Model struct {
 	// Interfaces list
 	ifaceList      list.Model
 	selectedIface  string
-	rxHist, txHist []float64
+	rxHist, txHist series
+	rxPktHist      series // packets/s, for the p toggle
+	txPktHist      series
+	ifacePackets   bool                 // p: the charts show packets/s rather than bytes/s
+	ifaceChart     chartKind            // c: sparklines, bar or braille charts
+	ifaceWindow    chartWindow          // w: the span of time the charts show
+	paused         bool                 // P: nothing is refreshed, see togglePause
+	ifaceHists     map[string]ifaceHist // every interface, for Overview
+	ifacePresent   map[string]bool      // every interface, hidden ones too
+	ifaceGone      map[string]goneIface // by trackIfaceResets
+	hideVirtual    bool                 // v: loopback, veth, Docker and bridges hidden
+	units          Units                // u: rates in bytes or bits per second
+
+	// History tab
+	historyPeriod    store.Period
+	bandwidthSavedAt time.Time
 
 	// Ports / procs
 	ports []probe.ListenPort
 	procs []probe.ProcNet
 
-	// Viewports
-	portsVP   viewport.Model
-	portsText string
+	tunnels      []probe.Tunnel
+	tunnelLister probe.TunnelLister
+
+	fw       []probe.FirewallCounter
+	fwErr    error
+	fwRates  map[string]float64 // bytes/s by FirewallCounter.Key
+	fwAt     time.Time
+	fwReader probe.FirewallReader
+
+	softnet       []probe.SoftnetStat
+	softnetErr    error
+	softnetAt     time.Time
+	softnetRates  softnetRates
+	softnetKnown  bool // two samples taken, so softnetRates are
+	softnetReader probe.SoftnetReader
+
+	casts      castSample // of the selected interface
+	castReader probe.CastReader
+
+	queues      queueSample // of the selected interface
+	queueReader probe.QueueReader
+	wifi        wifiSample // of the selected interface, if wireless
+	wifiReader  probe.WifiReader
+	link        linkMsg // of the selected interface
+	linkReader  probe.LinkReader
+	lease       leaseMsg // of the selected interface
+	leaseReader probe.LeaseReader
+
+	fwChains    []probe.FirewallChain
+	fwChainsErr error
+	fwRuleRates map[string]float64 // bytes/s by FirewallRule.Key
+	fwChainsAt  time.Time
+	firewallVP  viewport.Model
+
+	flows          []probe.Flow // busiest first
+	flowsErr       error
+	flowsVP        viewport.Model
+	flowsSearch    textinput.Model
+	flowsSearching bool
+	flowsQuery     string
+	flowLister     probe.FlowLister
+
+	connRate      probe.ConnRate
+	connRateErr   error
+	connHist      []float64
+	procConnRates map[int32]float64
+	procBW        map[int32]probe.ProcBandwidth  // nil when unavailable
+	peerBW        map[string]probe.ProcBandwidth // by remote IP; nil when unavailable
+
+	icmp    []probe.ICMPCounter
+	icmpErr error
+
+	// transient warning shown in the footer, e.g. ICMP redirect bursts
+	alert   string
+	alertAt time.Time
+
+	routes    []probe.Route
+	routesErr error
+	defRoutes map[string]probe.Route // preferred default route per family
+	rules     []probe.Rule
+	rulesErr  error
+	routingVP viewport.Model
+	bgp       []probe.BGPPeer
+	bgpErr    error
+	bgpUp     map[string]bool // session key -> established, from the last poll
+
+	conns        []probe.Conn
+	connsErr     error
+	connsVP      viewport.Model
+	connsKeys    []string
+	connsSel     string // connection key of the selected row, or a talkerKey
+	connsGrouped bool   // g: Top talkers instead of every connection
+	connsHideEph bool   // e: leave out connections from ephemeral local ports
+	connsMarked  marks
+	connDetail   connDetail
+	hostBlock    hostBlock
+
+	ephemeral probe.PortRange // zero until read; see ephemeralRange
+	ephReader probe.EphemeralRangeReader
+
+	execs  *execState
+	execVP viewport.Model
+
+	ras   *raTracker
+	raErr error
+
+	blocked   []blockHit
+	blockSeen map[string]bool // remote ip + pid already reported
+	rdns      *probe.Resolver
+	rep       repPanel
+
+	eyeballs eyeballsPanel
+	racer    probe.DualStackRacer
+
+	neigh       []probe.Neighbor
+	neighErr    error
+	neighReader probe.NeighborReader
+
+	timeSync    probe.TimeSync
+	timeSyncErr error
+	timeSyncAt  time.Time // last periodic check
+	timeSyncer  probe.TimeSyncChecker
+
+	dnsCacher     probe.DNSCacher
+	dnsConf       probe.DNSConfig
+	dnsConfErr    error
+	dnsConfReader probe.DNSConfigReader
+	dnsTests      map[string]dnsTest // by resolver, once d was pressed
+	dnsTestGen    int                // drops answers of an earlier run
+	sniffer       probe.DNSSniffer
+	dnsLog        dnsLog
+	dnsCache      probe.DNSCache
+	dnsCacheErr   error
+	pinger        *probe.PingMonitor
+	pings         []probe.PingStats
+	tracer        probe.Tracer
+	trace         tracePanel
+	traceVP       viewport.Model
+	lan           *lanWatch
+
+	notePrompt  textinput.Model
+	noting      bool
+	noteTargets []string // set when tagging rows, see openTagPrompt
+
+	export      exportPicker
+	ifacePicker ifacePicker
+	help        helpOverlay
+
+	// frozen tabs render from a copy of the model, see freeze.go
+	frozen   [tabCount]*Model
+	frozenAt time.Time // set on the copies only
+
+	events   *eventLog
+	eventsVP viewport.Model
+	scrub    scrubber // moment picked on the Events tab's timeline
+
+	// Overview scrolls where it is taller than the body
+	overviewVP viewport.Model
 
-	procsVP   viewport.Model
-	procsText string
+	// Viewports
+	portsVP     viewport.Model
+	portsText   string
+	portsKeys   []string // row key per line, see setPortsContent
+	portsHead   int      // line of the column header, see stickyHead
+	portsSel    string   // row key of the selected listener
+	portsMarked marks
+	portDetail  portDetail
+
+	procsVP     viewport.Model
+	procsText   string
+	procsKeys   []string
+	procsHead   int
+	procsSel    string // row key of the selected process
+	procsMarked marks
+	procKill    procKill
+
+	procDetail   procDetail
+	procDetailVP viewport.Model
 
 	ifaceDetailsVP   viewport.Model
 	ifaceDetailsText string
@@ -84,19 +311,88 @@ type Model struct {
 	portsSearch    textinput.Model
 	portsSearching bool
 	portsQuery     string
+	portsExpand    bool // show concrete addresses under wildcard listeners
+	portsSort      tableSort
+	urlPicker      urlPicker
+	portTimeline   *portTimeline
 
 	procsSearch    textinput.Model
 	procsSearching bool
 	procsQuery     string
+	procsGrouped   bool // merge processes by name
+	procsExpanded  bool // list member PIDs under each group
+	procsSort      tableSort
 
 	externalIP          string
 	externalIPErr       error
 	externalIPUpdatedAt time.Time
+	externalIPv6        string
+	externalIPv6Err     error
+	extIPFails          int       // consecutive failed lookups
+	extIPRetryAt        time.Time // periodic lookups back off until then
+	extIPRetryGen       int       // drops retries a later answer made moot
+	ifaceExits          map[string]ifaceExit
+
+	session *sessionStats
+	kill    *killSwitch
+
+	opts           Options
+	confirmingQuit bool
+
+	lastInput time.Time
+	idle      bool
+	fetchedAt fetchTimes // when the probes on their own intervals last ran
+	tickLoop  int        // ticks of older loops, left behind by + and -, are dropped
+
+	updateAvailable string
+	updatePending   bool // update check held back by metered mode
+	geo             geoState
+
+	metered        bool
+	meteredChecked bool // auto mode has had an answer
+	meteredChecker probe.MeteredChecker
+
+	// one-shot footer message, cleared by the next key press
+	notice    string
+	noticeErr error
 }
 
-func NewModel() Model {
-	ls := list.New([]list.Item{}, list.NewDefaultDelegate(), 30, 10)
-	ls.Title = "Interfaces"
+func NewModel(opts Options) Model {
+	opts.Probes = opts.Probes.withDefaults()
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	if opts.ExternalIP == nil {
+		opts.ExternalIP, _ = extip.New(extip.DefaultProviders, 0)
+	}
+	if opts.Refresh <= 0 {
+		opts.Refresh = time.Second
+	}
+	switch {
+	case opts.Refresh < MinRefresh:
+		opts.Refresh = MinRefresh
+	case opts.Refresh > MaxRefresh:
+		opts.Refresh = MaxRefresh
+	}
+	if opts.SlowRefresh <= 0 {
+		opts.SlowRefresh = 5 * time.Second
+	}
+	if opts.PortsRefresh <= 0 {
+		opts.PortsRefresh = opts.SlowRefresh
+	}
+	if opts.ProcsRefresh <= 0 {
+		opts.ProcsRefresh = opts.SlowRefresh
+	}
+	if opts.ExtIPEvery <= 0 {
+		opts.ExtIPEvery = 30 * time.Second
+	}
+	start := tabOverview
+	if t, ok := tabByName(opts.StartTab); ok && !(t == tabExec && len(opts.ExecProbes) == 0) {
+		start = t
+	}
+
+	ls := list.New([]list.Item{}, ifaceDelegate(false), 30, 10)
+	ls.Title = i18n.T("Interfaces")
 	ls.SetShowHelp(false)
 
 	// viewports (sizes are set on WindowSizeMsg)
@@ -105,38 +401,137 @@ func NewModel() Model {
 	dvp := viewport.New(0, 0)
 
 	ps := textinput.New()
-	ps.Placeholder = "search port / address / process"
+	ps.Placeholder = i18n.T("search port / address / process")
 	ps.Prompt = "/ "
 	ps.CharLimit = 64
 
 	qs := textinput.New()
-	qs.Placeholder = "search process name"
+	qs.Placeholder = i18n.T("search process name")
 	qs.Prompt = "/ "
 	qs.CharLimit = 64
 
-	return Model{
-		activeTab:  tabOverview,
-		netSampler: probe.NewNetSampler(),
-
-		ifaceList: ls,
+	fs := textinput.New()
+	fs.Placeholder = i18n.T("search address / protocol / state / NAT")
+	fs.Prompt = "/ "
+	fs.CharLimit = 64
+
+	m := Model{
+		activeTab:     start,
+		netSampler:    opts.Probes.Net,
+		sampling:      new(atomic.Bool),
+		portLister:    opts.Probes.Ports,
+		portInspector: opts.Probes.Inspect,
+		connInspector: opts.Probes.ConnOpts,
+		procLister:    opts.Probes.Procs,
+		procStop:      opts.Probes.Stop,
+		hostBlocker:   opts.Probes.Block,
+		procInspector: opts.Probes.Detail,
+		connRater:     opts.Probes.ConnRate,
+		icmpReader:    opts.Probes.ICMP,
+		routeReader:   opts.Probes.Routes,
+		ruleReader:    opts.Probes.Rules,
+		raReader:      opts.Probes.RA,
+		connLister:    opts.Probes.Conns,
+		procBWer:      opts.Probes.ProcBW,
+		peerBWer:      opts.Probes.PeerBW,
+		bgpReader:     opts.Probes.BGP,
+
+		meteredChecker: opts.Probes.Metered,
+		metered:        opts.Metered == MeteredOn,
+		updatePending:  opts.CheckUpdate && opts.Metered != MeteredOff,
+
+		tunnelLister:  opts.Probes.Tunnels,
+		fwReader:      opts.Probes.Firewall,
+		castReader:    opts.Probes.Casts,
+		queueReader:   opts.Probes.Queues,
+		wifiReader:    opts.Probes.Wifi,
+		linkReader:    opts.Probes.Link,
+		leaseReader:   opts.Probes.DHCP,
+		softnetReader: opts.Probes.Softnet,
+		flowLister:    opts.Probes.Flows,
+		racer:         opts.Probes.Eyeballs,
+		neighReader:   opts.Probes.Neigh,
+		timeSyncer:    opts.Probes.TimeSync,
+		dnsCacher:     opts.Probes.DNSCache,
+		dnsConfReader: opts.Probes.DNSConf,
+		sniffer:       opts.Probes.Sniff,
+		ephReader:     opts.Probes.Ephemeral,
+		tracer:        opts.Probes.Trace,
+		trace:         tracePanel{input: newTraceInput()},
+
+		ifaceList:   ls,
+		hideVirtual: opts.HideVirtual,
+		units:       opts.Units,
 
 		portsVP:        pvp,
+		eventsVP:       viewport.New(0, 0),
+		overviewVP:     viewport.New(0, 0),
+		routingVP:      viewport.New(0, 0),
+		firewallVP:     viewport.New(0, 0),
+		flowsVP:        viewport.New(0, 0),
+		connsVP:        viewport.New(0, 0),
+		execVP:         viewport.New(0, 0),
+		traceVP:        viewport.New(0, 0),
 		procsVP:        kvp,
+		procDetailVP:   viewport.New(0, 0),
 		ifaceDetailsVP: dvp,
 		portsSearch:    ps,
 		procsSearch:    qs,
+		flowsSearch:    fs,
+		portsSort:      defaultPortsSort,
+		procsSort:      defaultProcsSort,
+
+		session: newSessionStats(opts.Clock()),
+		kill:    newKillSwitch(),
+		lan:     newLANWatch(),
+
+		portTimeline: newPortTimeline(),
+		events:       newEventLog(),
+		ras:          newRATracker(),
+		blockSeen:    map[string]bool{},
+		rdns:         probe.NewResolver(opts.Probes.RDNS, 0, 0),
+		pinger:       probe.NewPingMonitor(opts.Probes.Ping, 0, 0),
+		geo:          geoState{loc: probe.CachedGeo(opts.Probes.Geo)},
+		execs:        newExecState(len(opts.ExecProbes)),
+		opts:         opts,
+		lastInput:    opts.Clock(),
+		fetchedAt:    newFetchTimes(opts.Clock()),
 	}
+	m.setBriefKinds()
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	// the gateways join once the routes are in
+	m.updatePingTargets()
+	cmds := []tea.Cmd{
 		m.refreshCmd(),
-		fetchPortsCmd(),
-		fetchProcsCmd(),
-		fetchExternalIPCmd(),
-		extIPTickEvery(30*time.Second),
-		tickEvery(1*time.Second),
-	)
+		m.fetchPortsCmd(),
+		m.fetchProcsCmd(),
+		m.fetchRoutesCmd(),
+		m.fetchBGPCmd(),
+		m.fetchNeighborsCmd(),
+		m.statGeoIPCmd(),
+		m.checkTimeSyncCmd(),
+		m.fetchDNSConfigCmd(),
+		m.fetchEphemeralRangeCmd(),
+		m.waitRACmd(),
+		m.waitRDNSCmd(),
+		m.fetchBlockedCmd(),
+		extIPTickEvery(m.opts.ExtIPEvery),
+		tickEvery(m.opts.Refresh, m.tickLoop),
+	}
+	switch m.opts.Metered {
+	case MeteredAuto:
+		// network calls wait for the answer, see meteredMsg
+		cmds = append(cmds, m.detectMeteredCmd())
+	case MeteredOff:
+		cmds = append(cmds, m.fetchExternalIPCmd())
+		if m.opts.CheckUpdate {
+			cmds = append(cmds, checkUpdateCmd())
+		}
+	}
+	return tea.Batch(cmds...)
 }
 
 // bodyHeight returns height available for the tab body area.
@@ -145,8 +540,39 @@ func (m Model) bodyHeight() int {
 	return max(8, m.h-headerH-footerH-2)
 }
 
+func (m Model) now() time.Time {
+	return m.opts.Clock()
+}
+
+// compact reports whether the terminal is too narrow for the regular layout.
+func (m Model) compact() bool {
+	return m.w > 0 && m.w < compactW
+}
+
+// ifacePanes returns box sizes for the interface list and details panes.
+// Regular layout puts them side by side, compact layout stacks them.
+func (m Model) ifacePanes() (listW, listH, detW, detH int) {
+	bodyH := m.bodyHeight()
+	if m.compact() {
+		// two stacked boxes share the body height including their borders
+		listH = max(3, (bodyH-2)/2)
+		detH = max(3, bodyH-2-listH)
+		return m.w - 2, listH, m.w - 2, detH
+	}
+	leftW := max(26, m.w/3)
+	return leftW, bodyH, m.w - leftW - 4, bodyH
+}
+
+// refreshCmd samples the interfaces. A refresh asked for while a sample is
+// under way, by a key or a tick, waits for that one's answer instead: one
+// right behind it would have rates over a few microseconds.
 func (m Model) refreshCmd() tea.Cmd {
+	busy := m.sampling
 	return func() tea.Msg {
+		if !busy.CompareAndSwap(false, true) {
+			return nil
+		}
+		defer busy.Store(false)
 		snap, err := m.netSampler.Sample()
 		if err != nil {
 			return errMsg{err}
@@ -156,13 +582,18 @@ func (m Model) refreshCmd() tea.Cmd {
 }
 
 type errMsg struct{ error }
+type noticeMsg struct {
+	text string
+	err  error
+}
+type updateMsg string
 type snapMsg probe.NetSnapshot
 type portsMsg []probe.ListenPort
 type procsMsg []probe.ProcNet
 
-func fetchPortsCmd() tea.Cmd {
+func (m Model) fetchPortsCmd() tea.Cmd {
 	return func() tea.Msg {
-		ports, err := probe.ListListening()
+		ports, err := m.portLister.ListListening()
 		if err != nil {
 			return errMsg{err}
 		}
@@ -170,9 +601,9 @@ func fetchPortsCmd() tea.Cmd {
 	}
 }
 
-func fetchProcsCmd() tea.Cmd {
+func (m Model) fetchProcsCmd() tea.Cmd {
 	return func() tea.Msg {
-		procs, err := probe.TopProcsByConnections(80)
+		procs, err := m.procLister.TopProcsByConnections(80)
 		if err != nil {
 			return errMsg{err}
 		}
@@ -180,59 +611,57 @@ func fetchProcsCmd() tea.Cmd {
 	}
 }
 
-func fetchExternalIPCmd() tea.Cmd {
+func checkUpdateCmd() tea.Cmd {
 	return func() tea.Msg {
-		const url = "https://api.ipify.org"
-
-		// Fresh transport each time avoids stale keep-alive sockets after VPN / route changes.
-		tr := &http.Transport{
-			Proxy:               http.ProxyFromEnvironment,
-			DisableKeepAlives:   true,
-			TLSHandshakeTimeout: 3 * time.Second,
-		}
-
-		c := &http.Client{
-			Timeout:   4 * time.Second,
-			Transport: tr,
-		}
-
-		resp, err := c.Get(url)
-		if err != nil {
-			return externalIPMsg{"", err}
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return externalIPMsg{"", fmt.Errorf("external ip: http %d", resp.StatusCode)}
+		rel, err := update.Latest()
+		if err != nil || !update.Newer(rel.Version(), version.Version) {
+			// best-effort: a failed check just means no notice
+			return nil
 		}
+		return updateMsg(rel.Version())
+	}
+}
 
-		b, err := io.ReadAll(io.LimitReader(resp.Body, 64))
-		if err != nil {
-			return externalIPMsg{"", err}
-		}
+// fetchExternalIPCmd looks up the public IPv4 address, and the IPv6 one
+// alongside.
+func (m Model) fetchExternalIPCmd() tea.Cmd {
+	p := m.opts.ExternalIP
+	return tea.Batch(func() tea.Msg {
+		// a chain gives each provider its own timeout; this bounds the lot
+		ctx, cancel := context.WithTimeout(context.Background(), extIPLookupTimeout)
+		defer cancel()
+
+		ip, err := p.Lookup(extip.WithFamily(ctx, 4))
+		return externalIPMsg{ip: ip, err: err}
+	}, m.fetchExternalIPv6Cmd())
+}
 
-		ip := strings.TrimSpace(string(b))
-		if ip == "" {
-			return externalIPMsg{"", fmt.Errorf("external ip: empty response")}
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.noteInput(msg) {
+		if m.paused {
+			return m, nil
 		}
-
-		return externalIPMsg{ip: ip, err: nil}
+		// the input only wakes the UI; catch up on data right away
+		return m, tea.Batch(m.refreshCmd(), m.fetchPortsCmd(), m.fetchProcsCmd())
+	}
+	if m.pausedDrop(msg) {
+		return m, nil
 	}
-}
 
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
 		m.w, m.h = msg.Width, msg.Height
 
-		leftW := max(26, m.w/3)
 		bodyH := m.bodyHeight()
+		listW, listH, detW, detH := m.ifacePanes()
 
 		m.ifaceList.SetSize(
-			max(1, leftW-2),
-			max(1, bodyH-2),
+			max(1, listW-2),
+			max(1, listH-2),
 		)
+		m.ifaceList.SetDelegate(ifaceDelegate(m.compact()))
+		m.ifaceList.SetShowStatusBar(!m.compact())
 
 		// Ports
 		portsW := min(m.w-2, 120)
@@ -243,47 +672,180 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		procsW := min(m.w-2, 120)
 		m.procsVP.Width = max(10, procsW-2)
 		m.procsVP.Height = max(5, bodyH-4)
-
-		// Interfaces right
-		rightW := m.w - leftW - 3
-		m.ifaceDetailsVP.Width = max(10, rightW-2)
-		m.ifaceDetailsVP.Height = max(5, bodyH-2)
+		m.procDetailVP.Width, m.procDetailVP.Height = m.procsVP.Width, m.procsVP.Height
+
+		// Connections, routing, events
+		m.connsVP.Width = max(10, min(m.w-2, 120)-2)
+		m.connsVP.Height = max(5, bodyH-2)
+		m.execVP.Width = max(10, min(m.w-2, 120)-2)
+		m.execVP.Height = max(5, bodyH-2)
+		m.routingVP.Width = max(10, min(m.w-2, 120)-2)
+		m.routingVP.Height = max(5, bodyH-2)
+		m.overviewVP.Width, m.overviewVP.Height = m.routingVP.Width, m.routingVP.Height
+		m.firewallVP.Width = max(10, min(m.w-2, 120)-2)
+		m.firewallVP.Height = max(5, bodyH-2)
+		m.flowsVP.Width = max(10, min(m.w-2, 120)-2)
+		m.flowsVP.Height = max(3, bodyH-7)
+		m.eventsVP.Width = max(10, min(m.w-2, 120)-2)
+		m.eventsVP.Height = max(3, bodyH-4-timelineLines)
+		m.traceVP.Width = max(10, min(m.w-2, 120)-2)
+		m.traceVP.Height = max(5, bodyH-4)
+
+		// Interfaces details (right, or below in compact layout)
+		m.ifaceDetailsVP.Width = max(10, detW-2)
+		m.ifaceDetailsVP.Height = max(3, detH-2)
 
 		m.ifaceDetailsVP.SetContent(
 			hardClipLinesToWidth(m.ifaceDetailsText, m.ifaceDetailsVP.Width),
 		)
-		m.portsVP.SetContent(
-			hardClipLinesToWidth(m.portsText, m.portsVP.Width),
-		)
-		m.procsVP.SetContent(
-			hardClipLinesToWidth(m.procsText, m.procsVP.Width),
+		m.setPortsContent()
+		m.setProcsContent()
+		m.setConnsContent()
+		m.setExecContent()
+		m.eventsVP.SetContent(
+			hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width),
 		)
+		m.setRoutingContent()
+		m.setFirewallContent()
+		m.setFlowsContent()
+		m.setTraceContent()
+		m.resizeFrozen(msg)
 
 		return m, nil
 
+	case updateMsg:
+		m.updateAvailable = string(msg)
+		return m, nil
+
 	case externalIPMsg:
 		if msg.err != nil {
 			m.externalIPErr = msg.err
-			return m, nil
+			m.extIPFails++
+			return m, m.retryExternalIPCmd(extIPBackoff(m.opts.ExtIPEvery, m.extIPFails))
+		}
+		m.extIPFails, m.extIPRetryAt = 0, time.Time{}
+		m.extIPRetryGen++
+		m.session.addExternalIP(msg.ip, m.externalIP, m.now())
+		var cmd tea.Cmd
+		if m.externalIP != "" && msg.ip != m.externalIP {
+			text := fmt.Sprintf(i18n.T("external IP changed: %s → %s"), m.externalIP, msg.ip)
+			if g := m.geoOf(msg.ip); !g.IsZero() {
+				text += " (" + g.String() + ")"
+			}
+			m.events.add(m.now(), evIP, text)
+			m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+			m.alert, m.alertAt = text, m.now()
+			cmd = m.recordIPChangeCmd(m.externalIP, msg.ip)
 		}
 		m.externalIP = msg.ip
 		m.externalIPErr = nil
-		m.externalIPUpdatedAt = time.Now()
+		m.externalIPUpdatedAt = m.now()
+		return m, cmd
+
+	case externalIPv6Msg:
+		m.externalIPv6, m.externalIPv6Err = msg.ip, msg.err
+		return m, nil
+
+	case ifaceExitMsg:
+		m.applyIfaceExit(msg)
+		m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+		m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
 		return m, nil
 
 	case tickMsg:
-		cmds := []tea.Cmd{m.refreshCmd(), tickEvery(1 * time.Second)}
-		if time.Now().Unix()%5 == 0 {
-			cmds = append(cmds, fetchPortsCmd(), fetchProcsCmd())
+		if msg.loop != m.tickLoop {
+			return m, nil
+		}
+		m.pings = m.pinger.Stats()
+		m.checkIdle(msg.at)
+		if m.paused || m.idle && !m.due(&m.fetchedAt.idle, idleRefresh) {
+			return m, tickEvery(m.opts.Refresh, m.tickLoop)
+		}
+
+		cmds := []tea.Cmd{m.refreshCmd(), m.fetchConnRateCmd(), m.fetchICMPCmd(), m.fetchSoftnetCmd(), tickEvery(m.opts.Refresh, m.tickLoop)}
+		cmds = append(cmds, m.dueExecCmds(msg.at)...)
+		slow := m.due(&m.fetchedAt.slow, m.opts.SlowRefresh)
+		procs := m.due(&m.fetchedAt.procs, m.opts.ProcsRefresh)
+		if m.due(&m.fetchedAt.ports, m.opts.PortsRefresh) {
+			cmds = append(cmds, m.fetchPortsCmd())
+		}
+		if procs {
+			cmds = append(cmds, m.fetchProcsCmd(), m.fetchProcConnRatesCmd())
+			if m.activeTab == tabProcs && m.procDetail.open {
+				cmds = append(cmds, m.fetchProcDetailCmd(m.procDetail.pid))
+			}
+		}
+		if m.activeTab == tabProcs || procs {
+			cmds = append(cmds, m.fetchProcBWCmd())
+		}
+		switch m.activeTab {
+		case tabIfaces:
+			cmds = append(cmds, m.fetchCastsCmd(), m.fetchQueuesCmd(), m.fetchWifiCmd())
+		case tabConns:
+			cmds = append(cmds, m.fetchPeerBWCmd())
+		case tabFirewall:
+			cmds = append(cmds, m.fetchFirewallRulesCmd())
+		}
+		// keep the "12s ago" stamps current
+		switch m.activeTab {
+		case tabEvents:
+			m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+		case tabExec:
+			m.setExecContent()
+		}
+		if slow {
+			cmds = append(cmds, m.fetchRoutesCmd(), m.fetchBlockedCmd(), m.fetchBGPCmd())
+			if m.opts.WatchLAN || m.activeTab == tabRouting {
+				cmds = append(cmds, m.fetchNeighborsCmd())
+			}
+			switch m.activeTab {
+			case tabRouting:
+				cmds = append(cmds, m.fetchRulesCmd())
+			case tabIfaces:
+				cmds = append(cmds, m.fetchFirewallCmd(), m.fetchLinkCmd(), m.fetchLeaseCmd())
+			case tabConns:
+				cmds = append(cmds, m.fetchConnsCmd())
+			case tabFlows:
+				cmds = append(cmds, m.fetchFlowsCmd())
+			case tabStats:
+				cmds = append(cmds, m.fetchDNSCacheCmd())
+			case tabOverview:
+				cmds = append(cmds, m.fetchDNSConfigCmd())
+			}
 		}
 		return m, tea.Batch(cmds...)
 
 	case extIPTickMsg:
-		return m, tea.Batch(fetchExternalIPCmd(), extIPTickEvery(30*time.Second))
+		cmds := []tea.Cmd{extIPTickEvery(m.opts.ExtIPEvery), m.detectMeteredCmd(), m.statGeoIPCmd()}
+		if m.extIPDue() {
+			cmds = append(cmds, m.fetchExternalIPCmd(), m.fetchIfaceExitCmd(m.selectedIface))
+		}
+		if m.timeSyncDue() {
+			m.timeSyncAt = m.now()
+			cmds = append(cmds, m.checkTimeSyncCmd())
+		}
+		return m, tea.Batch(cmds...)
+
+	case extIPRetryMsg:
+		if msg.gen != m.extIPRetryGen || m.metered {
+			return m, nil
+		}
+		return m, tea.Batch(m.fetchExternalIPCmd(), m.fetchIfaceExitCmd(m.selectedIface))
+
+	case meteredMsg:
+		return m.applyMetered(msg)
 
 	case snapMsg:
+		prev := m.lastSnap.Ifaces
 		m.lastSnap = probe.NetSnapshot(msg)
+		breaks := m.trackIfaceResets(m.lastSnap.Ifaces)
+		saveCmd := m.recordBandwidth(m.lastSnap)
+		m.lastSnap.Ifaces = m.visibleIfaces(m.lastSnap.Ifaces)
 		m.err = nil
+		m.session.addSnapshot(m.lastSnap, m.now())
+		m.logIfaceFlaps(prev)
+		m.addIfaceHists(breaks)
+		m.checkKillSwitch()
 
 		prevSel := m.selectedIface
 		prevIndex := m.ifaceList.Index()
@@ -297,17 +859,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		items := make([]list.Item, 0, len(m.lastSnap.Ifaces))
 		for _, ii := range m.lastSnap.Ifaces {
-			desc := fmt.Sprintf(
-				"MAC %s  RX %s  TX %s",
-				ii.Hardware,
-				probe.HumanBytesPerSec(ii.RxBps),
-				probe.HumanBytesPerSec(ii.TxBps),
-			)
-
-			desc = trunc(desc, descMax)
 			items = append(items, ifaceItem{
-				name: ii.Name,
-				desc: desc,
+				name:    ii.Name,
+				mac:     ii.Hardware,
+				rx:      ii.RxBps,
+				tx:      ii.TxBps,
+				units:   m.units,
+				descMax: descMax,
 			})
 		}
 
@@ -317,12 +875,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ifaceList.Select(0)
 			m.selectedIface = items[0].(ifaceItem).name
 		} else if prevSel != "" {
+			found := false
 			for i, it := range items {
 				if it.(ifaceItem).name == prevSel {
 					m.ifaceList.Select(i)
+					found = true
 					break
 				}
 			}
+			// hidden by the v filter or gone: fall back to the first one
+			if !found && len(items) > 0 {
+				m.ifaceList.Select(0)
+				m.selectedIface = items[0].(ifaceItem).name
+				m.rxHist, m.txHist = series{}, series{}
+				m.rxPktHist, m.txPktHist = series{}, series{}
+			}
 		} else if prevIndex >= 0 && prevIndex < len(items) {
 			m.ifaceList.Select(prevIndex)
 			m.selectedIface = items[prevIndex].(ifaceItem).name
@@ -330,12 +897,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		for _, ii := range m.lastSnap.Ifaces {
 			if ii.Name == m.selectedIface {
-				m.rxHist = append(m.rxHist, ii.RxBps)
-				m.txHist = append(m.txHist, ii.TxBps)
+				rx, tx, rxPkt, txPkt := ii.RxBps, ii.TxBps, ii.RxPps, ii.TxPps
+				if _, brk := breaks[ii.Name]; brk {
+					rx, tx, rxPkt, txPkt = sparkBreak, sparkBreak, sparkBreak, sparkBreak
+				}
 
-				maxHist := max(30, min(200, m.w/2))
-				m.rxHist = probe.ClampHistory(m.rxHist, maxHist)
-				m.txHist = probe.ClampHistory(m.txHist, maxHist)
+				m.rxHist = m.rxHist.add(m.now(), rx)
+				m.txHist = m.txHist.add(m.now(), tx)
+				m.rxPktHist = m.rxPktHist.add(m.now(), rxPkt)
+				m.txPktHist = m.txPktHist.add(m.now(), txPkt)
 				break
 			}
 		}
@@ -347,45 +917,320 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 		m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
 
-		return m, nil
+		cmd := m.followDNSLog()
+		return m, tea.Batch(cmd, saveCmd)
 
 	case portsMsg:
 		m.ports = msg
-		m.portsText = m.renderPortsText()
-		m.portsText = hardClipLinesToWidth(m.portsText, m.portsVP.Width)
-		m.portsVP.SetContent(m.portsText)
+		sortPorts(m.ports, m.portsSort)
+		m.logListeners(m.session.addPorts(m.ports))
+		fresh := m.session.addListenHistory(m.ports, m.now())
+		m.portTimeline.update(m.ports, m.now())
+		m.portsMarked = m.portsMarked.kept(func(k string) bool { return m.portTimeline.seen[k] != nil })
+		m.setPortsContent()
+		if m.procDetail.open {
+			m.setProcDetailContent()
+		}
+		return m, tea.Batch(m.fetchTunnelsCmd(msg), m.recordListenHistoryCmd(fresh))
+
+	case firewallMsg:
+		m.applyFirewall(msg)
+		return m, nil
+
+	case flowsMsg:
+		m.applyFlows(msg)
+		return m, nil
+
+	case softnetMsg:
+		m.applySoftnet(msg)
+		return m, nil
+
+	case linkMsg:
+		m.applyLink(msg)
+		return m, nil
+
+	case leaseMsg:
+		m.applyLease(msg)
+		return m, nil
+
+	case wifiMsg:
+		m.applyWifi(msg)
+		return m, nil
+
+	case queuesMsg:
+		m.applyQueues(msg)
+		return m, nil
+
+	case castsMsg:
+		m.applyCasts(msg)
+		return m, nil
+
+	case firewallRulesMsg:
+		m.applyFirewallRules(msg)
+		return m, nil
+
+	case tunnelsMsg:
+		m.tunnels = msg
+		m.setPortsContent()
 		return m, nil
 
 	case procsMsg:
 		m.procs = msg
-		m.procsText = m.renderProcsText()
-		m.procsText = hardClipLinesToWidth(m.procsText, m.procsVP.Width)
-		m.procsVP.SetContent(m.procsText)
+		sortProcs(m.procs, m.procsSort)
+		m.pruneProcMarks()
+		m.setProcsContent()
 		return m, nil
 
 	case errMsg:
 		m.err = msg.error
 		return m, nil
 
+	case connRateMsg:
+		m.connRate, m.connRateErr = msg.rate, msg.err
+		if msg.err == nil {
+			m.connHist = probe.ClampHistory(append(m.connHist, msg.rate.Total()), max(30, min(200, m.w/2)))
+		}
+		return m, nil
+
+	case icmpMsg:
+		m.applyICMP(msg)
+		return m, nil
+
+	case procDetailMsg:
+		m.applyProcDetail(msg)
+		if f := m.frozen[tabProcs]; f != nil {
+			// opened on the frozen copy, which gets no messages of its own
+			fm := *f
+			fm.applyProcDetail(msg)
+			m.frozen[tabProcs] = &fm
+		}
+		return m, nil
+
+	case dnsQueryMsg:
+		return m, m.applyDNSQuery(msg)
+
+	case dnsLogDoneMsg:
+		m.applyDNSLogDone(msg)
+		return m, nil
+
+	case portDetailMsg:
+		m.applyPortDetail(msg)
+		if f := m.frozen[tabPorts]; f != nil {
+			fm := *f
+			fm.applyPortDetail(msg)
+			m.frozen[tabPorts] = &fm
+		}
+		return m, nil
+
+	case connDetailMsg:
+		m.applyConnDetail(msg)
+		if f := m.frozen[tabConns]; f != nil {
+			fm := *f
+			fm.applyConnDetail(msg)
+			m.frozen[tabConns] = &fm
+		}
+		return m, nil
+
+	case dnsCacheMsg:
+		m.dnsCache, m.dnsCacheErr = msg.cache, msg.err
+		return m, nil
+
+	case dnsConfigMsg:
+		m.dnsConf, m.dnsConfErr = msg.c, msg.err
+		return m, nil
+
+	case ephemeralRangeMsg:
+		// without it, IANA's range stands in
+		if msg.err == nil {
+			m.ephemeral = msg.r
+			m.setConnsContent()
+		}
+		return m, nil
+
+	case dnsTestMsg:
+		m.applyDNSTest(msg)
+		return m, nil
+
+	case routesMsg:
+		m.applyRoutes(msg)
+		m.setRoutingContent()
+		return m, nil
+
+	case blockedMsg:
+		m.applyBlocked(msg)
+		m.setConnsContent()
+		return m, nil
+
+	case bgpMsg:
+		m.applyBGP(msg)
+		m.setRoutingContent()
+		return m, nil
+
+	case execMsg:
+		m.applyExec(msg)
+		return m, nil
+
+	case connsMsg:
+		m.conns, m.connsErr = msg.conns, msg.err
+		if m.conns == nil && m.connsErr == nil {
+			m.conns = []probe.Conn{}
+		}
+		m.pruneConnMarks()
+		m.setConnsContent()
+		return m, nil
+
+	case raMsg:
+		return m, m.applyRA(msg)
+
+	case rdnsMsg:
+		return m, m.applyRDNS(msg)
+
+	case geoipMsg:
+		return m, m.applyGeoIP(msg)
+
+	case timeSyncMsg:
+		m.applyTimeSync(msg)
+		return m, nil
+
+	case geoOpenMsg:
+		m.applyGeoOpen(msg)
+		return m, nil
+
+	case neighborsMsg:
+		m.applyNeighbors(msg)
+		return m, nil
+
+	case rulesMsg:
+		m.rules, m.rulesErr = msg.rules, msg.err
+		if m.rules == nil && m.rulesErr == nil {
+			m.rules = []probe.Rule{}
+		}
+		m.setRoutingContent()
+		return m, nil
+
+	case procConnRatesMsg:
+		m.procConnRates = msg
+		return m, nil
+
+	case peerBWMsg:
+		m.peerBW = msg
+		m.setConnsContent()
+		return m, nil
+
+	case procBWMsg:
+		// nil keeps the RX/TX columns hidden where bandwidth is unavailable
+		m.procBW = msg
+		m.setProcsContent()
+		return m, nil
+
+	case repMsg:
+		m.applyRep(msg)
+		return m, nil
+
+	case traceHopMsg:
+		return m, m.applyTraceHop(msg)
+
+	case traceDoneMsg:
+		m.applyTraceDone(msg)
+		return m, nil
+
+	case eyeballsMsg:
+		m.applyEyeballs(msg)
+		return m, nil
+
+	case noteSavedMsg:
+		m.notice = string(msg)
+		m.setPortsContent()
+		m.setConnsContent()
+		return m, nil
+
+	case noticeMsg:
+		m.notice, m.noticeErr = msg.text, msg.err
+		return m, nil
+
 	case tea.KeyMsg:
-		switch msg.String() {
+		m.notice, m.noticeErr = "", nil
+
+		if m.help.open && msg.String() != "ctrl+c" {
+			return m.updateHelp(msg)
+		}
+		if m.urlPicker.open && msg.String() != "ctrl+c" {
+			return m.updateURLPicker(msg)
+		}
+		if m.rep.open && msg.String() != "ctrl+c" {
+			return m.updateRep(msg)
+		}
+		if m.eyeballs.open && msg.String() != "ctrl+c" {
+			return m.updateEyeballs(msg)
+		}
+		if m.noting && msg.String() != "ctrl+c" {
+			return m.updateNotePrompt(msg)
+		}
+		if m.export.open && msg.String() != "ctrl+c" {
+			return m.updateExport(msg)
+		}
+		if m.ifacePicker.open && msg.String() != "ctrl+c" {
+			return m.updateIfacePicker(msg)
+		}
+		if m.procKill.open && msg.String() != "ctrl+c" {
+			return m.updateProcKill(msg)
+		}
+		if m.hostBlock.open && msg.String() != "ctrl+c" {
+			return m.updateHostBlock(msg)
+		}
+		if m.portDetail.open && msg.String() != "ctrl+c" {
+			return m.updatePortDetail(msg)
+		}
+		if m.connDetail.open && msg.String() != "ctrl+c" {
+			return m.updateConnDetail(msg)
+		}
+		if m.activeTab == tabProcs && m.procDetail.open && !m.procsSearching {
+			if nm, handled := m.updateProcDetail(msg); handled {
+				return nm, nil
+			}
+		}
+
+		if m.confirmingQuit {
+			m.confirmingQuit = false
+			switch msg.String() {
+			case "y", "Y", "q", "enter", "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		if m.frozenKey(msg) {
+			return m.updateFrozen(msg)
+		}
+
+		key := msg.String()
+		if m.searching() && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) {
+			// typed into the search or filter below, not taken as a command
+			key = ""
+		}
+		switch key {
 		case "ctrl+c":
 			return m, tea.Quit
 		case "q":
-			// disabled (no-op)
+			switch m.opts.Quit {
+			case QuitImmediate:
+				return m, tea.Quit
+			case QuitConfirm:
+				m.confirmingQuit = true
+			}
 			return m, nil
 		case "tab":
-			m.activeTab = (m.activeTab + 1) % 4
-			return m, nil
+			m.setTab(m.stepTab(1))
+			return m, m.tabEnterCmd()
 		case "shift+tab":
-			m.activeTab = (m.activeTab + 3) % 4
-			return m, nil
+			m.setTab(m.stepTab(-1))
+			return m, m.tabEnterCmd()
 		case "right":
-			m.activeTab = (m.activeTab + 1) % 4
-			return m, nil
+			m.setTab(m.stepTab(1))
+			return m, m.tabEnterCmd()
 		case "left":
-			m.activeTab = (m.activeTab + 3) % 4
-			return m, nil
+			m.setTab(m.stepTab(-1))
+			return m, m.tabEnterCmd()
 
 		case "/":
 			if m.activeTab == tabPorts {
@@ -400,25 +1245,292 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.procsSearch.SetValue(m.procsQuery)
 				return m, nil
 			}
+			if m.activeTab == tabFlows {
+				m.flowsSearching = true
+				m.flowsSearch.Focus()
+				m.flowsSearch.SetValue(m.flowsQuery)
+				return m, nil
+			}
 
 		case "ctrl+u":
 			if m.activeTab == tabPorts && !m.portsSearching {
 				m.portsQuery = ""
 				m.portsSearch.SetValue("")
-				m.portsText = m.renderPortsText()
-				m.portsVP.SetContent(m.portsText)
+				m.setPortsContent()
 				return m, nil
 			}
 			if m.activeTab == tabProcs && !m.procsSearching {
 				m.procsQuery = ""
 				m.procsSearch.SetValue("")
-				m.procsText = m.renderProcsText()
-				m.procsVP.SetContent(m.procsText)
+				m.setProcsContent()
+				return m, nil
+			}
+			if m.activeTab == tabFlows && !m.flowsSearching {
+				m.flowsQuery = ""
+				m.flowsSearch.SetValue("")
+				m.setFlowsContent()
 				return m, nil
 			}
 
 		case "ctrl+e":
-			return m, fetchExternalIPCmd()
+			return m, tea.Batch(m.fetchExternalIPCmd(), m.fetchIfaceExitCmd(m.selectedIface))
+
+		case "P":
+			return m, m.togglePause()
+
+		case "+", "-":
+			if msg.String() == "+" {
+				return m, m.stepRefresh(1)
+			}
+			return m, m.stepRefresh(-1)
+
+		case "?":
+			m.openHelp()
+			return m, nil
+
+		case "f":
+			m.toggleFreeze()
+			return m, nil
+
+		case "x":
+			if m.readOnly() {
+				m.notice = i18n.T("export is disabled in kiosk mode")
+				return m, nil
+			}
+			if m.openExport() {
+				return m, nil
+			}
+
+		case "n":
+			if m.readOnly() {
+				m.notice = i18n.T("notes are read-only in kiosk mode")
+				return m, nil
+			}
+			if m.opts.Notes == nil {
+				m.notice = i18n.T("notes are unavailable")
+				return m, nil
+			}
+			m.openNotePrompt()
+			return m, nil
+
+		case "m":
+			return m.toggleMetered()
+
+		case "v":
+			m.hideVirtual = !m.hideVirtual
+			m.notice = i18n.T("virtual interfaces shown")
+			if m.hideVirtual {
+				m.notice = i18n.T("virtual interfaces hidden (loopback, veth, Docker, bridges)")
+			}
+			m.setBriefKinds()
+			// the current snapshot is already filtered, take a fresh one
+			return m, m.refreshCmd()
+
+		case "p":
+			if m.activeTab == tabHistory {
+				m.stepHistoryPeriod()
+				return m, nil
+			}
+			if m.activeTab != tabIfaces {
+				break
+			}
+			m.ifacePackets = !m.ifacePackets
+			m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+			m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+			return m, nil
+
+		case "c":
+			if m.activeTab != tabIfaces {
+				break
+			}
+			m.ifaceChart = (m.ifaceChart + 1) % chartKinds
+			m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+			m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+			return m, nil
+
+		case "u":
+			if m.units == UnitsBits {
+				m.units, m.notice = UnitsBytes, i18n.T("rates in bytes per second")
+			} else {
+				m.units, m.notice = UnitsBits, i18n.T("rates in bits per second")
+			}
+			m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+			m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+			// the interface list is rebuilt from a snapshot
+			return m, m.refreshCmd()
+
+		case "r":
+			if m.metered {
+				m.notice = i18n.T("reputation lookups are paused on a metered connection (m)")
+				return m, nil
+			}
+			if m.opts.Reputation == nil {
+				m.notice = i18n.T("reputation lookups are off (start with --reputation)")
+				return m, nil
+			}
+			m.openRep()
+			return m, nil
+
+		case "H":
+			m.openEyeballs()
+			return m, nil
+
+		case "i":
+			// the Interfaces tab has the list itself
+			if m.activeTab == tabIfaces {
+				break
+			}
+			m.openIfacePicker()
+			return m, nil
+
+		case " ":
+			if m.activeTab != tabPorts && m.activeTab != tabProcs && m.activeTab != tabConns {
+				break
+			}
+			m.toggleMark()
+			return m, nil
+
+		case "t":
+			if m.activeTab != tabPorts && m.activeTab != tabProcs && m.activeTab != tabConns {
+				break
+			}
+			if m.readOnly() {
+				m.notice = i18n.T("notes are read-only in kiosk mode")
+				return m, nil
+			}
+			if m.opts.Notes == nil {
+				m.notice = i18n.T("notes are unavailable")
+				return m, nil
+			}
+			m.openTagPrompt()
+			return m, nil
+
+		case "b":
+			if m.activeTab != tabConns {
+				break
+			}
+			if m.readOnly() {
+				m.notice = i18n.T("blocking hosts is disabled in kiosk mode")
+				return m, nil
+			}
+			m.openHostBlock()
+			return m, nil
+
+		case "[", "]", "esc":
+			if msg.String() == "esc" && m.marked(m.activeTab).any() && !m.searching() {
+				m.clearMarks(m.activeTab)
+				return m, nil
+			}
+			if m.activeTab != tabEvents || (msg.String() == "esc" && !m.scrub.on) {
+				break
+			}
+			if msg.String() == "]" {
+				m.stepScrub(1)
+			} else if msg.String() == "[" {
+				m.stepScrub(-1)
+			} else {
+				m.scrub = scrubber{}
+				m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+			}
+			return m, nil
+
+		case "X", "K":
+			// x is export; X asks the process to exit, K kills it
+			if m.activeTab != tabProcs && !m.marked(m.activeTab).any() {
+				break
+			}
+			if m.readOnly() {
+				m.notice = i18n.T("stopping processes is disabled in kiosk mode")
+				return m, nil
+			}
+			m.openProcKill(msg.String() == "K")
+			return m, nil
+
+		case "d":
+			if m.activeTab != tabOverview {
+				break
+			}
+			if len(m.dnsConf.Servers) == 0 {
+				m.notice = i18n.T("no resolvers to test")
+				return m, nil
+			}
+			return m, m.testDNSCmd()
+
+		case "C":
+			if m.activeTab != tabStats || m.dnsCache.Daemon == "" {
+				break
+			}
+			if m.readOnly() {
+				m.notice = i18n.T("flushing the DNS cache is disabled in kiosk mode")
+				return m, nil
+			}
+			return m, m.flushDNSCacheCmd()
+
+		case "o":
+			if m.activeTab == tabPorts && !m.portsSearching {
+				urls := listenerURLs(m.ports)
+				if len(urls) == 0 {
+					m.notice = i18n.T("no HTTP-looking listeners")
+					return m, nil
+				}
+				m.urlPicker = urlPicker{open: true, urls: urls}
+				return m, nil
+			}
+
+		case "w":
+			if m.activeTab == tabIfaces {
+				m.ifaceWindow = (m.ifaceWindow + 1) % chartWindows
+				m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+				m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+				return m, nil
+			}
+			if m.activeTab == tabPorts && !m.portsSearching {
+				m.portsExpand = !m.portsExpand
+				m.setPortsContent()
+				return m, nil
+			}
+
+		case "s", "S":
+			if m.activeTab == tabPorts && !m.portsSearching {
+				if msg.String() == "s" {
+					m.portsSort = m.portsSort.next(portsColCount)
+				} else {
+					m.portsSort.desc = !m.portsSort.desc
+				}
+				sortPorts(m.ports, m.portsSort)
+				m.setPortsContent()
+				return m, nil
+			}
+			if m.activeTab == tabProcs && !m.procsSearching {
+				if msg.String() == "s" {
+					m.procsSort = m.procsSort.next(procsColCount, procsByConns, procsByListen)
+				} else {
+					m.procsSort.desc = !m.procsSort.desc
+				}
+				sortProcs(m.procs, m.procsSort)
+				m.setProcsContent()
+				return m, nil
+			}
+
+		case "g", "e":
+			if m.activeTab == tabConns {
+				if msg.String() == "g" {
+					m.connsGrouped = !m.connsGrouped
+				} else {
+					m.connsHideEph = !m.connsHideEph
+				}
+				m.setConnsContent()
+				return m, nil
+			}
+			if m.activeTab == tabProcs && !m.procsSearching {
+				if msg.String() == "g" {
+					m.procsGrouped = !m.procsGrouped
+				} else if m.procsGrouped {
+					m.procsExpanded = !m.procsExpanded
+				}
+				m.setProcsContent()
+				return m, nil
+			}
 		}
 	}
 
@@ -432,13 +1544,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Auto-follow selection
 		if it, ok := m.ifaceList.SelectedItem().(ifaceItem); ok {
 			if m.selectedIface != it.name {
-				m.selectedIface = it.name
-				m.rxHist, m.txHist = nil, nil
+				m.selectIface(it.name)
 				needExtRefresh = true
-
-				m.ifaceDetailsText = m.renderIfaceDetailsText()
-				m.ifaceDetailsText = hardClipLinesToWidth(m.ifaceDetailsText, m.ifaceDetailsVP.Width)
-				m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
 			}
 		}
 
@@ -446,7 +1553,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ifaceDetailsVP, cmd2 = m.ifaceDetailsVP.Update(msg)
 
 		if needExtRefresh {
-			return m, tea.Batch(cmd, cmd2, fetchExternalIPCmd())
+			return m, tea.Batch(cmd, cmd2, m.fetchExternalIPCmd(), m.fetchIfaceExitCmd(m.selectedIface), m.fetchCastsCmd(), m.fetchQueuesCmd(), m.fetchWifiCmd(), m.fetchLinkCmd(), m.fetchLeaseCmd())
 		}
 		return m, tea.Batch(cmd, cmd2)
 	}
@@ -462,8 +1569,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.portsQuery = strings.TrimSpace(m.portsSearch.Value())
 				m.portsSearching = false
 				m.portsSearch.Blur()
-				m.portsText = m.renderPortsText()
-				m.portsVP.SetContent(m.portsText)
+				m.setPortsContent()
 				return m, nil
 
 			case "esc":
@@ -480,45 +1586,181 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
-	// Procs search mode
-	if m.activeTab == tabProcs && m.procsSearching {
+	// Flows search mode
+	if m.activeTab == tabFlows && m.flowsSearching {
+		var cmd tea.Cmd
+		m.flowsSearch, cmd = m.flowsSearch.Update(msg)
+
+		if km, ok := msg.(tea.KeyMsg); ok {
+			switch km.String() {
+			case "enter":
+				m.flowsQuery = strings.TrimSpace(m.flowsSearch.Value())
+				m.flowsSearching = false
+				m.flowsSearch.Blur()
+				m.flowsVP.GotoTop()
+				m.setFlowsContent()
+				return m, nil
+
+			case "esc":
+				m.flowsSearching = false
+				m.flowsSearch.Blur()
+				return m, nil
+
+			case "ctrl+u":
+				m.flowsSearch.SetValue("")
+				return m, cmd
+			}
+		}
+		return m, cmd
+	}
+
+	// Procs search mode
+	if m.activeTab == tabProcs && m.procsSearching {
+		var cmd tea.Cmd
+		m.procsSearch, cmd = m.procsSearch.Update(msg)
+
+		if km, ok := msg.(tea.KeyMsg); ok {
+			switch km.String() {
+			case "enter":
+				m.procsQuery = strings.TrimSpace(m.procsSearch.Value())
+				m.procsSearching = false
+				m.procsSearch.Blur()
+				m.setProcsContent()
+				return m, nil
+
+			case "esc":
+				m.procsSearching = false
+				m.procsSearch.Blur()
+				return m, nil
+
+			case "ctrl+u":
+				m.procsSearch.SetValue("")
+				return m, cmd
+			}
+		}
+		return m, cmd
+	}
+
+	if m.activeTab == tabTrace {
+		if km, ok := msg.(tea.KeyMsg); ok {
+			if nm, cmd, handled := m.updateTrace(km); handled {
+				return nm, cmd
+			}
+		}
+	}
+
+	if _, ok := msg.(tea.MouseMsg); ok && m.frozen[m.activeTab] != nil {
+		return m.updateFrozen(msg)
+	}
+
+	// Ports tab: ↑↓ move the selection, enter shows its socket details, the
+	// rest scrolls the viewport
+	if m.activeTab == tabPorts {
+		if km, ok := msg.(tea.KeyMsg); ok {
+			switch km.String() {
+			case "enter":
+				return m, m.openPortDetail()
+			case "up", "k":
+				m.movePortSel(-1)
+				return m, nil
+			case "down", "j":
+				m.movePortSel(1)
+				return m, nil
+			}
+		}
+		var cmd tea.Cmd
+		m.portsVP, cmd = m.portsVP.Update(msg)
+		m.keepPortSelInView()
+		return m, cmd
+	}
+
+	// Procs tab: ↑↓ move the selection, enter opens its details, the rest
+	// scrolls the viewport
+	if m.activeTab == tabProcs {
+		if m.procDetail.open {
+			var cmd tea.Cmd
+			m.procDetailVP, cmd = m.procDetailVP.Update(msg)
+			return m, cmd
+		}
+		if km, ok := msg.(tea.KeyMsg); ok {
+			switch km.String() {
+			case "enter":
+				return m, m.openProcDetail()
+			case "up", "k":
+				m.moveProcSel(-1)
+				return m, nil
+			case "down", "j":
+				m.moveProcSel(1)
+				return m, nil
+			}
+		}
+		var cmd tea.Cmd
+		m.procsVP, cmd = m.procsVP.Update(msg)
+		m.keepProcSelInView()
+		return m, cmd
+	}
+
+	if m.activeTab == tabOverview {
 		var cmd tea.Cmd
-		m.procsSearch, cmd = m.procsSearch.Update(msg)
+		m.setOverviewContent()
+		m.overviewVP, cmd = m.overviewVP.Update(msg)
+		return m, cmd
+	}
+
+	if m.activeTab == tabRouting {
+		var cmd tea.Cmd
+		m.routingVP, cmd = m.routingVP.Update(msg)
+		return m, cmd
+	}
+
+	if m.activeTab == tabFirewall {
+		var cmd tea.Cmd
+		m.firewallVP, cmd = m.firewallVP.Update(msg)
+		return m, cmd
+	}
+
+	if m.activeTab == tabFlows {
+		var cmd tea.Cmd
+		m.flowsVP, cmd = m.flowsVP.Update(msg)
+		return m, cmd
+	}
 
+	// Conns tab: ↑↓ move the selection, enter shows its socket options, the
+	// rest scrolls the viewport
+	if m.activeTab == tabConns {
 		if km, ok := msg.(tea.KeyMsg); ok {
 			switch km.String() {
 			case "enter":
-				m.procsQuery = strings.TrimSpace(m.procsSearch.Value())
-				m.procsSearching = false
-				m.procsSearch.Blur()
-				m.procsText = m.renderProcsText()
-				m.procsVP.SetContent(m.procsText)
+				return m, m.openConnDetail()
+			case "up", "k":
+				m.moveConnSel(-1)
 				return m, nil
-
-			case "esc":
-				m.procsSearching = false
-				m.procsSearch.Blur()
+			case "down", "j":
+				m.moveConnSel(1)
 				return m, nil
-
-			case "ctrl+u":
-				m.procsSearch.SetValue("")
-				return m, cmd
 			}
 		}
+		var cmd tea.Cmd
+		m.connsVP, cmd = m.connsVP.Update(msg)
+		m.keepConnSelInView()
 		return m, cmd
 	}
 
-	// Ports tab: scroll via viewport
-	if m.activeTab == tabPorts {
+	if m.activeTab == tabEvents {
 		var cmd tea.Cmd
-		m.portsVP, cmd = m.portsVP.Update(msg)
+		m.eventsVP, cmd = m.eventsVP.Update(msg)
 		return m, cmd
 	}
 
-	// Procs tab: scroll via viewport
-	if m.activeTab == tabProcs {
+	if m.activeTab == tabExec {
 		var cmd tea.Cmd
-		m.procsVP, cmd = m.procsVP.Update(msg)
+		m.execVP, cmd = m.execVP.Update(msg)
+		return m, cmd
+	}
+
+	if m.activeTab == tabTrace {
+		var cmd tea.Cmd
+		m.traceVP, cmd = m.traceVP.Update(msg)
 		return m, cmd
 	}
 
@@ -528,6 +1770,61 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) View() string {
 	header := m.renderHeader()
 
+	body := m.renderBody()
+	if f := m.frozen[m.activeTab]; f != nil {
+		body = f.renderBody()
+	}
+	if m.rep.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewRep())
+	}
+	if m.eyeballs.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewEyeballs())
+	}
+	if m.help.open {
+		body = m.viewHelp()
+	}
+
+	footer := subtleStyle.Render(i18n.T("Keys: tab/shift+tab • ←/→ • / search • Ctrl+u clear • ctrl+e ext-ip") + " • ? " + i18n.T("help") + " • " + m.quitKeys() + " " + i18n.T("quit"))
+	if m.compact() {
+		footer = subtleStyle.Render("tab ←/→ • / • ^u • ^e • ? • " + m.quitKeys())
+	}
+	if m.hideVirtual {
+		footer += "  " + warnStyle.Render(i18n.T("v: virtual ifaces hidden"))
+	}
+	if a := m.activeAlert(); a != "" {
+		footer = warnStyle.Render("⚠ " + a)
+		if m.kill.leaking != "" {
+			footer = errStyle.Render("⚠ " + a)
+		}
+	}
+	if m.notice != "" {
+		footer = okStyle.Render(m.notice)
+	}
+	if m.noticeErr != nil {
+		footer = errStyle.Render(i18n.T("Error: ") + m.noticeErr.Error())
+	}
+	if m.confirmingQuit {
+		footer = warnStyle.Render(i18n.T("Quit ducknetview? (y/n)"))
+	}
+	if m.noting {
+		footer = m.notePrompt.View()
+	}
+	if m.err != nil {
+		footer = errStyle.Render(i18n.T("Error: ") + m.err.Error())
+	}
+
+	footer = clampToWidthOneLine(footer, m.w)
+	footer = lipgloss.NewStyle().Width(m.w).Render(footer)
+
+	out := lipgloss.JoinVertical(lipgloss.Left, header, body, footer)
+	if m.idle {
+		out = dim(out)
+	}
+	return out + "\x1b[0m"
+}
+
+// renderBody renders the active tab without the header and footer.
+func (m Model) renderBody() string {
 	var body string
 	switch m.activeTab {
 	case tabOverview:
@@ -538,35 +1835,189 @@ func (m Model) View() string {
 		body = m.viewPorts()
 	case tabProcs:
 		body = m.viewProcs()
+		if m.procDetail.open {
+			body = m.viewProcDetail()
+		}
+	case tabStats:
+		body = m.viewStats()
+	case tabRouting:
+		body = m.viewRouting()
+	case tabFirewall:
+		body = m.viewFirewall()
+	case tabFlows:
+		body = m.viewFlows()
+	case tabConns:
+		body = m.viewConns()
+	case tabEvents:
+		body = m.viewEvents()
+	case tabLatency:
+		body = m.viewLatency()
+	case tabTrace:
+		body = m.viewTrace()
+	case tabHistory:
+		body = m.viewHistory()
+	case tabExec:
+		body = m.viewExec()
+	}
+	if m.export.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewExport())
+	}
+	if m.ifacePicker.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewIfacePicker())
+	}
+	if m.procKill.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewProcKill())
+	}
+	if m.hostBlock.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewHostBlock())
+	}
+	if m.portDetail.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewPortDetail())
+	}
+	if m.connDetail.open {
+		body = boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.viewConnDetail())
 	}
+	return body
+}
 
-	footer := subtleStyle.Render("Keys: tab/shift+tab • ←/→ • / search • Ctrl+u clear • ctrl+e ext-ip")
-	if m.err != nil {
-		footer = errStyle.Render("Error: " + m.err.Error())
+// setTab switches the active tab. In kiosk mode filters don't outlive the
+// tab they were typed in, so a dashboard never stays stuck on a stale query.
+func (m *Model) setTab(t tab) {
+	if m.opts.Kiosk && t != m.activeTab {
+		m.resetSearches()
 	}
+	m.activeTab = t
+}
 
-	footer = clampToWidthOneLine(footer, m.w)
-	footer = lipgloss.NewStyle().Width(m.w).Render(footer)
+func (m *Model) resetSearches() {
+	m.portsSearching, m.procsSearching, m.flowsSearching = false, false, false
+	m.portsSearch.Blur()
+	m.procsSearch.Blur()
+	m.flowsSearch.Blur()
+	m.portsSearch.SetValue("")
+	m.procsSearch.SetValue("")
+	m.flowsSearch.SetValue("")
+	if m.portsQuery != "" {
+		m.portsQuery = ""
+		m.setPortsContent()
+	}
+	if m.procsQuery != "" {
+		m.procsQuery = ""
+		m.setProcsContent()
+	}
+	if m.flowsQuery != "" {
+		m.flowsQuery = ""
+		m.setFlowsContent()
+	}
+}
+
+// readOnly reports whether actions that change the system are disabled.
+func (m Model) readOnly() bool {
+	return m.opts.Kiosk
+}
+
+// searching reports whether a search input on the active tab has focus.
+func (m Model) searching() bool {
+	return (m.activeTab == tabIfaces && m.ifaceList.FilterState() == list.Filtering) ||
+		(m.activeTab == tabPorts && m.portsSearching) ||
+		(m.activeTab == tabProcs && m.procsSearching) ||
+		(m.activeTab == tabFlows && m.flowsSearching) ||
+		(m.activeTab == tabTrace && m.trace.input.Focused())
+}
+
+// quitKeys lists the keys that currently quit the program.
+func (m Model) quitKeys() string {
+	if m.opts.Quit == QuitDisabled {
+		return "ctrl+c"
+	}
+	return "q/ctrl+c"
+}
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, body, footer) + "\x1b[0m"
+// tab labels, indexed by tab; short names are used in the compact header
+var tabNames = [tabCount]struct{ full, short string }{
+	tabOverview: {"Overview", "Ovw"},
+	tabIfaces:   {"Interfaces", "If"},
+	tabPorts:    {"Ports", "Ports"},
+	tabProcs:    {"Processes", "Procs"},
+	tabConns:    {"Connections", "Conns"},
+	tabStats:    {"Stats", "Stats"},
+	tabRouting:  {"Routing", "Rt"},
+	tabFirewall: {"Firewall", "Fw"},
+	tabFlows:    {"Flows", "Flows"},
+	tabEvents:   {"Events", "Ev"},
+	tabLatency:  {"Latency", "Lat"},
+	tabTrace:    {"Traceroute", "Trace"},
+	tabHistory:  {"History", "Hist"},
+	tabExec:     {"Custom", "Cust"},
 }
 
 func (m Model) renderHeader() string {
-	tabs := []string{
-		renderTab("1 Overview", m.activeTab == tabOverview),
-		renderTab("2 Interfaces", m.activeTab == tabIfaces),
-		renderTab("3 Ports", m.activeTab == tabPorts),
-		renderTab("4 Processes", m.activeTab == tabProcs),
+	tabs := make([]string, 0, tabCount)
+	active := 0
+	for t := tab(0); t < tabCount; t++ {
+		if m.tabHidden(t) {
+			continue
+		}
+		if m.activeTab == t {
+			active = len(tabs)
+		}
+		tabs = append(tabs, renderTab(fmt.Sprintf("%d %s", t+1, m.tabLabel(t, tabNames[t].full)), m.activeTab == t))
+	}
+
+	title := titleStyle.Render("ducknetview 🦆 " + version.Version)
+	size := " " + subtleStyle.Render(fmt.Sprintf("(%dx%d)", m.w, m.h))
+	left := " " + subtleStyle.Render("↻ "+refreshLabel(m.opts.Refresh))
+	if m.readOnly() {
+		left += " " + warnStyle.Render(i18n.T("KIOSK"))
+	}
+	if m.updateAvailable != "" {
+		left += " " + okStyle.Render(fmt.Sprintf(i18n.T("update available: %s"), m.updateAvailable))
 	}
+	if m.metered {
+		left += " " + warnStyle.Render(i18n.T("METERED"))
+	}
+	if m.kill.leaking != "" {
+		left += " " + errStyle.Render(i18n.T("VPN LEAK"))
+	}
+	if m.anyFrozen() {
+		left += " " + m.freezeBadge()
+	}
+
+	if m.compact() {
+		// condensed header: short title, numbered tabs with abbreviated names
+		tabs = tabs[:0]
+		for t := tab(0); t < tabCount; t++ {
+			if m.tabHidden(t) {
+				continue
+			}
+			tabs = append(tabs, renderTabCompact(fmt.Sprintf("%d %s", t+1, m.tabLabel(t, tabNames[t].short)), m.activeTab == t))
+		}
+		title, size, left = titleStyle.Render("dnv 🦆"), "", ""
+	}
+	if m.paused {
+		left += " " + warnStyle.Render(i18n.T("PAUSED"))
+	}
+	left = title + size + left
 
-	left := titleStyle.Render("ducknetview 🦆 0.0.4") + " " + subtleStyle.Render(fmt.Sprintf("(%dx%d)", m.w, m.h))
+	rem := max(0, m.w-lipgloss.Width(left))
 
-	rem := m.w - lipgloss.Width(left)
-	if rem < 0 {
-		rem = 0
+	// fall back to short names, then drop the terminal size, before tabs
+	// get cut off
+	if !m.compact() && lipgloss.Width(strings.Join(tabs, " ")) > rem {
+		tabs = tabs[:0]
+		for t := tab(0); t < tabCount; t++ {
+			if m.tabHidden(t) {
+				continue
+			}
+			tabs = append(tabs, renderTab(fmt.Sprintf("%d %s", t+1, m.tabLabel(t, tabNames[t].short)), m.activeTab == t))
+		}
+		if lipgloss.Width(strings.Join(tabs, " ")) > rem {
+			left = strings.Replace(left, size, "", 1)
+			rem = max(0, m.w-lipgloss.Width(left))
+		}
 	}
 
-	right := joinTabsWithinWidth(tabs, rem)
+	right := joinTabsWithinWidth(tabs, active, rem)
 
 	line := left + padTo(rem, right)
 	return lipgloss.NewStyle().Width(m.w).Render(line)
@@ -579,10 +2030,29 @@ func renderTab(s string, active bool) string {
 	return subtleStyle.Padding(0, 1).Render(s)
 }
 
+func renderTabCompact(s string, active bool) string {
+	if active {
+		return selectedStyle.Render(s)
+	}
+	return subtleStyle.Render(s)
+}
+
 func (m Model) viewOverview() string {
 	if m.lastSnap.TakenAt.IsZero() {
-		return boxStyle.Render("Collecting data…")
+		return boxStyle.Render(i18n.T("Collecting data…"))
 	}
+	m.setOverviewContent()
+	return boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(m.overviewVP.View())
+}
+
+// setOverviewContent renders Overview into its viewport, which it outgrows
+// on all but the tallest terminals. Its lines change with every sample, so
+// it is rendered where it is shown or scrolled rather than kept up to date.
+func (m *Model) setOverviewContent() {
+	m.overviewVP.SetContent(hardClipLinesToWidth(m.renderOverviewText(), m.overviewVP.Width))
+}
+
+func (m Model) renderOverviewText() string {
 
 	up, down := 0, 0
 	for _, ii := range m.lastSnap.Ifaces {
@@ -594,44 +2064,77 @@ func (m Model) viewOverview() string {
 	}
 
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("Host: %s\n", okStyle.Render(m.lastSnap.Hostname)))
-	b.WriteString(fmt.Sprintf("Uptime: %s\n", m.lastSnap.Uptime.Truncate(time.Second)))
-	b.WriteString(fmt.Sprintf("Time: %s\n", m.lastSnap.TakenAt.Format("2006-01-02 15:04:05 -07:00")))
-	b.WriteString(fmt.Sprintf("Ifaces: %d total  (%s up, %s down)\n\n",
+	b.WriteString(fmt.Sprintf(i18n.T("Host: %s")+"\n", okStyle.Render(m.lastSnap.Hostname)))
+	b.WriteString(fmt.Sprintf(i18n.T("Uptime: %s")+"\n", humanDuration(m.lastSnap.Uptime)))
+	b.WriteString(fmt.Sprintf(i18n.T("Time: %s")+"\n", i18n.DateTime(m.lastSnap.TakenAt)))
+	b.WriteString(m.renderTimeSyncLine() + "\n")
+	if g := m.renderGeoIPLine(); g != "" {
+		b.WriteString(g + "\n")
+	}
+	b.WriteString(fmt.Sprintf(i18n.T("Ifaces: %d total  (%s up, %s down)")+"\n",
 		len(m.lastSnap.Ifaces),
 		okStyle.Render(fmt.Sprintf("%d", up)),
 		subtleStyle.Render(fmt.Sprintf("%d", down)),
 	))
+	b.WriteString(m.renderIfaceGlance(min(m.w-2, 120)-2) + "\n")
 
-	b.WriteString(titleStyle.Render("Selected interface") + "\n")
+	b.WriteString(titleStyle.Render(i18n.T("Selected interface")) + "  " + subtleStyle.Render(i18n.T("i change")) + "\n")
 	b.WriteString(m.renderIfaceDetailsText())
 	b.WriteString("\n")
 
+	b.WriteString(m.renderKindBreakdown(min(m.w-2, 120) - 2))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderSessionTotals(min(m.w-2, 120) - 2))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderConnRate(min(m.w-2, 120) - 2))
+	b.WriteString("\n")
+
+	b.WriteString(m.renderSoftnet())
+	b.WriteString("\n")
+
+	b.WriteString(m.renderDNSConfig(min(m.w-2, 120) - 2))
+	b.WriteString("\n")
+
 	ext := m.externalIP
 	if ext == "" {
 		ext = "…"
+		if m.metered {
+			ext = i18n.T("not checked on a metered connection")
+		}
+	}
+	line := fmt.Sprintf(i18n.T("External IP: %s"), ext)
+	if g := m.geoOf(m.externalIP); !g.IsZero() {
+		line += "  " + okStyle.Render(g.String())
 	}
-	line := fmt.Sprintf("External IP: %s", ext)
 	if !m.externalIPUpdatedAt.IsZero() {
-		line += fmt.Sprintf("  (updated %s)", m.externalIPUpdatedAt.Format("15:04:05"))
+		line += "  " + fmt.Sprintf(i18n.T("(updated %s)"), m.ago(m.externalIPUpdatedAt))
 	}
 	b.WriteString(line + "\n")
+	if l := m.renderExternalIPv6(); l != "" {
+		b.WriteString(l + "\n")
+	}
+	b.WriteString(m.renderIPChanges())
 	if m.externalIPErr != nil {
-		b.WriteString(fmt.Sprintf("External IP error: %s\n", subtleStyle.Render(m.externalIPErr.Error())))
+		errText := m.externalIPErr.Error()
+		if m.extIPFails > 1 {
+			errText += "  " + fmt.Sprintf(i18n.T("(retry %s, ctrl+e now)"), i18n.Clock(m.extIPRetryAt))
+		}
+		b.WriteString(fmt.Sprintf(i18n.T("External IP error: %s")+"\n", subtleStyle.Render(errText)))
 	}
-
-	return boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(b.String())
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 func (m Model) viewIfaces() string {
-	leftW := max(26, m.w/3)
-	bodyH := m.bodyHeight()
-
-	left := boxStyle.Width(leftW).Height(bodyH).Render(m.ifaceList.View())
+	listW, listH, detW, detH := m.ifacePanes()
 
-	rightW := m.w - leftW - 3
+	left := boxStyle.Width(listW).Height(listH).Render(m.ifaceList.View())
+	right := boxStyle.Width(detW).Height(detH).Render(m.ifaceDetailsVP.View())
 
-	right := boxStyle.Width(rightW).Height(bodyH).Render(m.ifaceDetailsVP.View())
+	if m.compact() {
+		return lipgloss.JoinVertical(lipgloss.Left, left, right)
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
 }
 
@@ -640,19 +2143,28 @@ func (m Model) viewPorts() string {
 	portsH := m.bodyHeight()
 
 	if m.portsText == "" {
-		m.portsText = m.renderPortsText()
-		m.portsVP.SetContent(m.portsText)
+		m.setPortsContent()
 	}
 
-	searchLine := subtleStyle.Render("Press / to search")
+	searchLine := subtleStyle.Render(i18n.T("Press / to search"))
 	if m.portsQuery != "" {
-		searchLine = subtleStyle.Render("Filter: ") + titleStyle.Render(m.portsQuery) + subtleStyle.Render("  (press / to change, ctrl+u to clear)")
+		searchLine = subtleStyle.Render(i18n.T("Filter: ")) + titleStyle.Render(m.portsQuery) + subtleStyle.Render(m.filterHint())
+	}
+	if m.portsExpand {
+		searchLine += subtleStyle.Render("  •  " + i18n.T("w collapse wildcards"))
+	} else {
+		searchLine += subtleStyle.Render("  •  " + i18n.T("w expand wildcards"))
 	}
+	searchLine += subtleStyle.Render("  •  " + i18n.T("o open URL"))
+	searchLine += m.markedNote(tabPorts)
 	if m.portsSearching {
 		searchLine = m.portsSearch.View()
 	}
 
-	content := searchLine + "\n\n" + m.portsVP.View()
+	content := searchLine + "\n\n" + stickyHead(m.portsVP, m.portsText, m.portsHead)
+	if m.urlPicker.open {
+		content = m.viewURLPicker()
+	}
 	return boxStyle.Width(portsW).Height(portsH).Render(content)
 }
 
@@ -661,24 +2173,49 @@ func (m Model) viewProcs() string {
 	procsH := m.bodyHeight()
 
 	if m.procsText == "" {
-		m.procsText = m.renderProcsText()
-		m.procsVP.SetContent(m.procsText)
+		m.setProcsContent()
 	}
 
-	searchLine := subtleStyle.Render("Press / to search")
+	searchLine := subtleStyle.Render(i18n.T("Press / to search"))
 	if m.procsQuery != "" {
-		searchLine = subtleStyle.Render("Filter: ") + titleStyle.Render(m.procsQuery) + subtleStyle.Render("  (press / to change, ctrl+u to clear)")
+		searchLine = subtleStyle.Render(i18n.T("Filter: ")) + titleStyle.Render(m.procsQuery) + subtleStyle.Render(m.filterHint())
+	}
+	if !m.procsGrouped {
+		searchLine += subtleStyle.Render("  •  " + i18n.T("g group"))
 	}
+	searchLine += m.markedNote(tabProcs)
 	if m.procsSearching {
 		searchLine = m.procsSearch.View()
 	}
 
-	content := searchLine + "\n\n" + m.procsVP.View()
+	content := searchLine + "\n\n" + stickyHead(m.procsVP, m.procsText, m.procsHead)
 	return boxStyle.Width(procsW).Height(procsH).Render(content)
 }
 
+func (m Model) filterHint() string {
+	if m.compact() {
+		return "  " + i18n.T("(/ change, ^u clear)")
+	}
+	return "  " + i18n.T("(press / to change, ctrl+u to clear)")
+}
+
 func (m Model) renderPortsText() string {
+	s, _, _ := m.renderPorts()
+	return s
+}
+
+// renderPorts also returns the row key of each line ("" for headers and
+// sub-rows), used to keep the scroll anchored across refreshes, and the
+// line of the column header.
+func (m Model) renderPorts() (string, []string, int) {
 	var b strings.Builder
+	var keys []string
+	key := func(k string) {
+		for n := strings.Count(b.String(), "\n"); len(keys) < n; {
+			keys = append(keys, "")
+		}
+		keys = append(keys, k)
+	}
 
 	w := m.portsVP.Width
 	if w <= 0 {
@@ -688,25 +2225,48 @@ func (m Model) renderPortsText() string {
 	colProto := 4
 	colLocal := min(38, max(18, w-4-2-7-1-12))
 	colPID := 7
+	hProc := i18n.T("PROCESS")
+
+	b.WriteString(m.renderTunnels(w))
+
+	if m.compact() {
+		// narrow columns so the process name still gets some room
+		colProto = 3
+		colPID = 6
+		colLocal = min(38, max(12, w-3-2-6-1-10))
+		hProc = i18n.T("PROC")
+		b.WriteString(i18n.T("Listening ports") + "\n\n")
+	} else {
+		b.WriteString(i18n.T("Open listening ports") + "\n")
+		b.WriteString(i18n.T("↑↓ select • space mark • enter details") + "  " + sortHint() + "\n\n")
+	}
 
-	b.WriteString("Open listening ports\n")
-	b.WriteString("Scroll: ↑↓ PgUp/PgDn Home/End\n\n")
+	colSeen := 0
+	if !m.compact() {
+		colSeen = 14
+	}
 
-	hProto := padRight("PR", colProto)
-	hLocal := padRight("LOCAL", colLocal)
-	hPID := padRight("PID", colPID)
-	hProc := "PROCESS"
-	b.WriteString(fmt.Sprintf("%s  %s  %s %s\n", hProto, hLocal, hPID, hProc))
-	b.WriteString(strings.Repeat("─", min(w, colProto+2+colLocal+2+colPID+1+len(hProc))) + "\n")
+	hProto := padRight(m.portsSort.header(i18n.T("PR"), portsByProto), colProto)
+	hLocal := padRight(m.portsSort.header(i18n.T("LOCAL"), portsByPort), colLocal)
+	hPID := padRight(m.portsSort.header(i18n.T("PID"), portsByPID), colPID)
+	hProc = m.portsSort.header(hProc, portsByProcess)
+	hSeen := ""
+	if colSeen > 0 {
+		hSeen = padRight(i18n.T("SEEN"), colSeen) + " "
+	}
+	head := strings.Count(b.String(), "\n")
+	b.WriteString(fmt.Sprintf("%s  %s  %s %s%s\n", hProto, hLocal, hPID, hSeen, hProc))
+	b.WriteString(strings.Repeat("─", min(w, colProto+2+colLocal+2+colPID+1+utf8.RuneCountInString(hSeen)+utf8.RuneCountInString(hProc))) + "\n")
 
 	if len(m.ports) == 0 {
-		b.WriteString("No data (yet)…\n")
-		return b.String()
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return b.String(), nil, head
 	}
 
 	q := m.portsQuery
+	now := m.now()
 
-	for _, p := range m.ports {
+	writePort := func(p probe.ListenPort, seen *portSeen) {
 		proc := p.Process
 		if proc == "" {
 			proc = "-"
@@ -716,33 +2276,89 @@ func (m Model) renderPortsText() string {
 			local = "-"
 		}
 
-		if q != "" && !(containsFold(local, q) || containsFold(proc, q) || containsFold(p.Proto, q)) {
-			continue
+		var reach []string
+		if m.portsExpand && (seen == nil || !seen.gone) {
+			reach = probe.ReachableAddrs(p, m.lastSnap.Ifaces)
+		}
+
+		note := m.portNote(p)
+		if !m.portMatches(p, reach) {
+			return
 		}
 
 		proto := padRight(trunc(p.Proto, colProto), colProto)
 
 		localTr := padRight(trunc(local, colLocal), colLocal)
 		procTr := proc
-		rest := w - (colProto + 2 + colLocal + 2 + colPID + 1)
+		rest := w - (colProto + 2 + colLocal + 2 + colPID + 1 + colSeen)
 		if rest < 5 {
 			rest = 5
 		}
+		if note != "" {
+			procTr += "  # " + note
+		}
 		procTr = trunc(procTr, rest)
 
-		localTr = highlightFold(localTr, q)
-		procTr = highlightFold(procTr, q)
+		selected := listenerKey(p) == m.portsSel
+		if !selected {
+			localTr = highlightFold(localTr, q)
+			procTr = highlightFold(procTr, q)
+		}
 
 		pid := padRight(fmt.Sprintf("%d", p.PID), colPID)
 
-		b.WriteString(fmt.Sprintf("%s  %s  %s %s\n", proto, localTr, pid, procTr))
+		seenS := ""
+		if colSeen > 0 {
+			seenS = padRight(trunc(seen.label(now), colSeen), colSeen) + " "
+		}
+
+		row := fmt.Sprintf("%s  %s  %s %s%s", proto, localTr, pid, seenS, procTr)
+		switch {
+		case selected:
+			row = selectedStyle.Render(row)
+		case m.portsMarked[listenerKey(p)]:
+			row = markedStyle.Render(row)
+		case seen != nil && seen.gone:
+			row = subtleStyle.Render(row)
+		}
+		key(listenerKey(p))
+		b.WriteString(row + "\n")
+
+		for _, a := range reach {
+			sub := highlightFold(padRight(trunc(" └ "+a, colLocal), colLocal), q)
+			if ip, _ := probe.SplitLocal(a); m.hostName(ip) != "" {
+				sub += "  " + subtleStyle.Render(m.hostName(ip))
+			}
+			b.WriteString(fmt.Sprintf("%s  %s\n", strings.Repeat(" ", colProto), sub))
+		}
 	}
 
-	return b.String()
+	for _, p := range m.ports {
+		writePort(p, m.portTimeline.get(p))
+	}
+	for _, ps := range m.portTimeline.goneList() {
+		writePort(ps.port, ps)
+	}
+
+	return b.String(), keys, head
 }
 
 func (m Model) renderProcsText() string {
+	s, _, _ := m.renderProcs()
+	return s
+}
+
+// renderProcs is renderProcsText plus the row key of each line and the line
+// of the column header.
+func (m Model) renderProcs() (string, []string, int) {
 	var b strings.Builder
+	var keys []string
+	key := func(k string) {
+		for n := strings.Count(b.String(), "\n"); len(keys) < n; {
+			keys = append(keys, "")
+		}
+		keys = append(keys, k)
+	}
 
 	w := m.procsVP.Width
 	if w <= 0 {
@@ -751,10 +2367,28 @@ func (m Model) renderProcsText() string {
 
 	colPID := 7
 	colConns := 6
-	colListen := 6
-
+	colListen := 7
+	colRate := 11
 	minName := 16
-	colName := w - (colPID + 2 + colConns + 2 + colListen)
+	hConns, hListen := i18n.T("CONNS"), i18n.T("LISTEN")
+
+	if m.compact() {
+		colPID = 6
+		colConns = 4
+		colListen = 4
+		colRate = 10
+		minName = 10
+		hConns, hListen = i18n.T("CON"), i18n.T("LSN")
+	}
+
+	// throughput columns only when the bandwidth sampler works here
+	showBW := m.procBW != nil
+	rateW := 0
+	if showBW {
+		rateW = 2 * (colRate + 2)
+	}
+
+	colName := w - (colPID + 2 + colConns + 2 + colListen + 2 + rateW)
 	if colName < minName {
 		colName = minName
 	}
@@ -762,47 +2396,135 @@ func (m Model) renderProcsText() string {
 		colName = 40
 	}
 
-	b.WriteString("Processes by network connections (proxy)\n")
-	b.WriteString("Scroll: ↑↓ PgUp/PgDn Home/End\n\n")
+	if m.procsGrouped {
+		b.WriteString(i18n.T("Processes grouped by name") + "\n")
+		b.WriteString(subtleStyle.Render(i18n.T("g ungroup • e expand/collapse PIDs")) + "  " + sortHint() + "\n\n")
+	} else if m.compact() {
+		b.WriteString(i18n.T("Processes by connections") + "\n\n")
+	} else {
+		if showBW {
+			b.WriteString(i18n.T("Processes by network connections, with TCP throughput") + "\n")
+		} else {
+			b.WriteString(i18n.T("Processes by network connections (proxy)") + "\n")
+		}
+		b.WriteString(i18n.T("↑↓ select • space mark • enter details • X stop • K kill") + "  " + sortHint() + "\n\n")
+	}
 
-	h := fmt.Sprintf("%s  %s  %s  %s\n",
-		padRight("PID", colPID),
-		padRight("NAME", colName),
-		padRight("CONNS", colConns),
-		padRight("LISTEN", colListen),
+	h := fmt.Sprintf("%s  %s  %s  %s",
+		padRight(m.procsSort.header(i18n.T("PID"), procsByPID), colPID),
+		padRight(m.procsSort.header(i18n.T("NAME"), procsByName), colName),
+		padRight(m.procsSort.header(hConns, procsByConns), colConns),
+		padRight(m.procsSort.header(hListen, procsByListen), colListen),
 	)
-	b.WriteString(h)
-	b.WriteString(strings.Repeat("─", min(w, colPID+2+colName+2+colConns+2+colListen)) + "\n")
+	if showBW {
+		h += fmt.Sprintf("  %s  %s", padRight("RX/s", colRate), padRight("TX/s", colRate))
+	}
+	head := strings.Count(b.String(), "\n")
+	b.WriteString(h + "\n")
+	b.WriteString(strings.Repeat("─", min(w, colPID+2+colName+2+colConns+2+colListen+rateW)) + "\n")
 
 	if len(m.procs) == 0 {
-		b.WriteString("No data (yet)…\n")
-		return b.String()
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return b.String(), nil, head
 	}
 
 	q := m.procsQuery
 
-	for _, p := range m.procs {
-		name := p.Name
-		if name == "" {
-			name = "-"
+	writeRow := func(pid, name string, conns, listen int, bw probe.ProcBandwidth) {
+		key(pid + " " + name)
+		selected := pid+" "+name == m.procsSel
+		pidS := padRight(trunc(pid, colPID), colPID)
+		nameS := padRight(trunc(name, colName), colName)
+		conS := padRight(trunc(fmt.Sprintf("%d", conns), colConns), colConns)
+		lisS := padRight(trunc(fmt.Sprintf("%d", listen), colListen), colListen)
+
+		if !selected {
+			nameS = highlightFold(nameS, q)
+			pidS = highlightFold(pidS, q)
+		}
+
+		row := fmt.Sprintf("%s  %s  %s  %s", pidS, nameS, conS, lisS)
+		if showBW {
+			row += fmt.Sprintf("  %s  %s", padRight(procRate(bw.RxBps, m.units), colRate), padRight(procRate(bw.TxBps, m.units), colRate))
+		}
+		if selected {
+			row = selectedStyle.Render(row)
+		} else if m.procsMarked[pid] || strings.HasPrefix(pid, "×") && m.procsMarked.all(m.groupPIDs(name)) {
+			row = markedStyle.Render(row)
+		}
+		b.WriteString(row + "\n")
+	}
+
+	if m.procsGrouped {
+		for _, g := range groupProcs(m.procs, m.procsSort) {
+			name := procName(g.name)
+			if q != "" && !containsFold(name, q) && !groupHasPID(g, q) {
+				continue
+			}
+			if len(g.members) == 1 {
+				p := g.members[0]
+				writeRow(fmt.Sprintf("%d", p.PID), name, p.ConnCount, p.ListenCount, m.procBW[p.PID])
+				continue
+			}
+			var sum probe.ProcBandwidth
+			for _, p := range g.members {
+				sum.RxBps += m.procBW[p.PID].RxBps
+				sum.TxBps += m.procBW[p.PID].TxBps
+			}
+			writeRow(fmt.Sprintf("×%d", len(g.members)), name, g.connCount, g.listenCount, sum)
+			if m.procsExpanded {
+				for _, p := range g.members {
+					writeRow(fmt.Sprintf("%d", p.PID), " └ "+name, p.ConnCount, p.ListenCount, m.procBW[p.PID])
+				}
+			}
 		}
+		return b.String(), keys, head
+	}
 
-		if q != "" && !(containsFold(name, q) || containsFold(fmt.Sprintf("%d", p.PID), q)) {
+	for _, p := range m.procs {
+		if !m.procMatches(p) {
 			continue
 		}
+		writeRow(fmt.Sprintf("%d", p.PID), procName(p.Name), p.ConnCount, p.ListenCount, m.procBW[p.PID])
+	}
 
-		pidS := padRight(trunc(fmt.Sprintf("%d", p.PID), colPID), colPID)
-		nameS := padRight(trunc(name, colName), colName)
-		conS := padRight(trunc(fmt.Sprintf("%d", p.ConnCount), colConns), colConns)
-		lisS := padRight(trunc(fmt.Sprintf("%d", p.ListenCount), colListen), colListen)
+	return b.String(), keys, head
+}
+
+// portMatches applies the ports search; reach are the expanded addresses
+// of a wildcard listener, which are searchable too.
+func (m Model) portMatches(p probe.ListenPort, reach []string) bool {
+	q := m.portsQuery
+	if q == "" {
+		return true
+	}
+	proc := p.Process
+	if proc == "" {
+		proc = "-"
+	}
+	return containsFold(p.Local, q) || containsFold(proc, q) || containsFold(p.Proto, q) ||
+		anyContainsFold(reach, q) || containsFold(m.portNote(p), q)
+}
 
-		nameS = highlightFold(nameS, q)
-		pidS = highlightFold(pidS, q)
+func (m Model) procMatches(p probe.ProcNet) bool {
+	q := m.procsQuery
+	return q == "" || containsFold(procName(p.Name), q) || containsFold(fmt.Sprintf("%d", p.PID), q)
+}
 
-		b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n", pidS, nameS, conS, lisS))
+func procName(n string) string {
+	if n == "" {
+		return "-"
 	}
+	return n
+}
 
-	return b.String()
+func groupHasPID(g procGroup, q string) bool {
+	for _, p := range g.members {
+		if containsFold(fmt.Sprintf("%d", p.PID), q) {
+			return true
+		}
+	}
+	return false
 }
 
 var hlStyle = lipgloss.NewStyle().Reverse(true)
@@ -852,13 +2574,13 @@ func (m Model) renderIfaceDetailsText() string {
 		}
 	}
 	if ii == nil {
-		return "Select an interface…\n"
+		return i18n.T("Select an interface…") + "\n"
 	}
 
-	state := "DOWN"
+	state := i18n.T("DOWN")
 	st := subtleStyle
 	if ii.IsUp {
-		state = "UP"
+		state = i18n.T("UP")
 		st = okStyle
 	}
 
@@ -868,23 +2590,111 @@ func (m Model) renderIfaceDetailsText() string {
 	}
 	chartW := max(10, avail-6)
 
-	rx := Spark(m.rxHist, chartW)
-	tx := Spark(m.txHist, chartW)
+	rxRate, txRate := humanRate(ii.RxBps, m.units), humanRate(ii.TxBps, m.units)
+	rxHist, txHist := m.rxHist, m.txHist
+	label := func(bps float64) string { return humanRate(bps, m.units) }
+	unitHint := i18n.T("p shows packets/s")
+	if m.ifacePackets {
+		rxRate, txRate = humanPps(ii.RxPps), humanPps(ii.TxPps)
+		rxHist, txHist, label = m.rxPktHist, m.txPktHist, humanPps
+		unitHint = i18n.T("packets/s • p shows data rates")
+	}
+	chartHint := [...]string{
+		chartSpark:   i18n.T("c bar charts"),
+		chartBars:    i18n.T("c braille charts"),
+		chartBraille: i18n.T("c sparklines"),
+	}[m.ifaceChart]
+	// the two charts share what the pane has left below the rest, less
+	// their scale and axis lines; sparklines ignore it
+	link, lease, wifi := m.renderLinkText(ii), m.renderLeaseText(ii), m.renderWifiText(ii, avail)
+	h := max(3, (m.ifaceDetailsVP.Height-14-strings.Count(link+lease+wifi, "\n"))/2-3)
+	rx := strings.TrimSuffix(m.ifaceChartView(rxHist, chartW, h, label), "\n")
+	tx := strings.TrimSuffix(m.ifaceChartView(txHist, chartW, h, label), "\n")
 
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("%s %s  MTU %d\n", st.Render(state), titleStyle.Render(ii.Name), ii.MTU))
 	b.WriteString(fmt.Sprintf("MAC: %s\n", ii.Hardware))
+	b.WriteString(link)
 	if len(ii.Addrs) > 0 {
-		b.WriteString("Addrs: " + strings.Join(ii.Addrs, ", ") + "\n")
+		b.WriteString(i18n.T("Addrs: ") + strings.Join(ii.Addrs, ", ") + "\n")
+	}
+	b.WriteString(lease)
+	b.WriteString(m.renderIfaceExit(*ii))
+	if wifi != "" {
+		b.WriteString("\n" + wifi)
 	}
 	b.WriteString("\n")
-	b.WriteString(fmt.Sprintf("RX: %s\n%s\n\n", probe.HumanBytesPerSec(ii.RxBps), rx))
-	b.WriteString(fmt.Sprintf("TX: %s\n%s\n", probe.HumanBytesPerSec(ii.TxBps), tx))
+	b.WriteString(subtleStyle.Render(unitHint+" • "+chartHint+" • "+fmt.Sprintf(i18n.T("w window %s"), m.ifaceWindow)) + "\n")
+	b.WriteString(fmt.Sprintf("RX: %s\n%s\n", rxRate, rx))
+	if ii.Speed > 0 {
+		b.WriteString(linkGauge(ii.RxBps, ii.Speed, chartW) + "\n")
+	}
+	b.WriteString(fmt.Sprintf("\nTX: %s\n%s\n", txRate, tx))
+	if ii.Speed > 0 {
+		b.WriteString(linkGauge(ii.TxBps, ii.Speed, chartW) + "\n")
+	}
+	b.WriteString("\n" + i18n.T("Errors: ") + linkCounters(ii.Errin, ii.ErrinRate, ii.Errout, ii.ErroutRate) + "\n")
+	b.WriteString(i18n.T("Drops: ") + linkCounters(ii.Dropin, ii.DropinRate, ii.Dropout, ii.DropoutRate) + "\n")
+	b.WriteString(m.renderCastText(ii))
+	if q := m.renderQueueText(ii); q != "" {
+		b.WriteString("\n" + q)
+	}
+	if fw := m.renderFirewallText(ii); fw != "" {
+		b.WriteString("\n" + fw)
+	}
+	b.WriteString("\n" + m.renderRAText(ii.Name))
 	return b.String()
 }
 
 // helpers
 
+// linkGauge shows a byte rate as a share of a link speed given in Mbit/s.
+func linkGauge(bps float64, speed, width int) string {
+	util := bps * 8 / (float64(speed) * 1e6)
+	label := " " + i18n.Number(fmt.Sprintf(i18n.T("%.1f%% of %s"), util*100, linkSpeedLabel(speed)))
+	return gauge(util, max(5, width-lipgloss.Width(label))) + label
+}
+
+// linkCounters shows an in/out pair of error or drop counters, with the
+// rate since the previous sample; non-zero ones are warnings.
+func linkCounters(in uint64, inRate float64, out uint64, outRate float64) string {
+	return counterCell(i18n.T("in"), in, inRate) + "  " + counterCell(i18n.T("out"), out, outRate)
+}
+
+// counterCell shows a counter, after label unless that is empty, with its
+// rate; a non-zero one is a warning.
+func counterCell(label string, n uint64, rate float64) string {
+	s := fmt.Sprint(n)
+	if label != "" {
+		s = label + " " + s
+	}
+	if rate > 0 {
+		s += " " + i18n.Number(fmt.Sprintf(i18n.T("(+%.1f/s)"), rate))
+	}
+	if n == 0 {
+		return subtleStyle.Render(s)
+	}
+	return warnStyle.Render(s)
+}
+
+// linkSpeedLabel formats Mbit/s the way NICs are usually named: 100 Mb/s,
+// 1 Gb/s, 2.5 Gb/s.
+func linkSpeedLabel(speed int) string {
+	if speed >= 1000 {
+		return strconv.FormatFloat(float64(speed)/1000, 'f', -1, 64) + " Gb/s"
+	}
+	return strconv.Itoa(speed) + " Mb/s"
+}
+
+// humanRate renders a byte rate in units u with the locale's decimal
+// separator.
+func humanRate(bps float64, u Units) string {
+	if u == UnitsBits {
+		return i18n.Number(probe.HumanBitsPerSec(bps))
+	}
+	return i18n.Number(probe.HumanBytesPerSec(bps))
+}
+
 func padTo(width int, s string) string {
 	if width <= 0 {
 		return ""
@@ -896,17 +2706,13 @@ func padTo(width int, s string) string {
 	return strings.Repeat(" ", width-w) + s
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-func max(a, b int) int {
-	if a > b {
-		return a
+func anyContainsFold(ss []string, q string) bool {
+	for _, s := range ss {
+		if containsFold(s, q) {
+			return true
+		}
 	}
-	return b
+	return false
 }
 
 func containsFold(s, q string) bool {
@@ -992,45 +2798,64 @@ func ansiSafeTruncate(s string, maxWidth int) string {
 	return out.String()
 }
 
-func joinTabsWithinWidth(tabs []string, maxW int) string {
+// joinTabsWithinWidth joins as many tabs as fit in maxW around the active
+// one, marking a side that is cut off with "…".
+func joinTabsWithinWidth(tabs []string, active, maxW int) string {
 	if maxW <= 0 || len(tabs) == 0 {
 		return ""
 	}
+	active = min(max(active, 0), len(tabs)-1)
 
-	var out strings.Builder
-	used := 0
 	sep := " "
-
-	for i, t := range tabs {
-		tw := lipgloss.Width(t)
-		addSep := i > 0
-		sepW := 0
-		if addSep {
-			sepW = lipgloss.Width(sep)
+	ell := subtleStyle.Render("…")
+	sepW, ellW := lipgloss.Width(sep), lipgloss.Width(ell)
+	width := func(lo, hi int) int {
+		w := 0
+		for i := lo; i <= hi; i++ {
+			w += lipgloss.Width(tabs[i])
 		}
-
-		if used+sepW+tw > maxW {
-			ell := subtleStyle.Render("…")
-			ellW := lipgloss.Width(ell)
-			if used > 0 && used+sepW+ellW <= maxW {
-				out.WriteString(sep)
-				out.WriteString(ell)
-			} else if used == 0 && ellW <= maxW {
-				out.WriteString(ell)
-			}
-			break
+		w += (hi - lo) * sepW
+		if lo > 0 {
+			w += ellW + sepW
 		}
-
-		if addSep {
-			out.WriteString(sep)
-			used += sepW
+		if hi < len(tabs)-1 {
+			w += sepW + ellW
 		}
+		return w
+	}
 
-		out.WriteString(t)
-		used += tw
+	lo, hi := active, active
+	if width(lo, hi) > maxW {
+		// no room for the marks: the active tab alone, if even that fits
+		if lipgloss.Width(tabs[active]) <= maxW {
+			return tabs[active]
+		}
+		if ellW <= maxW {
+			return ell
+		}
+		return ""
+	}
+	// grow right first, so the strip starts at the first tab while it can
+	for grown := true; grown; {
+		grown = false
+		if hi < len(tabs)-1 && width(lo, hi+1) <= maxW {
+			hi++
+			grown = true
+		}
+		if lo > 0 && width(lo-1, hi) <= maxW {
+			lo--
+			grown = true
+		}
 	}
 
-	return out.String()
+	parts := tabs[lo : hi+1]
+	if lo > 0 {
+		parts = append([]string{ell}, parts...)
+	}
+	if hi < len(tabs)-1 {
+		parts = append(parts[:len(parts):len(parts)], ell)
+	}
+	return strings.Join(parts, sep)
 }
 
 func hardClipLinesToWidth(s string, w int) string {
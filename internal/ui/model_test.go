@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+	"github.com/nexusriot/ducknetview/pkg/probe/probetest"
+)
+
+// testProbes feeds every probe of the UI from f.
+func testProbes(f *probetest.Probes) Probes {
+	return Probes{
+		Net: f, Ports: f, Inspect: f, Procs: f, Stop: f, Block: f, Detail: f,
+		ConnRate: f, ICMP: f, Routes: f, Rules: f, RA: f, Conns: f, ConnOpts: f,
+		Metered: f, BGP: f, ProcBW: f, PeerBW: f, Tunnels: f, Firewall: f,
+		Casts: f, Softnet: f, Flows: f, Queues: f, Wifi: f, Link: f,
+		DHCP: f, Eyeballs: f, Neigh: f, RDNS: f, Geo: f, TimeSync: f, Ping: f,
+		Trace: f, DNSCache: f, DNSConf: f, Sniff: f, Ephemeral: f,
+	}
+}
+
+// newTestModel is a model of the probetest fixture at its fixed clock,
+// sized w×h, with the first answer of every probe in and the reverse
+// lookups of the addresses it shows answered.
+func newTestModel(t *testing.T, w, h int, opts Options) Model {
+	t.Helper()
+	return newFixtureModel(t, probetest.Fixture(), w, h, opts)
+}
+
+// newFixtureModel is newTestModel on f, for tests that change the fixture
+// or look at what the model did to it.
+func newFixtureModel(t *testing.T, f *probetest.Probes, w, h int, opts Options) Model {
+	t.Helper()
+	opts.Probes = testProbes(f)
+	opts.Clock = probetest.NewClock().Now
+	m := NewModel(opts)
+	m = apply(m, tea.WindowSizeMsg{Width: w, Height: h})
+	for _, cmd := range []tea.Cmd{
+		m.refreshCmd(), m.fetchPortsCmd(), m.fetchProcsCmd(), m.fetchConnRateCmd(), m.fetchProcConnRatesCmd(),
+		m.fetchICMPCmd(), m.fetchRoutesCmd(), m.fetchRulesCmd(), m.waitRACmd(), m.fetchConnsCmd(),
+		m.fetchProcBWCmd(), m.fetchPeerBWCmd(), m.fetchTunnelsCmd(nil), m.fetchFirewallCmd(), m.fetchFirewallRulesCmd(),
+		m.fetchNeighborsCmd(), m.checkTimeSyncCmd(), m.fetchDNSCacheCmd(), m.fetchDNSConfigCmd(), m.fetchFlowsCmd(),
+		m.fetchSoftnetCmd(), m.fetchEphemeralRangeCmd(),
+	} {
+		m = apply(m, cmd())
+	}
+	// the per-interface probes of the selected interface
+	for _, cmd := range []tea.Cmd{m.fetchCastsCmd(), m.fetchQueuesCmd(), m.fetchWifiCmd(), m.fetchLinkCmd(), m.fetchLeaseCmd()} {
+		if cmd != nil {
+			m = apply(m, cmd())
+		}
+	}
+	return settleRDNS(m)
+}
+
+// settleRDNS renders every tab, which queues the reverse lookups of the
+// addresses on it, until a round of renders queues none.
+func settleRDNS(m Model) Model {
+	at := m.activeTab
+	for {
+		for tb := tab(0); tb < tabCount; tb++ {
+			m.setTab(tb)
+			m.View()
+		}
+		var msg rdnsMsg
+	drain:
+		for {
+			select {
+			case res := <-m.rdns.Done():
+				msg = append(msg, res)
+			case <-time.After(50 * time.Millisecond):
+				break drain
+			}
+		}
+		if len(msg) == 0 {
+			m.setTab(at)
+			return m
+		}
+		m.applyRDNS(msg)
+	}
+}
+
+func apply(m Model, msg tea.Msg) Model {
+	nm, _ := m.Update(msg)
+	return nm.(Model)
+}
+
+// keyMsg is the key named as tea.KeyMsg.String() names it.
+func keyMsg(k string) tea.KeyMsg {
+	named := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEscape, "tab": tea.KeyTab, "shift+tab": tea.KeyShiftTab,
+		"up": tea.KeyUp, "down": tea.KeyDown, "pgup": tea.KeyPgUp, "pgdown": tea.KeyPgDown, "home": tea.KeyHome, "end": tea.KeyEnd, "left": tea.KeyLeft, "right": tea.KeyRight,
+		" ": tea.KeySpace, "ctrl+c": tea.KeyCtrlC, "ctrl+u": tea.KeyCtrlU, "ctrl+e": tea.KeyCtrlE,
+	}
+	if t, ok := named[k]; ok {
+		if t == tea.KeySpace {
+			return tea.KeyMsg{Type: t, Runes: []rune(k)}
+		}
+		return tea.KeyMsg{Type: t}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// press sends the keys in turn and returns the model and the command of
+// the last one.
+func press(m Model, keys ...string) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var nm tea.Model
+		nm, cmd = m.Update(keyMsg(k))
+		m = nm.(Model)
+	}
+	return m, cmd
+}
+
+// quits reports whether cmd, or one it batches, quits the program. Commands
+// that wait, as ticks do, count as not quitting.
+func quits(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	ch := make(chan tea.Msg, 1)
+	go func() { ch <- cmd() }()
+	select {
+	case msg := <-ch:
+		switch msg := msg.(type) {
+		case tea.QuitMsg:
+			return true
+		case tea.BatchMsg:
+			for _, c := range msg {
+				if quits(c) {
+					return true
+				}
+			}
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+	return false
+}
+
+func TestKeysTypedIntoSearch(t *testing.T) {
+	tests := []struct {
+		name  string
+		tab   tab
+		open  []string
+		value func(Model) string
+	}{
+		{"interface filter", tabIfaces, []string{"/"}, func(m Model) string { return m.ifaceList.FilterInput.Value() }},
+		{"ports search", tabPorts, []string{"/"}, func(m Model) string { return m.portsSearch.Value() }},
+		{"processes search", tabProcs, []string{"/"}, func(m Model) string { return m.procsSearch.Value() }},
+		{"flows search", tabFlows, []string{"/"}, func(m Model) string { return m.flowsSearch.Value() }},
+	}
+	const typed = "fvmxnPupcwq+ "
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModel(t, 120, 40, Options{Quit: QuitImmediate})
+			m.setTab(tt.tab)
+			m, _ = press(m, tt.open...)
+			if !m.searching() {
+				t.Fatal("searching() = false after opening the search")
+			}
+			hideVirtual, metered, refresh := m.hideVirtual, m.metered, m.opts.Refresh
+			for _, k := range typed {
+				var cmd tea.Cmd
+				m, cmd = press(m, string(k))
+				if quits(cmd) {
+					t.Fatalf("%q quit the program", k)
+				}
+			}
+			if got := tt.value(m); got != typed {
+				t.Errorf("search = %q, want %q", got, typed)
+			}
+			switch {
+			case m.frozen[tt.tab] != nil:
+				t.Error("f froze the tab")
+			case m.paused:
+				t.Error("P paused the refreshes")
+			case m.hideVirtual != hideVirtual:
+				t.Error("v toggled virtual interfaces")
+			case m.metered != metered:
+				t.Error("m toggled metered mode")
+			case m.opts.Refresh != refresh:
+				t.Error("+ changed the refresh interval")
+			case m.export.open || m.noting || m.help.open:
+				t.Error("a letter opened a dialog")
+			}
+		})
+	}
+}
+
+func TestQuitKey(t *testing.T) {
+	for _, tt := range []struct {
+		mode    QuitMode
+		quits   bool
+		confirm bool
+	}{
+		{QuitDisabled, false, false},
+		{QuitImmediate, true, false},
+		{QuitConfirm, false, true},
+	} {
+		m := newTestModel(t, 80, 24, Options{Quit: tt.mode})
+		m, cmd := press(m, "q")
+		if got := quits(cmd); got != tt.quits {
+			t.Errorf("%v: q quits = %v, want %v", tt.mode, got, tt.quits)
+		}
+		if m.confirmingQuit != tt.confirm {
+			t.Errorf("%v: q asks = %v, want %v", tt.mode, m.confirmingQuit, tt.confirm)
+		}
+		if _, cmd := press(m, "ctrl+c"); !quits(cmd) {
+			t.Errorf("%v: ctrl+c didn't quit", tt.mode)
+		}
+	}
+}
+
+// blockingSampler samples once release is closed, counting the calls.
+type blockingSampler struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *blockingSampler) Sample() (probe.NetSnapshot, error) {
+	s.calls.Add(1)
+	<-s.release
+	return probe.NetSnapshot{TakenAt: probetest.Epoch}, nil
+}
+
+func TestRefreshWhileSampling(t *testing.T) {
+	s := &blockingSampler{release: make(chan struct{})}
+	m := NewModel(Options{Probes: Probes{Net: s}})
+
+	first := make(chan tea.Msg)
+	go func() { first <- m.refreshCmd()() }()
+	for s.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// v, u, waking up or resuming while the tick's sample runs
+	if msg := m.refreshCmd()(); msg != nil {
+		t.Errorf("second refresh = %T, want none", msg)
+	}
+	close(s.release)
+	if _, ok := (<-first).(snapMsg); !ok {
+		t.Error("the first refresh brought no snapshot")
+	}
+	if n := s.calls.Load(); n != 1 {
+		t.Errorf("Sample called %d times, want 1", n)
+	}
+	if _, ok := m.refreshCmd()().(snapMsg); !ok {
+		t.Error("no snapshot from a refresh after the first was in")
+	}
+}
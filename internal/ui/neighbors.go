@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type neighborsMsg struct {
+	list []probe.Neighbor
+	err  error
+}
+
+func (m Model) fetchNeighborsCmd() tea.Cmd {
+	return func() tea.Msg {
+		ns, err := m.neighReader.Neighbors()
+		return neighborsMsg{list: ns, err: err}
+	}
+}
+
+// lanWatch remembers the MAC addresses seen in the neighbor table. The
+// first table read is the baseline; MACs turning up after it are new.
+// Shared by pointer like sessionStats.
+type lanWatch struct {
+	seen   map[string]bool // iface + MAC
+	fresh  map[string]bool // the ones that arrived after the baseline
+	primed bool
+}
+
+func newLANWatch() *lanWatch {
+	return &lanWatch{seen: map[string]bool{}, fresh: map[string]bool{}}
+}
+
+func neighborKey(n probe.Neighbor) string {
+	return n.Iface + " " + strings.ToLower(n.MAC)
+}
+
+func (m *Model) applyNeighbors(msg neighborsMsg) {
+	m.neigh, m.neighErr = msg.list, msg.err
+	if m.neigh == nil && m.neighErr == nil {
+		m.neigh = []probe.Neighbor{}
+	}
+
+	lw := m.lan
+	if msg.err == nil && m.opts.WatchLAN {
+		var logged bool
+		for _, n := range msg.list {
+			k := neighborKey(n)
+			if lw.seen[k] {
+				continue
+			}
+			lw.seen[k] = true
+			if lw.primed {
+				lw.fresh[k] = true
+				text := fmt.Sprintf(i18n.T("new device on %s: %s (%s)"), n.Iface, n.MAC, n.IP)
+				m.events.add(m.now(), evAlert, text)
+				m.alert, m.alertAt = text, m.now()
+				logged = true
+			}
+		}
+		lw.primed = true
+		if logged {
+			m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+		}
+	}
+	m.setRoutingContent()
+}
+
+// neighborsTable returns the neighbor table for export.
+func (m Model) neighborsTable() table {
+	t := table{name: "neighbors", header: []string{"iface", "family", "ip", "mac", "state", "note"}}
+	for _, n := range m.neigh {
+		t.rows = append(t.rows, []string{n.Iface, n.Family, n.IP, n.MAC, n.State, m.opts.Notes.Host(n.IP)})
+	}
+	return t
+}
+
+func (m Model) renderNeighborsText(w int) string {
+	var b strings.Builder
+	b.WriteString("\n" + titleStyle.Render(i18n.T("Neighbors (ARP/NDP)")))
+	if m.opts.WatchLAN {
+		b.WriteString("  " + subtleStyle.Render(fmt.Sprintf(i18n.T("watching for new devices • %d seen, %d new"), len(m.lan.seen), len(m.lan.fresh))))
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.neighErr != nil:
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.neighErr.Error()) + "\n")
+		return b.String()
+	case m.neigh == nil:
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return b.String()
+	case len(m.neigh) == 0:
+		b.WriteString(subtleStyle.Render(i18n.T("no neighbors")) + "\n")
+		return b.String()
+	}
+
+	const colDev, colIP, colMAC, colState = 12, 28, 19, 11
+	b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n",
+		padRight(sorted(i18n.T("DEV"), false), colDev),
+		padRight(i18n.T("ADDRESS"), colIP),
+		padRight(i18n.T("MAC"), colMAC),
+		i18n.T("STATE"),
+	))
+	b.WriteString(strings.Repeat("─", min(w, colDev+2+colIP+2+colMAC+2+colState)) + "\n")
+	for _, n := range m.neigh {
+		var extra []string
+		if m.lan.fresh[neighborKey(n)] {
+			extra = append(extra, warnStyle.Render(i18n.T("new")))
+		}
+		if note := m.opts.Notes.Host(n.IP); note != "" {
+			extra = append(extra, okStyle.Render("# "+note))
+		}
+		state := strings.ToLower(n.State)
+		if len(extra) > 0 {
+			state = padRight(state, colState) + "  " + strings.Join(extra, "  ")
+		}
+		row := fmt.Sprintf("%s  %s  %s  %s",
+			padRight(trunc(n.Iface, colDev), colDev),
+			padRight(trunc(n.IP, colIP), colIP),
+			padRight(n.MAC, colMAC),
+			state,
+		)
+		b.WriteString(row + "\n")
+	}
+	return b.String()
+}
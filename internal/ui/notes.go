@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/notes"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// openNotePrompt shows the "<ip|port> <note>" input in the footer.
+func (m *Model) openNotePrompt() {
+	in := textinput.New()
+	in.Prompt = i18n.T("note: ")
+	in.Placeholder = i18n.T("8080 dev server  •  203.0.113.5 backup box  •  empty note removes")
+	in.CharLimit = 120
+	in.Focus()
+	m.notePrompt, m.noting, m.noteTargets = in, true, nil
+}
+
+func (m Model) updateNotePrompt(km tea.KeyMsg) (Model, tea.Cmd) {
+	switch km.String() {
+	case "esc":
+		m.noting = false
+		return m, nil
+	case "enter":
+		m.noting = false
+		if ts := m.noteTargets; len(ts) > 0 {
+			m.clearMarks(m.activeTab)
+			return m, saveNotesCmd(m.opts.Notes, ts, strings.TrimSpace(m.notePrompt.Value()))
+		}
+		target, note, _ := strings.Cut(strings.TrimSpace(m.notePrompt.Value()), " ")
+		return m, saveNoteCmd(m.opts.Notes, target, note)
+	}
+	var cmd tea.Cmd
+	m.notePrompt, cmd = m.notePrompt.Update(km)
+	return m, cmd
+}
+
+// noteSavedMsg reports a stored note; views showing notes re-render.
+type noteSavedMsg string
+
+func saveNoteCmd(s *notes.Store, target, note string) tea.Cmd {
+	return func() tea.Msg {
+		key, err := notes.ParseTarget(target)
+		if err == nil {
+			err = s.Set(key, note)
+		}
+		if err != nil {
+			return noticeMsg{err: err}
+		}
+		if strings.TrimSpace(note) == "" {
+			return noteSavedMsg(fmt.Sprintf(i18n.T("note for %s removed"), target))
+		}
+		return noteSavedMsg(fmt.Sprintf(i18n.T("note for %s saved"), target))
+	}
+}
+
+// portNote returns the note attached to a listener's port.
+func (m Model) portNote(p probe.ListenPort) string {
+	_, port := probe.SplitLocal(p.Local)
+	return m.opts.Notes.Port(p.Proto, port)
+}
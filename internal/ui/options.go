@@ -0,0 +1,422 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/blocklist"
+	"github.com/nexusriot/ducknetview/internal/execprobe"
+	"github.com/nexusriot/ducknetview/internal/extip"
+	"github.com/nexusriot/ducknetview/internal/geoip"
+	"github.com/nexusriot/ducknetview/internal/iphistory"
+	"github.com/nexusriot/ducknetview/internal/notes"
+	"github.com/nexusriot/ducknetview/internal/porthistory"
+	"github.com/nexusriot/ducknetview/internal/reputation"
+	"github.com/nexusriot/ducknetview/internal/store"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// QuitMode controls what the q key does. ctrl+c always quits.
+type QuitMode int
+
+const (
+	QuitDisabled QuitMode = iota
+	QuitImmediate
+	QuitConfirm
+)
+
+func (q QuitMode) String() string {
+	switch q {
+	case QuitImmediate:
+		return "immediate"
+	case QuitConfirm:
+		return "confirm"
+	default:
+		return "off"
+	}
+}
+
+func ParseQuitMode(s string) (QuitMode, error) {
+	switch s {
+	case "", "off", "disabled":
+		return QuitDisabled, nil
+	case "immediate", "on":
+		return QuitImmediate, nil
+	case "confirm":
+		return QuitConfirm, nil
+	}
+	return QuitDisabled, fmt.Errorf("unknown quit mode %q (want off, immediate or confirm)", s)
+}
+
+// MeteredMode controls whether ducknetview holds back its own optional
+// network traffic (external IP polling, update checks, reputation lookups).
+type MeteredMode int
+
+const (
+	MeteredAuto MeteredMode = iota // ask NetworkManager
+	MeteredOn
+	MeteredOff
+)
+
+func (m MeteredMode) String() string {
+	switch m {
+	case MeteredOn:
+		return "on"
+	case MeteredOff:
+		return "off"
+	default:
+		return "auto"
+	}
+}
+
+func ParseMeteredMode(s string) (MeteredMode, error) {
+	switch s {
+	case "", "auto":
+		return MeteredAuto, nil
+	case "on", "yes":
+		return MeteredOn, nil
+	case "off", "no":
+		return MeteredOff, nil
+	}
+	return MeteredAuto, fmt.Errorf("unknown metered mode %q (want auto, on or off)", s)
+}
+
+// Units is how rates are written.
+type Units int
+
+const (
+	UnitsBytes Units = iota // IEC: KiB/s, MiB/s
+	UnitsBits               // SI, as links are rated: kb/s, Mb/s
+)
+
+func (u Units) String() string {
+	if u == UnitsBits {
+		return "bits"
+	}
+	return "bytes"
+}
+
+func ParseUnits(s string) (Units, error) {
+	switch s {
+	case "", "bytes":
+		return UnitsBytes, nil
+	case "bits":
+		return UnitsBits, nil
+	}
+	return UnitsBytes, fmt.Errorf("unknown units %q (want bytes or bits)", s)
+}
+
+// Options tune Model behavior; the zero value matches the defaults.
+type Options struct {
+	Quit QuitMode
+
+	// IdleDim dims the UI and slows refreshes after this long without
+	// key or mouse input. Zero disables it.
+	IdleDim time.Duration
+
+	// Kiosk disables actions that change the system and keeps search
+	// filters from persisting across tabs; meant for unattended dashboards.
+	Kiosk bool
+
+	// CheckUpdate looks for a newer GitHub release at startup.
+	CheckUpdate bool
+
+	// ExternalIP looks up the public addresses shown on Overview; nil uses
+	// extip.DefaultProviders.
+	ExternalIP extip.Provider
+
+	// Metered holds back optional network calls on metered uplinks; m
+	// toggles it at runtime.
+	Metered MeteredMode
+
+	// Blocklist flags connections to listed addresses and domains.
+	Blocklist *blocklist.List
+
+	// Reputation enables on-demand abuse lookups of remote addresses (r).
+	// Nil keeps them off, since lookups send addresses to a third party.
+	Reputation reputation.Checker
+
+	// GeoIP is the MaxMind database kept up to date (downloads pause on
+	// metered links); nil disables it.
+	GeoIP *geoip.DB
+
+	// ExecProbes are user commands shown as tables on the Custom tab.
+	ExecProbes []execprobe.Spec
+
+	// Refresh is how often interfaces are sampled, SlowRefresh how often
+	// routes, connections and the like are, and ExtIPEvery how often the
+	// external IP is looked up. Zero means 1s, 5s and 30s. Refresh can be
+	// changed at runtime (+ -) within MinRefresh and MaxRefresh.
+	Refresh     time.Duration
+	SlowRefresh time.Duration
+	ExtIPEvery  time.Duration
+
+	// PortsRefresh and ProcsRefresh are how often listening ports and
+	// processes are listed; zero means SlowRefresh.
+	PortsRefresh time.Duration
+	ProcsRefresh time.Duration
+
+	// StartTab is the tab shown at startup, by name or number (see
+	// CheckTab); empty means Overview.
+	StartTab string
+
+	// HideKinds are interface kinds left out of every view.
+	HideKinds []probe.IfaceKind
+
+	// HideVirtual starts with loopback, veth, Docker and bridge interfaces
+	// hidden; v toggles it at runtime.
+	HideVirtual bool
+
+	// Units are what rates are written in at startup; u switches them at
+	// runtime.
+	Units Units
+
+	// WatchLAN logs every MAC address that shows up in the neighbor table
+	// during the session to the Events tab.
+	WatchLAN bool
+
+	// CaptureDNS runs tcpdump on the selected interface and lists the DNS
+	// queries it sees on the Connections tab. It needs root or CAP_NET_RAW.
+	CaptureDNS bool
+
+	// PingTargets are pinged on the Latency tab, next to the default
+	// gateways and 1.1.1.1.
+	PingTargets []string
+
+	// IPHistory, when set, records external IP changes across sessions;
+	// Overview then lists earlier ones too.
+	IPHistory *iphistory.Log
+
+	// PortHistory, when set, records the ports each program listens on
+	// across sessions; the process details then list earlier ones too.
+	PortHistory *porthistory.Log
+
+	// Bandwidth, when set, keeps per-interface traffic totals across
+	// sessions, shown on the History tab.
+	Bandwidth *store.DB
+
+	// Notes are user annotations of hosts and ports (n); nil disables them.
+	Notes *notes.Store
+
+	// Probes supplies the data shown in the UI; nil fields use the live system.
+	Probes Probes
+
+	// Clock replaces time.Now, e.g. for deterministic rendering.
+	Clock func() time.Time
+}
+
+// CheckTab reports an error unless s names a tab: its number or its
+// English name, full or short ("processes", "procs", "4").
+func CheckTab(s string) error {
+	if _, ok := tabByName(s); !ok {
+		return fmt.Errorf("unknown tab %q", s)
+	}
+	return nil
+}
+
+func tabByName(s string) (tab, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for t := tab(0); t < tabCount; t++ {
+		if s == strconv.Itoa(int(t)+1) || s == strings.ToLower(tabNames[t].full) || s == strings.ToLower(tabNames[t].short) {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// virtualKinds are hidden by the v filter. Tunnels stay: they carry real
+// traffic and the VPN checks need them.
+var virtualKinds = []probe.IfaceKind{probe.IfaceLoopback, probe.IfaceVeth, probe.IfaceDockerBridge, probe.IfaceLinuxBridge}
+
+// hiddenKinds are the kinds in Options.HideKinds, and the virtual ones
+// while the v filter is on.
+func (m Model) hiddenKinds() []probe.IfaceKind {
+	if !m.hideVirtual {
+		return m.opts.HideKinds
+	}
+	return append(slices.Clone(m.opts.HideKinds), virtualKinds...)
+}
+
+// setBriefKinds has the sampler skip the addresses and link speed of the
+// hidden kinds, which no view shows; see probe.NetSampler.SetBrief. Their
+// counters are still read for the bandwidth history and reset tracking.
+func (m Model) setBriefKinds() {
+	if bs, ok := m.netSampler.(probe.BriefSampler); ok {
+		bs.SetBrief(m.hiddenKinds()...)
+	}
+}
+
+// visibleIfaces drops the interfaces of the hidden kinds.
+func (m Model) visibleIfaces(ifaces []probe.IfaceInfo) []probe.IfaceInfo {
+	hidden := m.hiddenKinds()
+	if len(hidden) == 0 {
+		return ifaces
+	}
+	out := make([]probe.IfaceInfo, 0, len(ifaces))
+	for _, ii := range ifaces {
+		if slices.Contains(hidden, ii.Kind) {
+			continue
+		}
+		out = append(out, ii)
+	}
+	return out
+}
+
+type Probes struct {
+	Net       probe.Sampler
+	Ports     probe.PortLister
+	Inspect   probe.PortInspector
+	Procs     probe.ProcLister
+	Stop      probe.ProcSignaler
+	Block     probe.HostBlocker
+	Detail    probe.ProcInspector
+	ConnRate  probe.ConnRater
+	ICMP      probe.ICMPReader
+	Routes    probe.RouteReader
+	Rules     probe.RuleReader
+	RA        probe.RAReader
+	Conns     probe.ConnLister
+	ConnOpts  probe.ConnInspector
+	Metered   probe.MeteredChecker
+	BGP       probe.BGPReader
+	ProcBW    probe.ProcBandwidthReader
+	PeerBW    probe.PeerBandwidthReader
+	Tunnels   probe.TunnelLister
+	Firewall  probe.FirewallReader
+	Casts     probe.CastReader
+	Softnet   probe.SoftnetReader
+	Flows     probe.FlowLister
+	Queues    probe.QueueReader
+	Wifi      probe.WifiReader
+	Link      probe.LinkReader
+	DHCP      probe.LeaseReader
+	Eyeballs  probe.DualStackRacer
+	Neigh     probe.NeighborReader
+	RDNS      probe.AddrResolver
+	Geo       probe.GeoLocator // nil: the Options.GeoIP database, once there
+	TimeSync  probe.TimeSyncChecker
+	Ping      probe.Pinger
+	Trace     probe.Tracer
+	DNSCache  probe.DNSCacher
+	DNSConf   probe.DNSConfigReader
+	Sniff     probe.DNSSniffer
+	Ephemeral probe.EphemeralRangeReader
+}
+
+func (p Probes) withDefaults() Probes {
+	if p.Net == nil {
+		p.Net = probe.NewNetSampler()
+	}
+	if p.Ports == nil {
+		p.Ports = probe.Host{}
+	}
+	if p.Inspect == nil {
+		p.Inspect = probe.Host{}
+	}
+	if p.ConnOpts == nil {
+		p.ConnOpts = probe.Host{}
+	}
+	if p.Procs == nil {
+		p.Procs = probe.Host{}
+	}
+	if p.Detail == nil {
+		p.Detail = probe.Host{}
+	}
+	if p.Stop == nil {
+		p.Stop = probe.Host{}
+	}
+	if p.Block == nil {
+		p.Block = probe.Host{}
+	}
+	if p.ConnRate == nil {
+		p.ConnRate = probe.NewConnRateSampler()
+	}
+	if p.ICMP == nil {
+		p.ICMP = probe.NewICMPSampler()
+	}
+	if p.Routes == nil {
+		p.Routes = probe.Host{}
+	}
+	if p.Rules == nil {
+		p.Rules = probe.Host{}
+	}
+	if p.RA == nil {
+		p.RA = probe.NewRAMonitor()
+	}
+	if p.Conns == nil {
+		p.Conns = probe.Host{}
+	}
+	if p.Metered == nil {
+		p.Metered = probe.Host{}
+	}
+	if p.BGP == nil {
+		p.BGP = probe.Host{}
+	}
+	if p.ProcBW == nil {
+		p.ProcBW = probe.NewProcBandwidthSampler()
+	}
+	if p.PeerBW == nil {
+		p.PeerBW = probe.NewPeerBandwidthSampler()
+	}
+	if p.Tunnels == nil {
+		p.Tunnels = probe.Host{}
+	}
+	if p.Firewall == nil {
+		p.Firewall = probe.Host{}
+	}
+	if p.Casts == nil {
+		p.Casts = probe.Host{}
+	}
+	if p.Softnet == nil {
+		p.Softnet = probe.Host{}
+	}
+	if p.Flows == nil {
+		p.Flows = probe.Host{}
+	}
+	if p.Queues == nil {
+		p.Queues = probe.Host{}
+	}
+	if p.Wifi == nil {
+		p.Wifi = probe.Host{}
+	}
+	if p.Link == nil {
+		p.Link = probe.Host{}
+	}
+	if p.DHCP == nil {
+		p.DHCP = probe.Host{}
+	}
+	if p.Eyeballs == nil {
+		p.Eyeballs = probe.Host{}
+	}
+	if p.Neigh == nil {
+		p.Neigh = probe.Host{}
+	}
+	if p.RDNS == nil {
+		p.RDNS = probe.Host{}
+	}
+	if p.TimeSync == nil {
+		p.TimeSync = probe.Host{}
+	}
+	if p.Ping == nil {
+		p.Ping = probe.Host{}
+	}
+	if p.Trace == nil {
+		p.Trace = probe.Host{}
+	}
+	if p.DNSCache == nil {
+		p.DNSCache = probe.Host{}
+	}
+	if p.DNSConf == nil {
+		p.DNSConf = probe.Host{}
+	}
+	if p.Sniff == nil {
+		p.Sniff = probe.Host{}
+	}
+	if p.Ephemeral == nil {
+		p.Ephemeral = probe.Host{}
+	}
+	return p
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/pkg/probe/probetest"
+)
+
+func TestProbesWithDefaults(t *testing.T) {
+	f := probetest.Fixture()
+	p := Probes{Ports: f, Ephemeral: f}.withDefaults()
+
+	v := reflect.ValueOf(p)
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Name
+		switch {
+		case name == "Geo":
+			// nil stays nil: the GeoIP database takes its place once there
+			if !v.Field(i).IsNil() {
+				t.Error("Geo defaulted")
+			}
+		case v.Field(i).IsNil():
+			t.Errorf("%s has no default", name)
+		}
+	}
+	if p.Ports != f || p.Ephemeral != f {
+		t.Error("withDefaults replaced probes that were given")
+	}
+}
+
+func TestModelReadsProbes(t *testing.T) {
+	f := probetest.Fixture()
+	m := newFixtureModel(t, f, 120, 40, Options{})
+	if m.lastSnap.Hostname != f.Snapshot.Hostname {
+		t.Errorf("host %q, want the fixture's %q", m.lastSnap.Hostname, f.Snapshot.Hostname)
+	}
+	if len(m.ports) != len(f.Ports) || len(m.procs) != len(f.Procs) || len(m.flows) != len(f.FlowList) {
+		t.Errorf("%d ports, %d processes, %d flows; the fixture has %d, %d, %d",
+			len(m.ports), len(m.procs), len(m.flows), len(f.Ports), len(f.Procs), len(f.FlowList))
+	}
+	if m.ephemeralRange() != f.Ephemeral {
+		t.Errorf("ephemeral range %v, want the fixture's %v", m.ephemeralRange(), f.Ephemeral)
+	}
+}
+
+func TestProbeErrorShown(t *testing.T) {
+	f := probetest.Fixture()
+	m := newFixtureModel(t, f, 120, 40, Options{})
+	f.Err = errors.New("netlink: operation not permitted")
+
+	m = apply(m, m.refreshCmd()())
+	if m.err == nil || !strings.Contains(m.View(), "netlink: operation not permitted") {
+		t.Fatalf("the error isn't shown: err %v", m.err)
+	}
+	if m.lastSnap.Hostname == "" {
+		t.Error("a failed sample dropped the last good one")
+	}
+
+	f.Err = nil
+	m = apply(m, m.refreshCmd()())
+	if m.err != nil {
+		t.Errorf("error %v kept after a good sample", m.err)
+	}
+}
+
+// run runs cmd and the commands it batches or sequences, applying what
+// they return to m. Commands that wait, as ticks do, are skipped.
+func run(m Model, cmd tea.Cmd) Model {
+	if cmd == nil {
+		return m
+	}
+	ch := make(chan tea.Msg, 1)
+	go func() { ch <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-ch:
+	case <-time.After(50 * time.Millisecond):
+		return m
+	}
+	if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice {
+		// tea.BatchMsg, and tea.Sequence's unexported message alike
+		for i := range v.Len() {
+			if c, ok := v.Index(i).Interface().(tea.Cmd); ok {
+				m = run(m, c)
+			}
+		}
+		return m
+	}
+	return apply(m, msg)
+}
+
+func TestStopProcess(t *testing.T) {
+	for _, tt := range []struct {
+		key   string
+		force bool
+	}{{"X", false}, {"K", true}} {
+		f := probetest.Fixture()
+		m := newFixtureModel(t, f, 120, 40, Options{})
+		m.setTab(tabProcs)
+		pid, _, ok := m.selectedPID()
+		if !ok {
+			t.Fatal("no process selected")
+		}
+
+		m, _ = press(m, tt.key)
+		if !m.procKill.open {
+			t.Fatalf("%s asked nothing", tt.key)
+		}
+		if _, cmd := press(m, "esc"); cmd != nil || len(f.Stopped) > 0 {
+			t.Errorf("%s, esc: stopped %v", tt.key, f.Stopped)
+		}
+
+		m, cmd := press(m, "y")
+		m = run(m, cmd)
+		if force, ok := f.Stopped[pid]; !ok || force != tt.force || len(f.Stopped) != 1 {
+			t.Errorf("%s, y: stopped %v, want PID %d force %v", tt.key, f.Stopped, pid, tt.force)
+		}
+		if !strings.Contains(m.notice, "sent SIG") {
+			t.Errorf("%s, y: notice %q", tt.key, m.notice)
+		}
+	}
+
+	f := probetest.Fixture()
+	m := newFixtureModel(t, f, 120, 40, Options{Kiosk: true})
+	m.setTab(tabProcs)
+	m, _ = press(m, "X")
+	if m.procKill.open {
+		t.Error("X asks to stop a process in kiosk mode")
+	}
+}
+
+func TestUnitsPerModel(t *testing.T) {
+	const bytes, bits = "↓1.5 MiB/s", "↓12.6 Mb/s" // eth0's, on Overview
+	m := newTestModel(t, 120, 40, Options{})
+	n := newTestModel(t, 120, 40, Options{Units: UnitsBits})
+	if v := m.View(); !strings.Contains(v, bytes) || strings.Contains(v, bits) {
+		t.Fatalf("bytes: no %q, or %q, in\n%s", bytes, bits, v)
+	}
+	if v := n.View(); !strings.Contains(v, bits) || strings.Contains(v, bytes) {
+		t.Fatalf("Options.Units bits: no %q, or %q, in\n%s", bits, bytes, v)
+	}
+
+	u, cmd := press(m, "u")
+	u = run(u, cmd)
+	if v := u.View(); !strings.Contains(v, bits) || strings.Contains(v, bytes) {
+		t.Errorf("u: no %q, or %q", bits, bytes)
+	}
+	if !strings.Contains(m.View(), bytes) || !strings.Contains(n.View(), bits) {
+		t.Error("u in one model changed the units of others")
+	}
+}
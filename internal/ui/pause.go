@@ -0,0 +1,36 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// Pausing (P) stops the refreshes of every tab, unlike freezing (f), which
+// keeps one tab on a copy while the live model goes on. The ticks keep
+// coming, so resuming doesn't start a second tick loop, but fetch nothing.
+
+// togglePause pauses or resumes all refreshes. Resuming fetches right away
+// rather than waiting for the slow refresh.
+func (m *Model) togglePause() tea.Cmd {
+	m.paused = !m.paused
+	if m.paused {
+		m.notice = i18n.T("paused; P resumes")
+		return nil
+	}
+	m.notice = i18n.T("resumed")
+	return tea.Batch(m.refreshCmd(), m.fetchPortsCmd(), m.fetchProcsCmd(), m.tabEnterCmd())
+}
+
+// pausedDrop reports whether msg is a result fetched before the pause,
+// which would otherwise change the data while it is being read.
+func (m Model) pausedDrop(msg tea.Msg) bool {
+	if !m.paused {
+		return false
+	}
+	switch msg.(type) {
+	case snapMsg, portsMsg, procsMsg, connsMsg, tunnelsMsg:
+		return true
+	}
+	return false
+}
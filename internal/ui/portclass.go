@@ -0,0 +1,69 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type ephemeralRangeMsg struct {
+	r   probe.PortRange
+	err error
+}
+
+// fetchEphemeralRangeCmd reads the host's ephemeral port range; once, at
+// start.
+func (m Model) fetchEphemeralRangeCmd() tea.Cmd {
+	r := m.ephReader
+	return func() tea.Msg {
+		pr, err := r.EphemeralRange()
+		return ephemeralRangeMsg{r: pr, err: err}
+	}
+}
+
+// ephemeralRange is the host's ephemeral port range, or IANA's until it is
+// read or where it can't be.
+func (m Model) ephemeralRange() probe.PortRange {
+	if m.ephemeral.Hi == 0 {
+		return probe.IANAEphemeral
+	}
+	return m.ephemeral
+}
+
+// portClassLabel names the class of the port of local, with the range for
+// ephemeral ones; "" without a port.
+func (m Model) portClassLabel(local string) string {
+	r := m.ephemeralRange()
+	c, ok := r.ClassOf(local)
+	if !ok {
+		return ""
+	}
+	s := i18n.T(c.String())
+	if c == probe.PortEphemeral {
+		s += " " + subtleStyle.Render("("+r.String()+")")
+	}
+	return s
+}
+
+// listeningPorts is the set of "proto/port" the host listens on.
+func (m Model) listeningPorts() map[string]bool {
+	out := make(map[string]bool, len(m.ports))
+	for _, p := range m.ports {
+		_, port := probe.SplitLocal(p.Local)
+		out[p.Proto+"/"+port] = true
+	}
+	return out
+}
+
+// ephemeralSource reports whether c's local end is a port the kernel handed
+// out, as it is for nearly every connection the host opened itself. A port
+// in the range that something listens on belongs to a service.
+func (m Model) ephemeralSource(c probe.Conn, listening map[string]bool) bool {
+	cl, ok := m.ephemeralRange().ClassOf(c.Local)
+	if !ok || cl != probe.PortEphemeral {
+		return false
+	}
+	_, port := probe.SplitLocal(c.Local)
+	return !listening[c.Proto+"/"+port]
+}
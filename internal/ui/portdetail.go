@@ -0,0 +1,239 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// portDetail is the modal with the full socket info of the selected
+// listener, opened with enter on the Ports tab.
+type portDetail struct {
+	open   bool
+	key    string
+	gone   bool
+	d      probe.PortDetail
+	err    error
+	loaded bool
+}
+
+type portDetailMsg struct {
+	key string
+	d   probe.PortDetail
+	err error
+}
+
+// selectedPort is the listener of the selected Ports row, and whether it
+// has gone away since.
+func (m Model) selectedPort() (probe.ListenPort, bool, bool) {
+	for _, p := range m.ports {
+		if listenerKey(p) == m.portsSel {
+			return p, false, true
+		}
+	}
+	for _, ps := range m.portTimeline.goneList() {
+		if listenerKey(ps.port) == m.portsSel {
+			return ps.port, true, true
+		}
+	}
+	return probe.ListenPort{}, false, false
+}
+
+func (m *Model) movePortSel(delta int) {
+	m.portsSel = stepRow(m.portsKeys, m.portsSel, delta, &m.portsVP, m.portsHead)
+	m.setPortsContent()
+}
+
+func (m *Model) keepPortSelInView() {
+	if sel := rowInView(m.portsKeys, m.portsSel, m.portsVP, m.portsHead); sel != m.portsSel {
+		m.portsSel = sel
+		m.setPortsContent()
+	}
+}
+
+func (m *Model) openPortDetail() tea.Cmd {
+	lp, gone, ok := m.selectedPort()
+	if !ok {
+		return nil
+	}
+	m.portDetail = portDetail{open: true, key: m.portsSel, gone: gone, d: probe.PortDetail{ListenPort: lp}}
+	if gone {
+		// nothing left to look at
+		m.portDetail.loaded = true
+		return nil
+	}
+	pi, key := m.portInspector, m.portsSel
+	return func() tea.Msg {
+		d, err := pi.InspectPort(lp)
+		return portDetailMsg{key: key, d: d, err: err}
+	}
+}
+
+func (m *Model) applyPortDetail(msg portDetailMsg) {
+	if !m.portDetail.open || msg.key != m.portDetail.key {
+		return
+	}
+	m.portDetail.d, m.portDetail.err, m.portDetail.loaded = msg.d, msg.err, true
+}
+
+func (m Model) updatePortDetail(km tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch km.String() {
+	case "esc", "enter", "q", "backspace":
+		m.portDetail.open = false
+	}
+	return m, nil
+}
+
+func (m Model) viewPortDetail() string {
+	pd := m.portDetail
+	d := pd.d
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(d.Proto+" "+d.Local) + "  " + subtleStyle.Render(i18n.T("esc close")) + "\n\n")
+	switch {
+	case pd.gone:
+		b.WriteString(subtleStyle.Render(i18n.T("This listener is gone; only what was last seen is left.")) + "\n\n")
+	case !pd.loaded:
+		return b.String() + i18n.T("No data (yet)…") + "\n"
+	case pd.err != nil:
+		return b.String() + errStyle.Render(i18n.T("Error: ")+pd.err.Error()) + "\n"
+	}
+
+	unknown := subtleStyle.Render("?")
+	num := func(n int) string {
+		if n < 0 {
+			return unknown
+		}
+		return fmt.Sprintf("%d", n)
+	}
+	row := func(label, value string) {
+		b.WriteString(padRight(i18n.T(label), 14) + value + "\n")
+	}
+
+	if !pd.gone {
+		row("Family", d.Family)
+	}
+	if class := m.portClassLabel(d.Local); class != "" {
+		if c, _ := m.ephemeralRange().ClassOf(d.Local); c == probe.PortEphemeral {
+			// the kernel may hand it to an outgoing connection while the
+			// service is down, and its restart then fails to bind
+			class += "  " + warnStyle.Render(i18n.T("an outgoing connection can take it while the service is down"))
+		}
+		row("Port class", class)
+	}
+	proc := d.Process
+	if proc == "" {
+		proc = unknown
+	}
+	if d.PID > 0 {
+		proc += fmt.Sprintf("  (PID %d)", d.PID)
+	}
+	row("Process", proc)
+	if pd.gone {
+		return b.String()
+	}
+	cmdline := d.Cmdline
+	if cmdline == "" {
+		cmdline = unknown
+	}
+	row("Command", cmdline)
+	owner := unknown
+	switch {
+	case d.UID >= 0 && d.User != "":
+		owner = fmt.Sprintf("%s (%d)", d.User, d.UID)
+	case d.UID >= 0:
+		owner = fmt.Sprintf("%d", d.UID)
+	case d.User != "":
+		owner = d.User
+	}
+	row("Owner", owner)
+	if d.Proto == "tcp" {
+		queue := num(d.RecvQ)
+		if d.RecvQ > 0 && d.SendQ > 0 && d.RecvQ*10 >= d.SendQ*9 {
+			// connections wait for accept(): the program can't keep up
+			queue = warnStyle.Render(queue)
+		}
+		row("Accept queue", fmt.Sprintf(i18n.T("%s waiting, backlog %s"), queue, num(d.SendQ)))
+	} else {
+		row("Queues", fmt.Sprintf(i18n.T("%s bytes received, %s to send"), num(d.RecvQ), num(d.SendQ)))
+	}
+	inode := unknown
+	if d.Inode > 0 {
+		inode = fmt.Sprintf("%d", d.Inode)
+	}
+	row("Socket inode", inode)
+	if note := m.portNote(d.ListenPort); note != "" {
+		row("Note", note)
+	}
+	b.WriteString("\n")
+	writeSockOpts(&b, d.Opts, d.Proto, false)
+	return b.String()
+}
+
+// writeSockOpts writes the rows of the socket options of a listener or,
+// with conn, of a connection: keepalive matters only to the one, address
+// sharing only to the other.
+func writeSockOpts(b *strings.Builder, o probe.SockOpts, proto string, conn bool) {
+	row := func(label, value string) {
+		b.WriteString(padRight(i18n.T(label), 14) + value + "\n")
+	}
+	if !o.Found {
+		row("Options", subtleStyle.Render(i18n.T("? (needs ss from iproute2)")))
+		return
+	}
+	size := func(n int) string {
+		if n < 0 {
+			return subtleStyle.Render("?")
+		}
+		return i18n.Number(probe.HumanBytes(uint64(n)))
+	}
+	bufs := fmt.Sprintf(i18n.T("receive %s, send %s"), size(o.RcvBuf), size(o.SndBuf))
+	if o.Drops > 0 {
+		bufs += ", " + warnStyle.Render(fmt.Sprintf(i18n.T("%d dropped"), o.Drops))
+	}
+	row("Buffers", bufs)
+
+	if !conn {
+		reuse := i18n.T("no")
+		if o.Shared > 1 {
+			reuse = accentStyle.Render(fmt.Sprintf(i18n.T("yes, %d sockets share the address"), o.Shared))
+		}
+		row("SO_REUSEPORT", reuse)
+		if o.V6Only >= 0 {
+			row("IPv6 only", map[bool]string{true: i18n.T("yes"), false: i18n.T("no, IPv4 too")}[o.V6Only == 1])
+		}
+	}
+	if proto != "tcp" {
+		return
+	}
+	if conn {
+		ka := i18n.T("no timer running")
+		if o.Keepalive {
+			ka = okStyle.Render(fmt.Sprintf(i18n.T("on, next probe in %s"), humanDuration(o.KeepaliveNext)))
+		}
+		if sk := o.SysKeepalive; sk.Idle > 0 {
+			ka += "  " + subtleStyle.Render(fmt.Sprintf(i18n.T("system: %s idle, then every %s × %d"), humanDuration(sk.Idle), humanDuration(sk.Interval), sk.Probes))
+		}
+		row("Keepalive", ka)
+	}
+	var tcp []string
+	if o.Congestion != "" {
+		tcp = append(tcp, o.Congestion)
+	}
+	tcp = append(tcp, o.Flags...)
+	if o.MSS > 0 {
+		tcp = append(tcp, fmt.Sprintf("MSS %d", o.MSS))
+	}
+	if o.RTT >= 0 {
+		tcp = append(tcp, "RTT "+i18n.Number(fmt.Sprintf("%.1f ms", float64(o.RTT)/float64(time.Millisecond))))
+	}
+	if len(tcp) > 0 {
+		row("TCP", strings.Join(tcp, "  "))
+	}
+	b.WriteString(subtleStyle.Render(i18n.T("SO_REUSEADDR and TCP_NODELAY can't be seen from outside the program.")) + "\n")
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/porthistory"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// rebindPorts is how many different ports a program has to have listened
+// on to be taken for one that binds a random port each start.
+const rebindPorts = 3
+
+// recordListenHistoryCmd appends ports programs listened on for the first
+// time to Options.PortHistory; nil without one or without new ones.
+func (m Model) recordListenHistoryCmd(es []porthistory.Entry) tea.Cmd {
+	l := m.opts.PortHistory
+	if l == nil || len(es) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := l.Add(es...); err != nil {
+			return noticeMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// listenHistoryRow is a port a program listened on, this session or in
+// an earlier one kept in Options.PortHistory.
+type listenHistoryRow struct {
+	proto, port string
+	first, last time.Time // last is zero for ports of earlier sessions only
+	now         bool
+}
+
+// renderListenHistory lists the ports the program name has listened on,
+// for the process details: still listening, since closed, or only in
+// earlier sessions. Empty when it never listened.
+func (m Model) renderListenHistory(name string) string {
+	rows := map[string]*listenHistoryRow{}
+	for _, e := range m.opts.PortHistory.Of(name) {
+		rows[e.Proto+"/"+e.Port] = &listenHistoryRow{proto: e.Proto, port: e.Port, first: e.First}
+	}
+	for _, ls := range m.session.procPorts[name] {
+		r, ok := rows[ls.proto+"/"+ls.port]
+		if !ok {
+			r = &listenHistoryRow{proto: ls.proto, port: ls.port, first: ls.first}
+			rows[ls.proto+"/"+ls.port] = r
+		}
+		r.last = ls.last
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	for _, p := range m.ports {
+		if p.Process != name {
+			continue
+		}
+		_, port := probe.SplitLocal(p.Local)
+		if r, ok := rows[p.Proto+"/"+port]; ok {
+			r.now = true
+		}
+	}
+	sorted := make([]*listenHistoryRow, 0, len(rows))
+	for _, r := range rows {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].first.Equal(sorted[j].first) {
+			return sorted[i].first.Before(sorted[j].first)
+		}
+		return sorted[i].proto+sorted[i].port < sorted[j].proto+sorted[j].port
+	})
+
+	// times of this session by the clock, earlier ones with their date
+	stamp := func(t time.Time) string {
+		if t.Before(m.session.startedAt) {
+			return i18n.DateTime(t)
+		}
+		return i18n.Clock(t)
+	}
+	var b strings.Builder
+	title := i18n.T("Ports listened on, this session")
+	if m.opts.PortHistory != nil {
+		title = i18n.T("Ports listened on")
+	}
+	b.WriteString(titleStyle.Render(title) + "\n")
+	for _, r := range sorted {
+		line := "  " + padRight(r.proto+"/"+r.port, 12) + "  "
+		switch {
+		case r.now:
+			line += fmt.Sprintf(i18n.T("since %s"), stamp(r.first)) + "  " + okStyle.Render(i18n.T("listening"))
+		case r.last.IsZero():
+			line += subtleStyle.Render(fmt.Sprintf(i18n.T("first %s, not this session"), stamp(r.first)))
+		default:
+			line += stamp(r.first) + " – " + i18n.Clock(r.last)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(sorted) >= rebindPorts {
+		b.WriteString(warnStyle.Render(fmt.Sprintf(i18n.T("%d different ports: it likely binds a new one each start; match it by name, not port"), len(sorted))) + "\n")
+	}
+	return b.String()
+}
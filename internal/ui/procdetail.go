@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// procDetail is the drill-down pane of the Processes tab, opened with
+// enter on a process row.
+type procDetail struct {
+	open   bool
+	pid    int32
+	d      probe.ProcDetails
+	err    error
+	loaded bool
+}
+
+type procDetailMsg struct {
+	pid int32
+	d   probe.ProcDetails
+	err error
+}
+
+func (m Model) fetchProcDetailCmd(pid int32) tea.Cmd {
+	pi := m.procInspector
+	return func() tea.Msg {
+		d, err := pi.ProcDetail(pid)
+		return procDetailMsg{pid: pid, d: d, err: err}
+	}
+}
+
+func (m *Model) openProcDetail() tea.Cmd {
+	pid, _, ok := m.selectedPID()
+	if !ok {
+		m.notice = i18n.T("select a single process (e lists the PIDs of a group)")
+		return nil
+	}
+	m.procDetail = procDetail{open: true, pid: pid}
+	m.procDetailVP.GotoTop()
+	m.setProcDetailContent()
+	return m.fetchProcDetailCmd(pid)
+}
+
+func (m *Model) applyProcDetail(msg procDetailMsg) {
+	if !m.procDetail.open || msg.pid != m.procDetail.pid {
+		return
+	}
+	m.procDetail.d, m.procDetail.err, m.procDetail.loaded = msg.d, msg.err, true
+	m.setProcDetailContent()
+}
+
+// updateProcDetail handles the keys that close the pane, and swallows
+// those meant for the process list behind it. Scrolling is left to the
+// Processes tab's viewport handling, the rest to the global keys.
+func (m Model) updateProcDetail(km tea.KeyMsg) (Model, bool) {
+	switch km.String() {
+	case "esc", "enter", "backspace":
+		m.procDetail.open = false
+		return m, true
+	case "/", "ctrl+u", "s", "S", "g", "e":
+		return m, true
+	}
+	return m, false
+}
+
+func (m *Model) setProcDetailContent() {
+	m.procDetailVP.SetContent(hardClipLinesToWidth(m.renderProcDetailText(), m.procDetailVP.Width))
+}
+
+func (m Model) renderProcDetailText() string {
+	pd := m.procDetail
+	var b strings.Builder
+	switch {
+	case !pd.loaded:
+		return i18n.T("No data (yet)…") + "\n"
+	case pd.err != nil:
+		return errStyle.Render(i18n.T("Error: ")+pd.err.Error()) + "\n"
+	}
+	d := pd.d
+
+	user, fds := d.User, fmt.Sprintf("%d", d.FDs)
+	if user == "" {
+		user = "?"
+	}
+	if d.FDs < 0 {
+		fds = "?"
+	}
+	b.WriteString(fmt.Sprintf(i18n.T("User: %s    Open files: %s"), user, fds) + "\n")
+	cmdline := d.Cmdline
+	if cmdline == "" {
+		cmdline = subtleStyle.Render(i18n.T("(not readable, try as root)"))
+	}
+	b.WriteString(i18n.T("Command: ") + cmdline + "\n\n")
+	if h := m.renderListenHistory(d.Name); h != "" {
+		b.WriteString(h + "\n")
+	}
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T("Sockets (%d)"), len(d.Sockets))) + "\n")
+	if len(d.Sockets) == 0 {
+		b.WriteString(subtleStyle.Render(i18n.T("none")) + "\n")
+		return b.String()
+	}
+	// STATE takes up to 11 (ESTABLISHED)
+	colProto := 5
+	colAddr := min(45, max(15, (m.procDetailVP.Width-colProto-6-11)/2))
+	h := fmt.Sprintf("%s  %s  %s  %s", padRight(i18n.T("PROTO"), colProto),
+		padRight(i18n.T("LOCAL"), colAddr), padRight(i18n.T("REMOTE"), colAddr), i18n.T("STATE"))
+	b.WriteString(h + "\n")
+	b.WriteString(strings.Repeat("─", min(m.procDetailVP.Width, colProto+6+2*colAddr+11)) + "\n")
+	for _, s := range d.Sockets {
+		remote := s.Remote
+		if remote == "" {
+			remote = "-"
+		}
+		state := s.Status
+		if state == "LISTEN" {
+			state = okStyle.Render(state)
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n", padRight(s.Proto, colProto),
+			padRight(trunc(s.Local, colAddr), colAddr), padRight(trunc(remote, colAddr), colAddr), state))
+	}
+	return b.String()
+}
+
+func (m Model) viewProcDetail() string {
+	pd := m.procDetail
+	title := fmt.Sprintf("%s  PID %d", procName(pd.d.Name), pd.pid)
+	if !pd.loaded || pd.err != nil {
+		title = fmt.Sprintf("PID %d", pd.pid)
+	}
+	line := titleStyle.Render(title) + "  " + subtleStyle.Render(i18n.T("esc back • X stop • K kill"))
+	return boxStyle.Width(min(m.w-2, 120)).Height(m.bodyHeight()).Render(line + "\n\n" + m.procDetailVP.View())
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// procGroup merges processes sharing a name (chrome, nginx workers, …).
+type procGroup struct {
+	name        string
+	members     []probe.ProcNet
+	connCount   int
+	listenCount int
+}
+
+func groupProcs(ps []probe.ProcNet, by tableSort) []procGroup {
+	idx := map[string]int{}
+	var out []procGroup
+	for _, p := range ps {
+		i, ok := idx[p.Name]
+		if !ok {
+			i = len(out)
+			idx[p.Name] = i
+			out = append(out, procGroup{name: p.Name})
+		}
+		g := &out[i]
+		g.members = append(g.members, p)
+		g.connCount += p.ConnCount
+		g.listenCount += p.ListenCount
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if by.desc {
+			a, b = b, a
+		}
+		switch by.col {
+		case procsByConns:
+			if a.connCount != b.connCount {
+				return a.connCount < b.connCount
+			}
+		case procsByListen:
+			if a.listenCount != b.listenCount {
+				return a.listenCount < b.listenCount
+			}
+		case procsByPID:
+			// members keep the table's order, so [0] leads the group
+			if a.members[0].PID != b.members[0].PID {
+				return a.members[0].PID < b.members[0].PID
+			}
+		}
+		return a.name < b.name
+	})
+	return out
+}
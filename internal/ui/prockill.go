@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// procKill is the confirmation before signalling the selected process, or
+// the processes behind the marked rows.
+type procKill struct {
+	open  bool
+	procs []procTarget
+	force bool // SIGKILL rather than SIGTERM
+}
+
+type procTarget struct {
+	pid  int32
+	name string
+}
+
+// selectedPID is the PID of the selected Processes row; false on a group
+// row or when nothing is listed.
+func (m Model) selectedPID() (int32, string, bool) {
+	pid, name, _ := strings.Cut(m.procsSel, " ")
+	n, err := strconv.ParseInt(pid, 10, 32)
+	if err != nil {
+		return 0, "", false
+	}
+	return int32(n), strings.TrimPrefix(name, " └ "), true
+}
+
+// moveProcSel selects the row delta rows up or down, scrolling it into view.
+func (m *Model) moveProcSel(delta int) {
+	m.procsSel = stepRow(m.procsKeys, m.procsSel, delta, &m.procsVP, m.procsHead)
+	m.setProcsContent()
+}
+
+// keepProcSelInView moves the selection along when the list is scrolled
+// past it, so it never sits off screen.
+func (m *Model) keepProcSelInView() {
+	if sel := rowInView(m.procsKeys, m.procsSel, m.procsVP, m.procsHead); sel != m.procsSel {
+		m.procsSel = sel
+		m.setProcsContent()
+	}
+}
+
+func (m *Model) openProcKill(force bool) {
+	if m.marked(m.activeTab).any() {
+		ps := m.markedProcs()
+		if len(ps) == 0 {
+			m.notice = i18n.T("no process owns the marked rows")
+			return
+		}
+		m.procKill = procKill{open: true, procs: ps, force: force}
+		return
+	}
+	pid, name, ok := m.selectedPID()
+	if !ok || m.activeTab != tabProcs {
+		m.notice = i18n.T("select a single process (e lists the PIDs of a group)")
+		return
+	}
+	m.procKill = procKill{open: true, procs: []procTarget{{pid, name}}, force: force}
+}
+
+func (m Model) updateProcKill(km tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.procKill
+	m.procKill.open = false
+	switch km.String() {
+	case "y", "Y", "enter":
+		if len(k.procs) > 1 {
+			m.clearMarks(m.activeTab)
+		}
+		return m, tea.Sequence(m.stopProcCmd(k), m.fetchProcsCmd())
+	}
+	return m, nil
+}
+
+func (m Model) stopProcCmd(k procKill) tea.Cmd {
+	stop := m.procStop
+	return func() tea.Msg {
+		sig := "SIGTERM"
+		if k.force {
+			sig = "SIGKILL"
+		}
+		var failed []error
+		for _, p := range k.procs {
+			if err := stop.StopProc(p.pid, k.force); err != nil {
+				if errors.Is(err, os.ErrPermission) {
+					err = fmt.Errorf(i18n.T("no permission to signal %s (PID %d), it belongs to another user"), p.name, p.pid)
+				}
+				failed = append(failed, err)
+			}
+		}
+		switch {
+		case len(k.procs) == 1 && len(failed) == 1:
+			return noticeMsg{err: failed[0]}
+		case len(k.procs) == 1:
+			return noticeMsg{text: fmt.Sprintf(i18n.T("sent %s to %s (PID %d)"), sig, k.procs[0].name, k.procs[0].pid)}
+		case len(failed) > 0:
+			return noticeMsg{err: fmt.Errorf(i18n.T("sent %s to %d of %d processes: %w"), sig, len(k.procs)-len(failed), len(k.procs), failed[0])}
+		}
+		return noticeMsg{text: fmt.Sprintf(i18n.T("sent %s to %d processes"), sig, len(k.procs))}
+	}
+}
+
+func (m Model) viewProcKill() string {
+	k := m.procKill
+	var b strings.Builder
+	switch {
+	case len(k.procs) > 1 && k.force:
+		b.WriteString(errStyle.Render(i18n.T("Kill processes")) + "\n\n")
+		b.WriteString(fmt.Sprintf(i18n.T("Send SIGKILL to %d processes? They end at once, without cleaning up."), len(k.procs)) + "\n\n")
+	case len(k.procs) > 1:
+		b.WriteString(warnStyle.Render(i18n.T("Stop processes")) + "\n\n")
+		b.WriteString(fmt.Sprintf(i18n.T("Send SIGTERM to %d processes?"), len(k.procs)) + "\n\n")
+	case k.force:
+		b.WriteString(errStyle.Render(i18n.T("Kill process")) + "\n\n")
+		b.WriteString(fmt.Sprintf(i18n.T("Send SIGKILL to %s (PID %d)? It ends at once, without cleaning up."), k.procs[0].name, k.procs[0].pid) + "\n\n")
+	default:
+		b.WriteString(warnStyle.Render(i18n.T("Stop process")) + "\n\n")
+		b.WriteString(fmt.Sprintf(i18n.T("Send SIGTERM to %s (PID %d)?"), k.procs[0].name, k.procs[0].pid) + "\n\n")
+	}
+	if len(k.procs) > 1 {
+		for i, p := range k.procs {
+			if i == bulkListed {
+				b.WriteString(subtleStyle.Render(fmt.Sprintf(i18n.T("… %d more"), len(k.procs)-bulkListed)) + "\n")
+				break
+			}
+			b.WriteString(fmt.Sprintf("  %-7d %s\n", p.pid, p.name))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(subtleStyle.Render(i18n.T("y/enter send • any other key cancels")))
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type queuesMsg struct {
+	iface string
+	q     probe.NICQueues
+	err   error
+	at    time.Time
+}
+
+// queueSample is the last reading of the queues of the selected interface,
+// with the rate of each interrupt since the one before.
+type queueSample struct {
+	iface string
+	q     probe.NICQueues
+	at    time.Time
+	rates map[int]float64 // by IRQ; missing until there are two readings
+}
+
+// fetchQueuesCmd reads the queues of the selected interface; only done
+// while the Interfaces tab is open.
+func (m Model) fetchQueuesCmd() tea.Cmd {
+	iface := m.selectedIface
+	if iface == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		q, err := m.queueReader.Queues(iface)
+		return queuesMsg{iface: iface, q: q, err: err, at: m.now()}
+	}
+}
+
+func (m *Model) applyQueues(msg queuesMsg) {
+	if msg.iface != m.selectedIface {
+		return
+	}
+	if msg.err != nil {
+		m.queues = queueSample{}
+		return
+	}
+	prev := m.queues
+	s := queueSample{iface: msg.iface, q: msg.q, at: msg.at, rates: map[int]float64{}}
+	if dt := msg.at.Sub(prev.at).Seconds(); prev.iface == msg.iface && dt > 0 {
+		old := map[int]uint64{}
+		for _, irq := range prev.q.IRQs {
+			old[irq.IRQ] = irq.Count
+		}
+		for _, irq := range msg.q.IRQs {
+			if n, ok := old[irq.IRQ]; ok {
+				s.rates[irq.IRQ] = probe.CounterRate(n, irq.Count, dt)
+			}
+		}
+	}
+	m.queues = s
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+// irqCPUs is where an interrupt runs: the CPUs it may, and the one it
+// does when the kernel says and that is fewer.
+func irqCPUs(irq probe.QueueIRQ) string {
+	if irq.Effective == "" || irq.Effective == irq.Affinity {
+		return irq.Affinity
+	}
+	return irq.Affinity + " → " + irq.Effective
+}
+
+// irqRunsOn is the CPUs an interrupt is handled on, as far as known.
+func irqRunsOn(irq probe.QueueIRQ) string {
+	if irq.Effective != "" {
+		return irq.Effective
+	}
+	return irq.Affinity
+}
+
+// renderQueueText shows the queues of a multi-queue NIC for the interface
+// details pane: their interrupts with rate and CPUs, and a warning when
+// the busy ones all land on one CPU. Single-queue interfaces show nothing.
+func (m Model) renderQueueText(ii *probe.IfaceInfo) string {
+	s := m.queues
+	if s.iface != ii.Name || (s.q.RX <= 1 && s.q.TX <= 1 && len(s.q.IRQs) == 0) {
+		return ""
+	}
+	var b strings.Builder
+	head := fmt.Sprintf(i18n.T("Queues: %d RX, %d TX"), s.q.RX, s.q.TX)
+	if len(s.q.IRQs) > 0 {
+		head += " • " + fmt.Sprintf(i18n.T("%d interrupts"), len(s.q.IRQs))
+	}
+	b.WriteString(head + "\n")
+	if len(s.q.IRQs) == 0 {
+		return b.String()
+	}
+
+	const colIRQ, colName, colRate = 5, 20, 10
+	b.WriteString(subtleStyle.Render(padRight(i18n.T("IRQ"), colIRQ)+"  "+padRight(i18n.T("NAME"), colName)+"  "+
+		padRight(i18n.T("RATE"), colRate)+"  "+i18n.T("CPUS")) + "\n")
+	busy, cpus := 0, map[string]bool{}
+	for _, irq := range s.q.IRQs {
+		rate := "-"
+		if r, ok := s.rates[irq.IRQ]; ok {
+			rate = i18n.Number(fmt.Sprintf("%.0f/s", r))
+			if r > 0 {
+				busy++
+				cpus[irqRunsOn(irq)] = true
+			}
+		}
+		where := irqCPUs(irq)
+		if where == "" {
+			where = "?"
+		}
+		b.WriteString(padRight(fmt.Sprint(irq.IRQ), colIRQ) + "  " + padRight(trunc(irq.Name, colName), colName) + "  " +
+			padRight(rate, colRate) + "  " + where + "\n")
+	}
+	if busy > 1 && len(cpus) == 1 {
+		for cpu := range cpus {
+			if cpu != "" && !strings.ContainsAny(cpu, ",-") {
+				b.WriteString(warnStyle.Render(fmt.Sprintf(i18n.T("every busy queue interrupts CPU %s: spread them with irqbalance or /proc/irq/N/smp_affinity_list"), cpu)) + "\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// raTracker remembers the routers advertising on each interface. The first
+// routers heard on an interface are taken as legitimate; a new source showing
+// up later is flagged as a possible rogue RA.
+type raTracker struct {
+	ifaces map[string]map[string]*raSeen // iface -> router -> last RA
+}
+
+type raSeen struct {
+	ra         probe.RouterAdvert
+	first      time.Time
+	count      int
+	unexpected bool
+}
+
+func newRATracker() *raTracker {
+	return &raTracker{ifaces: map[string]map[string]*raSeen{}}
+}
+
+// add records ra and reports whether its source is new on an interface that
+// already had a router.
+func (t *raTracker) add(ra probe.RouterAdvert) (unexpected bool) {
+	routers := t.ifaces[ra.Iface]
+	if routers == nil {
+		routers = map[string]*raSeen{}
+		t.ifaces[ra.Iface] = routers
+	}
+	rs := routers[ra.Router]
+	if rs == nil {
+		rs = &raSeen{first: ra.ReceivedAt, unexpected: len(routers) > 0}
+		routers[ra.Router] = rs
+		unexpected = rs.unexpected
+	}
+	rs.ra = ra
+	rs.count++
+	return unexpected
+}
+
+// routers returns the routers seen on iface, oldest first.
+func (t *raTracker) routers(iface string) []*raSeen {
+	var out []*raSeen
+	for _, rs := range t.ifaces[iface] {
+		out = append(out, rs)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].first.Equal(out[j].first) {
+			return out[i].first.Before(out[j].first)
+		}
+		return out[i].ra.Router < out[j].ra.Router
+	})
+	return out
+}
+
+type raMsg struct {
+	ra  probe.RouterAdvert
+	err error
+}
+
+// waitRACmd blocks until the next router advertisement arrives.
+func (m Model) waitRACmd() tea.Cmd {
+	return func() tea.Msg {
+		ra, err := m.raReader.NextRA()
+		return raMsg{ra: ra, err: err}
+	}
+}
+
+func (m *Model) applyRA(msg raMsg) tea.Cmd {
+	if msg.err != nil {
+		// the monitor is done; EOF just means the source ran dry
+		if !errors.Is(msg.err, io.EOF) {
+			m.raErr = msg.err
+		}
+		return nil
+	}
+
+	if m.ras.add(msg.ra) {
+		text := fmt.Sprintf(i18n.T("unexpected router advertisement on %s from %s"), msg.ra.Iface, msg.ra.Router)
+		if msg.ra.SourceMAC != "" {
+			text += " (" + msg.ra.SourceMAC + ")"
+		}
+		m.events.add(m.now(), evAlert, text)
+		m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+		m.alert, m.alertAt = text, m.now()
+	}
+
+	if msg.ra.Iface == m.selectedIface {
+		m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+		m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+	}
+	return m.waitRACmd()
+}
+
+// renderRAText describes the routers advertising on iface for the
+// interface details pane.
+func (m Model) renderRAText(iface string) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Router advertisements")) + "\n")
+
+	routers := m.ras.routers(iface)
+	if len(routers) == 0 {
+		if m.raErr != nil {
+			b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.raErr.Error()) + "\n")
+		} else {
+			b.WriteString(subtleStyle.Render(i18n.T("none seen")) + "\n")
+		}
+		return b.String()
+	}
+
+	for _, rs := range routers {
+		ra := rs.ra
+		line := ra.Router
+		if ra.SourceMAC != "" {
+			line += " (" + ra.SourceMAC + ")"
+		}
+		if n := m.opts.Notes.Host(ra.Router); n != "" {
+			line += "  # " + n
+		}
+		if rs.unexpected {
+			line = warnStyle.Render("⚠ " + line + " " + i18n.T("unexpected"))
+		}
+		b.WriteString(line + "\n")
+
+		var flags []string
+		if ra.Managed {
+			flags = append(flags, "M")
+		}
+		if ra.Other {
+			flags = append(flags, "O")
+		}
+		if len(flags) == 0 {
+			flags = append(flags, "-")
+		}
+		b.WriteString(fmt.Sprintf("  "+i18n.T("lifetime %s  pref %s  flags %s  seen %s ago"),
+			raDuration(ra.Lifetime), ra.Preference, strings.Join(flags, ""), shortAgo(m.now().Sub(ra.ReceivedAt))))
+		if ra.MTU > 0 {
+			b.WriteString(fmt.Sprintf("  MTU %d", ra.MTU))
+		}
+		b.WriteString("\n")
+
+		for _, p := range ra.Prefixes {
+			var pf []string
+			if p.OnLink {
+				pf = append(pf, "L")
+			}
+			if p.Autonomous {
+				pf = append(pf, "A")
+			}
+			b.WriteString(fmt.Sprintf("  %s %s  "+i18n.T("valid %s  preferred %s")+"\n",
+				p.Prefix, strings.Join(pf, ""), raDuration(p.Valid), raDuration(p.Preferred)))
+		}
+		if len(ra.RDNSS) > 0 {
+			b.WriteString("  RDNSS " + strings.Join(ra.RDNSS, ", ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+func raDuration(d time.Duration) string {
+	if d < 0 {
+		return "∞"
+	}
+	if d == 0 {
+		return "0"
+	}
+	return shortAgo(d)
+}
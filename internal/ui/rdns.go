@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"net"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// answers gathered into one rdnsMsg, so a burst re-renders once
+const rdnsCoalesce = 64
+
+// rdnsMsg carries the reverse lookups finished since the last one.
+type rdnsMsg []probe.Resolved
+
+// waitRDNSCmd blocks until the resolver finishes a lookup, then takes the
+// others already done with it.
+func (m Model) waitRDNSCmd() tea.Cmd {
+	done := m.rdns.Done()
+	return func() tea.Msg {
+		msg := rdnsMsg{<-done}
+		for len(msg) < rdnsCoalesce {
+			select {
+			case res := <-done:
+				msg = append(msg, res)
+			default:
+				return msg
+			}
+		}
+		return msg
+	}
+}
+
+// applyRDNS fills in the names on the tables that show addresses. The
+// rows keep their keys, so the scroll position stays put.
+func (m *Model) applyRDNS(msg rdnsMsg) tea.Cmd {
+	named := false
+	for _, res := range msg {
+		named = named || len(res.Names) > 0
+	}
+	if named {
+		m.setConnsContent()
+		m.setPortsContent()
+		m.setTraceContent()
+	}
+	return m.waitRDNSCmd()
+}
+
+// hostName is the reverse DNS name of ip, "" until the lookup is back or
+// when there is none. Unknown addresses are queued for lookup; wildcard,
+// loopback and link-local ones say nothing a name would add, and are
+// skipped.
+func (m Model) hostName(ip string) string {
+	ip = strings.Trim(ip, "[]")
+	if strings.Contains(ip, "%") {
+		return ""
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsUnspecified() || parsed.IsLoopback() || parsed.IsLinkLocalUnicast() {
+		return ""
+	}
+	name, _ := m.rdns.Name(parsed.String())
+	return name
+}
+
+// withHostName puts the name of the host in addr ("ip:port") in place of
+// the address, cutting the name rather than the port to fit width.
+func (m Model) withHostName(addr string, width int) string {
+	ip, port := probe.SplitLocal(addr)
+	name := m.hostName(ip)
+	if name == "" || port == "" {
+		return trunc(addr, width)
+	}
+	return trunc(name, max(1, width-len(port)-1)) + ":" + port
+}
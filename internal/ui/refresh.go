@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// MinRefresh and MaxRefresh bound Options.Refresh.
+const (
+	MinRefresh = 500 * time.Millisecond
+	MaxRefresh = 30 * time.Second
+)
+
+// refreshSteps are the intervals + and - step through.
+var refreshSteps = []time.Duration{MinRefresh, time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second, MaxRefresh}
+
+// fetchTimes are when the probes that run on intervals of their own,
+// rather than on every tick, last ran.
+type fetchTimes struct{ slow, ports, procs, idle time.Time }
+
+func newFetchTimes(now time.Time) fetchTimes { return fetchTimes{now, now, now, now} }
+
+// due reports whether d has passed since *last, and if so sets it to now.
+// Ticks come Refresh apart give or take a little, so half a tick early
+// counts.
+func (m Model) due(last *time.Time, d time.Duration) bool {
+	now := m.now()
+	if now.Sub(*last) < d-m.opts.Refresh/2 {
+		return false
+	}
+	*last = now
+	return true
+}
+
+// stepRefresh makes the sampling interval a step longer (dir > 0) or
+// shorter. The tick loop starts over at the new interval, so going from
+// 30s to 1s doesn't wait out the tick already scheduled.
+func (m *Model) stepRefresh(dir int) tea.Cmd {
+	// the first step at least as long as the interval, which may be
+	// between two steps when set in the config file
+	i, found := slices.BinarySearch(refreshSteps, m.opts.Refresh)
+	switch {
+	case dir > 0 && found:
+		i++
+	case dir < 0:
+		i--
+	}
+	i = max(0, min(i, len(refreshSteps)-1))
+	if refreshSteps[i] == m.opts.Refresh {
+		return nil
+	}
+	m.opts.Refresh = refreshSteps[i]
+	m.notice = fmt.Sprintf(i18n.T("refreshing every %s"), refreshLabel(m.opts.Refresh))
+	m.tickLoop++
+	return tickEvery(m.opts.Refresh, m.tickLoop)
+}
+
+// refreshLabel writes an interval for the header, 0.5s included.
+func refreshLabel(d time.Duration) string {
+	if d < time.Second {
+		return d.String()
+	}
+	return humanDuration(d)
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/reputation"
+)
+
+// repPanel is the reputation lookup overlay: an address prompt, then the
+// provider's verdict.
+type repPanel struct {
+	open    bool
+	input   textinput.Model
+	loading bool
+	ip      string
+	report  *reputation.Report
+	err     error
+}
+
+type repMsg struct {
+	ip     string
+	report reputation.Report
+	err    error
+}
+
+func repCheckCmd(c reputation.Checker, ip string) tea.Cmd {
+	return func() tea.Msg {
+		r, err := c.Check(ip)
+		return repMsg{ip: ip, report: r, err: err}
+	}
+}
+
+// openRep shows the lookup prompt, prefilled with the latest blocklist hit.
+func (m *Model) openRep() {
+	in := textinput.New()
+	in.Prompt = i18n.T("IP: ")
+	in.Placeholder = "203.0.113.7"
+	in.CharLimit = 45
+	if n := len(m.blocked); n > 0 {
+		in.SetValue(m.blocked[n-1].conn.RemoteIP())
+	}
+	in.Focus()
+	m.rep = repPanel{open: true, input: in}
+}
+
+func (m Model) updateRep(km tea.KeyMsg) (Model, tea.Cmd) {
+	p := &m.rep
+	if p.loading {
+		if km.String() == "esc" {
+			p.open = false
+		}
+		return m, nil
+	}
+
+	if !p.input.Focused() {
+		// showing a result
+		switch km.String() {
+		case "enter":
+			p.report, p.err = nil, nil
+			p.input.Focus()
+		case "esc", "r", "q":
+			p.open = false
+		}
+		return m, nil
+	}
+
+	switch km.String() {
+	case "esc":
+		p.open = false
+		return m, nil
+	case "enter":
+		ip := net.ParseIP(strings.TrimSpace(p.input.Value()))
+		if ip == nil {
+			p.err = fmt.Errorf(i18n.T("not an IP address: %q"), p.input.Value())
+			return m, nil
+		}
+		p.ip, p.loading, p.err, p.report = ip.String(), true, nil, nil
+		p.input.Blur()
+		return m, repCheckCmd(m.opts.Reputation, p.ip)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(km)
+	return m, cmd
+}
+
+func (m *Model) applyRep(msg repMsg) {
+	if !m.rep.open || msg.ip != m.rep.ip {
+		return
+	}
+	m.rep.loading = false
+	if msg.err != nil {
+		m.rep.err = msg.err
+		return
+	}
+	m.rep.report = &msg.report
+}
+
+func (m Model) viewRep() string {
+	p := m.rep
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T("IP reputation (%s)"), m.opts.Reputation.Name())) + "\n")
+
+	switch {
+	case p.input.Focused():
+		b.WriteString(subtleStyle.Render(i18n.T("enter check • esc close")) + "\n\n")
+		b.WriteString(p.input.View() + "\n")
+		if p.err != nil {
+			b.WriteString("\n" + errStyle.Render(p.err.Error()) + "\n")
+		}
+		return b.String()
+	case p.loading:
+		b.WriteString("\n" + fmt.Sprintf(i18n.T("checking %s…"), p.ip) + "\n")
+		return b.String()
+	}
+
+	b.WriteString(subtleStyle.Render(i18n.T("enter check another • esc close")) + "\n\n")
+	if p.err != nil {
+		b.WriteString(p.ip + "\n" + errStyle.Render(i18n.T("Error: ")+p.err.Error()) + "\n")
+		return b.String()
+	}
+
+	r := p.report
+	score := fmt.Sprintf("%d/100", r.Score)
+	switch {
+	case r.Score >= 50:
+		score = errStyle.Render(score)
+	case r.Score > 0:
+		score = warnStyle.Render(score)
+	default:
+		score = okStyle.Render(score)
+	}
+	b.WriteString(fmt.Sprintf("%s  %s %s\n", titleStyle.Render(r.IP), i18n.T("abuse score"), score))
+	if r.ISP != "" || r.Country != "" {
+		b.WriteString(subtleStyle.Render(strings.TrimSpace(r.ISP+"  "+r.Country+"  "+r.Usage)) + "\n")
+	}
+	if n := m.opts.Notes.Host(r.IP); n != "" {
+		b.WriteString(okStyle.Render("# "+n) + "\n")
+	}
+	b.WriteString("\n" + fmt.Sprintf(i18n.T("%d reports from %d users"), r.Reports, r.Reporters) + "\n")
+	if !r.LastReported.IsZero() {
+		b.WriteString(fmt.Sprintf(i18n.T("last reported %s"), i18n.DateTime(r.LastReported)) + "\n")
+	}
+	if len(r.Categories) > 0 {
+		b.WriteString(i18n.T("Categories: ") + strings.Join(r.Categories, ", ") + "\n")
+	}
+	return b.String()
+}
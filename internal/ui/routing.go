@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type rulesMsg struct {
+	rules []probe.Rule
+	err   error
+}
+
+func (m Model) fetchRulesCmd() tea.Cmd {
+	return func() tea.Msg {
+		rs, err := m.ruleReader.Rules()
+		return rulesMsg{rules: rs, err: err}
+	}
+}
+
+// tabEnterCmd loads data that is only polled while its tab is visible;
+// nothing while paused.
+func (m Model) tabEnterCmd() tea.Cmd {
+	if m.paused {
+		return nil
+	}
+	switch m.activeTab {
+	case tabRouting:
+		return tea.Batch(m.fetchRulesCmd(), m.fetchNeighborsCmd())
+	case tabConns:
+		return tea.Batch(m.fetchConnsCmd(), m.fetchPeerBWCmd())
+	case tabIfaces:
+		return tea.Batch(m.fetchFirewallCmd(), m.fetchCastsCmd(), m.fetchQueuesCmd(), m.fetchWifiCmd(), m.fetchLinkCmd(), m.fetchLeaseCmd())
+	case tabFirewall:
+		return m.fetchFirewallRulesCmd()
+	case tabFlows:
+		return m.fetchFlowsCmd()
+	case tabStats:
+		return m.fetchDNSCacheCmd()
+	case tabOverview:
+		return m.fetchDNSConfigCmd()
+	}
+	return nil
+}
+
+func (m *Model) setRoutingContent() {
+	m.routingVP.SetContent(hardClipLinesToWidth(m.renderRoutingText(), m.routingVP.Width))
+}
+
+func (m Model) renderRoutingText() string {
+	w := m.routingVP.Width
+	if w <= 0 {
+		w = 120
+	}
+
+	var b strings.Builder
+	b.WriteString(m.renderSplitText())
+	b.WriteString(titleStyle.Render(i18n.T("Main routing table")) + "\n")
+	switch {
+	case m.routesErr != nil:
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.routesErr.Error()) + "\n")
+	case len(m.routes) == 0:
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+	default:
+		colDst, colGw, colDev := 28, 26, 12
+		if m.compact() {
+			colDst, colGw, colDev = 20, 16, 8
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n",
+			padRight(i18n.T("DESTINATION"), colDst),
+			padRight(i18n.T("GATEWAY"), colGw),
+			padRight(i18n.T("DEV"), colDev),
+			sorted(i18n.T("METRIC"), false),
+		))
+		b.WriteString(strings.Repeat("─", min(w, colDst+2+colGw+2+colDev+2+6)) + "\n")
+		for _, r := range m.routes {
+			dst := padRight(trunc(r.Dst, colDst), colDst)
+			if r.IsDefault() {
+				dst = okStyle.Render(dst)
+			}
+			b.WriteString(fmt.Sprintf("%s  %s  %s  %d\n",
+				dst,
+				padRight(trunc(r.Gateway, colGw), colGw),
+				padRight(trunc(r.Iface, colDev), colDev),
+				r.Metric,
+			))
+		}
+	}
+
+	b.WriteString("\n" + titleStyle.Render(i18n.T("Policy rules")) + "\n")
+	switch {
+	case m.rulesErr != nil:
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.rulesErr.Error()) + "\n")
+	case m.rules == nil:
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+	default:
+		const colPrio, colFam, colTable, colN = 6, 5, 10, 6
+		colSel := max(12, min(40, w-(colPrio+2+colFam+2+colTable+2+colN+2+26)))
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s\n",
+			padRight(sorted(i18n.T("PRIO"), false), colPrio),
+			padRight(i18n.T("FAM"), colFam),
+			padRight(i18n.T("SELECTOR"), colSel),
+			padRight(i18n.T("TABLE"), colTable),
+			padRight(i18n.T("ROUTES"), colN),
+			i18n.T("DEFAULT"),
+		))
+		b.WriteString(strings.Repeat("─", min(w, colPrio+2+colFam+2+colSel+2+colTable+2+colN+2+26)) + "\n")
+		for _, r := range m.rules {
+			fam := "v4"
+			if r.Family == "inet6" {
+				fam = "v6"
+			}
+			table, n := r.Table, fmt.Sprintf("%d", r.Routes)
+			if r.Action != "lookup" {
+				table, n = warnStyle.Render(padRight(r.Action, colTable)), ""
+			}
+			def := r.Default
+			if def == "" {
+				def = "-"
+			}
+			b.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s\n",
+				padRight(fmt.Sprintf("%d", r.Priority), colPrio),
+				padRight(fam, colFam),
+				padRight(trunc(r.Selector, colSel), colSel),
+				padRight(table, colTable),
+				padRight(n, colN),
+				def,
+			))
+		}
+	}
+	b.WriteString(m.renderNeighborsText(w))
+	b.WriteString(m.renderBGPText(w))
+	return b.String()
+}
+
+func (m Model) viewRouting() string {
+	w := min(m.w-2, 120)
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(m.routingVP.View())
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// stickyLines are the column header and the rule under it, kept at the top
+// of a table once they have scrolled away; see stickyHead.
+const stickyLines = 2
+
+// setPortsContent re-renders the Ports table. The row at the top of the
+// viewport stays there as long as it is still listed, so refreshes and
+// filter changes don't make the list jump. A row stays selected, see
+// nearestRow.
+func (m *Model) setPortsContent() {
+	anchor, off := anchorKey(m.portsKeys, m.portsVP)
+	text, keys, head := m.renderPorts()
+	if sel := nearestRow(m.portsKeys, keys, m.portsSel); sel != m.portsSel {
+		m.portsSel = sel
+		text, keys, head = m.renderPorts()
+	}
+	m.portsText = hardClipLinesToWidth(text, m.portsVP.Width)
+	m.portsKeys, m.portsHead = keys, head
+	m.portsVP.SetContent(m.portsText)
+	restoreAnchor(&m.portsVP, keys, anchor, off)
+}
+
+// setProcsContent also keeps a row selected, see nearestRow.
+func (m *Model) setProcsContent() {
+	anchor, off := anchorKey(m.procsKeys, m.procsVP)
+	text, keys, head := m.renderProcs()
+	if sel := nearestRow(m.procsKeys, keys, m.procsSel); sel != m.procsSel {
+		m.procsSel = sel
+		text, keys, head = m.renderProcs()
+	}
+	m.procsText = hardClipLinesToWidth(text, m.procsVP.Width)
+	m.procsKeys, m.procsHead = keys, head
+	m.procsVP.SetContent(m.procsText)
+	restoreAnchor(&m.procsVP, keys, anchor, off)
+}
+
+// anchorKey returns the key of the first row in view and its distance from
+// the top of the viewport; "" when scrolled to the top, where new rows
+// should simply push in.
+func anchorKey(keys []string, vp viewport.Model) (string, int) {
+	if vp.YOffset == 0 {
+		return "", 0
+	}
+	for i := vp.YOffset; i < len(keys) && i < vp.YOffset+vp.Height; i++ {
+		if keys[i] != "" {
+			return keys[i], i - vp.YOffset
+		}
+	}
+	return "", 0
+}
+
+func restoreAnchor(vp *viewport.Model, keys []string, anchor string, off int) {
+	if anchor == "" {
+		return
+	}
+	for i, k := range keys {
+		if k == anchor {
+			vp.SetYOffset(max(0, i-off))
+			return
+		}
+	}
+}
+
+// stickyRows is how many lines at the top of vp the sticky header covers,
+// head being the line of the column header.
+func stickyRows(vp viewport.Model, head int) int {
+	if head < 0 || vp.YOffset <= head {
+		return 0
+	}
+	return stickyLines
+}
+
+// stickyHead is the view of vp with the column header of text, at line
+// head, and its rule drawn over the top once they have scrolled away, so
+// the columns stay labelled however far the table is scrolled.
+func stickyHead(vp viewport.Model, text string, head int) string {
+	v := vp.View()
+	if stickyRows(vp, head) == 0 {
+		return v
+	}
+	lines := strings.Split(text, "\n")
+	view := strings.Split(v, "\n")
+	if head+stickyLines > len(lines) || len(view) <= stickyLines {
+		return v
+	}
+	for i := range stickyLines {
+		l := lines[head+i]
+		view[i] = l + strings.Repeat(" ", max(0, vp.Width-lipgloss.Width(l)))
+	}
+	return strings.Join(view, "\n")
+}
+
+// stepRow returns the keyed row delta rows away from sel, or sel at either
+// end, and scrolls vp to show it below the sticky header at head.
+func stepRow(keys []string, sel string, delta int, vp *viewport.Model, head int) string {
+	i := indexOf(keys, sel)
+	for j := i + delta; j >= 0 && j < len(keys); j += delta {
+		if keys[j] != "" {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return sel
+	}
+	switch {
+	case i < vp.YOffset+stickyRows(*vp, head):
+		vp.SetYOffset(max(0, i-stickyLines))
+	case i >= vp.YOffset+vp.Height:
+		vp.SetYOffset(i - vp.Height + 1)
+	}
+	return keys[i]
+}
+
+// rowInView returns sel, or when vp was scrolled past it, the keyed row in
+// view closest to where it was. Rows under the sticky header at head are
+// not in view.
+func rowInView(keys []string, sel string, vp viewport.Model, head int) string {
+	top := vp.YOffset + stickyRows(vp, head)
+	i := indexOf(keys, sel)
+	if i < 0 || i >= top && i < vp.YOffset+vp.Height {
+		return sel
+	}
+	first, last := -1, -1
+	for j := top; j < len(keys) && j < vp.YOffset+vp.Height; j++ {
+		if keys[j] != "" {
+			if first < 0 {
+				first = j
+			}
+			last = j
+		}
+	}
+	switch {
+	case first < 0:
+		return sel
+	case i < top:
+		return keys[first]
+	default:
+		return keys[last]
+	}
+}
+
+// nearestRow keeps sel when it is still listed, or else picks the row now
+// where it was, so a row going away doesn't send the selection to the top.
+func nearestRow(old, keys []string, sel string) string {
+	if indexOf(keys, sel) >= 0 {
+		return sel
+	}
+	i := max(0, indexOf(old, sel))
+	for j := i; j < len(keys); j++ {
+		if keys[j] != "" {
+			return keys[j]
+		}
+	}
+	for j := min(i, len(keys)) - 1; j >= 0; j-- {
+		if keys[j] != "" {
+			return keys[j]
+		}
+	}
+	return ""
+}
+
+func indexOf(keys []string, k string) int {
+	if k == "" {
+		return -1
+	}
+	for i, key := range keys {
+		if key == k {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/internal/porthistory"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// sessionStats accumulates what happened while ducknetview was running.
+// It is shared by pointer so copies of Model update the same record.
+type sessionStats struct {
+	startedAt time.Time
+
+	ifaces    map[string]*ifaceSession
+	ifaceSeen []string // first-seen order
+
+	listeners     map[string]bool // current set, nil until first ports sample
+	listenAdded   []string
+	listenRemoved []string
+	procPorts     map[string][]*listenSeen // by process name, in first-seen order
+
+	ipChanges []ipChange
+
+	rates []rateSample // physical throughput per snapshot, for the timeline
+}
+
+// a day of samples at the default refresh
+const maxRateSamples = 24 * 60 * 60
+
+type rateSample struct {
+	at     time.Time
+	rx, tx float64
+}
+
+type ifaceSession struct {
+	kind           probe.IfaceKind
+	lastRx, lastTx uint64
+	rxBytes        uint64
+	txBytes        uint64
+	peakRx, peakTx float64
+}
+
+// listenSeen is a port a program listened on during the session.
+type listenSeen struct {
+	proto, port string
+	first, last time.Time
+}
+
+type ipChange struct {
+	at       time.Time
+	from, to string
+}
+
+func newSessionStats(now time.Time) *sessionStats {
+	return &sessionStats{
+		startedAt: now,
+		ifaces:    map[string]*ifaceSession{},
+	}
+}
+
+func (s *sessionStats) addSnapshot(snap probe.NetSnapshot, at time.Time) {
+	r := rateSample{at: at}
+	for _, ii := range snap.Ifaces {
+		if ii.Kind == probe.IfacePhysical {
+			r.rx += ii.RxBps
+			r.tx += ii.TxBps
+		}
+		is := s.ifaces[ii.Name]
+		if is == nil {
+			is = &ifaceSession{kind: ii.Kind, lastRx: ii.RxTotal, lastTx: ii.TxTotal}
+			s.ifaces[ii.Name] = is
+			s.ifaceSeen = append(s.ifaceSeen, ii.Name)
+		}
+		// counters only grow; a smaller value means the interface was reset
+		if ii.RxTotal >= is.lastRx {
+			is.rxBytes += ii.RxTotal - is.lastRx
+		}
+		if ii.TxTotal >= is.lastTx {
+			is.txBytes += ii.TxTotal - is.lastTx
+		}
+		is.lastRx, is.lastTx = ii.RxTotal, ii.TxTotal
+		is.peakRx = max(is.peakRx, ii.RxBps)
+		is.peakTx = max(is.peakTx, ii.TxBps)
+	}
+	s.rates = append(s.rates, r)
+	// trimmed in batches rather than copied on every sample
+	if n := len(s.rates); n > maxRateSamples+maxRateSamples/10 {
+		s.rates = append([]rateSample(nil), s.rates[n-maxRateSamples:]...)
+	}
+}
+
+// rateAt returns the last sample taken at or before t.
+func (s *sessionStats) rateAt(t time.Time) (rateSample, bool) {
+	i := sort.Search(len(s.rates), func(i int) bool { return s.rates[i].at.After(t) })
+	if i == 0 {
+		return rateSample{}, false
+	}
+	return s.rates[i-1], true
+}
+
+// physicalBytes totals the session's traffic on physical links, which is
+// what a metered uplink bills; tunnels and bridges ride on top of them.
+func (s *sessionStats) physicalBytes() (rx, tx uint64) {
+	for _, is := range s.ifaces {
+		if is.kind == probe.IfacePhysical {
+			rx += is.rxBytes
+			tx += is.txBytes
+		}
+	}
+	return rx, tx
+}
+
+// renderSessionTotals renders the Overview lines with the bytes moved since
+// ducknetview started, in total and per interface.
+func (m Model) renderSessionTotals(width int) string {
+	s := m.session
+	rx, tx := s.physicalBytes()
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T("This session (%s)"), humanDuration(m.now().Sub(s.startedAt)))))
+	b.WriteString(fmt.Sprintf("  "+i18n.T("RX %s  TX %s on physical links")+"\n",
+		i18n.Number(probe.HumanBytes(rx)), i18n.Number(probe.HumanBytes(tx))))
+
+	parts := make([]string, 0, len(s.ifaceSeen))
+	for _, n := range s.ifaceSeen {
+		is := s.ifaces[n]
+		if is.kind == probe.IfaceLoopback || is.rxBytes+is.txBytes == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s ↓%s ↑%s", n,
+			i18n.Number(probe.HumanBytes(is.rxBytes)), i18n.Number(probe.HumanBytes(is.txBytes))))
+	}
+	if len(parts) > 0 {
+		b.WriteString(clampToWidthOneLine(subtleStyle.Render(strings.Join(parts, "  •  ")), width) + "\n")
+	}
+	return b.String()
+}
+
+// addPorts records the listeners added and removed since the last sample
+// and returns them, sorted.
+func (s *sessionStats) addPorts(ports []probe.ListenPort) (added, removed []string) {
+	cur := make(map[string]bool, len(ports))
+	for _, p := range ports {
+		cur[listenerKey(p)] = true
+	}
+	if s.listeners != nil {
+		for k := range cur {
+			if !s.listeners[k] {
+				added = append(added, k)
+			}
+		}
+		for k := range s.listeners {
+			if !cur[k] {
+				removed = append(removed, k)
+			}
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	s.listenAdded = append(s.listenAdded, added...)
+	s.listenRemoved = append(s.listenRemoved, removed...)
+	s.listeners = cur
+	return added, removed
+}
+
+// addListenHistory records, per program, the ports it listens on at at,
+// and returns those it hadn't listened on before in the session.
+func (s *sessionStats) addListenHistory(ports []probe.ListenPort, at time.Time) []porthistory.Entry {
+	if s.procPorts == nil {
+		s.procPorts = map[string][]*listenSeen{}
+	}
+	var fresh []porthistory.Entry
+	for _, p := range ports {
+		if p.Process == "" {
+			continue
+		}
+		_, port := probe.SplitLocal(p.Local)
+		var seen *listenSeen
+		for _, ls := range s.procPorts[p.Process] {
+			if ls.proto == p.Proto && ls.port == port {
+				seen = ls
+				break
+			}
+		}
+		if seen == nil {
+			seen = &listenSeen{proto: p.Proto, port: port, first: at}
+			s.procPorts[p.Process] = append(s.procPorts[p.Process], seen)
+			fresh = append(fresh, porthistory.Entry{Process: p.Process, Proto: p.Proto, Port: port, First: at})
+		}
+		seen.last = at
+	}
+	return fresh
+}
+
+func (s *sessionStats) addExternalIP(ip, prev string, at time.Time) {
+	if prev == "" || ip == prev {
+		return
+	}
+	s.ipChanges = append(s.ipChanges, ipChange{at: at, from: prev, to: ip})
+}
+
+func listenerKey(p probe.ListenPort) string {
+	k := p.Proto + " " + p.Local
+	if p.Process != "" {
+		k += " (" + p.Process + ")"
+	}
+	return k
+}
+
+// Summary renders a plain-text report of the session, suitable for printing
+// to stdout after the TUI has exited.
+func (m Model) Summary() string {
+	s := m.session
+	if s == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(i18n.T("ducknetview session summary") + "\n")
+	b.WriteString(fmt.Sprintf(i18n.T("Duration: %s")+"\n\n", humanDuration(m.now().Sub(s.startedAt))))
+
+	b.WriteString(i18n.T("Interfaces:") + "\n")
+	nameW := 0
+	for _, n := range s.ifaceSeen {
+		nameW = max(nameW, len(n))
+	}
+	for _, n := range s.ifaceSeen {
+		is := s.ifaces[n]
+		b.WriteString(fmt.Sprintf("  %s  RX %s  TX %s  %s RX %s  TX %s\n",
+			padRight(n, nameW),
+			i18n.Number(probe.HumanBytes(is.rxBytes)),
+			i18n.Number(probe.HumanBytes(is.txBytes)),
+			i18n.T("peak"),
+			humanRate(is.peakRx, m.units),
+			humanRate(is.peakTx, m.units),
+		))
+	}
+
+	added := append([]string(nil), s.listenAdded...)
+	removed := append([]string(nil), s.listenRemoved...)
+	sort.Strings(added)
+	sort.Strings(removed)
+	b.WriteString("\n" + fmt.Sprintf(i18n.T("Listeners: %d added, %d removed"), len(added), len(removed)) + "\n")
+	for _, k := range added {
+		b.WriteString("  + " + k + "\n")
+	}
+	for _, k := range removed {
+		b.WriteString("  - " + k + "\n")
+	}
+
+	b.WriteString("\n" + fmt.Sprintf(i18n.T("External IP changes: %d"), len(s.ipChanges)) + "\n")
+	for _, c := range s.ipChanges {
+		b.WriteString(fmt.Sprintf("  %s  %s -> %s\n", i18n.Clock(c.at), c.from, c.to))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// timelineLines is the height of the session timeline on the Events tab,
+// with the blank line below it.
+const timelineLines = 5
+
+// scrubber is the moment picked on the session timeline: an event, by
+// number (see eventLog.dropped), or the live end when off.
+type scrubber struct {
+	on  bool
+	seq int
+}
+
+// timelineMarks are the timeline's glyphs per kind; the higher kind wins
+// where events share a column.
+var timelineMarks = [...]string{
+	evNote:     "•",
+	evListener: "+",
+	evIface:    "↕",
+	evIP:       "@",
+	evAlert:    "!",
+}
+
+// scrubbed returns the index in events.entries of the event picked on the
+// timeline, if any.
+func (m Model) scrubbed() (int, bool) {
+	n := len(m.events.entries)
+	if !m.scrub.on || n == 0 {
+		return 0, false
+	}
+	// the event may have been dropped from the log since
+	return min(max(m.scrub.seq-m.events.dropped, 0), n-1), true
+}
+
+// stepScrub moves the timeline cursor by delta events; stepping back from
+// live picks the newest event, stepping past the newest returns to live.
+func (m *Model) stepScrub(delta int) {
+	l := m.events
+	last := l.dropped + len(l.entries) - 1
+	switch {
+	case len(l.entries) == 0:
+		return
+	case !m.scrub.on && delta < 0:
+		m.scrub = scrubber{on: true, seq: last}
+	case !m.scrub.on:
+		return
+	default:
+		i, _ := m.scrubbed()
+		seq := l.dropped + i + delta
+		if seq > last {
+			m.scrub = scrubber{}
+		} else {
+			m.scrub.seq = max(seq, l.dropped)
+		}
+	}
+	m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+	if i, ok := m.scrubbed(); ok {
+		// the list is newest first
+		line := len(l.entries) - 1 - i
+		if line < m.eventsVP.YOffset || line >= m.eventsVP.YOffset+m.eventsVP.Height {
+			m.eventsVP.SetYOffset(line - m.eventsVP.Height/2)
+		}
+	}
+}
+
+// renderSessionTimeline draws the session from start to now: the events,
+// physical throughput below them, the time axis with the picked moment,
+// and the throughput at that moment or a legend.
+func (m Model) renderSessionTimeline() string {
+	w := m.eventsVP.Width
+	start, end := m.session.startedAt, m.now()
+	span := end.Sub(start)
+	if span <= 0 {
+		span = time.Second
+	}
+	col := func(t time.Time) int {
+		return min(max(int(float64(t.Sub(start))/float64(span)*float64(w-1)), 0), w-1)
+	}
+
+	kinds := make([]eventKind, w)
+	hit := make([]bool, w)
+	for _, e := range m.events.entries {
+		c := col(e.at)
+		if !hit[c] || e.kind > kinds[c] {
+			kinds[c], hit[c] = e.kind, true
+		}
+	}
+	var marks strings.Builder
+	for c := range w {
+		switch {
+		case !hit[c]:
+			marks.WriteString(subtleStyle.Render("─"))
+		case kinds[c] == evAlert:
+			marks.WriteString(warnStyle.Render(timelineMarks[evAlert]))
+		default:
+			marks.WriteString(accentStyle.Render(timelineMarks[kinds[c]]))
+		}
+	}
+
+	// the busiest moment of each column, so short bursts show
+	bps := make([]float64, w)
+	for _, r := range m.session.rates {
+		c := col(r.at)
+		bps[c] = max(bps[c], r.rx+r.tx)
+	}
+
+	axis := []rune(strings.Repeat(" ", w))
+	copy(axis, []rune(i18n.Clock(start)))
+	nowLabel := []rune(i18n.T("now"))
+	copy(axis[max(0, w-len(nowLabel)):], nowLabel)
+	i, scrubbing := m.scrubbed()
+	var info string
+	if scrubbing {
+		e := m.events.entries[i]
+		axis[col(e.at)] = '▲'
+		info = fmt.Sprintf("▲ %s  %s", i18n.Clock(e.at), m.ago(e.at))
+		if r, ok := m.session.rateAt(e.at); ok {
+			info += "  " + fmt.Sprintf(i18n.T("↓ %s  ↑ %s on physical links"), humanRate(r.rx, m.units), humanRate(r.tx, m.units))
+		}
+		info += "   " + subtleStyle.Render(i18n.T("[ ] step • esc back to live"))
+	} else {
+		info = subtleStyle.Render(i18n.T("! alert  @ external IP  ↕ interface  + listener  • other") + "   " + i18n.T("[ ] step through events"))
+	}
+
+	return marks.String() + "\n" +
+		subtleStyle.Render(Spark(bps, w)) + "\n" +
+		subtleStyle.Render(string(axis)) + "\n" +
+		clampToWidthOneLine(info, w) + "\n"
+}
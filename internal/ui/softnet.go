@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type softnetMsg struct {
+	stats []probe.SoftnetStat
+	err   error
+	at    time.Time
+}
+
+// softnetRates are the network stack counters of every CPU summed up, per
+// second since the previous sample, and the CPU that ran the most NET_RX
+// softirqs with its share.
+type softnetRates struct {
+	processed, dropped, squeezed float64
+	rx, tx                       float64
+	busiest                      int
+	busiestShare                 float64
+}
+
+func (m Model) fetchSoftnetCmd() tea.Cmd {
+	return func() tea.Msg {
+		s, err := m.softnetReader.Softnet()
+		return softnetMsg{stats: s, err: err, at: m.now()}
+	}
+}
+
+func (m *Model) applySoftnet(msg softnetMsg) {
+	m.softnetErr = msg.err
+	if msg.err != nil {
+		m.softnet, m.softnetKnown = nil, false
+		return
+	}
+	prev := map[int]probe.SoftnetStat{}
+	for _, s := range m.softnet {
+		prev[s.CPU] = s
+	}
+	dt := msg.at.Sub(m.softnetAt).Seconds()
+	var r softnetRates
+	var busiestRx float64
+	known := len(prev) > 0 && dt > 0
+	for _, s := range msg.stats {
+		p, ok := prev[s.CPU]
+		if !ok || s.Processed < p.Processed || s.NetRx < p.NetRx {
+			continue
+		}
+		rx := probe.CounterRate(p.NetRx, s.NetRx, dt)
+		r.processed += probe.CounterRate(p.Processed, s.Processed, dt)
+		r.dropped += probe.CounterRate(p.Dropped, s.Dropped, dt)
+		r.squeezed += probe.CounterRate(p.Squeezed, s.Squeezed, dt)
+		r.rx += rx
+		r.tx += probe.CounterRate(p.NetTx, s.NetTx, dt)
+		if rx > busiestRx {
+			r.busiest, busiestRx = s.CPU, rx
+		}
+	}
+	if r.rx > 0 {
+		r.busiestShare = busiestRx / r.rx
+	}
+	m.softnet, m.softnetAt = msg.stats, msg.at
+	if known {
+		m.softnetRates, m.softnetKnown = r, true
+	}
+}
+
+// renderSoftnet renders the Overview's network stack pressure: how busy the
+// softirqs that take packets off the NICs are and whether the host drops
+// some of what they delivered, which the interface counters never show.
+func (m Model) renderSoftnet() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Network stack")) + "\n")
+	switch {
+	case m.softnetErr != nil:
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.softnetErr.Error()) + "\n")
+		return b.String()
+	case !m.softnetKnown:
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return b.String()
+	}
+	r := m.softnetRates
+	num := func(v float64) string { return i18n.Number(fmt.Sprintf("%.0f", v)) }
+
+	var dropped, squeezed uint64
+	for _, s := range m.softnet {
+		dropped += s.Dropped
+		squeezed += s.Squeezed
+	}
+	state := okStyle.Render(i18n.T("ok"))
+	switch {
+	case r.dropped > 0:
+		state = errStyle.Render(i18n.T("dropping"))
+	case r.squeezed > 0:
+		state = warnStyle.Render(i18n.T("busy"))
+	}
+	b.WriteString(fmt.Sprintf(i18n.T("%s  softirqs NET_RX %s/s, NET_TX %s/s • %s packets/s"),
+		state, num(r.rx), num(r.tx), num(r.processed)) + "\n")
+	if len(m.softnet) > 1 && r.rx > 0 {
+		b.WriteString(subtleStyle.Render(i18n.Number(fmt.Sprintf(i18n.T("busiest CPU %d runs %.0f%% of NET_RX"),
+			r.busiest, r.busiestShare*100))) + "\n")
+	}
+	b.WriteString(i18n.T("Backlog drops: ") + counterCell("", dropped, r.dropped) + "  " +
+		i18n.T("budget squeezes: ") + counterCell("", squeezed, r.squeezed) + "\n")
+	switch {
+	case r.dropped > 0:
+		b.WriteString(subtleStyle.Render(i18n.T("The NICs delivered these packets but the CPU's backlog queue was full: raise net.core.netdev_max_backlog, or spread the load with RPS.")) + "\n")
+	case r.squeezed > 0:
+		b.WriteString(subtleStyle.Render(i18n.T("Softirqs ran out of budget with packets left: net.core.netdev_budget may be too low for this rate.")) + "\n")
+	}
+	return b.String()
+}
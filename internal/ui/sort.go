@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Tables are re-sorted on arrival with a full tie-break, so rows keep their
+// place between refreshes whatever order a probe returns them in.
+
+// tableSort is the column a table is ordered by (s cycles through them) and
+// its direction (S reverses it).
+type tableSort struct {
+	col  int
+	desc bool
+}
+
+// Ports table columns.
+const (
+	portsByProto = iota
+	portsByPort
+	portsByPID
+	portsByProcess
+	portsColCount
+)
+
+// Processes table columns.
+const (
+	procsByConns = iota
+	procsByListen
+	procsByPID
+	procsByName
+	procsColCount
+)
+
+var (
+	defaultPortsSort = tableSort{col: portsByProto}
+	defaultProcsSort = tableSort{col: procsByConns, desc: true}
+)
+
+// next moves to the following of n columns; counts start out descending,
+// everything else ascending.
+func (s tableSort) next(n int, descending ...int) tableSort {
+	s.col = (s.col + 1) % n
+	s.desc = false
+	for _, c := range descending {
+		s.desc = s.desc || c == s.col
+	}
+	return s
+}
+
+// header marks h when the table is ordered by col.
+func (s tableSort) header(h string, col int) string {
+	if s.col != col {
+		return h
+	}
+	return sorted(h, s.desc)
+}
+
+func portNumber(p probe.ListenPort) int {
+	n, _ := strconv.Atoi(p.Local[strings.LastIndexByte(p.Local, ':')+1:])
+	return n
+}
+
+func sortPorts(ps []probe.ListenPort, s tableSort) {
+	sort.SliceStable(ps, func(i, j int) bool {
+		a, b := ps[i], ps[j]
+		if s.desc {
+			a, b = b, a
+		}
+		switch s.col {
+		case portsByPort:
+			if pa, pb := portNumber(a), portNumber(b); pa != pb {
+				return pa < pb
+			}
+		case portsByPID:
+			if a.PID != b.PID {
+				return a.PID < b.PID
+			}
+		case portsByProcess:
+			if a.Process != b.Process {
+				return a.Process < b.Process
+			}
+		}
+		if a.Proto != b.Proto {
+			return a.Proto < b.Proto
+		}
+		if a.Local != b.Local {
+			return a.Local < b.Local
+		}
+		if a.PID != b.PID {
+			return a.PID < b.PID
+		}
+		return a.Process < b.Process
+	})
+}
+
+func sortProcs(ps []probe.ProcNet, s tableSort) {
+	sort.SliceStable(ps, func(i, j int) bool {
+		a, b := ps[i], ps[j]
+		if s.desc {
+			a, b = b, a
+		}
+		switch s.col {
+		case procsByConns:
+			if a.ConnCount != b.ConnCount {
+				return a.ConnCount < b.ConnCount
+			}
+		case procsByListen:
+			if a.ListenCount != b.ListenCount {
+				return a.ListenCount < b.ListenCount
+			}
+		case procsByName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		}
+		return a.PID < b.PID
+	})
+}
+
+// sortHint is the key help shown above a sortable table.
+func sortHint() string {
+	return subtleStyle.Render(i18n.T("s sort column • S reverse"))
+}
+
+// sorted marks the column a table is ordered by.
+func sorted(header string, desc bool) string {
+	if desc {
+		return header + "▼"
+	}
+	return header + "▲"
+}
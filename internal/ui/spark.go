@@ -1,10 +1,34 @@
 package ui
 
-import "strings"
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// chartKind is how the Interfaces tab draws its RX/TX charts.
+type chartKind int
+
+const (
+	chartSpark chartKind = iota
+	chartBars
+	chartBraille
+	chartKinds
+)
 
 // sparkline blocks: low -> high
 var blocks = []rune("▁▂▃▄▅▆▇█")
 
+// sparkBreak in a history marks a gap, such as counters restarting; Spark
+// draws it as sparkGap and leaves it out of the scale.
+var sparkBreak = math.NaN()
+
+const sparkGap = '┊'
+
 func Spark(values []float64, width int) string {
 	if width <= 0 {
 		return ""
@@ -16,23 +40,28 @@ func Spark(values []float64, width int) string {
 	if len(values) > width {
 		values = values[len(values)-width:]
 	}
-	minV, maxV := values[0], values[0]
+	minV, maxV := math.Inf(1), math.Inf(-1)
 	for _, v := range values {
-		if v < minV {
-			minV = v
-		}
-		if v > maxV {
-			maxV = v
+		if math.IsNaN(v) {
+			continue
 		}
+		minV = math.Min(minV, v)
+		maxV = math.Max(maxV, v)
 	}
-	// avoid div-by-zero
+	// avoid div-by-zero; also the case of nothing but breaks
 	span := maxV - minV
-	if span <= 1e-9 {
-		return strings.Repeat(string(blocks[0]), len(values)) + strings.Repeat(" ", width-len(values))
-	}
+	flat := !(span > 1e-9)
 
 	var b strings.Builder
 	for _, v := range values {
+		if math.IsNaN(v) {
+			b.WriteRune(sparkGap)
+			continue
+		}
+		if flat {
+			b.WriteRune(blocks[0])
+			continue
+		}
 		n := (v - minV) / span
 		idx := int(n * float64(len(blocks)-1))
 		if idx < 0 {
@@ -49,3 +78,233 @@ func Spark(values []float64, width int) string {
 	}
 	return b.String()
 }
+
+// barRows draws the last width values as columns height rows tall, top row
+// first and right-aligned, scaled from 0 to the largest value, which it returns. Breaks are
+// a column of sparkGap.
+func barRows(values []float64, width, height int) ([]string, float64) {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	top := 0.0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			top = math.Max(top, v)
+		}
+	}
+
+	rows := make([]string, height)
+	for r := range rows {
+		below := float64(height - 1 - r)
+		// the newest value goes at the right edge
+		var b strings.Builder
+		b.WriteString(strings.Repeat(" ", width-len(values)))
+		for _, v := range values {
+			if math.IsNaN(v) {
+				b.WriteRune(sparkGap)
+				continue
+			}
+			// how much of this cell the column fills, in cells
+			fill := 0.0
+			if top > 0 {
+				fill = v/top*float64(height) - below
+			}
+			switch {
+			case fill >= 1:
+				b.WriteRune(blocks[len(blocks)-1])
+			case fill > 0:
+				b.WriteRune(blocks[int(fill*float64(len(blocks)-1))])
+			default:
+				b.WriteRune(' ')
+			}
+		}
+		rows[r] = b.String()
+	}
+	return rows, top
+}
+
+// barChart is the larger alternative to Spark: the last values as a
+// histogram height rows tall under the top of its scale, written by label,
+// over the span of time they cover.
+func barChart(values []float64, width, height int, label func(float64) string, span time.Duration) string {
+	cols := max(1, width-1)
+	rows, top := barRows(values, cols, height)
+	return chartFrame(rows, top, span, cols, width, label)
+}
+
+// chartFrame puts rows under the top of their scale, on an axis cols wide,
+// over a line with the time they span.
+func chartFrame(rows []string, top float64, span time.Duration, cols, width int, label func(float64) string) string {
+	var b strings.Builder
+	b.WriteString(subtleStyle.Render("▲ "+label(top)) + "\n")
+	for _, row := range rows {
+		b.WriteString(subtleStyle.Render("│") + row + "\n")
+	}
+	b.WriteString(subtleStyle.Render("└"+strings.Repeat("─", cols)) + "\n")
+	ago := "-" + humanDuration(span)
+	now := i18n.T("now")
+	gap := max(1, width-lipgloss.Width(ago)-lipgloss.Width(now))
+	b.WriteString(subtleStyle.Render(ago+strings.Repeat(" ", gap)+now) + "\n")
+	return b.String()
+}
+
+// band is what one column of a chart stands for: the least, greatest and
+// sum of the n values it covers. Breaks aren't counted, so a band of
+// nothing but breaks is empty.
+type band struct {
+	lo, hi, sum float64
+	n           int
+}
+
+func bandOf(v float64) band {
+	if math.IsNaN(v) {
+		return band{}
+	}
+	return band{lo: v, hi: v, sum: v, n: 1}
+}
+
+func (b band) merge(o band) band {
+	switch {
+	case o.n == 0:
+		return b
+	case b.n == 0:
+		return o
+	}
+	return band{lo: math.Min(b.lo, o.lo), hi: math.Max(b.hi, o.hi), sum: b.sum + o.sum, n: b.n + o.n}
+}
+
+func (b band) avg() float64 { return b.sum / float64(b.n) }
+
+// fitBands spreads bs over n columns: several share a column when there
+// are more than n, so that a spike shows however many do, and each spans
+// several when there are fewer.
+func fitBands(bs []band, n int) []band {
+	if len(bs) == 0 || n <= 0 {
+		return nil
+	}
+	out := make([]band, n)
+	for i := range out {
+		lo := i * len(bs) / n
+		for _, b := range bs[lo:max(lo+1, (i+1)*len(bs)/n)] {
+			out[i] = out[i].merge(b)
+		}
+	}
+	return out
+}
+
+// bandAvgs is the mean of each of bs, for the charts that draw one value a
+// column, with sparkBreak for the empty ones.
+func bandAvgs(bs []band) []float64 {
+	out := make([]float64, len(bs))
+	for i, b := range bs {
+		out[i] = sparkBreak
+		if b.n > 0 {
+			out[i] = b.avg()
+		}
+	}
+	return out
+}
+
+// brailleBits are the dots of a braille cell by row, then left or right.
+var brailleBits = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// brailleRows draws the last bs in width cells of two dot columns each,
+// height rows of four dots tall, scaled from 0 to the greatest value, which
+// it returns. Each dot column is a band: dimmed from its least to its
+// greatest value, with the mean in the accent colour. Empty bands are left
+// blank.
+func brailleRows(bs []band, width, height int) ([]string, float64) {
+	if len(bs) > 2*width {
+		bs = bs[len(bs)-2*width:]
+	}
+	top := 0.0
+	for _, b := range bs {
+		if b.n > 0 {
+			top = math.Max(top, b.hi)
+		}
+	}
+	levels := height * 4
+	level := func(v float64) int {
+		if top <= 0 {
+			return 0
+		}
+		return min(levels-1, max(0, int(v/top*float64(levels-1)+0.5)))
+	}
+
+	// the newest values go at the right edge
+	pad := 2*width - len(bs)
+	rows := make([]string, height)
+	for r := range rows {
+		var b strings.Builder
+		var run strings.Builder
+		var runStyle *lipgloss.Style
+		flush := func() {
+			if runStyle != nil {
+				b.WriteString(runStyle.Render(run.String()))
+			} else {
+				b.WriteString(run.String())
+			}
+			run.Reset()
+		}
+		for c := range width {
+			cell, mean := rune(0), false
+			for side := range 2 {
+				i := 2*c + side - pad
+				if i < 0 || bs[i].n == 0 {
+					continue
+				}
+				lo, avg, hi := level(bs[i].lo), level(bs[i].avg()), level(bs[i].hi)
+				for dot := range 4 {
+					// dot levels count up from the bottom of the chart
+					l := (height-1-r)*4 + 3 - dot
+					if l >= lo && l <= hi {
+						cell |= brailleBits[dot][side]
+						mean = mean || l == avg
+					}
+				}
+			}
+			style := (*lipgloss.Style)(nil)
+			switch {
+			case mean:
+				style = &accentStyle
+			case cell != 0:
+				style = &subtleStyle
+			}
+			if style != runStyle {
+				flush()
+				runStyle = style
+			}
+			if cell == 0 {
+				run.WriteRune(' ')
+			} else {
+				run.WriteRune(0x2800 + cell)
+			}
+		}
+		flush()
+		rows[r] = b.String()
+	}
+	return rows, top
+}
+
+// brailleChart is barChart for bands, which shows what each column
+// covers rather than only its mean.
+func brailleChart(bs []band, width, height int, label func(float64) string, span time.Duration) string {
+	cols := max(1, width-1)
+	rows, top := brailleRows(bs, cols, height)
+	return chartFrame(rows, top, span, cols, width, label)
+}
+
+// gauge renders a horizontal bar filled to frac (0..1) of width.
+func gauge(frac float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := int(frac*float64(width) + 0.5)
+	if n < 0 {
+		n = 0
+	}
+	if n > width {
+		n = width
+	}
+	return strings.Repeat("█", n) + strings.Repeat("░", width-n)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// tunnelSplit is the split-tunnel audit of one address family.
+type tunnelSplit struct {
+	family  string
+	full    string   // how everything is sent through a VPN, "" when it isn't
+	through []string // prefixes routed into a VPN, e.g. "10.8.0.0/24 → wg0"
+	around  []string // more specific prefixes that bypass a full tunnel
+	warn    string
+}
+
+// halfDefaults are the two halves OpenVPN's def1 (and wg-quick without
+// policy routing) use to override the default route without replacing it.
+var halfDefaults = map[string][2]string{
+	"inet":  {"0.0.0.0/1", "128.0.0.0/1"},
+	"inet6": {"::/1", "8000::/1"},
+}
+
+// auditSplit works out, per family, whether traffic goes through the VPN
+// interfaces in vpns: all of it (a default route, both half-defaults, or a
+// policy rule whose table defaults into the VPN) or only some prefixes.
+func auditSplit(routes []probe.Route, rules []probe.Rule, vpns map[string]bool) []tunnelSplit {
+	var out []tunnelSplit
+	for _, fam := range []string{"inet", "inet6"} {
+		s := tunnelSplit{family: fam}
+		halves := map[string]string{}
+		var def *probe.Route
+		for i, r := range routes {
+			if r.Family != fam {
+				continue
+			}
+			switch {
+			case r.IsDefault():
+				// sorted by metric: the first one is used
+				if def == nil {
+					def = &routes[i]
+				}
+			case r.Dst == halfDefaults[fam][0] || r.Dst == halfDefaults[fam][1]:
+				halves[r.Dst] = r.Iface
+			}
+		}
+
+		lo, hi := halves[halfDefaults[fam][0]], halves[halfDefaults[fam][1]]
+		switch {
+		case vpns[lo] && vpns[hi]:
+			s.full = fmt.Sprintf(i18n.T("%s + %s via %s"), halfDefaults[fam][0], halfDefaults[fam][1], lo)
+		case def != nil && vpns[def.Iface]:
+			s.full = fmt.Sprintf(i18n.T("default route via %s"), def.Iface)
+		}
+		for _, r := range rules {
+			if s.full != "" || r.Family != fam || r.Action != "lookup" || r.Table == "main" || r.Table == "local" || r.Table == "default" {
+				continue
+			}
+			if _, dev, ok := strings.Cut(" "+r.Default, " dev "); ok && vpns[strings.Fields(dev)[0]] {
+				s.full = fmt.Sprintf(i18n.T("policy rule %d → table %s via %s"), r.Priority, r.Table, strings.Fields(dev)[0])
+			}
+		}
+
+		for _, r := range routes {
+			if r.Family != fam || r.IsDefault() || r.Dst == halfDefaults[fam][0] || r.Dst == halfDefaults[fam][1] || linkLocal(r.Dst) {
+				continue
+			}
+			if vpns[r.Iface] {
+				s.through = append(s.through, r.Dst+" → "+r.Iface)
+			} else if s.full != "" {
+				s.around = append(s.around, r.Dst+" → "+r.Iface)
+			}
+		}
+
+		if s.full == "" && len(s.through) == 0 {
+			if def == nil {
+				// no connectivity in this family, nothing to leak
+				continue
+			}
+			s.warn = fmt.Sprintf(i18n.T("nothing goes through the VPN; all traffic uses %s"), def.Iface)
+		}
+		out = append(out, s)
+	}
+
+	// an untunnelled family is only suspicious when the other is fully
+	// tunnelled, or when the VPN carries nothing at all
+	used := false
+	for _, s := range out {
+		used = used || s.warn == ""
+	}
+	if used {
+		for i := range out {
+			out[i].warn = ""
+		}
+	}
+	// the classic leak: IPv4 fully tunnelled, IPv6 left on the uplink
+	if len(out) == 2 && out[0].full != "" && out[1].full == "" {
+		out[1].warn = i18n.T("IPv4 is fully tunnelled but IPv6 is not: IPv6 traffic bypasses the VPN")
+	}
+	return out
+}
+
+func linkLocal(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && (ip.IsLinkLocalUnicast() || ip.IsMulticast())
+}
+
+// renderSplitText renders the audit for the Routing tab, or "" when no VPN
+// interface is up.
+func (m Model) renderSplitText() string {
+	vpns := map[string]bool{}
+	var names []string
+	for _, ii := range m.lastSnap.Ifaces {
+		if isVPN(ii.Kind) && ii.IsUp {
+			vpns[ii.Name] = true
+			names = append(names, ii.Name)
+		}
+	}
+	if len(vpns) == 0 || m.routesErr != nil || len(m.routes) == 0 {
+		return ""
+	}
+
+	// long lists are summarized; the tables below have the rest
+	const maxList = 6
+	list := func(b *strings.Builder, label string, items []string) {
+		shown := items
+		if len(shown) > maxList {
+			shown = shown[:maxList]
+		}
+		b.WriteString("  " + label + " " + strings.Join(shown, ", "))
+		if n := len(items) - len(shown); n > 0 {
+			b.WriteString(subtleStyle.Render(fmt.Sprintf(" "+i18n.T("(+%d more)"), n)))
+		}
+		b.WriteString("\n")
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("VPN split")) + "  " + subtleStyle.Render(strings.Join(names, ", ")) + "\n")
+	for _, s := range auditSplit(m.routes, m.rules, vpns) {
+		fam := "IPv4"
+		if s.family == "inet6" {
+			fam = "IPv6"
+		}
+		switch {
+		case s.full != "":
+			b.WriteString(fmt.Sprintf("%s  %s  %s\n", fam, okStyle.Render(i18n.T("full tunnel")), subtleStyle.Render(s.full)))
+			if len(s.around) > 0 {
+				list(&b, i18n.T("bypassing:"), s.around)
+			}
+		case len(s.through) > 0:
+			b.WriteString(fmt.Sprintf("%s  %s  %s\n", fam, warnStyle.Render(i18n.T("split tunnel")),
+				subtleStyle.Render(fmt.Sprintf(i18n.T("%d prefixes through, everything else around"), len(s.through)))))
+			list(&b, i18n.T("through:"), s.through)
+		default:
+			b.WriteString(fmt.Sprintf("%s  %s\n", fam, warnStyle.Render(i18n.T("not tunnelled"))))
+		}
+		if s.warn != "" {
+			b.WriteString("  " + errStyle.Render("⚠ "+s.warn) + "\n")
+		}
+	}
+	return b.String() + "\n"
+}
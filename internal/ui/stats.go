@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	// redirects/sec (v4+v6) considered a burst worth alerting on
+	redirectBurstRate = 5.0
+	alertHold         = 30 * time.Second
+)
+
+type icmpMsg struct {
+	counters []probe.ICMPCounter
+	err      error
+}
+
+func (m Model) fetchICMPCmd() tea.Cmd {
+	return func() tea.Msg {
+		c, err := m.icmpReader.ICMP()
+		return icmpMsg{counters: c, err: err}
+	}
+}
+
+func (m *Model) applyICMP(msg icmpMsg) {
+	m.icmp, m.icmpErr = msg.counters, msg.err
+
+	redirects := 0.0
+	for _, c := range msg.counters {
+		if c.Name == "InRedirects" {
+			redirects += c.Rate
+		}
+	}
+	if redirects >= redirectBurstRate {
+		m.alert = fmt.Sprintf(i18n.T("ICMP redirect burst: %s/s received"), i18n.Number(fmt.Sprintf("%.1f", redirects)))
+		m.alertAt = m.now()
+	}
+}
+
+// activeAlert returns the current alert text, if it is still fresh.
+func (m Model) activeAlert() string {
+	if m.alert == "" || m.now().Sub(m.alertAt) > alertHold {
+		return ""
+	}
+	return m.alert
+}
+
+func (m Model) viewStats() string {
+	w := min(m.w-2, 120)
+
+	var b strings.Builder
+	b.WriteString(m.renderDNSCache())
+	b.WriteString(titleStyle.Render(i18n.T("ICMP messages")) + "\n\n")
+
+	if m.icmpErr != nil {
+		b.WriteString(subtleStyle.Render(i18n.T("n/a")+": "+m.icmpErr.Error()) + "\n")
+		return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+	}
+	if len(m.icmp) == 0 {
+		b.WriteString(i18n.T("No data (yet)…") + "\n")
+		return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+	}
+
+	const colProto, colName, colTotal = 6, 16, 14
+	b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n",
+		padRight(i18n.T("PROTO"), colProto),
+		padRight(i18n.T("COUNTER"), colName),
+		padRight(i18n.T("TOTAL"), colTotal),
+		i18n.T("RATE/s"),
+	))
+	b.WriteString(strings.Repeat("─", min(w-2, colProto+2+colName+2+colTotal+2+8)) + "\n")
+
+	for _, c := range m.icmp {
+		rate := i18n.Number(fmt.Sprintf("%.1f", c.Rate))
+		if c.Rate > 0 {
+			rate = okStyle.Render(rate)
+		}
+		if c.Name == "InRedirects" && c.Rate > 0 {
+			rate = warnStyle.Render(i18n.Number(fmt.Sprintf("%.1f", c.Rate)))
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s\n",
+			padRight(c.Proto, colProto),
+			padRight(c.Name, colName),
+			padRight(fmt.Sprintf("%d", c.Total), colTotal),
+			rate,
+		))
+	}
+
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(b.String())
+}
@@ -1,13 +1,156 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 var (
-	titleStyle    = lipgloss.NewStyle().Bold(true)
-	subtleStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
-	okStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
-	warnStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
-	errStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-	boxStyle      = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
-	selectedStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	titleStyle = lipgloss.NewStyle().Bold(true)
+	boxStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+
+	// set by the theme, dark until SetTheme
+	subtleStyle, okStyle, warnStyle, errStyle, accentStyle lipgloss.Style
+	selectedStyle, markedStyle                             lipgloss.Style
 )
+
+func init() { Themes["dark"].apply() }
+
+// Theme is the colours of the UI, each a 256-colour number ("214") or a
+// hex RGB ("#ffaf00"). Empty ones are left uncoloured.
+type Theme struct {
+	Subtle, OK, Warn, Err, Accent string
+	SelectedFG, SelectedBG        string // the cursor row
+	MarkedFG, MarkedBG            string // rows marked with space
+}
+
+// Themes are the built-in themes. mono, which has no colours, isn't one:
+// SetTheme knows it apart.
+var Themes = map[string]Theme{
+	"dark": {
+		Subtle: "241", OK: "42", Warn: "214", Err: "196", Accent: "39",
+		SelectedFG: "229", SelectedBG: "57", MarkedFG: "229", MarkedBG: "238",
+	},
+	// darker shades that stay readable on a white background
+	"light": {
+		Subtle: "244", OK: "28", Warn: "130", Err: "160", Accent: "25",
+		SelectedFG: "231", SelectedBG: "25", MarkedBG: "153",
+	},
+	// the brightest colours on black, and no greys dim enough to fade
+	"high-contrast": {
+		Subtle: "252", OK: "46", Warn: "226", Err: "196", Accent: "51",
+		SelectedFG: "16", SelectedBG: "226", MarkedFG: "16", MarkedBG: "51",
+	},
+}
+
+// ThemeNames lists the themes SetTheme knows, for flag help and errors.
+func ThemeNames() []string {
+	names := []string{"mono"}
+	for n := range Themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// color is the field of t that a [colors] key of the config file sets.
+func (t *Theme) color(key string) *string {
+	switch key {
+	case "subtle":
+		return &t.Subtle
+	case "ok":
+		return &t.OK
+	case "warn":
+		return &t.Warn
+	case "error":
+		return &t.Err
+	case "accent":
+		return &t.Accent
+	case "selected_fg":
+		return &t.SelectedFG
+	case "selected_bg":
+		return &t.SelectedBG
+	case "marked_fg":
+		return &t.MarkedFG
+	case "marked_bg":
+		return &t.MarkedBG
+	}
+	return nil
+}
+
+// validColor accepts what lipgloss takes for a terminal colour.
+func validColor(s string) bool {
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return len(hex) == 6 && err == nil
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func colored(fg, bg string) lipgloss.Style {
+	st := lipgloss.NewStyle()
+	if fg != "" {
+		st = st.Foreground(lipgloss.Color(fg))
+	}
+	if bg != "" {
+		st = st.Background(lipgloss.Color(bg))
+	}
+	return st
+}
+
+func (t Theme) apply() {
+	subtleStyle = colored(t.Subtle, "")
+	okStyle = colored(t.OK, "")
+	warnStyle = colored(t.Warn, "")
+	errStyle = colored(t.Err, "")
+	accentStyle = colored(t.Accent, "")
+	selectedStyle = colored(t.SelectedFG, t.SelectedBG).Bold(true)
+	markedStyle = colored(t.MarkedFG, t.MarkedBG)
+}
+
+// SetTheme switches the colour styles to the theme called name (dark if
+// empty), with colors, by the keys of a config's [colors] table, over
+// its own. mono has no colours, so it ignores them. Like i18n.Set it is
+// global and meant to be called once, before the UI starts.
+func SetTheme(name string, colors map[string]string) error {
+	if name == "mono" {
+		// emphasis only, for monochrome terminals, NO_COLOR and screenshots
+		subtleStyle = lipgloss.NewStyle().Faint(true)
+		okStyle = lipgloss.NewStyle()
+		warnStyle = lipgloss.NewStyle().Bold(true)
+		errStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+		accentStyle = lipgloss.NewStyle()
+		selectedStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+		markedStyle = lipgloss.NewStyle().Underline(true)
+		return nil
+	}
+	if name == "" {
+		name = "dark"
+	}
+	t, ok := Themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (want %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+	keys := make([]string, 0, len(colors))
+	for k := range colors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f := t.color(k)
+		if f == nil {
+			return fmt.Errorf("[colors] unknown key %s", k)
+		}
+		if !validColor(colors[k]) {
+			return fmt.Errorf("[colors] %s: want a colour number 0-255 or #rrggbb", k)
+		}
+		*f = colors[k]
+	}
+	t.apply()
+	return nil
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// talker is a remote host with the established connections to it, for the
+// Top talkers view of the Connections tab (g).
+type talker struct {
+	ip    string
+	conns []string // by connKey
+	bw    probe.ProcBandwidth
+}
+
+// talkerKey is the row key of a Top talkers row; unlike a connKey it
+// doesn't start with a protocol.
+func talkerKey(ip string) string { return "host " + ip }
+
+// topTalkers groups the established connections by remote address, the
+// most connected first, then the busiest. Loopback peers are this host.
+func (m Model) topTalkers() []talker {
+	idx := map[string]int{}
+	var out []talker
+	for _, c := range m.conns {
+		ip := c.RemoteIP()
+		if c.Status != "ESTABLISHED" {
+			continue
+		}
+		if p := net.ParseIP(ip); p == nil || p.IsLoopback() {
+			continue
+		}
+		i, ok := idx[ip]
+		if !ok {
+			i = len(out)
+			idx[ip] = i
+			out = append(out, talker{ip: ip, bw: m.peerBW[ip]})
+		}
+		out[i].conns = append(out[i].conns, connKey(c))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if len(a.conns) != len(b.conns) {
+			return len(a.conns) > len(b.conns)
+		}
+		if ta, tb := a.bw.RxBytes+a.bw.TxBytes, b.bw.RxBytes+b.bw.TxBytes; ta != tb {
+			return ta > tb
+		}
+		return a.ip < b.ip
+	})
+	return out
+}
+
+// selectedTalker is the Top talkers row selected, if one is.
+func (m Model) selectedTalker() (talker, bool) {
+	ip, ok := strings.CutPrefix(m.connsSel, "host ")
+	if !ok {
+		return talker{}, false
+	}
+	for _, t := range m.topTalkers() {
+		if t.ip == ip {
+			return t, true
+		}
+	}
+	return talker{}, false
+}
+
+// renderTalkers is the Top talkers view, through line as in renderConns.
+// Byte totals need the peer bandwidth of ss and cover only the sockets
+// open now.
+func (m Model) renderTalkers(line func(s, key string), w int) {
+	showBytes := m.peerBW != nil
+	sub := i18n.T("established connections by remote host")
+	if !showBytes {
+		sub += ", " + i18n.T("no byte counts here")
+	}
+	line(titleStyle.Render(i18n.T("Top talkers"))+"  "+subtleStyle.Render(sub+" • "+i18n.T("g all connections")), "")
+	line("", "")
+
+	colAddr, colConns, colBytes := 26, 5, 11
+	if m.compact() {
+		colAddr = 21
+	}
+	rest := 2 + colConns
+	if showBytes {
+		rest += 2 + colBytes + 2 + colBytes
+	}
+	colName := min(40, max(10, w-colAddr-2-rest))
+	h := padRight(i18n.T("REMOTE"), colAddr) + "  " + padRight(i18n.T("NAME"), colName) + "  " + padRight(i18n.T("CONNS"), colConns)
+	if showBytes {
+		h += "  " + padRight("↓ "+i18n.T("TOTAL"), colBytes) + "  " + padRight("↑ "+i18n.T("TOTAL"), colBytes)
+	}
+	line(h, "")
+	line(strings.Repeat("─", min(w, colAddr+2+colName+rest)), "")
+
+	ts := m.topTalkers()
+	if len(ts) == 0 {
+		line(subtleStyle.Render(i18n.T("no established connections to other hosts")), "")
+		return
+	}
+	for _, t := range ts {
+		row := padRight(trunc(t.ip, colAddr), colAddr) + "  " +
+			padRight(trunc(m.hostName(t.ip), colName), colName) + "  " +
+			padRight(fmt.Sprint(len(t.conns)), colConns)
+		if showBytes {
+			row += "  " + padRight(i18n.Number(probe.HumanBytes(t.bw.RxBytes)), colBytes) +
+				"  " + padRight(i18n.Number(probe.HumanBytes(t.bw.TxBytes)), colBytes)
+		}
+		if n := m.opts.Notes.Host(t.ip); n != "" {
+			row += "  " + subtleStyle.Render("# "+n)
+		}
+		switch k := talkerKey(t.ip); {
+		case k == m.connsSel:
+			row = selectedStyle.Render(row)
+		case m.connsMarked.all(t.conns):
+			row = markedStyle.Render(row)
+		}
+		line(row, talkerKey(t.ip))
+	}
+}
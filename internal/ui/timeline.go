@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// gone listeners stay visible this long so flapping services are noticed
+const goneRetention = 2 * time.Minute
+
+// portTimeline remembers when each listener was first and last seen.
+type portTimeline struct {
+	seen    map[string]*portSeen
+	started bool // first ports sample processed
+}
+
+type portSeen struct {
+	port    probe.ListenPort
+	first   time.Time
+	last    time.Time
+	goneAt  time.Time // first sample the listener was missing from
+	initial bool      // already listening when ducknetview started
+	gone    bool
+}
+
+func newPortTimeline() *portTimeline {
+	return &portTimeline{seen: map[string]*portSeen{}}
+}
+
+func (t *portTimeline) update(ports []probe.ListenPort, now time.Time) {
+	cur := make(map[string]bool, len(ports))
+	for _, p := range ports {
+		k := listenerKey(p)
+		cur[k] = true
+		ps := t.seen[k]
+		if ps == nil || ps.gone {
+			ps = &portSeen{port: p, first: now, initial: !t.started}
+			t.seen[k] = ps
+		}
+		ps.last = now
+	}
+	for k, ps := range t.seen {
+		if cur[k] {
+			continue
+		}
+		if !ps.gone {
+			ps.gone = true
+			ps.goneAt = now
+		}
+		if now.Sub(ps.goneAt) > goneRetention {
+			delete(t.seen, k)
+		}
+	}
+	t.started = true
+}
+
+func (t *portTimeline) get(p probe.ListenPort) *portSeen {
+	return t.seen[listenerKey(p)]
+}
+
+// gone returns recently disappeared listeners, most recent first.
+func (t *portTimeline) goneList() []*portSeen {
+	var out []*portSeen
+	for _, ps := range t.seen {
+		if ps.gone {
+			out = append(out, ps)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].goneAt.Equal(out[j].goneAt) {
+			return out[i].goneAt.After(out[j].goneAt)
+		}
+		return listenerKey(out[i].port) < listenerKey(out[j].port)
+	})
+	return out
+}
+
+// label describes a listener's lifetime, e.g. "new 12s ago".
+func (ps *portSeen) label(now time.Time) string {
+	switch {
+	case ps == nil:
+		return ""
+	case ps.gone:
+		return fmt.Sprintf(i18n.T("gone %s ago"), shortAgo(now.Sub(ps.goneAt)))
+	case ps.initial:
+		return "-"
+	default:
+		return fmt.Sprintf(i18n.T("new %s ago"), shortAgo(now.Sub(ps.first)))
+	}
+}
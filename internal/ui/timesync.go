@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+const (
+	// timeSyncEvery is how often the clock is checked, and so how often
+	// ntpServer may be asked.
+	timeSyncEvery = 5 * time.Minute
+	// ntpServer is asked for the offset when no local daemon knows it.
+	ntpServer = "pool.ntp.org"
+
+	// clock offsets worth a look, and ones that break TOTP codes, Kerberos
+	// and, a little later, TLS certificate checks
+	skewWarn = time.Second
+	skewBad  = 30 * time.Second
+)
+
+type timeSyncMsg struct {
+	ts  probe.TimeSync
+	err error
+}
+
+// checkTimeSyncCmd asks the local time daemons, and ntpServer when none
+// of them knows the offset and the link isn't metered (or not known yet
+// not to be).
+func (m Model) checkTimeSyncCmd() tea.Cmd {
+	server := ntpServer
+	if m.metered || m.opts.Metered == MeteredAuto && !m.meteredChecked {
+		server = ""
+	}
+	return func() tea.Msg {
+		ts, err := m.timeSyncer.TimeSync(server)
+		return timeSyncMsg{ts: ts, err: err}
+	}
+}
+
+// timeSyncDue reports whether the periodic clock check should run now.
+// The startup check only asks the local daemons, so it doesn't count.
+func (m Model) timeSyncDue() bool {
+	return m.timeSyncAt.IsZero() || m.now().Sub(m.timeSyncAt) >= timeSyncEvery
+}
+
+// applyTimeSync records a check and logs the clock losing or regaining
+// synchronization.
+func (m *Model) applyTimeSync(msg timeSyncMsg) {
+	prev, hadPrev := m.timeSync, m.timeSyncErr == nil && m.timeSync.Known
+	m.timeSync, m.timeSyncErr = msg.ts, msg.err
+	if msg.err != nil || !msg.ts.Known || !hadPrev || prev.Synced == msg.ts.Synced {
+		return
+	}
+	text, kind := i18n.T("system clock synchronized again"), evNote
+	if !msg.ts.Synced {
+		text, kind = i18n.T("system clock lost NTP synchronization"), evAlert
+		m.alert, m.alertAt = text, m.now()
+	}
+	m.events.add(m.now(), kind, text)
+	m.eventsVP.SetContent(hardClipLinesToWidth(m.renderEventsText(), m.eventsVP.Width))
+}
+
+// renderTimeSyncLine is the Overview line about the system clock.
+func (m Model) renderTimeSyncLine() string {
+	ts := m.timeSync
+	switch {
+	case m.timeSyncErr != nil:
+		return fmt.Sprintf(i18n.T("Clock sync: %s"), subtleStyle.Render(i18n.T("n/a")+": "+m.timeSyncErr.Error()))
+	case !ts.Known && !ts.HasOffset:
+		return fmt.Sprintf(i18n.T("Clock sync: %s"), "…")
+	}
+
+	var state string
+	switch {
+	case !ts.Known:
+		state = subtleStyle.Render(i18n.T("no time daemon"))
+	case ts.Synced:
+		state = okStyle.Render(i18n.T("synchronized"))
+	default:
+		state = warnStyle.Render(i18n.T("not synchronized"))
+	}
+	via := ts.Daemon
+	if ts.Server != "" {
+		if via != "" {
+			via += ", "
+		}
+		via += ts.Server
+	}
+	if via != "" {
+		state += " " + subtleStyle.Render("("+via+")")
+	}
+	line := fmt.Sprintf(i18n.T("Clock sync: %s"), state)
+	if !ts.HasOffset {
+		return line
+	}
+
+	off := fmt.Sprintf(i18n.T("offset %s"), humanOffset(ts.Offset))
+	if ts.OffsetFrom != ts.Daemon {
+		off += " " + fmt.Sprintf(i18n.T("per %s"), ts.OffsetFrom)
+	}
+	skew := ts.Offset.Abs()
+	switch {
+	case skew >= skewBad:
+		off = errStyle.Render(off + "  " + i18n.T("TLS, TOTP and Kerberos may fail"))
+	case skew >= skewWarn:
+		off = warnStyle.Render(off)
+	}
+	return line + "  " + off
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// tracePanel is the Traceroute tab: a host prompt and the hops of the
+// latest trace, filled in as they come back.
+type tracePanel struct {
+	input   textinput.Model
+	host    string
+	run     *traceRun
+	running bool
+	hops    []probe.Hop
+	err     error
+	started time.Time
+	took    time.Duration
+}
+
+// traceRun connects one trace to the UI; messages of an older run are
+// dropped by id.
+type traceRun struct {
+	id     int
+	hops   chan probe.Hop
+	done   chan error
+	cancel context.CancelFunc
+}
+
+type traceHopMsg struct {
+	id  int
+	hop probe.Hop
+}
+
+type traceDoneMsg struct {
+	id  int
+	err error
+}
+
+func newTraceInput() textinput.Model {
+	in := textinput.New()
+	in.Prompt = i18n.T("Host: ")
+	in.Placeholder = "example.com"
+	in.CharLimit = 253
+	return in
+}
+
+// startTrace stops any running trace and starts one to host.
+func (m *Model) startTrace(host string) tea.Cmd {
+	m.stopTrace()
+	ctx, cancel := context.WithCancel(context.Background())
+	id := 1
+	if m.trace.run != nil {
+		id = m.trace.run.id + 1
+	}
+	r := &traceRun{id: id, hops: make(chan probe.Hop), done: make(chan error, 1), cancel: cancel}
+	m.trace.host, m.trace.run, m.trace.running = host, r, true
+	m.trace.hops, m.trace.err, m.trace.started, m.trace.took = nil, nil, m.now(), 0
+	m.setTraceContent()
+
+	tracer := m.tracer
+	return func() tea.Msg {
+		go func() { r.done <- tracer.Traceroute(ctx, host, r.hops) }()
+		return waitTraceCmd(r)()
+	}
+}
+
+func (m *Model) stopTrace() {
+	if m.trace.running {
+		m.trace.run.cancel()
+	}
+}
+
+// waitTraceCmd delivers the next hop, or the end of the trace. Hops are
+// sent unbuffered, so all of them are in before done is.
+func waitTraceCmd(r *traceRun) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case h := <-r.hops:
+			return traceHopMsg{id: r.id, hop: h}
+		case err := <-r.done:
+			return traceDoneMsg{id: r.id, err: err}
+		}
+	}
+}
+
+func (m *Model) applyTraceHop(msg traceHopMsg) tea.Cmd {
+	if m.trace.run == nil || msg.id != m.trace.run.id {
+		return nil
+	}
+	m.trace.hops = append(m.trace.hops, msg.hop)
+	m.setTraceContent()
+	return waitTraceCmd(m.trace.run)
+}
+
+func (m *Model) applyTraceDone(msg traceDoneMsg) {
+	if m.trace.run == nil || msg.id != m.trace.run.id {
+		return
+	}
+	m.trace.running = false
+	m.trace.took = m.now().Sub(m.trace.started)
+	if !errors.Is(msg.err, context.Canceled) {
+		m.trace.err = msg.err
+	}
+	m.setTraceContent()
+}
+
+// updateTrace handles the keys of the Traceroute tab; ok is false for
+// keys it leaves to the viewport.
+func (m Model) updateTrace(km tea.KeyMsg) (Model, tea.Cmd, bool) {
+	t := &m.trace
+	if t.input.Focused() {
+		switch km.String() {
+		case "esc":
+			t.input.Blur()
+			return m, nil, true
+		case "enter":
+			host, _, err := splitTarget(t.input.Value())
+			if err != nil {
+				t.err = err
+				m.setTraceContent()
+				return m, nil, true
+			}
+			t.input.Blur()
+			return m, m.startTrace(host), true
+		}
+		var cmd tea.Cmd
+		t.input, cmd = t.input.Update(km)
+		return m, cmd, true
+	}
+
+	switch km.String() {
+	case "enter", "/":
+		t.input.Focus()
+		t.input.CursorEnd()
+		return m, nil, true
+	case "esc":
+		m.stopTrace()
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+func (m *Model) setTraceContent() {
+	m.traceVP.SetContent(hardClipLinesToWidth(m.renderTraceText(), m.traceVP.Width))
+}
+
+func (m Model) renderTraceText() string {
+	w := m.traceVP.Width
+	if w <= 0 {
+		w = 120
+	}
+
+	var b strings.Builder
+	switch {
+	case m.trace.host == "" && m.trace.err == nil:
+		b.WriteString(subtleStyle.Render(i18n.T("Press enter, type a host and press enter again to trace the route to it.")) + "\n")
+		return b.String()
+	case m.trace.err != nil:
+		b.WriteString(errStyle.Render(i18n.T("Error: ")+m.trace.err.Error()) + "\n\n")
+	}
+	if m.trace.host == "" {
+		return b.String()
+	}
+
+	colAddr, colT := 39, 9
+	if m.compact() {
+		colAddr, colT = 15, 8
+	}
+	hdr := fmt.Sprintf("%s  %s  %s  %s  %s  %s  %s",
+		padRight(i18n.T("HOP"), 3), padRight(i18n.T("ADDRESS"), colAddr),
+		padRight(i18n.T("AVG"), colT), padRight(i18n.T("BEST"), colT), padRight(i18n.T("WORST"), colT),
+		padRight(i18n.T("LOSS"), 5), padRight(i18n.T("NAME"), 16))
+	b.WriteString(hdr + "\n")
+	b.WriteString(strings.Repeat("─", min(w, utf8.RuneCountInString(hdr))) + "\n")
+
+	for _, h := range m.trace.hops {
+		addr, avg, best, worst := "*", "-", "-", "-"
+		if h.Addr != "" {
+			addr = h.Addr
+		}
+		if n := len(h.RTTs); n > 0 {
+			lo, hi, sum := h.RTTs[0], h.RTTs[0], time.Duration(0)
+			for _, d := range h.RTTs {
+				if d < lo {
+					lo = d
+				}
+				if d > hi {
+					hi = d
+				}
+				sum += d
+			}
+			avg, best, worst = humanRTT(sum/time.Duration(n)), humanRTT(lo), humanRTT(hi)
+		}
+		loss := padRight(fmt.Sprintf("%d/%d", h.Lost(), h.Sent), 5)
+		switch {
+		case h.Sent > 0 && h.Lost() == h.Sent:
+			loss = subtleStyle.Render(loss)
+		case h.Lost() > 0:
+			loss = warnStyle.Render(loss)
+		}
+		name := ""
+		if h.Addr != "" {
+			name = m.hostName(h.Addr)
+		}
+		if h.Note != "" {
+			name = strings.TrimSpace(errStyle.Render(h.Note) + " " + name)
+		}
+		b.WriteString(fmt.Sprintf("%s  %s  %s  %s  %s  %s  %s\n",
+			padRight(fmt.Sprintf("%2d", h.TTL), 3), padRight(trunc(addr, colAddr), colAddr),
+			padRight(avg, colT), padRight(best, colT), padRight(worst, colT), loss, name))
+	}
+
+	switch {
+	case m.trace.running:
+		b.WriteString("\n" + subtleStyle.Render(fmt.Sprintf(i18n.T("tracing %s… (esc stops)"), m.trace.host)) + "\n")
+	case m.trace.err == nil:
+		b.WriteString("\n" + okStyle.Render(fmt.Sprintf(i18n.T("done in %s"), humanDuration(m.trace.took))) + "\n")
+	}
+	return b.String()
+}
+
+func (m Model) viewTrace() string {
+	w := min(m.w-2, 120)
+
+	line := titleStyle.Render(i18n.T("Traceroute"))
+	if m.trace.host != "" {
+		line += "  " + m.trace.host
+	}
+	line += "  " + subtleStyle.Render(i18n.T("enter new trace"))
+	if m.trace.input.Focused() {
+		line = m.trace.input.View()
+	}
+	return boxStyle.Width(w).Height(m.bodyHeight()).Render(line + "\n\n" + m.traceVP.View())
+}
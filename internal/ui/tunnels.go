@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+type tunnelsMsg []probe.Tunnel
+
+// fetchTunnelsCmd runs after each ports refresh, since tunnels are matched
+// against the listeners. Failures just leave the last result.
+func (m Model) fetchTunnelsCmd(ports []probe.ListenPort) tea.Cmd {
+	return func() tea.Msg {
+		ts, err := m.tunnelLister.Tunnels(ports)
+		if err != nil {
+			return nil
+		}
+		return tunnelsMsg(ts)
+	}
+}
+
+var tunnelKinds = map[string]string{"local": "-L", "remote": "-R", "dynamic": "-D", "socks": "socks"}
+
+func (m Model) tunnelMatches(t probe.Tunnel) bool {
+	q := m.portsQuery
+	return q == "" || containsFold(t.Listen, q) || containsFold(t.Target, q) ||
+		containsFold(t.Server, q) || containsFold(t.Process, q)
+}
+
+// renderTunnels renders the Tunnels group shown above the listeners, or ""
+// when there are none.
+func (m Model) renderTunnels(w int) string {
+	var rows []string
+	colKind, colAddr := 6, 24
+	if m.compact() {
+		colAddr = 18
+	}
+	for _, t := range m.tunnels {
+		if !m.tunnelMatches(t) {
+			continue
+		}
+		target := t.Target
+		switch {
+		case target != "":
+			target = m.withHostName(target, colAddr)
+		case t.Kind == "dynamic" || t.Kind == "socks" || (t.Kind == "remote" && t.Process != "sshd"):
+			target = i18n.T("any (SOCKS)")
+		default:
+			target = "?"
+		}
+		via := t.Process
+		if t.Server != "" {
+			via += " " + t.Server
+		}
+		via = fmt.Sprintf("%s (%d)", via, t.PID)
+		row := fmt.Sprintf("%s  %s → %s  %s",
+			padRight(tunnelKinds[t.Kind], colKind),
+			padRight(trunc(t.Listen, colAddr), colAddr),
+			padRight(trunc(target, colAddr), colAddr),
+			via,
+		)
+		row = highlightFold(trunc(row, w), m.portsQuery)
+		if !t.Active && t.Kind != "remote" {
+			row = subtleStyle.Render(row + "  " + i18n.T("(not listening)"))
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return titleStyle.Render(i18n.T("Tunnels")) + "\n" + strings.Join(rows, "\n") + "\n\n"
+}
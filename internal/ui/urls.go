@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/nexusriot/ducknetview/internal/desktop"
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+var (
+	httpPorts  = map[int]bool{80: true, 3000: true, 3001: true, 4200: true, 5000: true, 5173: true, 8000: true, 8008: true, 8080: true, 8081: true, 8888: true, 9000: true, 9090: true}
+	httpsPorts = map[int]bool{443: true, 8443: true, 9443: true}
+)
+
+// listenerURL guesses a browsable URL for HTTP-looking TCP listeners.
+func listenerURL(p probe.ListenPort) (string, bool) {
+	if p.Proto != "tcp" {
+		return "", false
+	}
+	ip, ps := probe.SplitLocal(p.Local)
+	port, err := strconv.Atoi(ps)
+	if err != nil {
+		return "", false
+	}
+
+	scheme := ""
+	switch {
+	case httpPorts[port]:
+		scheme = "http"
+	case httpsPorts[port]:
+		scheme = "https"
+	default:
+		return "", false
+	}
+
+	host := ip
+	if p.IsWildcard() {
+		host = "localhost"
+	}
+	if (scheme == "http" && port == 80) || (scheme == "https" && port == 443) {
+		if net.ParseIP(host) != nil && net.ParseIP(host).To4() == nil {
+			host = "[" + host + "]"
+		}
+		return scheme + "://" + host + "/", true
+	}
+	return scheme + "://" + net.JoinHostPort(host, ps) + "/", true
+}
+
+// listenerURLs returns the distinct URLs of all HTTP-looking listeners.
+func listenerURLs(ports []probe.ListenPort) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, p := range ports {
+		u, ok := listenerURL(p)
+		if !ok || seen[u] {
+			continue
+		}
+		seen[u] = true
+		out = append(out, u)
+	}
+	return out
+}
+
+type urlPicker struct {
+	open   bool
+	urls   []string
+	cursor int
+}
+
+func openURLCmd(u string) tea.Cmd {
+	return func() tea.Msg {
+		if err := desktop.OpenURL(u); err != nil {
+			return noticeMsg{err: err}
+		}
+		return noticeMsg{text: fmt.Sprintf(i18n.T("opened %s"), u)}
+	}
+}
+
+func copyCmd(s string) tea.Cmd {
+	return func() tea.Msg {
+		if err := desktop.Copy(s); err != nil {
+			return noticeMsg{err: err}
+		}
+		return noticeMsg{text: fmt.Sprintf(i18n.T("copied %s"), s)}
+	}
+}
+
+func (m Model) updateURLPicker(km tea.KeyMsg) (Model, tea.Cmd) {
+	p := &m.urlPicker
+	switch km.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.urls)-1 {
+			p.cursor++
+		}
+	case "enter":
+		p.open = false
+		return m, openURLCmd(p.urls[p.cursor])
+	case "c":
+		p.open = false
+		return m, copyCmd(p.urls[p.cursor])
+	case "esc", "o", "q":
+		p.open = false
+	}
+	return m, nil
+}
+
+func (m Model) viewURLPicker() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Open local URL")) + "\n")
+	b.WriteString(subtleStyle.Render(i18n.T("enter open • c copy • esc close")) + "\n\n")
+	for i, u := range m.urlPicker.urls {
+		line := "  " + u
+		if i == m.urlPicker.cursor {
+			line = selectedStyle.Render("> " + u)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestViewFitsTerminal(t *testing.T) {
+	for _, size := range [][2]int{{60, 20}, {80, 24}, {120, 40}} {
+		w, h := size[0], size[1]
+		m := newTestModel(t, w, h, Options{})
+		for tb := tab(0); tb < tabCount; tb++ {
+			t.Run(fmt.Sprintf("%dx%d/%s", w, h, tabNames[tb].full), func(t *testing.T) {
+				m.setTab(tb)
+				v := m.View()
+				if got := lipgloss.Height(v); got != h {
+					t.Errorf("%d lines, want %d", got, h)
+				}
+				if got := lipgloss.Width(v); got > w {
+					t.Errorf("%d columns, want at most %d", got, w)
+				}
+				header := ansiSeq.ReplaceAllString(strings.SplitN(v, "\n", 2)[0], "")
+				full := fmt.Sprintf("%d %s", tb+1, tabNames[tb].full)
+				short := fmt.Sprintf("%d %s", tb+1, tabNames[tb].short)
+				if !m.tabHidden(tb) && !strings.Contains(header, full) && !strings.Contains(header, short) {
+					t.Errorf("the active tab isn't in the header %q", header)
+				}
+			})
+		}
+	}
+}
+
+func TestJoinTabsWithinWidth(t *testing.T) {
+	tabs := make([]string, tabCount)
+	for tb := range tabs {
+		tabs[tb] = fmt.Sprintf("%d %s", tb+1, tabNames[tb].short)
+	}
+	for _, maxW := range []int{12, 30, 60, 200} {
+		for active := range tabs {
+			got := ansiSeq.ReplaceAllString(joinTabsWithinWidth(tabs, active, maxW), "")
+			if w := lipgloss.Width(got); w > maxW {
+				t.Errorf("width %d, tab %d: %q is %d wide", maxW, active, got, w)
+			}
+			if !strings.Contains(got, tabs[active]) {
+				t.Errorf("width %d, tab %d: %q doesn't show it", maxW, active, got)
+			}
+			if cut := !strings.HasPrefix(got, tabs[0]); cut != strings.HasPrefix(got, "…") {
+				t.Errorf("width %d, tab %d: %q, cut on the left %v", maxW, active, got, cut)
+			}
+			if cut := !strings.HasSuffix(got, tabs[len(tabs)-1]); cut != strings.HasSuffix(got, "…") {
+				t.Errorf("width %d, tab %d: %q, cut on the right %v", maxW, active, got, cut)
+			}
+		}
+	}
+}
+
+func TestOverviewScrolls(t *testing.T) {
+	m := newTestModel(t, 80, 24, Options{})
+	m.setTab(tabOverview)
+	top := m.View()
+	m, _ = press(m, "pgdown")
+	if m.View() == top {
+		t.Error("pgdown didn't scroll Overview")
+	}
+	m, _ = press(m, "pgup")
+	if m.View() != top {
+		t.Error("pgup didn't bring Overview back to the top")
+	}
+}
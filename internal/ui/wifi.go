@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// maxWifiSignals is how many signal readings the sparkline keeps, one per
+// refresh.
+const maxWifiSignals = 120
+
+type wifiMsg struct {
+	iface string
+	w     probe.WifiInfo
+	err   error
+}
+
+// wifiSample is the link of the selected wireless interface, with its
+// signal over the time it has been selected; NaN while not connected.
+type wifiSample struct {
+	iface   string
+	w       probe.WifiInfo
+	err     error
+	signals []float64
+}
+
+// fetchWifiCmd reads the link of the selected interface when it is a
+// wireless one; only done while the Interfaces tab is open.
+func (m Model) fetchWifiCmd() tea.Cmd {
+	iface := m.selectedIface
+	if !strings.HasPrefix(iface, "wl") {
+		return nil
+	}
+	return func() tea.Msg {
+		w, err := m.wifiReader.Wifi(iface)
+		return wifiMsg{iface: iface, w: w, err: err}
+	}
+}
+
+func (m *Model) applyWifi(msg wifiMsg) {
+	if msg.iface != m.selectedIface {
+		return
+	}
+	s := wifiSample{iface: msg.iface, w: msg.w, err: msg.err}
+	if m.wifi.iface == msg.iface {
+		s.signals = m.wifi.signals
+	}
+	v := math.NaN()
+	if msg.err == nil && msg.w.Connected && msg.w.Signal != 0 {
+		v = float64(msg.w.Signal)
+	}
+	// a fresh array: frozen copies share the old one
+	s.signals = append(s.signals[max(0, len(s.signals)-maxWifiSignals+1):len(s.signals):len(s.signals)], v)
+	m.wifi = s
+	m.ifaceDetailsText = hardClipLinesToWidth(m.renderIfaceDetailsText(), m.ifaceDetailsVP.Width)
+	m.ifaceDetailsVP.SetContent(m.ifaceDetailsText)
+}
+
+// wifiSignalWord rates a signal in dBm the way Wi-Fi folk do.
+func wifiSignalWord(dBm int) string {
+	switch {
+	case dBm >= -50:
+		return okStyle.Render(i18n.T("excellent"))
+	case dBm >= -60:
+		return okStyle.Render(i18n.T("good"))
+	case dBm >= -70:
+		return warnStyle.Render(i18n.T("fair"))
+	}
+	return errStyle.Render(i18n.T("weak"))
+}
+
+// wifiBand names the band of a frequency in MHz.
+func wifiBand(freq int) string {
+	switch {
+	case freq >= 5925:
+		return "6 GHz"
+	case freq >= 4900:
+		return "5 GHz"
+	}
+	return "2.4 GHz"
+}
+
+// renderWifiText shows the link of a wireless interface for the interface
+// details pane: the network, its channel, how strong it is and has been,
+// and the rates frames go at.
+func (m Model) renderWifiText(ii *probe.IfaceInfo, width int) string {
+	s := m.wifi
+	if s.iface != ii.Name {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(i18n.T("Wi-Fi")))
+	switch {
+	case errors.Is(s.err, probe.ErrNoWifiTool):
+		b.WriteString("  " + subtleStyle.Render(i18n.T("n/a")+": "+i18n.T("install iw to see the link")) + "\n")
+		return b.String()
+	case s.err != nil:
+		b.WriteString("  " + subtleStyle.Render(i18n.T("n/a")+": "+s.err.Error()) + "\n")
+		return b.String()
+	case !s.w.Connected:
+		b.WriteString("  " + warnStyle.Render(i18n.T("not connected")) + "\n")
+		return b.String()
+	}
+	w := s.w
+	ssid := w.SSID
+	if ssid == "" {
+		ssid = i18n.T("(hidden)")
+	}
+	b.WriteString("  " + ssid + subtleStyle.Render("  "+w.BSSID) + "\n")
+
+	var parts []string
+	if w.Freq > 0 {
+		ch := fmt.Sprintf(i18n.T("%d MHz, %s"), w.Freq, wifiBand(w.Freq))
+		if w.Channel > 0 {
+			ch = fmt.Sprintf(i18n.T("channel %d"), w.Channel) + " (" + ch + ")"
+		}
+		parts = append(parts, i18n.Number(ch))
+	}
+	if w.RxRate > 0 || w.TxRate > 0 {
+		rate := func(r float64) string {
+			if r <= 0 {
+				return "?"
+			}
+			return linkSpeedLabel(int(math.Round(r)))
+		}
+		parts = append(parts, i18n.T("rate")+" "+i18n.T("in")+" "+rate(w.RxRate)+"  "+i18n.T("out")+" "+rate(w.TxRate))
+	}
+	if len(parts) > 0 {
+		b.WriteString(strings.Join(parts, " • ") + "\n")
+	}
+	if w.Signal == 0 {
+		return b.String()
+	}
+
+	sig := fmt.Sprintf(i18n.T("signal %d dBm"), w.Signal) + " " + wifiSignalWord(w.Signal)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range s.signals {
+		if !math.IsNaN(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	if len(s.signals) > 1 && hi >= lo {
+		label := " " + fmt.Sprintf(i18n.T("%.0f to %.0f dBm"), lo, hi)
+		sig += "  " + Spark(s.signals, max(5, min(40, width-lipgloss.Width(sig)-2-len([]rune(label))))) + subtleStyle.Render(label)
+	}
+	b.WriteString(sig + "\n")
+	return b.String()
+}
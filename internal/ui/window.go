@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nexusriot/ducknetview/internal/i18n"
+)
+
+// chartWindow is the span of time the interface charts show (w), whatever
+// the width of the terminal.
+type chartWindow int
+
+const (
+	window30s chartWindow = iota
+	window5m
+	window30m
+	windowSession
+	chartWindows
+)
+
+func (w chartWindow) String() string {
+	if w == windowSession {
+		return i18n.T("session")
+	}
+	return [...]string{"30s", "5m", "30m"}[w]
+}
+
+// span is 0 for the session.
+func (w chartWindow) span() time.Duration {
+	return [...]time.Duration{30 * time.Second, 5 * time.Minute, 30 * time.Minute, 0}[w]
+}
+
+// sessionBuckets bounds the session tier of a series: past it, pairs of
+// buckets are merged into ones twice as long.
+const sessionBuckets = 720
+
+// bucket is the band of the samples from start on, for the span of its
+// tier.
+type bucket struct {
+	start time.Time
+	band
+}
+
+// seriesTiers are the resolutions a series keeps: every sample for 30s
+// and 5m, 10s buckets for 30m and, for the session, buckets from 10s up,
+// as long as it takes to stay under sessionBuckets.
+var seriesTiers = [...]struct {
+	step time.Duration // of a bucket; 0: a bucket per sample
+	keep time.Duration // how far back; 0: the session
+}{
+	{keep: window5m.span()},
+	{step: 10 * time.Second, keep: window30m.span()},
+	{step: 10 * time.Second},
+}
+
+// tier keeps the samples of a series at one of seriesTiers.
+type tier struct {
+	done    []bucket // oldest first
+	open    bucket   // being filled; a zero start if none
+	doubled uint     // how often the session buckets were merged in pairs
+}
+
+// add counts a sample taken at at. A frozen copy of the model shares the
+// slices, so no bucket in them is written to; only the open one is.
+func (t tier) add(step, keep time.Duration, at time.Time, v float64) tier {
+	step <<= t.doubled
+	if !t.open.start.IsZero() && at.Sub(t.open.start) < step {
+		t.open.band = t.open.band.merge(bandOf(v))
+		return t
+	}
+	if !t.open.start.IsZero() {
+		t.done = append(t.done, t.open)
+	}
+	t.open = bucket{start: at.Truncate(step), band: bandOf(v)}
+
+	if keep > 0 {
+		from := at.Add(-keep)
+		t.done = t.done[sort.Search(len(t.done), func(i int) bool { return !t.done[i].start.Before(from) }):]
+	} else if len(t.done) > sessionBuckets {
+		merged := make([]bucket, 0, len(t.done)/2+1)
+		for i := 0; i < len(t.done); i += 2 {
+			b := t.done[i]
+			if i+1 < len(t.done) {
+				b.band = b.band.merge(t.done[i+1].band)
+			}
+			merged = append(merged, b)
+		}
+		t.done = merged
+		t.doubled++
+	}
+	return t
+}
+
+// series is the history of one chart, a tier by seriesTiers. The zero
+// value is an empty one.
+type series [len(seriesTiers)]tier
+
+func (s series) add(at time.Time, v float64) series {
+	for i, st := range seriesTiers {
+		s[i] = s[i].add(st.step, st.keep, at, v)
+	}
+	return s
+}
+
+// window is the part of s that w shows at now, oldest first, and the span
+// of time it covers, which is less than w's while s is younger.
+func (s series) window(w chartWindow, now time.Time) ([]band, time.Duration) {
+	t := s[[...]int{window30s: 0, window5m: 0, window30m: 1, windowSession: 2}[w]]
+	bs := t.done
+	if !t.open.start.IsZero() {
+		bs = append(bs[:len(bs):len(bs)], t.open)
+	}
+	if span := w.span(); span > 0 {
+		from := now.Add(-span)
+		bs = bs[sort.Search(len(bs), func(i int) bool { return !bs[i].start.Before(from) }):]
+	}
+	if len(bs) == 0 {
+		return nil, 0
+	}
+	out := make([]band, len(bs))
+	for i, b := range bs {
+		out[i] = b.band
+	}
+	return out, now.Sub(bs[0].start)
+}
+
+// windowCols is how many of cols columns a window of span want gives to a
+// history spanning have, so that a young one isn't stretched over all.
+func windowCols(cols int, have, want time.Duration) int {
+	if want == 0 || have >= want {
+		return cols
+	}
+	return max(1, int(float64(cols)*have.Seconds()/want.Seconds()+0.5))
+}
+
+// ifaceChartView draws s in the chart kind and window of the Interfaces tab,
+// width wide and, but for sparklines, height rows tall.
+func (m Model) ifaceChartView(s series, width, height int, label func(float64) string) string {
+	bs, have := s.window(m.ifaceWindow, m.now())
+	fit := func(cols int) []band { return fitBands(bs, windowCols(cols, have, m.ifaceWindow.span())) }
+	switch m.ifaceChart {
+	case chartSpark:
+		return Spark(bandAvgs(fit(width)), width)
+	case chartBars:
+		return barChart(bandAvgs(fit(width-1)), width, height, label, have)
+	}
+	return brailleChart(fit(2*(width-1)), width, height, label, have)
+}
@@ -0,0 +1,228 @@
+package update
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const latestURL = "https://api.github.com/repos/nexusriot/ducknetview/releases/latest"
+
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the release tag without a leading "v".
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Latest fetches the most recent GitHub release.
+func Latest() (Release, error) {
+	c := &http.Client{Timeout: 5 * time.Second}
+
+	req, err := http.NewRequest(http.MethodGet, latestURL, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return Release{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Release{}, fmt.Errorf("update check: http %d", resp.StatusCode)
+	}
+
+	var r Release
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&r); err != nil {
+		return Release{}, err
+	}
+	if r.Tag == "" {
+		return Release{}, errors.New("update check: release has no tag")
+	}
+	return r, nil
+}
+
+// Newer reports whether version a is newer than b. Versions are dotted
+// numbers, optionally prefixed with "v"; non-numeric parts compare as 0.
+func Newer(a, b string) bool {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < max(len(pa), len(pb)); i++ {
+		na, nb := part(pa, i), part(pb, i)
+		if na != nb {
+			return na > nb
+		}
+	}
+	return false
+}
+
+func part(p []string, i int) int {
+	if i >= len(p) {
+		return 0
+	}
+	// drop pre-release / build suffixes like "5-rc1"
+	s := p[i]
+	if j := strings.IndexAny(s, "-+"); j >= 0 {
+		s = s[:j]
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// BinaryAsset picks the raw binary for the running platform, skipping
+// packages and archives.
+func (r Release) BinaryAsset() (Asset, bool) {
+	arches := []string{runtime.GOARCH}
+	if runtime.GOARCH == "amd64" {
+		arches = append(arches, "x86_64")
+	}
+	for _, a := range r.Assets {
+		n := strings.ToLower(a.Name)
+		if !strings.Contains(n, runtime.GOOS) || isPackage(n) {
+			continue
+		}
+		for _, arch := range arches {
+			if strings.Contains(n, arch) {
+				return a, true
+			}
+		}
+	}
+	return Asset{}, false
+}
+
+func isPackage(name string) bool {
+	for _, suf := range []string{".deb", ".rpm", ".tar.gz", ".tgz", ".zip", ".sha256", ".txt", ".sig", ".asc"} {
+		if strings.HasSuffix(name, suf) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChecksumAsset picks the SHA-256 checksum of a: a file of its own,
+// "NAME.sha256", or else a list of them all, "SHA256SUMS" or "checksums.txt"
+// as release tools name it.
+func (r Release) ChecksumAsset(a Asset) (Asset, bool) {
+	for _, c := range r.Assets {
+		if strings.EqualFold(c.Name, a.Name+".sha256") {
+			return c, true
+		}
+	}
+	for _, c := range r.Assets {
+		n := strings.ToLower(c.Name)
+		if n == "sha256sums" || n == "sha256sums.txt" || strings.HasSuffix(n, "checksums.txt") {
+			return c, true
+		}
+	}
+	return Asset{}, false
+}
+
+// parseChecksum finds the SHA-256 of name in a checksum file: lines of
+// sha256sum's "HEX  NAME" (a "*" before binary names), or a lone hash.
+func parseChecksum(data []byte, name string) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for _, line := range lines {
+		f := strings.Fields(line)
+		switch {
+		case len(f) == 1 && len(lines) == 1:
+		case len(f) == 2 && strings.TrimPrefix(f[1], "*") == name:
+		default:
+			continue
+		}
+		sum, err := hex.DecodeString(f[0])
+		if err != nil || len(sum) != sha256.Size {
+			return nil, fmt.Errorf("checksum of %s: malformed %q", name, f[0])
+		}
+		return sum, nil
+	}
+	return nil, fmt.Errorf("checksum of %s: not listed", name)
+}
+
+// Apply downloads the asset, checks it against the release's SHA-256
+// checksum and only then atomically replaces the binary at exe. A release
+// without a checksum for the asset is refused.
+func Apply(r Release, a Asset, exe string) error {
+	c := &http.Client{Timeout: 2 * time.Minute}
+
+	sa, ok := r.ChecksumAsset(a)
+	if !ok {
+		return fmt.Errorf("release %s has no checksum for %s; not updating", r.Tag, a.Name)
+	}
+	sums, err := download(c, sa, 1<<20)
+	if err != nil {
+		return err
+	}
+	want, err := parseChecksum(sums, a.Name)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Get(a.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("download %s: http %d", a.Name, resp.StatusCode)
+	}
+
+	// temp file next to the binary so the final rename stays on one filesystem
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".ducknetview-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		return fmt.Errorf("download %s: sha256 %x, release lists %x; not updating", a.Name, got, want)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), exe)
+}
+
+// download reads a small asset whole, at most limit bytes of it.
+func download(c *http.Client, a Asset, limit int64) ([]byte, error) {
+	resp, err := c.Get(a.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("download %s: http %d", a.Name, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, limit))
+}
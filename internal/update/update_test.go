@@ -0,0 +1,98 @@
+package update
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseChecksum(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	tests := []struct {
+		name, data string
+		ok         bool
+	}{
+		{"lone hash", sum + "\n", true},
+		{"sha256sum line", sum + "  ducknetview-linux-amd64\n", true},
+		{"binary mode", sum + " *ducknetview-linux-amd64\n", true},
+		{"list", strings.Repeat("cd", sha256.Size) + "  ducknetview-darwin-arm64\n" + sum + "  ducknetview-linux-amd64\n", true},
+		{"other asset only", sum + "  ducknetview-darwin-arm64\n", false},
+		{"short hash", "abcd  ducknetview-linux-amd64\n", false},
+		{"not hex", strings.Repeat("zz", sha256.Size) + "\n", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseChecksum([]byte(tt.data), "ducknetview-linux-amd64")
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok %v", err, tt.ok)
+			}
+			if tt.ok && fmt.Sprintf("%x", got) != sum {
+				t.Errorf("sum = %x, want %s", got, sum)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	const bin = "#!/bin/sh\necho new\n"
+	good := fmt.Sprintf("%x", sha256.Sum256([]byte(bin)))
+	files := map[string]string{
+		"/ducknetview-linux-amd64": bin,
+		"/good.sha256":             good + "  ducknetview-linux-amd64\n",
+		"/bad.sha256":              strings.Repeat("00", sha256.Size) + "  ducknetview-linux-amd64\n",
+		"/SHA256SUMS":              good + "  ducknetview-linux-amd64\n",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	binary := Asset{Name: "ducknetview-linux-amd64", URL: srv.URL + "/ducknetview-linux-amd64"}
+	sums := func(path string) Asset {
+		return Asset{Name: "ducknetview-linux-amd64.sha256", URL: srv.URL + path}
+	}
+	tests := []struct {
+		name   string
+		assets []Asset
+		ok     bool
+	}{
+		{"own checksum", []Asset{binary, sums("/good.sha256")}, true},
+		{"checksum list", []Asset{binary, {Name: "SHA256SUMS", URL: srv.URL + "/SHA256SUMS"}}, true},
+		{"mismatch", []Asset{binary, sums("/bad.sha256")}, false},
+		{"no checksum", []Asset{binary}, false},
+		{"checksum missing", []Asset{binary, sums("/gone.sha256")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exe := filepath.Join(t.TempDir(), "ducknetview")
+			if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			err := Apply(Release{Tag: "v0.0.5", Assets: tt.assets}, binary, exe)
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok %v", err, tt.ok)
+			}
+			want := "old"
+			if tt.ok {
+				want = bin
+			}
+			if b, _ := os.ReadFile(exe); string(b) != want {
+				t.Errorf("binary = %q, want %q", b, want)
+			}
+			if left, _ := filepath.Glob(filepath.Join(filepath.Dir(exe), ".ducknetview-update-*")); len(left) > 0 {
+				t.Errorf("left behind %v", left)
+			}
+		})
+	}
+}
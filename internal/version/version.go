@@ -0,0 +1,5 @@
+package version
+
+// Version is the release version, overridden at build time with
+// -ldflags "-X github.com/nexusriot/ducknetview/internal/version.Version=x.y.z".
+var Version = "0.0.4"
@@ -0,0 +1,265 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// BGPPeer is one BGP session of a local routing daemon.
+type BGPPeer struct {
+	Daemon   string // "bird" or "frr"
+	Name     string // BIRD protocol name; the neighbor address for FRR
+	Neighbor string
+	AS       int
+	State    string // e.g. "Established", "Active", "Idle"
+	Since    string // as the daemon prints it
+	Imported int    // prefixes received
+	Exported int    // prefixes sent, -1 when unknown
+}
+
+// Up reports whether the session is established.
+func (p BGPPeer) Up() bool { return p.State == "Established" }
+
+// ErrNoRoutingDaemon means neither BIRD nor FRR could be reached; callers
+// usually hide BGP output rather than show it as an error.
+var ErrNoRoutingDaemon = errors.New("no BIRD or FRR control socket")
+
+// BGPReader reads BGP session status.
+type BGPReader interface {
+	BGPPeers() ([]BGPPeer, error)
+}
+
+// birdSockets are the usual BIRD control socket locations.
+var birdSockets = []string{"/run/bird/bird.ctl", "/var/run/bird/bird.ctl", "/run/bird.ctl", "/var/run/bird.ctl"}
+
+// BGPPeers asks BIRD over its control socket, then FRR through vtysh,
+// which talks to the daemons' vty sockets. Reading them usually needs root
+// or membership in the bird/frrvty group.
+func (Host) BGPPeers() ([]BGPPeer, error) { return BGPPeers() }
+
+func BGPPeers() ([]BGPPeer, error) {
+	var errs []error
+	for _, path := range birdSockets {
+		peers, err := birdPeers(path)
+		if err == nil {
+			return peers, nil
+		}
+		if !errors.Is(err, errNoSocket) {
+			errs = append(errs, err)
+		}
+	}
+	peers, err := frrPeers()
+	if err == nil {
+		return peers, nil
+	}
+	if !errors.Is(err, exec.ErrNotFound) {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return nil, ErrNoRoutingDaemon
+}
+
+var errNoSocket = errors.New("no socket")
+
+func birdPeers(path string) ([]BGPPeer, error) {
+	c, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		// missing or stale socket: BIRD isn't running here
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, errNoSocket
+		}
+		return nil, fmt.Errorf("bird %s: %w", path, err)
+	}
+	defer c.Close()
+	_ = c.SetDeadline(time.Now().Add(3 * time.Second))
+
+	r := bufio.NewReader(c)
+	// the greeting, "0001 BIRD 2.x ready."
+	if _, err := r.ReadString('\n'); err != nil {
+		return nil, fmt.Errorf("bird %s: %w", path, err)
+	}
+	if _, err := io.WriteString(c, "show protocols all\n"); err != nil {
+		return nil, fmt.Errorf("bird %s: %w", path, err)
+	}
+	peers, err := parseBirdProtocols(r)
+	if err != nil {
+		return nil, fmt.Errorf("bird %s: %w", path, err)
+	}
+	return peers, nil
+}
+
+// parseBirdProtocols reads a `show protocols all` reply up to its final
+// 0000 line. Table rows (code 1002) start a protocol; detail lines (1006
+// and their space-prefixed continuations) supply the neighbor and route
+// counts.
+func parseBirdProtocols(r *bufio.Reader) ([]BGPPeer, error) {
+	var (
+		peers []BGPPeer
+		cur   *BGPPeer
+	)
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if err != nil && line == "" {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+
+		code, text := "", line
+		if len(line) >= 5 && (line[4] == '-' || line[4] == ' ') && isDigits(line[:4]) {
+			code, text = line[:4], line[5:]
+		}
+		switch {
+		case code == "0000":
+			return peers, nil
+		case code == "2002":
+			continue
+		case code == "1002":
+			cur = nil
+			p, ok := birdRow(text)
+			if ok {
+				peers = append(peers, p)
+				cur = &peers[len(peers)-1]
+			}
+			continue
+		case code != "" && code[0] >= '8':
+			// 8xxx/9xxx are errors, e.g. "8007 Access denied"
+			return nil, errors.New(strings.TrimSpace(text))
+		}
+		if cur == nil {
+			continue
+		}
+		k, v, ok := strings.Cut(strings.TrimSpace(text), ":")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		switch k {
+		case "Neighbor address":
+			cur.Neighbor = v
+		case "Neighbor AS":
+			cur.AS, _ = strconv.Atoi(v)
+		case "BGP state":
+			cur.State = v
+		case "Routes":
+			// "10 imported, 5 exported, 10 preferred", summed over channels
+			for _, part := range strings.Split(v, ",") {
+				f := strings.Fields(part)
+				if len(f) != 2 {
+					continue
+				}
+				n, _ := strconv.Atoi(f[0])
+				switch f[1] {
+				case "imported":
+					cur.Imported += n
+				case "exported":
+					cur.Exported += n
+				}
+			}
+		}
+	}
+}
+
+// birdRow parses "name BGP table state since info". Since is a time, a
+// date or, with the iso long format, both, so it is one or two fields.
+func birdRow(row string) (BGPPeer, bool) {
+	f := strings.Fields(row)
+	if len(f) < 4 || f[1] != "BGP" {
+		return BGPPeer{}, false
+	}
+	p := BGPPeer{Daemon: "bird", Name: f[0], State: f[3]}
+	rest := f[4:]
+	if len(rest) > 0 {
+		p.Since, rest = rest[0], rest[1:]
+		if len(rest) > 0 && strings.Count(p.Since, "-") == 2 && strings.Contains(rest[0], ":") {
+			p.Since, rest = p.Since+" "+rest[0], rest[1:]
+		}
+	}
+	// Info is the BGP state; "BGP state" in the details overrides it
+	if len(rest) > 0 {
+		p.State = rest[0]
+	}
+	return p, true
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// frrPeers reads `show bgp summary json`, which lists peers per address
+// family; a peer in several families is reported once with summed counts.
+func frrPeers() ([]BGPPeer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "vtysh", "-c", "show bgp summary json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("vtysh: %w", err)
+	}
+	return parseFRRSummary(out)
+}
+
+type frrSummary map[string]struct {
+	AS    int `json:"as"`
+	Peers map[string]struct {
+		RemoteAS   int    `json:"remoteAs"`
+		State      string `json:"state"`
+		PeerUptime string `json:"peerUptime"`
+		PfxRcd     int    `json:"pfxRcd"`
+		PfxSnt     *int   `json:"pfxSnt"`
+		Hostname   string `json:"hostname"`
+	} `json:"peers"`
+}
+
+func parseFRRSummary(b []byte) ([]BGPPeer, error) {
+	var s frrSummary
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("vtysh: %w", err)
+	}
+	byAddr := map[string]*BGPPeer{}
+	for _, af := range s {
+		for addr, p := range af.Peers {
+			bp := byAddr[addr]
+			if bp == nil {
+				bp = &BGPPeer{Daemon: "frr", Name: addr, Neighbor: addr, AS: p.RemoteAS, State: p.State, Since: p.PeerUptime}
+				if p.Hostname != "" {
+					bp.Name = p.Hostname
+				}
+				byAddr[addr] = bp
+			}
+			bp.Imported += p.PfxRcd
+			if p.PfxSnt == nil {
+				bp.Exported = -1
+			} else if bp.Exported >= 0 {
+				bp.Exported += *p.PfxSnt
+			}
+		}
+	}
+	out := make([]BGPPeer, 0, len(byAddr))
+	for _, p := range byAddr {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Neighbor < out[j].Neighbor })
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package probe
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBirdProtocols(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     []BGPPeer
+		err      string
+	}{
+		{
+			"bird 2 with channels",
+			`2002-Name       Proto      Table      State  Since         Info
+1002-device1    Device     ---        up     2024-01-02
+1006-
+1002-upstream   BGP        ---        up     2024-01-02 10:00:00  Established
+1006-  BGP state:          Established
+     Neighbor address: 192.0.2.1
+     Neighbor AS:      64500
+   Channel ipv4
+     Routes:         900 imported, 3 exported, 850 preferred
+   Channel ipv6
+     Routes:         100 imported, 1 exported, 90 preferred
+1002-backup     BGP        ---        start  10:31:02      Active        Socket: Connection refused
+1006-  BGP state:          Active
+     Neighbor address: 2001:db8::2
+     Neighbor AS:      64501
+` + "0000 \n", // the end, with a space as every last line
+			[]BGPPeer{
+				{Daemon: "bird", Name: "upstream", Neighbor: "192.0.2.1", AS: 64500, State: "Established", Since: "2024-01-02 10:00:00", Imported: 1000, Exported: 4},
+				{Daemon: "bird", Name: "backup", Neighbor: "2001:db8::2", AS: 64501, State: "Active", Since: "10:31:02"},
+			},
+			"",
+		},
+		{"no peers", "2002-Name Proto Table State Since Info\n0000 \n", nil, ""},
+		{"denied", "8007 Access denied\n", nil, "Access denied"},
+		{"cut short", "1002-upstream BGP --- up 10:00:00 Established\n", nil, io.ErrUnexpectedEOF.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBirdProtocols(bufio.NewReader(strings.NewReader(tt.in)))
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("error %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Fatalf("error %v, want one with %q", err, tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFRRSummary(t *testing.T) {
+	const in = `{
+ "ipv4Unicast": {"as": 64496, "peers": {
+   "192.0.2.1": {"remoteAs": 64500, "state": "Established", "peerUptime": "01:02:03", "pfxRcd": 900, "pfxSnt": 3, "hostname": "edge1"},
+   "192.0.2.9": {"remoteAs": 64509, "state": "Idle", "peerUptime": "never", "pfxRcd": 0}
+ }},
+ "ipv6Unicast": {"as": 64496, "peers": {
+   "192.0.2.1": {"remoteAs": 64500, "state": "Established", "peerUptime": "01:02:03", "pfxRcd": 100, "pfxSnt": 1, "hostname": "edge1"}
+ }}
+}`
+	got, err := parseFRRSummary([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BGPPeer{
+		{Daemon: "frr", Name: "edge1", Neighbor: "192.0.2.1", AS: 64500, State: "Established", Since: "01:02:03", Imported: 1000, Exported: 4},
+		{Daemon: "frr", Name: "192.0.2.9", Neighbor: "192.0.2.9", AS: 64509, State: "Idle", Since: "never", Exported: -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	if _, err := parseFRRSummary([]byte("% BGP instance not found")); err == nil {
+		t.Error("vtysh's error text: no error")
+	}
+}
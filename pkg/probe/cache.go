@@ -0,0 +1,179 @@
+package probe
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CacheConfig bounds a Cache: how long an entry is good for and how many
+// are kept. Zero values keep the cache's defaults.
+type CacheConfig struct {
+	TTL  time.Duration
+	Size int
+}
+
+// CacheNames are the caches that ConfigureCache knows.
+var CacheNames = []string{"rdns", "geoip", "procname"}
+
+var (
+	cacheMu      sync.Mutex
+	cacheConfigs = map[string]CacheConfig{}
+	cacheStats   = map[string]func() CacheStat{}
+)
+
+// ConfigureCache overrides the defaults of the caches called name made
+// from now on; call it before the UI starts.
+func ConfigureCache(name string, cfg CacheConfig) error {
+	known := false
+	for _, n := range CacheNames {
+		known = known || n == name
+	}
+	if !known {
+		return fmt.Errorf("unknown cache %q", name)
+	}
+	if cfg.TTL < 0 || cfg.Size < 0 {
+		return fmt.Errorf("cache %s: negative ttl or size", name)
+	}
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cacheConfigs[name] = cfg
+	return nil
+}
+
+// CacheStat is how a cache has done since it was made.
+type CacheStat struct {
+	Name         string
+	Hits, Misses uint64
+	Len, Size    int
+}
+
+// CacheStats reports every cache by name; of several with the same name,
+// the newest one.
+func CacheStats() []CacheStat {
+	cacheMu.Lock()
+	fns := make([]func() CacheStat, 0, len(cacheStats))
+	for _, fn := range cacheStats {
+		fns = append(fns, fn)
+	}
+	cacheMu.Unlock()
+
+	out := make([]CacheStat, 0, len(fns))
+	for _, fn := range fns {
+		out = append(out, fn())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Cache is an LRU map whose entries expire after a TTL, for enrichment
+// lookups (names, GeoIP) that would otherwise be redone on every refresh.
+// It counts hits and misses for CacheStats. It is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	name string
+	ttl  time.Duration // 0: entries don't expire
+	size int
+	now  func() time.Time
+
+	mu           sync.Mutex
+	entries      map[K]*list.Element // of cacheEntry
+	lru          *list.List          // front: most recently used
+	hits, misses uint64
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	val     V
+	expires time.Time
+}
+
+// NewCache returns a cache called name bounded by def, or by what
+// ConfigureCache set for name.
+func NewCache[K comparable, V any](name string, def CacheConfig) *Cache[K, V] {
+	cacheMu.Lock()
+	cfg := cacheConfigs[name]
+	cacheMu.Unlock()
+	if cfg.TTL == 0 {
+		cfg.TTL = def.TTL
+	}
+	if cfg.Size == 0 {
+		cfg.Size = def.Size
+	}
+
+	c := &Cache[K, V]{
+		name:    name,
+		ttl:     cfg.TTL,
+		size:    max(1, cfg.Size),
+		now:     time.Now,
+		entries: map[K]*list.Element{},
+		lru:     list.New(),
+	}
+	cacheMu.Lock()
+	cacheStats[name] = c.stat
+	cacheMu.Unlock()
+	return c
+}
+
+// entry returns the live entry of k, dropping it if it expired.
+func (c *Cache[K, V]) entry(k K) (*list.Element, bool) {
+	e, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+	if c.ttl > 0 && c.now().After(e.Value.(cacheEntry[K, V]).expires) {
+		c.lru.Remove(e)
+		delete(c.entries, k)
+		return nil, false
+	}
+	return e, true
+}
+
+// Get returns the value of k unless it is missing or expired.
+func (c *Cache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entry(k)
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.lru.MoveToFront(e)
+	return e.Value.(cacheEntry[K, V]).val, true
+}
+
+// Contains is Get without counting or touching the entry.
+func (c *Cache[K, V]) Contains(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entry(k)
+	return ok
+}
+
+// Put stores v under k for the TTL, evicting the least recently used
+// entries beyond the size.
+func (c *Cache[K, V]) Put(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ce := cacheEntry[K, V]{key: k, val: v, expires: c.now().Add(c.ttl)}
+	if e, ok := c.entries[k]; ok {
+		e.Value = ce
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[k] = c.lru.PushFront(ce)
+	for c.lru.Len() > c.size {
+		old := c.lru.Back()
+		c.lru.Remove(old)
+		delete(c.entries, old.Value.(cacheEntry[K, V]).key)
+	}
+}
+
+func (c *Cache[K, V]) stat() CacheStat {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStat{Name: c.name, Hits: c.hits, Misses: c.misses, Len: c.lru.Len(), Size: c.size}
+}
@@ -0,0 +1,91 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CastStats are the multicast and broadcast packets an interface has
+// seen since its counters were reset; -1 where they aren't counted.
+type CastStats struct {
+	RxMulticast, TxMulticast int64
+	RxBroadcast, TxBroadcast int64
+}
+
+// CastReader reads the multicast and broadcast counters of an interface.
+type CastReader interface {
+	CastCounters(iface string) (CastStats, error)
+}
+
+// CastCounters reads the received multicast packets from sysfs, the
+// multicast column of /proc/net/dev, which every driver keeps. The kernel
+// has no broadcast counter, nor one of sent multicast; these come from the
+// driver's own statistics through `ethtool -S` where it has them, which
+// most wired NICs do and virtual interfaces don't.
+func (Host) CastCounters(iface string) (CastStats, error) { return CastCounters(iface) }
+
+func CastCounters(iface string) (CastStats, error) {
+	c := CastStats{RxMulticast: -1, TxMulticast: -1, RxBroadcast: -1, TxBroadcast: -1}
+	b, err := os.ReadFile(filepath.Join(sysRoot, "class/net", iface, "statistics/multicast"))
+	if err != nil {
+		return c, err
+	}
+	c.RxMulticast, _ = strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "ethtool", "-S", iface).Output()
+	if err != nil {
+		// no ethtool, or a driver without statistics
+		return c, nil
+	}
+	c.parseEthtool(out)
+	return c, nil
+}
+
+// ethtoolCasts maps the names drivers give their cast counters in ethtool
+// -S, less a "vport_" (mlx5), to the counter.
+var ethtoolCasts = map[string]func(*CastStats) *int64{
+	"tx_multicast":         func(c *CastStats) *int64 { return &c.TxMulticast },
+	"tx_mcast":             func(c *CastStats) *int64 { return &c.TxMulticast },
+	"tx_multicast_packets": func(c *CastStats) *int64 { return &c.TxMulticast },
+	"tx_mcast_packets":     func(c *CastStats) *int64 { return &c.TxMulticast },
+	"rx_broadcast":         func(c *CastStats) *int64 { return &c.RxBroadcast },
+	"rx_bcast":             func(c *CastStats) *int64 { return &c.RxBroadcast },
+	"rx_broadcast_packets": func(c *CastStats) *int64 { return &c.RxBroadcast },
+	"rx_bcast_packets":     func(c *CastStats) *int64 { return &c.RxBroadcast },
+	"broadcast":            func(c *CastStats) *int64 { return &c.RxBroadcast }, // r8169
+	"tx_broadcast":         func(c *CastStats) *int64 { return &c.TxBroadcast },
+	"tx_bcast":             func(c *CastStats) *int64 { return &c.TxBroadcast },
+	"tx_broadcast_packets": func(c *CastStats) *int64 { return &c.TxBroadcast },
+	"tx_bcast_packets":     func(c *CastStats) *int64 { return &c.TxBroadcast },
+}
+
+// parseEthtool reads the counters of ethtool -S:
+//
+//	NIC statistics:
+//	     rx_packets: 1204
+//	     rx_broadcast: 310
+func (c *CastStats) parseEthtool(out []byte) {
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(strings.TrimSpace(sc.Text()), ":")
+		if !ok {
+			continue
+		}
+		field, ok := ethtoolCasts[strings.Replace(k, "vport_", "", 1)]
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+			*field(c) = n
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package probe
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	gnet "github.com/shirou/gopsutil/v4/net"
+)
+
+// ConnRate is the system-wide rate of new TCP connections.
+type ConnRate struct {
+	Active  float64 // outgoing connects/sec
+	Passive float64 // accepted connections/sec
+}
+
+// Total is the combined connection rate.
+func (r ConnRate) Total() float64 { return r.Active + r.Passive }
+
+// ConnRater measures how quickly connections are opened.
+type ConnRater interface {
+	Rate() (ConnRate, error)
+	ProcRates() (map[int32]float64, error)
+}
+
+// ConnRateSampler derives connection rates from consecutive samples.
+// System-wide rates come from the kernel TCP open counters; per-process
+// rates count connections that were not present in the previous sample,
+// so very short-lived connections between samples are missed.
+type ConnRateSampler struct {
+	mu sync.Mutex
+
+	lastActive, lastPassive int64
+	lastAt                  time.Time
+
+	lastConns  map[string]bool
+	lastProcAt time.Time
+}
+
+func NewConnRateSampler() *ConnRateSampler {
+	return &ConnRateSampler{}
+}
+
+// Rate returns TCP opens/sec since the previous call; zero on the first call.
+func (s *ConnRateSampler) Rate() (ConnRate, error) {
+	pc, err := gnet.ProtoCounters([]string{"tcp"})
+	if err != nil {
+		return ConnRate{}, err
+	}
+	if len(pc) == 0 {
+		return ConnRate{}, fmt.Errorf("connection rate: no tcp counters")
+	}
+	active, passive := pc[0].Stats["ActiveOpens"], pc[0].Stats["PassiveOpens"]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var r ConnRate
+	if !s.lastAt.IsZero() {
+		dt := now.Sub(s.lastAt).Seconds()
+		if dt > 0 && active >= s.lastActive && passive >= s.lastPassive {
+			r.Active = float64(active-s.lastActive) / dt
+			r.Passive = float64(passive-s.lastPassive) / dt
+		}
+	}
+	s.lastActive, s.lastPassive, s.lastAt = active, passive, now
+	return r, nil
+}
+
+// ProcRates returns new TCP connections/sec per PID since the previous call.
+func (s *ConnRateSampler) ProcRates() (map[int32]float64, error) {
+	conns, err := gnet.Connections("tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	cur := make(map[string]bool, len(conns))
+	for _, c := range conns {
+		if c.Pid <= 0 || c.Status == "LISTEN" {
+			continue
+		}
+		cur[connKey(c)] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	out := map[int32]float64{}
+	if s.lastConns != nil {
+		dt := now.Sub(s.lastProcAt).Seconds()
+		if dt <= 0 {
+			dt = 1
+		}
+		for _, c := range conns {
+			if c.Pid <= 0 || c.Status == "LISTEN" || s.lastConns[connKey(c)] {
+				continue
+			}
+			out[c.Pid] += 1 / dt
+		}
+	}
+	s.lastConns, s.lastProcAt = cur, now
+	return out, nil
+}
+
+func connKey(c gnet.ConnectionStat) string {
+	return fmt.Sprintf("%d|%s:%d|%s:%d", c.Pid, c.Laddr.IP, c.Laddr.Port, c.Raddr.IP, c.Raddr.Port)
+}
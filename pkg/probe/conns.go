@@ -0,0 +1,70 @@
+package probe
+
+import (
+	"fmt"
+	"sort"
+
+	gnet "github.com/shirou/gopsutil/v4/net"
+)
+
+// Conn is a socket with a remote peer.
+type Conn struct {
+	Proto   string
+	Local   string // ip:port, IPv6 unbracketed as in ListenPort
+	Remote  string // ip:port
+	Status  string // ESTABLISHED, SYN_SENT, TIME_WAIT, …
+	PID     int32
+	Process string
+}
+
+// RemoteIP returns the address part of Remote.
+func (c Conn) RemoteIP() string {
+	ip, _ := SplitLocal(c.Remote)
+	return ip
+}
+
+// ConnLister lists sockets that have a remote peer.
+type ConnLister interface {
+	ListConnections() ([]Conn, error)
+}
+
+func (Host) ListConnections() ([]Conn, error) { return ListConnections() }
+
+// ListConnections returns all sockets with a remote peer (established,
+// connecting or closing), sorted by remote address. Process names are
+// best-effort and may need privileges.
+func ListConnections() ([]Conn, error) {
+	conns, err := gnet.Connections("inet")
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Conn, 0, len(conns))
+	for _, c := range conns {
+		if c.Raddr.Port == 0 || c.Status == "LISTEN" {
+			continue
+		}
+		cn := Conn{
+			Proto:  connProto(c),
+			Local:  fmt.Sprintf("%s:%d", c.Laddr.IP, c.Laddr.Port),
+			Remote: fmt.Sprintf("%s:%d", c.Raddr.IP, c.Raddr.Port),
+			Status: c.Status,
+			PID:    c.Pid,
+		}
+		if c.Pid > 0 {
+			cn.Process = procName(c.Pid)
+		}
+		out = append(out, cn)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Remote != out[j].Remote {
+			return out[i].Remote < out[j].Remote
+		}
+		if out[i].Local != out[j].Local {
+			return out[i].Local < out[j].Local
+		}
+		return out[i].PID < out[j].PID
+	})
+	return out, nil
+}
@@ -0,0 +1,285 @@
+package demo
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Host is Fixture come to life, for ducknetview --demo: interface traffic
+// that swells and ebbs with downloads bursting in, counters that grow
+// along, a Wi-Fi signal that drifts and pings that jitter. The rest is
+// Fixture's. Nothing is read from, or done to, the host it runs on.
+type Host struct {
+	*Probes
+
+	mu      sync.Mutex
+	rng     *rand.Rand
+	start   time.Time
+	last    time.Time
+	base    []probe.IfaceInfo // Fixture's, whose rates the traffic swings around
+	ifaces  []probe.IfaceInfo // the last sample, its totals grown
+	load    float64           // eth0's traffic against Fixture's
+	casts   map[string]probe.CastStats
+	queues  map[string]probe.NICQueues
+	softnet []probe.SoftnetStat
+	signal  float64
+}
+
+// New starts a made-up host, its session beginning now.
+func New() *Host {
+	f := Fixture()
+	now := time.Now()
+	d := &Host{
+		Probes: f,
+		rng:    rand.New(rand.NewSource(now.UnixNano())),
+		start:  now,
+		last:   now,
+		base:   f.Snapshot.Ifaces,
+		ifaces: f.Snapshot.Ifaces,
+		load:   1,
+		casts:  map[string]probe.CastStats{},
+		queues: map[string]probe.NICQueues{},
+		signal: float64(f.Wifis["wlan0"].Signal),
+	}
+	for k, v := range f.Casts {
+		d.casts[k] = v
+	}
+	for k, v := range f.NICQueues {
+		d.queues[k] = v
+	}
+	d.softnet = append(d.softnet, f.SoftnetStats...)
+	return d
+}
+
+// swing is how far above or below its usual rate an interface is t into
+// the session: slow waves with noise, plus its own bursts.
+func (d *Host) swing(name string, t float64) float64 {
+	f := (1 + 0.5*math.Sin(2*math.Pi*t/240) + 0.25*math.Sin(2*math.Pi*t/37)) * (0.85 + 0.3*d.rng.Float64())
+	switch name {
+	case "eth0":
+		// a large download for 20s of every 2 minutes
+		if math.Mod(t, 120) >= 60 && math.Mod(t, 120) < 80 {
+			f *= 6
+		}
+	case "docker0":
+		// a container pulling layers now and then
+		if math.Mod(t, 45) < 5 {
+			f *= 20
+		}
+	}
+	return f
+}
+
+// Sample moves the traffic on to now and grows the counters by it.
+func (d *Host) Sample() (probe.NetSnapshot, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	dt := now.Sub(d.last).Seconds()
+	t := now.Sub(d.start).Seconds()
+	d.last = now
+
+	// a new slice each time: the UI keeps earlier snapshots
+	out := make([]probe.IfaceInfo, len(d.ifaces))
+	var pps float64
+	for i, ii := range d.ifaces {
+		b := d.base[i]
+		if !b.IsUp {
+			out[i] = ii
+			continue
+		}
+		s := d.swing(ii.Name, t)
+		ii.RxBps, ii.TxBps = b.RxBps*s, b.TxBps*s
+		ii.RxPps, ii.TxPps = packets(ii.RxBps, b.RxBps, b.RxPps, 900), packets(ii.TxBps, b.TxBps, b.TxPps, 300)
+		ii.RxTotal += uint64(ii.RxBps * dt)
+		ii.TxTotal += uint64(ii.TxBps * dt)
+		if b.DropinRate > 0 {
+			n := uint64(0)
+			if d.rng.Float64() < b.DropinRate*s*dt {
+				n = 1 + uint64(d.rng.Intn(3))
+			}
+			ii.Dropin += n
+			ii.DropinRate = float64(n) / math.Max(dt, 1e-3)
+		}
+		if ii.Name == "eth0" {
+			d.load = s
+		}
+		if ii.Kind == probe.IfacePhysical {
+			pps += ii.RxPps + ii.TxPps
+		}
+		d.grow(ii, dt)
+		out[i] = ii
+	}
+	d.ifaces = out
+	d.growSoftnet(pps, dt)
+
+	snap := d.Snapshot
+	snap.Ifaces = out
+	snap.TakenAt = now
+	snap.Uptime += now.Sub(d.start)
+	return snap, nil
+}
+
+// packets is the packet rate going with bps, at Fixture's packet size for
+// the interface or else at size bytes each.
+func packets(bps, baseBps, basePps float64, size float64) float64 {
+	if baseBps > 0 && basePps > 0 {
+		return bps * basePps / baseBps
+	}
+	return bps / size
+}
+
+// grow adds dt of ii's packets to its multicast, broadcast and queue
+// interrupt counters.
+func (d *Host) grow(ii probe.IfaceInfo, dt float64) {
+	if c, ok := d.casts[ii.Name]; ok {
+		add := func(n *int64, share, pps float64) {
+			if *n >= 0 {
+				*n += int64(pps * share * dt)
+			}
+		}
+		add(&c.RxMulticast, 0.03, ii.RxPps)
+		add(&c.TxMulticast, 0.01, ii.TxPps)
+		add(&c.RxBroadcast, 0.008, ii.RxPps)
+		add(&c.TxBroadcast, 0.002, ii.TxPps)
+		d.casts[ii.Name] = c
+	}
+	if q, ok := d.queues[ii.Name]; ok && len(q.IRQs) > 0 {
+		// interrupt coalescing: one for every 8 packets or so, spread
+		// unevenly by the flows' hashes; the last is the link's own
+		irqs := append([]probe.QueueIRQ(nil), q.IRQs...)
+		weights := []float64{0.4, 0.3, 0.2, 0.1}
+		for i := range irqs {
+			w := 0.0
+			if i < len(weights) {
+				w = weights[i]
+			}
+			irqs[i].Count += uint64((ii.RxPps + ii.TxPps) / 8 * w * dt * (0.9 + 0.2*d.rng.Float64()))
+		}
+		q.IRQs = irqs
+		d.queues[ii.Name] = q
+	}
+}
+
+// growSoftnet hands pps packets a second to the CPUs, most to the first,
+// squeezing the softirqs' budget now and then while it is busy.
+func (d *Host) growSoftnet(pps, dt float64) {
+	out := append([]probe.SoftnetStat(nil), d.softnet...)
+	for i := range out {
+		share := 0.3
+		if i == 0 {
+			share = 0.7
+		}
+		n := pps * share * dt
+		out[i].Processed += uint64(n)
+		out[i].NetRx += uint64(n / 6)
+		out[i].NetTx += uint64(n / 40)
+		if d.load > 4 && d.rng.Float64() < 0.3 {
+			out[i].Squeezed++
+		}
+	}
+	d.softnet = out
+}
+
+func (d *Host) CastCounters(iface string) (probe.CastStats, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if c, ok := d.casts[iface]; ok {
+		return c, nil
+	}
+	return d.Probes.CastCounters(iface)
+}
+
+func (d *Host) Queues(iface string) (probe.NICQueues, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queues[iface], nil
+}
+
+func (d *Host) Softnet() ([]probe.SoftnetStat, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.softnet, nil
+}
+
+// Wifi lets the signal wander a few dBm around Fixture's.
+func (d *Host) Wifi(iface string) (probe.WifiInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	w, ok := d.Wifis[iface]
+	if !ok || !w.Connected {
+		return w, nil
+	}
+	d.signal += d.rng.NormFloat64()*1.5 + (float64(w.Signal)-d.signal)*0.1
+	d.signal = math.Max(-85, math.Min(-35, d.signal))
+	w.Signal = int(math.Round(d.signal))
+	return w, nil
+}
+
+// ProcBandwidth and PeerBandwidth follow eth0's traffic.
+func (d *Host) ProcBandwidth() (map[int32]probe.ProcBandwidth, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make(map[int32]probe.ProcBandwidth, len(d.ProcBW))
+	for pid, bw := range d.ProcBW {
+		bw.RxBps, bw.TxBps = bw.RxBps*d.load, bw.TxBps*d.load
+		out[pid] = bw
+	}
+	return out, nil
+}
+
+func (d *Host) PeerBandwidth() (map[string]probe.ProcBandwidth, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	el := time.Since(d.start).Seconds()
+	out := make(map[string]probe.ProcBandwidth, len(d.PeerBW))
+	for ip, bw := range d.PeerBW {
+		bw.RxBytes += uint64(bw.RxBps * el)
+		bw.TxBytes += uint64(bw.TxBps * el)
+		bw.RxBps, bw.TxBps = bw.RxBps*d.load, bw.TxBps*d.load
+		out[ip] = bw
+	}
+	return out, nil
+}
+
+// Rate opens connections at Fixture's pace, faster during downloads.
+func (d *Host) Rate() (probe.ConnRate, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	r := d.ConnRate
+	r.Active *= d.load
+	r.Passive *= 0.5 + d.rng.Float64()
+	return r, nil
+}
+
+// Ping jitters Fixture's round trips, with the odd spike.
+func (d *Host) Ping(ctx context.Context, host string) (time.Duration, string, error) {
+	rtt, via, err := d.Probes.Ping(ctx, host)
+	if err != nil {
+		return rtt, via, err
+	}
+	d.mu.Lock()
+	f := 0.8 + 0.4*d.rng.Float64()
+	if d.rng.Float64() < 0.05 {
+		f *= 5
+	}
+	d.mu.Unlock()
+	return time.Duration(float64(rtt) * f), via, nil
+}
+
+// Lease moves Fixture's leases from Epoch to when the demo started.
+func (d *Host) Lease(iface string) (probe.DHCPLease, error) {
+	l, err := d.Probes.Lease(iface)
+	if err != nil {
+		return l, err
+	}
+	l.Obtained = l.Obtained.Add(d.start.Sub(Epoch))
+	l.Expires = l.Expires.Add(d.start.Sub(Epoch))
+	return l, nil
+}
@@ -0,0 +1,600 @@
+// Package demo is a made-up host: canned probe implementations, and Host,
+// which brings them to life for ducknetview --demo. Nothing is read from,
+// or done to, the host it runs on.
+package demo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexusriot/ducknetview/pkg/probe"
+)
+
+// Epoch is the fixed instant of Fixture.
+var Epoch = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// Probes returns canned data. It implements probe.Sampler, probe.PortLister,
+// probe.ProcLister, probe.ConnRater, probe.ICMPReader, probe.RouteReader,
+// probe.RuleReader, probe.RAReader, probe.ConnLister, probe.MeteredChecker,
+// probe.BGPReader, probe.ProcBandwidthReader, probe.PeerBandwidthReader,
+// probe.TunnelLister, probe.FirewallReader, probe.DualStackRacer,
+// probe.NeighborReader, probe.AddrResolver, probe.GeoLocator,
+// probe.TimeSyncChecker, probe.Pinger, probe.Tracer, probe.ProcSignaler,
+// probe.DNSCacher, probe.ProcInspector, probe.PortInspector,
+// probe.ConnInspector, probe.CastReader, probe.SoftnetReader,
+// probe.FlowLister, probe.QueueReader, probe.WifiReader, probe.LinkReader,
+// probe.LeaseReader, probe.DNSConfigReader, probe.DNSSniffer,
+// probe.EphemeralRangeReader and probe.HostBlocker; Err, when set, is returned by all of them.
+type Probes struct {
+	Snapshot probe.NetSnapshot
+	Ports    []probe.ListenPort
+	Procs    []probe.ProcNet
+
+	ConnRate      probe.ConnRate
+	ProcConnRates map[int32]float64
+	ICMPCounters  []probe.ICMPCounter
+	RouteTable    []probe.Route
+	RuleList      []probe.Rule
+	Conns         []probe.Conn
+	IsMetered     bool
+	BGP           []probe.BGPPeer // nil: no routing daemon
+	ProcBW        map[int32]probe.ProcBandwidth
+	PeerBW        map[string]probe.ProcBandwidth
+	TunnelList    []probe.Tunnel
+	Firewall      []probe.FirewallCounter
+	Chains        []probe.FirewallChain
+	Casts         map[string]probe.CastStats // by interface; others count nothing
+	SoftnetStats  []probe.SoftnetStat
+	FlowList      []probe.Flow
+	NICQueues     map[string]probe.NICQueues // by interface
+	Wifis         map[string]probe.WifiInfo  // by interface; others aren't connected
+	Links         map[string]probe.LinkInfo  // by interface; others are virtual
+	Leases        map[string]probe.DHCPLease // by interface; others have none
+	NeighborList  []probe.Neighbor
+	HostNames     map[string][]string // reverse DNS, by IP
+	Geo           map[string]probe.GeoInfo
+	Clock         probe.TimeSync
+	RTTs          map[string]time.Duration // ping round trips by host; others time out
+	Hops          []probe.Hop              // the route Traceroute reports to any host
+	CacheStats    probe.DNSCache           // zero Daemon: no caching resolver
+	DNSConf       probe.DNSConfig
+	LookupRTTs    map[string]time.Duration // timed lookups by resolver; others time out
+	Queries       []probe.DNSQuery         // what SniffDNS sees on any interface
+	Ephemeral     probe.PortRange
+
+	// Eyeballs is returned by RaceDualStack for any host.
+	Eyeballs probe.EyeballsRace
+
+	// RAs are handed out one per NextRA call, then io.EOF.
+	RAs  []probe.RouterAdvert
+	raMu sync.Mutex
+
+	// Stopped records StopProc calls: PID → force. Flushes counts
+	// FlushDNSCache calls. Blocked records the addresses passed to
+	// BlockHosts.
+	Stopped map[int32]bool
+	Flushes int
+	Blocked []string
+	mu      sync.Mutex
+
+	Err error
+}
+
+func (p *Probes) Sample() (probe.NetSnapshot, error) {
+	return p.Snapshot, p.Err
+}
+
+func (p *Probes) ListListening() ([]probe.ListenPort, error) {
+	return p.Ports, p.Err
+}
+
+func (p *Probes) TopProcsByConnections(limit int) ([]probe.ProcNet, error) {
+	out := p.Procs
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, p.Err
+}
+
+func (p *Probes) Rate() (probe.ConnRate, error) {
+	return p.ConnRate, p.Err
+}
+
+func (p *Probes) ProcRates() (map[int32]float64, error) {
+	return p.ProcConnRates, p.Err
+}
+
+func (p *Probes) ICMP() ([]probe.ICMPCounter, error) {
+	return p.ICMPCounters, p.Err
+}
+
+func (p *Probes) Routes() ([]probe.Route, error) {
+	return p.RouteTable, p.Err
+}
+
+func (p *Probes) Rules() ([]probe.Rule, error) {
+	return p.RuleList, p.Err
+}
+
+func (p *Probes) ListConnections() ([]probe.Conn, error) {
+	return p.Conns, p.Err
+}
+
+func (p *Probes) Metered() (bool, error) {
+	return p.IsMetered, p.Err
+}
+
+func (p *Probes) BGPPeers() ([]probe.BGPPeer, error) {
+	if p.Err == nil && p.BGP == nil {
+		return nil, probe.ErrNoRoutingDaemon
+	}
+	return p.BGP, p.Err
+}
+
+func (p *Probes) ProcBandwidth() (map[int32]probe.ProcBandwidth, error) {
+	return p.ProcBW, p.Err
+}
+
+func (p *Probes) PeerBandwidth() (map[string]probe.ProcBandwidth, error) {
+	return p.PeerBW, p.Err
+}
+
+func (p *Probes) Tunnels([]probe.ListenPort) ([]probe.Tunnel, error) {
+	return p.TunnelList, p.Err
+}
+
+func (p *Probes) FirewallCounters() ([]probe.FirewallCounter, error) {
+	return p.Firewall, p.Err
+}
+
+func (p *Probes) ListFirewallRules() ([]probe.FirewallChain, error) {
+	return p.Chains, p.Err
+}
+
+func (p *Probes) Softnet() ([]probe.SoftnetStat, error) {
+	return p.SoftnetStats, p.Err
+}
+
+func (p *Probes) ListFlows() ([]probe.Flow, error) {
+	return p.FlowList, p.Err
+}
+
+func (p *Probes) Queues(iface string) (probe.NICQueues, error) {
+	return p.NICQueues[iface], p.Err
+}
+
+func (p *Probes) Wifi(iface string) (probe.WifiInfo, error) {
+	return p.Wifis[iface], p.Err
+}
+
+func (p *Probes) Link(iface string) (probe.LinkInfo, error) {
+	return p.Links[iface], p.Err
+}
+
+func (p *Probes) Lease(iface string) (probe.DHCPLease, error) {
+	if l, ok := p.Leases[iface]; ok || p.Err != nil {
+		return l, p.Err
+	}
+	return probe.DHCPLease{}, probe.ErrNoLease
+}
+
+func (p *Probes) CastCounters(iface string) (probe.CastStats, error) {
+	if c, ok := p.Casts[iface]; ok {
+		return c, p.Err
+	}
+	return probe.CastStats{RxMulticast: -1, TxMulticast: -1, RxBroadcast: -1, TxBroadcast: -1}, p.Err
+}
+
+func (p *Probes) Neighbors() ([]probe.Neighbor, error) {
+	return p.NeighborList, p.Err
+}
+
+func (p *Probes) LookupAddr(_ context.Context, ip string) ([]string, error) {
+	return p.HostNames[ip], p.Err
+}
+
+func (p *Probes) GeoLookup(ip string) (probe.GeoInfo, error) {
+	return p.Geo[ip], p.Err
+}
+
+func (p *Probes) TimeSync(server string) (probe.TimeSync, error) {
+	return p.Clock, p.Err
+}
+
+func (p *Probes) Ping(_ context.Context, host string) (time.Duration, string, error) {
+	if p.Err != nil {
+		return 0, "", p.Err
+	}
+	rtt, ok := p.RTTs[host]
+	if !ok {
+		return 0, "", context.DeadlineExceeded
+	}
+	return rtt, "icmp", nil
+}
+
+func (p *Probes) Traceroute(ctx context.Context, _ string, hops chan<- probe.Hop) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	for _, h := range p.Hops {
+		select {
+		case hops <- h:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// SniffDNS hands out Queries, then captures on until ctx is done.
+func (p *Probes) SniffDNS(ctx context.Context, _ string, queries chan<- probe.DNSQuery) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	for _, q := range p.Queries {
+		select {
+		case queries <- q:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// ProcDetail makes up the details from Procs and Conns: the sockets are
+// the process's entries in Conns.
+func (p *Probes) ProcDetail(pid int32) (probe.ProcDetails, error) {
+	if p.Err != nil {
+		return probe.ProcDetails{}, p.Err
+	}
+	for _, pn := range p.Procs {
+		if pn.PID != pid {
+			continue
+		}
+		d := probe.ProcDetails{PID: pid, Name: pn.Name, Cmdline: "/usr/bin/" + pn.Name, User: "duck", FDs: 12 + pn.ConnCount}
+		for _, c := range p.Conns {
+			if c.PID == pid {
+				d.Sockets = append(d.Sockets, c)
+			}
+		}
+		return d, nil
+	}
+	return probe.ProcDetails{}, fmt.Errorf("process %d not found", pid)
+}
+
+// InspectPort makes up the details of any listener: a process of user duck
+// with an idle queue.
+func (p *Probes) InspectPort(lp probe.ListenPort) (probe.PortDetail, error) {
+	if p.Err != nil {
+		return probe.PortDetail{}, p.Err
+	}
+	d := probe.PortDetail{ListenPort: lp, Family: "IPv4", UID: 1000, User: "duck", RecvQ: 0, SendQ: 128, Inode: 40000 + uint64(lp.PID)}
+	if ip, _ := probe.SplitLocal(lp.Local); strings.Contains(ip, ":") {
+		d.Family = "IPv6"
+	}
+	if lp.Process != "" {
+		d.Cmdline = "/usr/bin/" + lp.Process
+	}
+	if lp.Proto == "udp" {
+		d.SendQ = 0
+	}
+	d.Opts = sockOpts(d.Family == "IPv6", lp.Proto)
+	d.Opts.Shared = 1
+	return d, nil
+}
+
+// InspectConn makes up the options of any connection: the ones of
+// InspectPort, with keepalive on for SSH.
+func (p *Probes) InspectConn(c probe.Conn) (probe.SockOpts, error) {
+	if p.Err != nil {
+		return probe.SockOpts{}, p.Err
+	}
+	ip, port := probe.SplitLocal(c.Local)
+	o := sockOpts(strings.Contains(ip, ":"), c.Proto)
+	if c.Proto == "tcp" {
+		o.RTT = 18 * time.Millisecond
+		if port == "22" {
+			o.Keepalive, o.KeepaliveNext = true, 14*time.Second
+		}
+	}
+	return o, nil
+}
+
+func sockOpts(v6 bool, proto string) probe.SockOpts {
+	o := probe.SockOpts{Found: true, RcvBuf: 131072, SndBuf: 16384, Drops: 0, V6Only: -1, MSS: -1, RTT: -1,
+		SysKeepalive: probe.KeepaliveTimes{Idle: 2 * time.Hour, Interval: 75 * time.Second, Probes: 9}}
+	if v6 {
+		o.V6Only = 1
+	}
+	if proto == "tcp" {
+		o.Congestion, o.Flags, o.MSS = "cubic", []string{"ts", "sack", "wscale:7,7"}, 1448
+	}
+	return o
+}
+
+func (p *Probes) StopProc(pid int32, force bool) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Stopped == nil {
+		p.Stopped = map[int32]bool{}
+	}
+	p.Stopped[pid] = force
+	return nil
+}
+
+func (p *Probes) BlockHosts(ips []string) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Blocked = append(p.Blocked, ips...)
+	return nil
+}
+
+func (p *Probes) DNSCache() (probe.DNSCache, error) {
+	if p.Err == nil && p.CacheStats.Daemon == "" {
+		return probe.DNSCache{}, probe.ErrNoDNSCache
+	}
+	return p.CacheStats, p.Err
+}
+
+func (p *Probes) DNSConfig() (probe.DNSConfig, error) {
+	return p.DNSConf, p.Err
+}
+
+func (p *Probes) EphemeralRange() (probe.PortRange, error) {
+	return p.Ephemeral, p.Err
+}
+
+func (p *Probes) TimeLookup(ctx context.Context, server, name string) (time.Duration, error) {
+	if p.Err != nil {
+		return 0, p.Err
+	}
+	if rtt, ok := p.LookupRTTs[server]; ok {
+		return rtt, nil
+	}
+	return 0, context.DeadlineExceeded
+}
+
+func (p *Probes) FlushDNSCache() error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Flushes++
+	return nil
+}
+
+func (p *Probes) RaceDualStack(host, port string) (probe.EyeballsRace, error) {
+	r := p.Eyeballs
+	r.Host, r.Port = host, port
+	return r, p.Err
+}
+
+func (p *Probes) NextRA() (probe.RouterAdvert, error) {
+	p.raMu.Lock()
+	defer p.raMu.Unlock()
+	if p.Err != nil {
+		return probe.RouterAdvert{}, p.Err
+	}
+	if len(p.RAs) == 0 {
+		return probe.RouterAdvert{}, io.EOF
+	}
+	ra := p.RAs[0]
+	p.RAs = p.RAs[1:]
+	return ra, nil
+}
+
+// Fixture returns a small but representative host: a physical NIC, a Wi-Fi
+// one, loopback, a docker bridge and a down veth, plus a few listeners and
+// processes.
+func Fixture() *Probes {
+	return &Probes{
+		Snapshot: probe.NetSnapshot{
+			Hostname: "duckhost",
+			Uptime:   26*time.Hour + 13*time.Minute,
+			TakenAt:  Epoch,
+			Ifaces: []probe.IfaceInfo{
+				{Name: "lo", MTU: 65536, Addrs: []string{"127.0.0.1/8", "::1/128"}, IsUp: true,
+					RxBps: 512, TxBps: 512, RxTotal: 1 << 20, TxTotal: 1 << 20, Kind: probe.IfaceLoopback},
+				{Name: "eth0", MTU: 1500, Hardware: "52:54:00:12:34:56", Addrs: []string{"192.168.1.10/24", "fe80::5054:ff:fe12:3456/64"}, IsUp: true,
+					RxBps: 1.5 * 1024 * 1024, TxBps: 220 * 1024, RxTotal: 3 << 30, TxTotal: 400 << 20, Kind: probe.IfacePhysical, Speed: 100,
+					RxPps: 1100, TxPps: 420, Dropin: 42, DropinRate: 0.5},
+				{Name: "wlan0", MTU: 1500, Hardware: "3c:22:fb:9a:0b:17", Addrs: []string{"10.20.0.23/24"}, IsUp: true,
+					RxBps: 36 * 1024, TxBps: 8 * 1024, RxTotal: 700 << 20, TxTotal: 90 << 20, Kind: probe.IfacePhysical,
+					RxPps: 40, TxPps: 22},
+				{Name: "docker0", MTU: 1500, Hardware: "02:42:ac:11:00:01", Addrs: []string{"172.17.0.1/16"}, IsUp: true,
+					RxBps: 2048, TxBps: 4096, RxTotal: 10 << 20, TxTotal: 20 << 20, Kind: probe.IfaceDockerBridge},
+				{Name: "veth1a2b3c", MTU: 1500, Hardware: "9a:1b:2c:3d:4e:5f", IsUp: false, Kind: probe.IfaceVeth},
+			},
+		},
+		Ports: []probe.ListenPort{
+			{Proto: "tcp", Local: "0.0.0.0:22", PID: 812, Process: "sshd"},
+			{Proto: "tcp", Local: "127.0.0.1:5432", PID: 1022, Process: "postgres"},
+			{Proto: "tcp", Local: ":::8080", PID: 2301, Process: "python3"},
+			{Proto: "udp", Local: "0.0.0.0:5353", PID: 640, Process: "avahi-daemon"},
+		},
+		Procs: []probe.ProcNet{
+			{PID: 2301, Name: "python3", ConnCount: 12, ListenCount: 1},
+			{PID: 1022, Name: "postgres", ConnCount: 7, ListenCount: 1},
+			{PID: 812, Name: "sshd", ConnCount: 3, ListenCount: 1},
+			{PID: 640, Name: "avahi-daemon", ConnCount: 2, ListenCount: 0},
+		},
+		ConnRate:      probe.ConnRate{Active: 2.5, Passive: 0.5},
+		ProcConnRates: map[int32]float64{2301: 2, 812: 0.2},
+		ProcBW: map[int32]probe.ProcBandwidth{
+			2301: {RxBps: 1.2 * 1024 * 1024, TxBps: 40 * 1024},
+			812:  {RxBps: 300, TxBps: 2048},
+		},
+		PeerBW: map[string]probe.ProcBandwidth{
+			"93.184.215.14": {RxBps: 1.2 * 1024 * 1024, TxBps: 40 * 1024, RxBytes: 310 << 20, TxBytes: 9 << 20},
+			"192.168.1.20":  {RxBps: 300, TxBps: 2048, RxBytes: 48 << 10, TxBytes: 512 << 10},
+		},
+		ICMPCounters: []probe.ICMPCounter{
+			{Proto: "icmp", Name: "InMsgs", Total: 1200, Rate: 1},
+			{Proto: "icmp", Name: "InEchos", Total: 310, Rate: 1},
+			{Proto: "icmp", Name: "InDestUnreachs", Total: 42},
+			{Proto: "icmp", Name: "InRedirects", Total: 0},
+			{Proto: "icmp6", Name: "InMsgs", Total: 530},
+			{Proto: "icmp6", Name: "InRedirects", Total: 0},
+		},
+		RouteTable: []probe.Route{
+			{Family: "inet", Dst: "0.0.0.0/0", Gateway: "192.168.1.1", Iface: "eth0", Metric: 100},
+			{Family: "inet", Dst: "172.17.0.0/16", Iface: "docker0"},
+			{Family: "inet", Dst: "192.168.1.0/24", Iface: "eth0", Metric: 100},
+			{Family: "inet6", Dst: "fe80::/64", Iface: "eth0", Metric: 256},
+		},
+		RuleList: []probe.Rule{
+			{Family: "inet", Priority: 0, Selector: "from all", Table: "local", Action: "lookup", Routes: 5},
+			{Family: "inet", Priority: 32766, Selector: "from all", Table: "main", Action: "lookup", Routes: 3, Default: "via 192.168.1.1 dev eth0"},
+			{Family: "inet", Priority: 32767, Selector: "from all", Table: "default", Action: "lookup"},
+		},
+		TunnelList: []probe.Tunnel{
+			{Kind: "local", Listen: "127.0.0.1:15432", Target: "db.internal:5432", Server: "bastion", PID: 3100, Process: "autossh", Active: true},
+			{Kind: "dynamic", Listen: "127.0.0.1:1080", Server: "bastion", PID: 3100, Process: "autossh"},
+		},
+		Firewall: []probe.FirewallCounter{
+			{Family: "inet", Table: "filter", Chain: "input", Handle: 4, Dir: "in", Ifaces: []string{"eth0"}, Verdict: "drop", Comment: "block telnet", Packets: 42, Bytes: 2520},
+			{Family: "inet", Table: "filter", Chain: "forward", Handle: 9, Dir: "out", Ifaces: []string{"docker*"}, Verdict: "accept", Packets: 1800, Bytes: 2 << 20},
+		},
+		Chains: []probe.FirewallChain{
+			{Family: "inet", Table: "filter", Name: "input", Hook: "input", Policy: "drop", Rules: []probe.FirewallRule{
+				{Handle: 2, Text: "ct state established,related accept", Verdict: "accept", Counted: true, Packets: 91000, Bytes: 120 << 20},
+				{Handle: 3, Text: `iifname "lo" accept`, Verdict: "accept"},
+				{Handle: 4, Text: `iifname "eth0" tcp dport 23 drop`, Verdict: "drop", Comment: "block telnet", Counted: true, Packets: 42, Bytes: 2520},
+				{Handle: 5, Text: "tcp dport { 22, 8080 } accept", Verdict: "accept", Counted: true, Packets: 310, Bytes: 18600},
+			}},
+			{Family: "inet", Table: "filter", Name: "forward", Hook: "forward", Policy: "accept", Rules: []probe.FirewallRule{
+				{Handle: 9, Text: `oifname "docker*" accept`, Verdict: "accept", Counted: true, Packets: 1800, Bytes: 2 << 20},
+			}},
+			{Family: "ip", Table: "nat", Name: "postrouting", Hook: "postrouting", Priority: 100, Policy: "accept", Rules: []probe.FirewallRule{
+				{Handle: 12, Text: `ip saddr 172.17.0.0/16 oifname != "docker0" masquerade`, Verdict: "masquerade", Counted: true, Packets: 64, Bytes: 3840},
+			}},
+		},
+		Casts: map[string]probe.CastStats{
+			"eth0":    {RxMulticast: 48210, TxMulticast: 1320, RxBroadcast: 9120, TxBroadcast: 210},
+			"docker0": {RxMulticast: 0, TxMulticast: -1, RxBroadcast: -1, TxBroadcast: -1},
+		},
+		SoftnetStats: []probe.SoftnetStat{
+			{CPU: 0, Processed: 8_120_400, Squeezed: 12, NetRx: 2_310_000, NetTx: 41_000},
+			{CPU: 1, Processed: 1_204_900, NetRx: 690_000, NetTx: 38_500},
+		},
+		NICQueues: map[string]probe.NICQueues{
+			"eth0": {RX: 4, TX: 4, IRQs: []probe.QueueIRQ{
+				{IRQ: 45, Name: "eth0-TxRx-0", Count: 1_204_551, Affinity: "0", Effective: "0"},
+				{IRQ: 46, Name: "eth0-TxRx-1", Count: 880_102, Affinity: "1", Effective: "1"},
+				{IRQ: 47, Name: "eth0-TxRx-2", Count: 912_840, Affinity: "0-3", Effective: "2"},
+				{IRQ: 48, Name: "eth0-TxRx-3", Count: 45_210, Affinity: "0-3", Effective: "3"},
+				{IRQ: 49, Name: "eth0", Count: 12, Affinity: "0-3", Effective: "0"},
+			}},
+			"docker0": {RX: 1, TX: 1},
+		},
+		Wifis: map[string]probe.WifiInfo{
+			"wlan0": {Connected: true, SSID: "duckpond", BSSID: "a4:2b:b0:11:22:33", Freq: 5180, Channel: 36,
+				Signal: -58, RxRate: 433.3, TxRate: 390},
+		},
+		Links: map[string]probe.LinkInfo{
+			"eth0":  {Speed: 100, Duplex: "full", Carrier: "up", CarrierChanges: 6, Driver: "e1000e", Autoneg: "on", MaxSpeed: 1000},
+			"wlan0": {Carrier: "up", CarrierChanges: 2, Driver: "iwlwifi"},
+		},
+		Leases: map[string]probe.DHCPLease{
+			"eth0": {Source: "systemd-networkd", Addr: "192.168.1.10", Server: "192.168.1.1", Router: "192.168.1.1",
+				DNS: []string{"192.168.1.1", "1.1.1.1"}, Domain: "lan", Obtained: Epoch.Add(-3 * time.Hour), Expires: Epoch.Add(21 * time.Hour)},
+			"wlan0": {Source: "NetworkManager", Addr: "10.20.0.23", Server: "10.20.0.1", Router: "10.20.0.1",
+				DNS: []string{"10.20.0.1"}, Obtained: Epoch.Add(-50 * time.Minute), Expires: Epoch.Add(10 * time.Minute)},
+		},
+		FlowList: []probe.Flow{
+			{Family: "ipv4", Proto: "tcp", State: "ESTABLISHED", Expiry: 431990,
+				Src: "192.168.1.10:51234", Dst: "93.184.215.14:443", ReplySrc: "93.184.215.14:443", ReplyDst: "192.168.1.10:51234",
+				Packets: 120, Bytes: 9400, ReplyPackets: 210, ReplyBytes: 284000, Assured: true},
+			{Family: "ipv4", Proto: "tcp", State: "ESTABLISHED", Expiry: 431200,
+				Src: "172.17.0.2:40112", Dst: "140.82.121.4:443", ReplySrc: "140.82.121.4:443", ReplyDst: "192.168.1.10:40112",
+				Packets: 64, Bytes: 5200, ReplyPackets: 88, ReplyBytes: 96000, Assured: true},
+			{Family: "ipv4", Proto: "tcp", State: "ESTABLISHED", Expiry: 86000,
+				Src: "192.168.1.20:55002", Dst: "192.168.1.10:8080", ReplySrc: "172.17.0.2:80", ReplyDst: "192.168.1.20:55002",
+				Packets: 12, Bytes: 1400, ReplyPackets: 10, ReplyBytes: 8200, Assured: true},
+			{Family: "ipv4", Proto: "udp", Expiry: 28,
+				Src: "192.168.1.10:41000", Dst: "192.168.1.1:53", ReplySrc: "192.168.1.1:53", ReplyDst: "192.168.1.10:41000",
+				Packets: 1, Bytes: 72, ReplyPackets: 1, ReplyBytes: 120},
+			{Family: "ipv4", Proto: "icmp", Expiry: 25,
+				Src: "192.168.1.10", Dst: "192.168.1.77", ReplySrc: "192.168.1.77", ReplyDst: "192.168.1.10",
+				Packets: 1, Bytes: 84, Unreplied: true},
+		},
+		NeighborList: []probe.Neighbor{
+			{Family: "inet", IP: "192.168.1.1", MAC: "52:54:00:00:00:01", Iface: "eth0", State: "REACHABLE"},
+			{Family: "inet", IP: "192.168.1.20", MAC: "3c:22:fb:12:34:56", Iface: "eth0", State: "STALE"},
+			{Family: "inet6", IP: "fe80::1", MAC: "52:54:00:00:00:01", Iface: "eth0", State: "REACHABLE"},
+		},
+		HostNames: map[string][]string{
+			"93.184.215.14": {"example.com."},
+			"192.168.1.10":  {"duckbox.lan."},
+			"192.168.1.20":  {"laptop.lan."},
+		},
+		Geo: map[string]probe.GeoInfo{
+			"93.184.215.14": {Country: "US", ASN: 15133, Org: "Edgecast Inc."},
+			"203.0.113.66":  {Country: "NL"},
+		},
+		Clock: probe.TimeSync{Daemon: "chrony", Known: true, Synced: true, Server: "ntp1.example.net",
+			Offset: 312 * time.Microsecond, HasOffset: true, OffsetFrom: "chrony"},
+		RTTs: map[string]time.Duration{
+			"192.168.1.1": 1200 * time.Microsecond,
+			"1.1.1.1":     14 * time.Millisecond,
+		},
+		Hops: []probe.Hop{
+			{TTL: 1, Addr: "192.168.1.1", Sent: 3, RTTs: []time.Duration{1100 * time.Microsecond, 900 * time.Microsecond, 1300 * time.Microsecond}},
+			{TTL: 2, Sent: 3},
+			{TTL: 3, Addr: "198.51.100.9", Sent: 3, RTTs: []time.Duration{9 * time.Millisecond, 11 * time.Millisecond}},
+			{TTL: 4, Addr: "93.184.215.14", Sent: 3, RTTs: []time.Duration{14 * time.Millisecond, 15 * time.Millisecond, 14 * time.Millisecond}},
+		},
+		CacheStats: probe.DNSCache{Daemon: "systemd-resolved", Size: 42, Hits: 500, Misses: 734},
+		DNSConf: probe.DNSConfig{
+			Servers: []probe.DNSServer{
+				{Addr: "127.0.0.53", From: "resolv.conf"},
+				{Addr: "1.1.1.1", Name: "cloudflare-dns.com", From: "systemd-resolved"},
+				{Addr: "192.168.1.1", Iface: "eth0", From: "systemd-resolved"},
+				{Addr: "10.20.0.1", Iface: "wlan0", From: "systemd-resolved"},
+			},
+			Search:   []string{"lan"},
+			Resolved: true, DoT: "opportunistic", DNSSEC: "allow-downgrade",
+		},
+		LookupRTTs: map[string]time.Duration{
+			"127.0.0.53":  180 * time.Microsecond,
+			"1.1.1.1":     11400 * time.Microsecond,
+			"192.168.1.1": 2100 * time.Microsecond,
+		},
+		Ephemeral: probe.PortRange{Lo: 32768, Hi: 60999},
+		Queries: []probe.DNSQuery{
+			{Name: "api.github.com", Type: "A", Client: "192.168.1.50"},
+			{Name: "api.github.com", Type: "AAAA", Client: "192.168.1.50"},
+			{Name: "duckduckgo.com", Type: "HTTPS", Client: "192.168.1.50"},
+			{Name: "duckduckgo.com", Type: "HTTPS", Client: "192.168.1.50", Addrs: []string{"203.0.113.66"}},
+			{Name: "api.github.com", Type: "A", Client: "192.168.1.50"},
+		},
+		Eyeballs: probe.EyeballsRace{
+			V4: probe.EyeballsAttempt{Addr: "93.184.215.14", Lookup: 12 * time.Millisecond, Connect: 31 * time.Millisecond},
+			V6: probe.EyeballsAttempt{Addr: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", Lookup: 14 * time.Millisecond, Connect: 118 * time.Millisecond},
+		},
+		Conns: []probe.Conn{
+			{Proto: "tcp", Local: "192.168.1.10:22", Remote: "192.168.1.20:50312", Status: "ESTABLISHED", PID: 812, Process: "sshd"},
+			{Proto: "tcp", Local: "192.168.1.10:41234", Remote: "93.184.215.14:443", Status: "ESTABLISHED", PID: 2301, Process: "python3"},
+			{Proto: "tcp", Local: "192.168.1.10:41238", Remote: "93.184.215.14:443", Status: "ESTABLISHED", PID: 2301, Process: "python3"},
+			{Proto: "tcp", Local: "192.168.1.10:41236", Remote: "203.0.113.66:443", Status: "SYN_SENT", PID: 2301, Process: "python3"},
+			{Proto: "tcp", Local: "127.0.0.1:5432", Remote: "127.0.0.1:38110", Status: "ESTABLISHED", PID: 1022, Process: "postgres"},
+		},
+		RAs: []probe.RouterAdvert{
+			{Router: "fe80::1", Iface: "eth0", ReceivedAt: Epoch, HopLimit: 64, Other: true, Preference: "medium",
+				Lifetime: 30 * time.Minute, MTU: 1500, SourceMAC: "52:54:00:00:00:01", RDNSS: []string{"2001:db8::53"},
+				Prefixes: []probe.RAPrefix{{Prefix: "2001:db8:1::/64", OnLink: true, Autonomous: true, Valid: 24 * time.Hour, Preferred: 4 * time.Hour}}},
+		},
+	}
+}
@@ -0,0 +1,297 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DHCPLease is the IPv4 lease an interface holds from a DHCP server.
+type DHCPLease struct {
+	Source   string // systemd-networkd, NetworkManager, dhclient
+	Addr     string // the address leased
+	Server   string // the server that leased it
+	Router   string
+	DNS      []string // the resolvers the server offered
+	Domain   string
+	Obtained time.Time // zero when the client doesn't say
+	Expires  time.Time // zero when the client doesn't say
+}
+
+// ErrNoLease means no DHCP client on the host knows of a lease for the
+// interface: it is configured statically, virtual, or its client is one
+// not supported.
+var ErrNoLease = errors.New("dhcp: no lease")
+
+// LeaseReader reads the DHCP lease of an interface.
+type LeaseReader interface {
+	Lease(iface string) (DHCPLease, error)
+}
+
+// Where the DHCP clients keep their leases; variables so that they can be
+// pointed elsewhere.
+var (
+	networkdLeaseDir  = "/run/systemd/netif/leases"
+	dhclientLeaseGlob = []string{"/var/lib/dhcp/dhclient*.leases", "/var/lib/dhclient/*.lease*", "/var/lib/NetworkManager/dhclient-*.lease"}
+)
+
+// Lease asks systemd-networkd, NetworkManager and dhclient, in that order,
+// for the lease of iface; ErrNoLease when none has one. Linux only.
+func (Host) Lease(iface string) (DHCPLease, error) { return Lease(iface) }
+
+func Lease(iface string) (DHCPLease, error) {
+	if l, ok := networkdLease(iface); ok {
+		return l, nil
+	}
+	if l, ok := nmcliLease(iface); ok {
+		return l, nil
+	}
+	var best DHCPLease
+	for _, pat := range dhclientLeaseGlob {
+		files, _ := filepath.Glob(pat)
+		for _, f := range files {
+			fh, err := os.Open(f)
+			if err != nil {
+				continue
+			}
+			l, ok := parseDhclientLeases(fh, iface)
+			fh.Close()
+			// clients append renewals; the newest lease wins
+			if ok && (best.Addr == "" || l.Expires.After(best.Expires)) {
+				best = l
+			}
+		}
+	}
+	if best.Addr != "" {
+		return best, nil
+	}
+	return DHCPLease{}, ErrNoLease
+}
+
+// networkdLease reads the lease systemd-networkd keeps for the interface,
+// by its index. The file carries no times: it is rewritten on every
+// renewal, so it was obtained when it was last written.
+func networkdLease(iface string) (DHCPLease, bool) {
+	idx := readTrimmed(filepath.Join(sysRoot, "class/net", iface, "ifindex"))
+	if idx == "" {
+		return DHCPLease{}, false
+	}
+	path := filepath.Join(networkdLeaseDir, idx)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return DHCPLease{}, false
+	}
+	l, lifetime := parseNetworkdLease(b)
+	if l.Addr == "" {
+		return DHCPLease{}, false
+	}
+	if fi, err := os.Stat(path); err == nil {
+		l.Obtained = fi.ModTime()
+		if lifetime > 0 {
+			l.Expires = l.Obtained.Add(lifetime)
+		}
+	}
+	return l, true
+}
+
+// parseNetworkdLease reads systemd-networkd's lease file, and the lease's
+// lifetime:
+//
+//	# This is private data. Do not parse.
+//	ADDRESS=192.168.1.10
+//	ROUTER=192.168.1.1
+//	SERVER_ADDRESS=192.168.1.1
+//	LIFETIME=86400
+//	DNS=192.168.1.1 1.1.1.1
+//	DOMAINNAME=lan
+func parseNetworkdLease(b []byte) (DHCPLease, time.Duration) {
+	l := DHCPLease{Source: "systemd-networkd"}
+	var lifetime time.Duration
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(strings.TrimSpace(sc.Text()), "=")
+		if !ok || strings.HasPrefix(k, "#") {
+			continue
+		}
+		switch k {
+		case "ADDRESS":
+			l.Addr = v
+		case "SERVER_ADDRESS":
+			l.Server = v
+		case "ROUTER":
+			l.Router = firstField(v)
+		case "DNS":
+			l.DNS = strings.Fields(v)
+		case "DOMAINNAME":
+			l.Domain = v
+		case "LIFETIME":
+			if n, err := strconv.Atoi(v); err == nil {
+				lifetime = time.Duration(n) * time.Second
+			}
+		}
+	}
+	return l, lifetime
+}
+
+func nmcliLease(iface string) (DHCPLease, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "nmcli", "-t", "-f", "DHCP4", "device", "show", iface).Output()
+	if err != nil {
+		return DHCPLease{}, false
+	}
+	l := parseNmcliDHCP4(out)
+	return l, l.Addr != ""
+}
+
+// parseNmcliDHCP4 reads the DHCP options NetworkManager lists for a device,
+// where expiry is when the lease runs out in seconds since the epoch:
+//
+//	DHCP4.OPTION[2]:dhcp_lease_time = 86400
+//	DHCP4.OPTION[3]:dhcp_server_identifier = 192.168.1.1
+//	DHCP4.OPTION[5]:domain_name_servers = 192.168.1.1 1.1.1.1
+//	DHCP4.OPTION[6]:expiry = 1704294245
+//	DHCP4.OPTION[7]:ip_address = 192.168.1.10
+func parseNmcliDHCP4(out []byte) DHCPLease {
+	l := DHCPLease{Source: "NetworkManager"}
+	var lifetime time.Duration
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		_, opt, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			continue
+		}
+		k, v, ok := strings.Cut(opt, "=")
+		if !ok {
+			continue
+		}
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		switch k {
+		case "ip_address":
+			l.Addr = v
+		case "dhcp_server_identifier":
+			l.Server = v
+		case "routers":
+			l.Router = firstField(v)
+		case "domain_name_servers":
+			l.DNS = strings.Fields(v)
+		case "domain_name":
+			l.Domain = firstField(v)
+		case "dhcp_lease_time":
+			if n, err := strconv.Atoi(v); err == nil {
+				lifetime = time.Duration(n) * time.Second
+			}
+		case "expiry":
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				l.Expires = time.Unix(n, 0)
+			}
+		}
+	}
+	if !l.Expires.IsZero() && lifetime > 0 {
+		l.Obtained = l.Expires.Add(-lifetime)
+	}
+	return l
+}
+
+// parseDhclientLeases reads the last lease for iface in an ISC dhclient
+// leases file, which holds one block per lease obtained or renewed. Times
+// are UTC, or seconds since the epoch with db-time-format local:
+//
+//	lease {
+//	  interface "eth0";
+//	  fixed-address 192.168.1.10;
+//	  option routers 192.168.1.1;
+//	  option dhcp-lease-time 86400;
+//	  option dhcp-server-identifier 192.168.1.1;
+//	  option domain-name-servers 192.168.1.1,1.1.1.1;
+//	  option domain-name "lan";
+//	  renew 2 2024/01/02 22:04:05;
+//	  expire 3 2024/01/03 15:04:05;
+//	}
+func parseDhclientLeases(r io.Reader, iface string) (DHCPLease, bool) {
+	var (
+		cur, last DHCPLease
+		curIface  string
+		lifetime  time.Duration
+		found     bool
+	)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSuffix(strings.TrimSpace(sc.Text()), ";")
+		if c := strings.Index(line, "#"); c >= 0 {
+			line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line[:c]), ";"))
+		}
+		switch {
+		case strings.HasPrefix(line, "lease {"):
+			cur, curIface, lifetime = DHCPLease{Source: "dhclient"}, "", 0
+			continue
+		case line == "}":
+			if curIface == iface && cur.Addr != "" {
+				if !cur.Expires.IsZero() && lifetime > 0 {
+					cur.Obtained = cur.Expires.Add(-lifetime)
+				}
+				last, found = cur, true
+			}
+			continue
+		}
+		k, v, _ := strings.Cut(line, " ")
+		if k == "option" {
+			k, v, _ = strings.Cut(v, " ")
+		}
+		v = strings.Trim(v, `"`)
+		switch k {
+		case "interface":
+			curIface = v
+		case "fixed-address":
+			cur.Addr = v
+		case "dhcp-server-identifier":
+			cur.Server = v
+		case "routers":
+			cur.Router = strings.Split(v, ",")[0]
+		case "domain-name-servers":
+			cur.DNS = strings.Split(v, ",")
+		case "domain-name":
+			cur.Domain = firstField(v)
+		case "dhcp-lease-time":
+			if n, err := strconv.Atoi(v); err == nil {
+				lifetime = time.Duration(n) * time.Second
+			}
+		case "expire":
+			cur.Expires = parseDhclientTime(v)
+		}
+	}
+	return last, found
+}
+
+// parseDhclientTime reads "3 2024/01/03 15:04:05" (weekday, then UTC) or
+// "epoch 1704294245"; zero for "never" and what it doesn't know.
+func parseDhclientTime(v string) time.Time {
+	f := strings.Fields(v)
+	switch {
+	case len(f) == 2 && f[0] == "epoch":
+		if n, err := strconv.ParseInt(f[1], 10, 64); err == nil {
+			return time.Unix(n, 0)
+		}
+	case len(f) == 3:
+		if t, err := time.Parse("2006/01/02 15:04:05", f[1]+" "+f[2]); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func firstField(s string) string {
+	if f := strings.Fields(s); len(f) > 0 {
+		return f[0]
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package probe
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNetworkdLease(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     DHCPLease
+		lifetime time.Duration
+	}{
+		{
+			"full",
+			"# This is private data. Do not parse.\nADDRESS=192.168.1.10\nNETMASK=255.255.255.0\nROUTER=192.168.1.1 192.168.1.2\nSERVER_ADDRESS=192.168.1.1\nLIFETIME=86400\nDNS=192.168.1.1 1.1.1.1\nDOMAINNAME=lan\n",
+			DHCPLease{Source: "systemd-networkd", Addr: "192.168.1.10", Server: "192.168.1.1", Router: "192.168.1.1", DNS: []string{"192.168.1.1", "1.1.1.1"}, Domain: "lan"},
+			24 * time.Hour,
+		},
+		{
+			"bad lifetime",
+			"ADDRESS=10.0.0.5\nLIFETIME=forever\n",
+			DHCPLease{Source: "systemd-networkd", Addr: "10.0.0.5"},
+			0,
+		},
+		{"empty", "", DHCPLease{Source: "systemd-networkd"}, 0},
+	}
+	for _, tt := range tests {
+		got, lifetime := parseNetworkdLease([]byte(tt.in))
+		if !reflect.DeepEqual(got, tt.want) || lifetime != tt.lifetime {
+			t.Errorf("%s: got %+v, %v\nwant %+v, %v", tt.name, got, lifetime, tt.want, tt.lifetime)
+		}
+	}
+}
+
+func TestParseNmcliDHCP4(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     DHCPLease
+	}{
+		{
+			"lease",
+			`DHCP4.OPTION[1]:broadcast_address = 192.168.1.255
+DHCP4.OPTION[2]:dhcp_lease_time = 86400
+DHCP4.OPTION[3]:dhcp_server_identifier = 192.168.1.1
+DHCP4.OPTION[4]:domain_name = lan home
+DHCP4.OPTION[5]:domain_name_servers = 192.168.1.1 1.1.1.1
+DHCP4.OPTION[6]:expiry = 1704294245
+DHCP4.OPTION[7]:ip_address = 192.168.1.10
+DHCP4.OPTION[8]:routers = 192.168.1.1
+`,
+			DHCPLease{
+				Source: "NetworkManager", Addr: "192.168.1.10", Server: "192.168.1.1", Router: "192.168.1.1",
+				DNS: []string{"192.168.1.1", "1.1.1.1"}, Domain: "lan",
+				Obtained: time.Unix(1704294245-86400, 0), Expires: time.Unix(1704294245, 0),
+			},
+		},
+		{
+			"no expiry",
+			"DHCP4.OPTION[1]:ip_address = 10.0.0.5\nDHCP4.OPTION[2]:dhcp_lease_time = 3600\n",
+			DHCPLease{Source: "NetworkManager", Addr: "10.0.0.5"},
+		},
+		{"not managed", "", DHCPLease{Source: "NetworkManager"}},
+	}
+	for _, tt := range tests {
+		if got := parseNmcliDHCP4([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseDhclientLeases(t *testing.T) {
+	const leases = `lease {
+  interface "eth0";
+  fixed-address 192.168.1.10;
+  option subnet-mask 255.255.255.0;
+  option routers 192.168.1.1,192.168.1.2;
+  option dhcp-lease-time 86400;
+  option dhcp-server-identifier 192.168.1.1;
+  option domain-name-servers 192.168.1.1,1.1.1.1;
+  option domain-name "lan";
+  renew 2 2024/01/02 22:04:05;
+  expire 3 2024/01/03 15:04:05;
+}
+lease {
+  interface "wlan0";
+  fixed-address 10.0.0.5;
+  expire never;
+}
+lease {
+  interface "eth0";
+  fixed-address 192.168.1.11; # renewed with another address
+  option dhcp-lease-time 3600;
+  expire epoch 1704297845; # Wed Jan 03 16:04:05 2024
+}
+`
+	expires := time.Date(2024, 1, 3, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name, in, iface string
+		want            DHCPLease
+		ok              bool
+	}{
+		{
+			"the newest block wins", leases, "eth0",
+			DHCPLease{Source: "dhclient", Addr: "192.168.1.11", Obtained: time.Unix(1704297845-3600, 0), Expires: time.Unix(1704297845, 0)},
+			true,
+		},
+		{
+			"another interface", leases, "wlan0",
+			DHCPLease{Source: "dhclient", Addr: "10.0.0.5"},
+			true,
+		},
+		{
+			"one block", leases[:strings.Index(leases, "lease {\n  interface \"wlan0\"")], "eth0",
+			DHCPLease{
+				Source: "dhclient", Addr: "192.168.1.10", Server: "192.168.1.1", Router: "192.168.1.1",
+				DNS: []string{"192.168.1.1", "1.1.1.1"}, Domain: "lan",
+				Obtained: expires.Add(-24 * time.Hour), Expires: expires,
+			},
+			true,
+		},
+		{"no lease for it", leases, "eth1", DHCPLease{}, false},
+		{"cut short", "lease {\n  interface \"eth0\";\n  fixed-address 192.168.1.10;\n", "eth0", DHCPLease{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseDhclientLeases(strings.NewReader(tt.in), tt.iface)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, %v\nwant %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseDhclientTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"3 2024/01/03 15:04:05", time.Date(2024, 1, 3, 15, 4, 5, 0, time.UTC)},
+		{"epoch 1704294245", time.Unix(1704294245, 0)},
+		{"never", time.Time{}},
+		{"3 2024-01-03 15:04:05", time.Time{}},
+		{"epoch soon", time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := parseDhclientTime(tt.in); !got.Equal(tt.want) {
+			t.Errorf("parseDhclientTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
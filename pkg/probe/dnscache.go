@@ -0,0 +1,243 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// DNSCache is the state of the local caching resolver.
+type DNSCache struct {
+	Daemon       string // "systemd-resolved" or "dnsmasq"
+	Size         int    // entries cached now; -1 when the daemon doesn't say
+	Capacity     int    // configured maximum; 0 when unknown
+	Hits, Misses uint64
+}
+
+// HitRate is the share of lookups answered from the cache, in percent.
+func (c DNSCache) HitRate() float64 {
+	if c.Hits+c.Misses == 0 {
+		return 0
+	}
+	return float64(c.Hits) * 100 / float64(c.Hits+c.Misses)
+}
+
+// DNSCacher reads and flushes the cache of the local resolver.
+type DNSCacher interface {
+	// DNSCache asks systemd-resolved, then dnsmasq.
+	DNSCache() (DNSCache, error)
+	// FlushDNSCache empties the cache of the daemon DNSCache found.
+	FlushDNSCache() error
+}
+
+func (Host) DNSCache() (DNSCache, error) { return ReadDNSCache() }
+
+func (Host) FlushDNSCache() error { return FlushDNSCache() }
+
+// ErrNoDNSCache is returned when no supported caching resolver answers.
+var ErrNoDNSCache = errors.New("dnscache: no systemd-resolved or dnsmasq")
+
+// dnsmasqAddr is where dnsmasq listens by default.
+const dnsmasqAddr = "127.0.0.1:53"
+
+func ReadDNSCache() (DNSCache, error) {
+	if c, err := resolvedStats(); err == nil {
+		return c, nil
+	}
+	if c, err := dnsmasqStats(dnsmasqAddr); err == nil {
+		return c, nil
+	}
+	return DNSCache{}, ErrNoDNSCache
+}
+
+// FlushDNSCache runs `resolvectl flush-caches`, or sends dnsmasq a SIGHUP,
+// which clears its cache (and rereads its hosts files). Both need root.
+func FlushDNSCache() error {
+	c, err := ReadDNSCache()
+	if err != nil {
+		return err
+	}
+	switch c.Daemon {
+	case "systemd-resolved":
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		out, err := exec.CommandContext(ctx, "resolvectl", "flush-caches").CombinedOutput()
+		if err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				return fmt.Errorf("resolvectl: %s", msg)
+			}
+			return fmt.Errorf("resolvectl: %w", err)
+		}
+		return nil
+	default:
+		return signalDnsmasq()
+	}
+}
+
+func signalDnsmasq() error {
+	procs, err := process.Processes()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, p := range procs {
+		if name, err := p.Name(); err != nil || name != "dnsmasq" {
+			continue
+		}
+		found = true
+		if err := p.SendSignal(syscall.SIGHUP); err != nil {
+			return fmt.Errorf("dnsmasq (PID %d): %w", p.Pid, err)
+		}
+	}
+	if !found {
+		return errors.New("dnsmasq: no process found")
+	}
+	return nil
+}
+
+// resolvedStats parses the Cache section of `resolvectl statistics`:
+//
+//	Cache
+//	  Current Cache Size: 42
+//	          Cache Hits: 500
+//	        Cache Misses: 734
+func resolvedStats() (DNSCache, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "resolvectl", "statistics").Output()
+	if err != nil {
+		return DNSCache{}, fmt.Errorf("resolvectl: %w", err)
+	}
+	c := DNSCache{Daemon: "systemd-resolved", Size: -1}
+	seen := 0
+	sc := bufio.NewScanner(strings.NewReader(string(out)))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch strings.TrimSpace(k) {
+		case "Current Cache Size":
+			c.Size = int(n)
+		case "Cache Hits":
+			c.Hits = n
+		case "Cache Misses":
+			c.Misses = n
+		default:
+			continue
+		}
+		seen++
+	}
+	if seen == 0 {
+		return DNSCache{}, errors.New("resolvectl: no cache statistics")
+	}
+	return c, nil
+}
+
+// dnsmasqStats asks dnsmasq at addr for the counters it serves as TXT
+// records in the CHAOS class (what `dig CH TXT hits.bind` shows).
+func dnsmasqStats(addr string) (DNSCache, error) {
+	conn, err := net.DialTimeout("udp", addr, time.Second)
+	if err != nil {
+		return DNSCache{}, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	get := func(name string) (uint64, error) {
+		txt, err := chaosTXT(conn, name)
+		if err != nil {
+			return 0, fmt.Errorf("dnsmasq %s: %w", name, err)
+		}
+		return strconv.ParseUint(txt, 10, 64)
+	}
+	c := DNSCache{Daemon: "dnsmasq", Size: -1}
+	capacity, err := get("cachesize.bind")
+	if err != nil {
+		return DNSCache{}, err
+	}
+	c.Capacity = int(capacity)
+	if c.Hits, err = get("hits.bind"); err != nil {
+		return DNSCache{}, err
+	}
+	if c.Misses, err = get("misses.bind"); err != nil {
+		return DNSCache{}, err
+	}
+	return c, nil
+}
+
+// chaosTXT sends one CHAOS-class TXT query for name over conn and returns
+// the first string of the answer.
+func chaosTXT(conn net.Conn, name string) (string, error) {
+	id := uint16(time.Now().UnixNano())
+	q := make([]byte, 12, 64)
+	binary.BigEndian.PutUint16(q[0:], id)
+	binary.BigEndian.PutUint16(q[4:], 1) // one question
+	for _, label := range strings.Split(name, ".") {
+		q = append(q, byte(len(label)))
+		q = append(q, label...)
+	}
+	q = append(q, 0, 0, 16, 0, 3) // root, type TXT, class CH
+	if _, err := conn.Write(q); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 512)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return "", err
+		}
+		r := buf[:n]
+		if n < 12 || binary.BigEndian.Uint16(r) != id {
+			continue // a late answer to an earlier query
+		}
+		if rcode := r[3] & 0xf; rcode != 0 {
+			return "", fmt.Errorf("rcode %d", rcode)
+		}
+		if binary.BigEndian.Uint16(r[6:]) == 0 {
+			return "", errors.New("no answer")
+		}
+		// the answer follows our question, which is echoed unchanged
+		off := len(q)
+		off, ok := skipName(r, off)
+		if !ok || off+10 > n {
+			return "", errors.New("short answer")
+		}
+		rdlen := int(binary.BigEndian.Uint16(r[off+8:]))
+		rdata := r[off+10:]
+		if rdlen > len(rdata) || rdlen == 0 || int(rdata[0]) >= rdlen {
+			return "", errors.New("short answer")
+		}
+		return string(rdata[1 : 1+int(rdata[0])]), nil
+	}
+}
+
+// skipName steps over a domain name at off, compressed or not.
+func skipName(b []byte, off int) (int, bool) {
+	for off < len(b) {
+		switch l := int(b[off]); {
+		case l == 0:
+			return off + 1, true
+		case l&0xc0 == 0xc0:
+			return off + 2, true
+		default:
+			off += 1 + l
+		}
+	}
+	return 0, false
+}
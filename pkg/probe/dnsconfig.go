@@ -0,0 +1,242 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DNSConfig is how the host resolves names: the resolvers it asks, the
+// domains it searches and what sits between them.
+type DNSConfig struct {
+	Servers  []DNSServer
+	Search   []string
+	Resolved bool   // resolv.conf points at systemd-resolved's stub, 127.0.0.53
+	DoT      string // systemd-resolved's DNSOverTLS: yes, opportunistic, no; "" without it
+	DNSSEC   string // systemd-resolved's DNSSEC setting; "" without it
+	Proxy    string // the local forwarder on another loopback nameserver: dnsmasq, unbound, dnscrypt-proxy…
+	DoH      bool   // Proxy is one that forwards over HTTPS
+}
+
+// DNSServer is one resolver the host asks.
+type DNSServer struct {
+	Addr  string
+	Name  string // the TLS server name systemd-resolved checks, from "addr#name"
+	Iface string // for systemd-resolved's per-link servers; "" for global ones
+	From  string // "resolv.conf" or "systemd-resolved"
+}
+
+// Target is the address to send the server queries at: link-local ones
+// need the interface as their zone.
+func (s DNSServer) Target() string {
+	if ip := net.ParseIP(s.Addr); ip != nil && ip.IsLinkLocalUnicast() && s.Iface != "" && !strings.Contains(s.Addr, "%") {
+		return s.Addr + "%" + s.Iface
+	}
+	return s.Addr
+}
+
+// DNSConfigReader reads the host's resolver configuration and times
+// lookups on its resolvers.
+type DNSConfigReader interface {
+	DNSConfig() (DNSConfig, error)
+	// TimeLookup resolves name on server, over plain DNS on port 53, and
+	// returns how long the answer took.
+	TimeLookup(ctx context.Context, server, name string) (time.Duration, error)
+}
+
+// DNSTestName is the name looked up to time resolvers: one every resolver
+// should answer, and likely to have cached.
+const DNSTestName = "example.com"
+
+// resolvConfPath is where the resolver configuration is read from.
+var resolvConfPath = "/etc/resolv.conf"
+
+// localForwarders are the local DNS forwarders recognised by the name of
+// the process listening on a loopback nameserver, and whether they forward
+// over HTTPS.
+var localForwarders = map[string]bool{
+	"dnsmasq":         false,
+	"unbound":         false,
+	"stubby":          false,
+	"named":           false,
+	"pdns_recursor":   false,
+	"dnscrypt-proxy":  true,
+	"cloudflared":     true,
+	"dnsproxy":        true,
+	"https_dns_proxy": true,
+	"doh-client":      true,
+}
+
+func (Host) DNSConfig() (DNSConfig, error) { return ReadDNSConfig() }
+
+func (Host) TimeLookup(ctx context.Context, server, name string) (time.Duration, error) {
+	return TimeLookup(ctx, server, name)
+}
+
+// ReadDNSConfig reads resolv.conf and, where it points at systemd-resolved,
+// the servers and settings `resolvectl status` shows; where it points at
+// another loopback address, the forwarder listening there.
+func ReadDNSConfig() (DNSConfig, error) {
+	b, err := os.ReadFile(resolvConfPath)
+	if err != nil {
+		return DNSConfig{}, err
+	}
+	c := parseResolvConf(b)
+	for _, s := range c.Servers {
+		switch {
+		case s.Addr == "127.0.0.53" || s.Addr == "127.0.0.54":
+			c.Resolved = true
+		case c.Proxy == "" && isLoopback(s.Addr):
+			c.Proxy = listenerOn(s.Addr, "53")
+			c.DoH = localForwarders[c.Proxy]
+		}
+	}
+	if c.Resolved {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if out, err := exec.CommandContext(ctx, "resolvectl", "status").Output(); err == nil {
+			c.parseResolvectl(out)
+		}
+	}
+	return c, nil
+}
+
+// parseResolvConf reads the nameserver and search lines of resolv.conf;
+// as in the C library, the last search or domain line wins.
+func parseResolvConf(b []byte) DNSConfig {
+	var c DNSConfig
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 2 {
+			continue
+		}
+		switch f[0] {
+		case "nameserver":
+			c.Servers = append(c.Servers, DNSServer{Addr: f[1], From: "resolv.conf"})
+		case "search", "domain":
+			c.Search = f[1:]
+		}
+	}
+	return c
+}
+
+// parseResolvectl adds the upstream servers and settings of systemd-resolved
+// from `resolvectl status`:
+//
+//	Global
+//	         Protocols: +LLMNR +mDNS -DNSOverTLS DNSSEC=no/unsupported
+//	  resolv.conf mode: stub
+//	       DNS Servers: 1.1.1.1#cloudflare-dns.com
+//	                    9.9.9.9
+//
+//	Link 2 (eth0)
+//	       DNS Servers: 192.168.1.1
+//
+// Older versions put the settings on lines of their own,
+// "DNSOverTLS setting: no" and "DNSSEC setting: allow-downgrade".
+func (c *DNSConfig) parseResolvectl(out []byte) {
+	iface, key := "", ""
+	seen := map[DNSServer]bool{}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		t := strings.TrimSpace(line)
+		switch {
+		case t == "":
+			continue
+		case line == "Global":
+			iface, key = "", ""
+			continue
+		case strings.HasPrefix(line, "Link "):
+			if i, j := strings.Index(line, "("), strings.LastIndex(line, ")"); i >= 0 && j > i {
+				iface = line[i+1 : j]
+			}
+			key = ""
+			continue
+		}
+		// "key: value", or a value continuing the key above; IPv6
+		// addresses have colons, but never one followed by a space
+		val := t
+		if k, v, ok := strings.Cut(t+" ", ": "); ok {
+			key, val = k, strings.TrimSpace(v)
+		}
+		switch key {
+		case "DNS Servers":
+			for _, a := range strings.Fields(val) {
+				s := DNSServer{Addr: a, Iface: iface, From: "systemd-resolved"}
+				s.Addr, s.Name, _ = strings.Cut(a, "#")
+				if !seen[s] {
+					seen[s] = true
+					c.Servers = append(c.Servers, s)
+				}
+			}
+		case "Protocols":
+			if iface != "" {
+				break
+			}
+			for _, p := range strings.Fields(val) {
+				switch {
+				case p == "+DNSOverTLS":
+					c.DoT = "yes"
+				case p == "-DNSOverTLS":
+					c.DoT = "no"
+				case strings.HasPrefix(p, "DNSOverTLS="):
+					c.DoT = strings.TrimPrefix(p, "DNSOverTLS=")
+				case strings.HasPrefix(p, "DNSSEC="):
+					c.DNSSEC, _, _ = strings.Cut(strings.TrimPrefix(p, "DNSSEC="), "/")
+				}
+			}
+		case "DNSOverTLS setting":
+			if iface == "" {
+				c.DoT = val
+			}
+		case "DNSSEC setting":
+			if iface == "" {
+				c.DNSSEC = val
+			}
+		}
+	}
+}
+
+// TimeLookup resolves name on server directly, bypassing resolv.conf.
+func TimeLookup(ctx context.Context, server, name string) (time.Duration, error) {
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, net.JoinHostPort(server, "53"))
+		},
+	}
+	start := time.Now()
+	if _, err := r.LookupHost(ctx, name); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+func isLoopback(addr string) bool {
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsLoopback()
+}
+
+// listenerOn names the process listening on addr:port; "" when unknown,
+// as it is without the privileges to see other users' sockets.
+func listenerOn(addr, port string) string {
+	ports, err := ListListening()
+	if err != nil {
+		return ""
+	}
+	for _, p := range ports {
+		ip, pt := SplitLocal(p.Local)
+		if pt == port && (ip == addr || p.IsWildcard()) && p.Process != "" {
+			return p.Process
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+)
+
+// DNSQuery is a DNS question seen on the wire. It is reported once when
+// asked and, if the reply is seen, again with Addrs.
+type DNSQuery struct {
+	Name   string // without the trailing dot
+	Type   string // "A", "AAAA", "HTTPS", …
+	Client string // address that asked
+
+	Addrs []string // the A and AAAA records of the reply
+}
+
+// DNSSniffer passively watches the DNS queries on an interface.
+type DNSSniffer interface {
+	// SniffDNS sends every query and answered query seen on iface to
+	// queries and returns when the capture fails or ctx is done.
+	SniffDNS(ctx context.Context, iface string, queries chan<- DNSQuery) error
+}
+
+func (Host) SniffDNS(ctx context.Context, iface string, queries chan<- DNSQuery) error {
+	return SniffDNS(ctx, iface, queries)
+}
+
+// ErrNoCapture is returned when tcpdump, the capture backend, isn't
+// installed.
+var ErrNoCapture = errors.New("dnslog: tcpdump not found")
+
+// SniffDNS runs tcpdump on iface, line-buffered, and reads the queries
+// it decodes. Only plain DNS over UDP is seen, not DoH or DoT. Capturing
+// needs root or CAP_NET_RAW.
+func SniffDNS(ctx context.Context, iface string, queries chan<- DNSQuery) error {
+	if iface == "" || strings.HasPrefix(iface, "-") {
+		return fmt.Errorf("dnslog: bad interface %q", iface)
+	}
+	cmd := exec.CommandContext(ctx, "tcpdump", "-l", "-n", "-t", "-s", "512", "-i", iface, "udp port 53")
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrNoCapture
+		}
+		return fmt.Errorf("tcpdump: %w", err)
+	}
+
+	// replies name no question, so they are matched to the query by
+	// client port and ID
+	asked := map[string]DNSQuery{}
+	sc := bufio.NewScanner(out)
+	for sc.Scan() {
+		line := sc.Text()
+		q, id, ok := parseDNSQuery(line)
+		if ok {
+			if len(asked) > 1000 {
+				clear(asked) // unanswered; tcpdump drops packets under load
+			}
+			asked[id] = q
+		} else if a, isAnswer := parseDNSAnswer(line); isAnswer {
+			q, ok = asked[a.id]
+			delete(asked, a.id)
+			q.Addrs = a.addrs
+			ok = ok && len(a.addrs) > 0
+		}
+		if !ok {
+			continue
+		}
+		select {
+		case queries <- q:
+		case <-ctx.Done():
+		}
+	}
+	err = cmd.Wait()
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		// e.g. "tcpdump: eth0: You don't have permission to capture on
+		// that device"; "listening on eth0, …" is chatter
+		for _, line := range strings.Split(stderr.String(), "\n") {
+			if line = strings.TrimSpace(line); strings.HasPrefix(line, "tcpdump: ") && !strings.Contains(line, "verbose output suppressed") {
+				return errors.New(line)
+			}
+		}
+		return fmt.Errorf("tcpdump: %w", err)
+	}
+	return nil
+}
+
+// parseDNSQuery reads a query line of tcpdump -n -t:
+//
+//	IP 192.168.1.10.41235 > 192.168.1.1.53: 4731+ A? example.com. (29)
+//	IP6 2001:db8::10.41235 > 2001:db8::1.53: 4731+ [1au] AAAA? example.com. (40)
+//
+// id identifies the query for parseDNSAnswer.
+func parseDNSQuery(line string) (q DNSQuery, id string, ok bool) {
+	f := strings.Fields(line)
+	if len(f) < 6 || (f[0] != "IP" && f[0] != "IP6") || f[2] != ">" {
+		return DNSQuery{}, "", false
+	}
+	q.Client = f[1]
+	if i := strings.LastIndex(q.Client, "."); i > 0 {
+		q.Client = q.Client[:i] // the source port
+	}
+	for i := 4; i+1 < len(f); i++ {
+		if t := f[i]; len(t) > 1 && strings.HasSuffix(t, "?") {
+			q.Type = strings.TrimSuffix(t, "?")
+			q.Name = strings.ToLower(strings.TrimSuffix(f[i+1], "."))
+			break
+		}
+	}
+	if q.Name == "" {
+		return DNSQuery{}, "", false
+	}
+	return q, f[1] + " " + dnsID(f[4]), true
+}
+
+type dnsAnswer struct {
+	id    string
+	addrs []string
+}
+
+// parseDNSAnswer reads a reply line of tcpdump -n -t:
+//
+//	IP 192.168.1.1.53 > 192.168.1.10.41235: 4731 2/0/0 CNAME www.example.com., A 93.184.216.34 (64)
+//	IP 192.168.1.1.53 > 192.168.1.10.41236: 4732 NXDomain 0/1/0 (102)
+func parseDNSAnswer(line string) (dnsAnswer, bool) {
+	f := strings.Fields(line)
+	if len(f) < 6 || (f[0] != "IP" && f[0] != "IP6") || f[2] != ">" || !strings.HasSuffix(f[1], ".53") {
+		return dnsAnswer{}, false
+	}
+	a := dnsAnswer{id: strings.TrimSuffix(f[3], ":") + " " + dnsID(f[4])}
+	for i := 5; i+1 < len(f); i++ {
+		if f[i] != "A" && f[i] != "AAAA" {
+			continue
+		}
+		if ip := net.ParseIP(strings.TrimSuffix(f[i+1], ",")); ip != nil {
+			a.addrs = append(a.addrs, ip.String())
+		}
+	}
+	return a, true
+}
+
+// dnsID is the ID of "4731+", "4731*-" and the like: tcpdump appends
+// flags to it.
+func dnsID(s string) string {
+	return strings.TrimRightFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+}
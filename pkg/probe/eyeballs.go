@@ -0,0 +1,141 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// EyeballsDelay is the head start RFC 8305 clients give an IPv6
+	// connection attempt before also trying IPv4 (browsers use 250-300ms).
+	EyeballsDelay = 250 * time.Millisecond
+	// EyeballsResolutionDelay is how long clients wait for the AAAA answer
+	// once the A answer is in before starting on IPv4 alone.
+	EyeballsResolutionDelay = 50 * time.Millisecond
+
+	eyeballsTimeout = 5 * time.Second
+)
+
+// EyeballsAttempt is one address family's side of a dual-stack race.
+type EyeballsAttempt struct {
+	Addr    string        // address dialled, "" when the lookup failed
+	Lookup  time.Duration // A or AAAA lookup
+	Connect time.Duration // TCP handshake, zero unless it succeeded
+	Err     error         // lookup or connect error
+}
+
+// OK reports whether the family connected.
+func (a EyeballsAttempt) OK() bool { return a.Err == nil && a.Addr != "" }
+
+// EyeballsRace is the outcome of connecting to a host over IPv4 and IPv6
+// at the same time.
+type EyeballsRace struct {
+	Host string
+	Port string
+	V4   EyeballsAttempt
+	V6   EyeballsAttempt
+}
+
+// Winner is the family whose handshake finished first when both started
+// as soon as their lookups did, and by how much; "" when neither connected.
+func (r EyeballsRace) Winner() (family string, margin time.Duration) {
+	switch {
+	case r.V4.OK() && r.V6.OK():
+		v4, v6 := r.V4.Lookup+r.V4.Connect, r.V6.Lookup+r.V6.Connect
+		if v6 <= v4 {
+			return "inet6", v4 - v6
+		}
+		return "inet", v6 - v4
+	case r.V6.OK():
+		return "inet6", 0
+	case r.V4.OK():
+		return "inet", 0
+	}
+	return "", 0
+}
+
+// Preferred is the family an RFC 8305 client ends up using given the
+// measured timings: IPv6 starts as soon as AAAA is in (unless it lags A by
+// more than EyeballsResolutionDelay) and IPv4 EyeballsDelay later.
+func (r EyeballsRace) Preferred() string {
+	switch {
+	case !r.V6.OK() && !r.V4.OK():
+		return ""
+	case !r.V6.OK():
+		return "inet"
+	case !r.V4.OK():
+		return "inet6"
+	}
+	v6Start := r.V6.Lookup
+	v4Start := max(r.V4.Lookup, v6Start+EyeballsDelay)
+	if r.V6.Lookup > r.V4.Lookup+EyeballsResolutionDelay {
+		v4Start = r.V4.Lookup + EyeballsResolutionDelay
+	}
+	if v6Start+r.V6.Connect <= v4Start+r.V4.Connect {
+		return "inet6"
+	}
+	return "inet"
+}
+
+// DualStackRacer races IPv4 against IPv6 connections to a host.
+type DualStackRacer interface {
+	RaceDualStack(host, port string) (EyeballsRace, error)
+}
+
+// RaceDualStack looks up host's A and AAAA records and opens a TCP
+// connection to the first address of each family, both at once with no
+// head start, timing each step. It fails only when neither lookup does.
+func (Host) RaceDualStack(host, port string) (EyeballsRace, error) {
+	return RaceDualStack(host, port)
+}
+
+func RaceDualStack(host, port string) (EyeballsRace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), eyeballsTimeout)
+	defer cancel()
+
+	r := EyeballsRace{Host: host, Port: port}
+	var wg sync.WaitGroup
+	for _, a := range []struct {
+		network string
+		out     *EyeballsAttempt
+	}{{"4", &r.V4}, {"6", &r.V6}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			*a.out = eyeballsAttempt(ctx, a.network, host, port)
+		}()
+	}
+	wg.Wait()
+
+	if r.V4.Addr == "" && r.V6.Addr == "" {
+		// the same "no such host" for both, usually
+		return r, r.V4.Err
+	}
+	return r, nil
+}
+
+// eyeballsAttempt resolves host in one family ("4" or "6") and connects.
+func eyeballsAttempt(ctx context.Context, family, host, port string) EyeballsAttempt {
+	var a EyeballsAttempt
+	start := time.Now()
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip"+family, host)
+	a.Lookup = time.Since(start)
+	if err != nil {
+		a.Err = err
+		return a
+	}
+	a.Addr = ips[0].String()
+
+	start = time.Now()
+	var d net.Dialer
+	c, err := d.DialContext(ctx, "tcp"+family, net.JoinHostPort(a.Addr, port))
+	if err != nil {
+		a.Err = err
+		return a
+	}
+	a.Connect = time.Since(start)
+	c.Close()
+	return a
+}
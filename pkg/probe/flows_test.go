@@ -0,0 +1,52 @@
+package probe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConntrack(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     []Flow
+	}{
+		{
+			"tcp with accounting",
+			"ipv4     2 tcp      6 431999 ESTABLISHED src=10.0.0.2 dst=93.184.215.14 sport=51234 dport=443 packets=12 bytes=1800 src=93.184.215.14 dst=192.168.1.10 sport=443 dport=51234 packets=10 bytes=9000 [ASSURED] mark=0 zone=0 use=2\n",
+			[]Flow{{
+				Family: "ipv4", Proto: "tcp", State: "ESTABLISHED", Expiry: 431999,
+				Src: "10.0.0.2:51234", Dst: "93.184.215.14:443", ReplySrc: "93.184.215.14:443", ReplyDst: "192.168.1.10:51234",
+				Packets: 12, Bytes: 1800, ReplyPackets: 10, ReplyBytes: 9000, Assured: true,
+			}},
+		},
+		{
+			"udp unreplied, no state",
+			"ipv4 2 udp 17 29 src=192.168.1.10 dst=1.1.1.1 sport=40000 dport=53 [UNREPLIED] src=1.1.1.1 dst=192.168.1.10 sport=53 dport=40000 mark=0 use=1\n",
+			[]Flow{{
+				Family: "ipv4", Proto: "udp", Expiry: 29,
+				Src: "192.168.1.10:40000", Dst: "1.1.1.1:53", ReplySrc: "1.1.1.1:53", ReplyDst: "192.168.1.10:40000", Unreplied: true,
+			}},
+		},
+		{
+			"icmpv6 has no ports",
+			"ipv6 10 icmpv6 58 29 src=fe80::1 dst=ff02::1 type=128 code=0 id=7 src=ff02::1 dst=fe80::1 type=129 code=0 id=7 mark=0 use=1\n",
+			[]Flow{{
+				Family: "ipv6", Proto: "icmpv6", Expiry: 29,
+				Src: "fe80::1", Dst: "ff02::1", ReplySrc: "ff02::1", ReplyDst: "fe80::1",
+			}},
+		},
+		{
+			"short and one-way lines are skipped",
+			"ipv4 2 tcp\nipv4 2 tcp 6 10 SYN_SENT src=10.0.0.2 dst=10.0.0.3 sport=1 dport=2\n",
+			nil,
+		},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseConntrack([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
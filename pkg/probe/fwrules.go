@@ -0,0 +1,395 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FirewallChain is a chain of the firewall ruleset with its rules, in
+// ruleset order.
+type FirewallChain struct {
+	Family   string // inet, ip, ip6, …
+	Table    string
+	Name     string
+	Hook     string // input, forward, prerouting, …; "" for a chain only jumped to
+	Priority int
+	Policy   string // accept or drop; "" without a hook
+	Rules    []FirewallRule
+}
+
+// FirewallRule is a rule of a FirewallChain.
+type FirewallRule struct {
+	Handle  int    // nft's handle; the position in the chain for iptables
+	Text    string // as nft or iptables-save print it, without counter and comment
+	Verdict string // as in FirewallCounter
+	Comment string
+
+	Counted        bool // the rule has a counter; Packets and Bytes are 0 without
+	Packets, Bytes uint64
+}
+
+// Key identifies the rule of c across samples.
+func (r FirewallRule) Key(c FirewallChain) string {
+	return fmt.Sprintf("%s %s %s %d", c.Family, c.Table, c.Name, r.Handle)
+}
+
+// Dropped reports whether the rule discards what it matches.
+func (r FirewallRule) Dropped() bool {
+	return r.Verdict == "drop" || r.Verdict == "reject"
+}
+
+// ErrNoFirewall means neither nft nor iptables-save is installed.
+var ErrNoFirewall = errors.New("neither nft nor iptables-save found")
+
+// ListFirewallRules lists the chains of the ruleset with their rules and
+// counters. It reads `nft -j list ruleset` and, where nft is missing or
+// lists nothing, the legacy tables through `iptables-save -c` and
+// `ip6tables-save -c`. Either needs CAP_NET_ADMIN.
+func (Host) ListFirewallRules() ([]FirewallChain, error) { return ListFirewallRules() }
+
+func ListFirewallRules() ([]FirewallChain, error) {
+	out, err := runFirewallTool("nft", "-j", "list", "ruleset")
+	if err == nil {
+		chains, err := parseNftChains(out)
+		if err != nil || len(chains) > 0 {
+			return chains, err
+		}
+	} else if !errors.Is(err, exec.ErrNotFound) {
+		return nil, err
+	}
+
+	var chains []FirewallChain
+	for _, fam := range []string{"ip", "ip6"} {
+		out, err := runFirewallTool(fam+"tables-save", "-c")
+		if err != nil {
+			if fam == "ip6" && len(chains) > 0 {
+				break
+			}
+			if errors.Is(err, exec.ErrNotFound) {
+				return nil, ErrNoFirewall
+			}
+			return nil, err
+		}
+		chains = append(chains, parseIptablesSave(fam, out)...)
+	}
+	return chains, nil
+}
+
+// runFirewallTool runs name, turning a failure into the first line it
+// printed to stderr.
+func runFirewallTool(name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, err
+		}
+		if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+			return nil, fmt.Errorf("%s: %s", name, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+func parseNftChains(b []byte) ([]FirewallChain, error) {
+	var rs nftRuleset
+	if err := json.Unmarshal(b, &rs); err != nil {
+		return nil, fmt.Errorf("nft: %w", err)
+	}
+	var out []FirewallChain
+	idx := map[string]int{}
+	for _, o := range rs.Nftables {
+		if c := o.Chain; c != nil {
+			idx[c.Family+" "+c.Table+" "+c.Name] = len(out)
+			out = append(out, FirewallChain{Family: c.Family, Table: c.Table, Name: c.Name, Hook: c.Hook, Priority: c.Prio, Policy: c.Policy})
+			continue
+		}
+		r := o.Rule
+		if r == nil {
+			continue
+		}
+		i, ok := idx[r.Family+" "+r.Table+" "+r.Chain]
+		if !ok {
+			continue
+		}
+		rule := FirewallRule{Handle: r.Handle, Comment: r.Comment}
+		var text []string
+		for _, raw := range r.Expr {
+			var e map[string]json.RawMessage
+			if json.Unmarshal(raw, &e) != nil {
+				continue
+			}
+			for k, v := range e {
+				if k == "counter" {
+					var ctr struct{ Packets, Bytes uint64 }
+					if json.Unmarshal(v, &ctr) == nil {
+						rule.Packets, rule.Bytes, rule.Counted = ctr.Packets, ctr.Bytes, true
+						continue
+					}
+				}
+				switch k {
+				case "accept", "drop", "reject", "return", "queue", "masquerade", "snat", "dnat":
+					rule.Verdict = k
+				case "jump", "goto":
+					var t struct{ Target string }
+					_ = json.Unmarshal(v, &t)
+					rule.Verdict = k + " " + t.Target
+				}
+				text = append(text, nftStmt(k, v))
+			}
+		}
+		rule.Text = strings.Join(text, " ")
+		out[i].Rules = append(out[i].Rules, rule)
+	}
+	return out, nil
+}
+
+// nftStmt writes a statement of a rule's JSON expression about the way
+// nft prints it; what it doesn't know shows as its name.
+func nftStmt(k string, v json.RawMessage) string {
+	var o map[string]json.RawMessage
+	_ = json.Unmarshal(v, &o)
+	str := func(key string) string {
+		var s string
+		if json.Unmarshal(o[key], &s) == nil {
+			return s
+		}
+		return nftValue(o[key])
+	}
+	switch k {
+	case "match":
+		left := nftLeft(o["left"])
+		right := nftValue(o["right"])
+		if strings.HasSuffix(left, "ifname") && !strings.HasPrefix(right, "{") {
+			right = strconv.Quote(right)
+		}
+		if op := str("op"); op != "==" && op != "in" {
+			return left + " " + op + " " + right
+		}
+		return left + " " + right
+	case "counter":
+		var name string
+		_ = json.Unmarshal(v, &name)
+		return "counter name " + strconv.Quote(name)
+	case "jump", "goto":
+		return k + " " + str("target")
+	case "reject":
+		if t := str("type"); t != "" && o["expr"] != nil {
+			return "reject with " + t + " " + str("expr")
+		}
+	case "log":
+		if p := str("prefix"); o["prefix"] != nil {
+			return "log prefix " + strconv.Quote(p)
+		}
+	case "limit":
+		return "limit rate " + str("rate") + "/" + str("per")
+	case "snat", "dnat":
+		to := str("addr")
+		if o["port"] != nil {
+			to += ":" + str("port")
+		}
+		return k + " to " + to
+	case "redirect":
+		if o["port"] != nil {
+			return "redirect to :" + str("port")
+		}
+	case "xt":
+		// an iptables extension the rule was made with through iptables-nft
+		return "xt " + str("name")
+	}
+	return k
+}
+
+// nftLeft writes the left side of a match: what of the packet it looks at.
+func nftLeft(raw json.RawMessage) string {
+	var l map[string]map[string]json.RawMessage
+	if json.Unmarshal(raw, &l) != nil {
+		return nftValue(raw)
+	}
+	for k, o := range l {
+		s := func(key string) string {
+			var s string
+			_ = json.Unmarshal(o[key], &s)
+			return s
+		}
+		switch k {
+		case "meta":
+			switch key := s("key"); key {
+			case "iifname", "oifname", "iif", "oif", "mark":
+				return key
+			default:
+				return "meta " + key
+			}
+		case "payload":
+			if p := s("protocol"); p != "" {
+				return p + " " + s("field")
+			}
+			return "@" + s("base") + "," + nftValue(o["offset"]) + "," + nftValue(o["len"])
+		case "ct":
+			if d := s("dir"); d != "" {
+				return "ct " + d + " " + s("key")
+			}
+			return "ct " + s("key")
+		case "fib":
+			var flags []string
+			_ = json.Unmarshal(o["flags"], &flags)
+			return "fib " + strings.Join(flags, " . ") + " " + s("result")
+		}
+		return k
+	}
+	return "?"
+}
+
+// nftValue writes the right side of a match: a value, a list of flags, or
+// an anonymous set, range or prefix.
+func nftValue(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		vs := make([]string, len(list))
+		for i, e := range list {
+			vs[i] = nftValue(e)
+		}
+		return strings.Join(vs, ",")
+	}
+	var o struct {
+		Set    []json.RawMessage
+		Range  []json.RawMessage
+		Concat []json.RawMessage
+		Prefix *struct {
+			Addr string
+			Len  int
+		}
+	}
+	if json.Unmarshal(raw, &o) != nil {
+		return "?"
+	}
+	join := func(es []json.RawMessage, sep string) string {
+		vs := make([]string, len(es))
+		for i, e := range es {
+			vs[i] = nftValue(e)
+		}
+		return strings.Join(vs, sep)
+	}
+	switch {
+	case o.Set != nil:
+		return "{ " + join(o.Set, ", ") + " }"
+	case o.Range != nil:
+		return join(o.Range, "-")
+	case o.Concat != nil:
+		return join(o.Concat, " . ")
+	case o.Prefix != nil:
+		return fmt.Sprintf("%s/%d", o.Prefix.Addr, o.Prefix.Len)
+	}
+	return "?"
+}
+
+// parseIptablesSave reads the output of iptables-save -c:
+//
+//	*filter
+//	:INPUT DROP [120:9600]
+//	:DOCKER - [0:0]
+//	[10:500] -A INPUT -i lo -m comment --comment "loopback" -j ACCEPT
+//	COMMIT
+func parseIptablesSave(family string, b []byte) []FirewallChain {
+	var out []FirewallChain
+	var table string
+	idx := map[string]int{}
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case strings.HasPrefix(line, "*"):
+			table = line[1:]
+		case strings.HasPrefix(line, ":"):
+			f := strings.Fields(line[1:])
+			if len(f) < 2 {
+				continue
+			}
+			c := FirewallChain{Family: family, Table: table, Name: f[0]}
+			if f[1] != "-" {
+				c.Hook, c.Policy = strings.ToLower(f[0]), strings.ToLower(f[1])
+			}
+			idx[table+" "+f[0]] = len(out)
+			out = append(out, c)
+		case strings.HasPrefix(line, "["):
+			ctr, rest, _ := strings.Cut(line[1:], "] ")
+			p, bs, _ := strings.Cut(ctr, ":")
+			f := strings.Fields(rest)
+			if len(f) < 2 || f[0] != "-A" {
+				continue
+			}
+			i, ok := idx[table+" "+f[1]]
+			if !ok {
+				continue
+			}
+			r := FirewallRule{Handle: len(out[i].Rules) + 1, Counted: true}
+			r.Packets, _ = strconv.ParseUint(p, 10, 64)
+			r.Bytes, _ = strconv.ParseUint(bs, 10, 64)
+			r.Text, r.Comment = iptablesComment(strings.TrimSpace(strings.TrimPrefix(rest, "-A "+f[1])))
+			r.Verdict = iptablesVerdict(strings.Fields(r.Text))
+			out[i].Rules = append(out[i].Rules, r)
+		}
+	}
+	return out
+}
+
+// iptablesComment takes the `-m comment --comment "…"` match out of a rule.
+func iptablesComment(rule string) (string, string) {
+	const m = `-m comment --comment `
+	before, after, ok := strings.Cut(rule, m)
+	if !ok {
+		return rule, ""
+	}
+	comment, rest := after, ""
+	if strings.HasPrefix(after, `"`) {
+		if end := strings.Index(after[1:], `"`); end >= 0 {
+			comment, rest = after[1:end+1], after[end+2:]
+		}
+	} else if i := strings.IndexByte(after, ' '); i >= 0 {
+		comment, rest = after[:i], after[i:]
+	}
+	return strings.TrimSpace(strings.TrimSpace(before) + " " + strings.TrimSpace(rest)), comment
+}
+
+// iptablesVerdict is the Verdict of a rule's -j or -g target.
+func iptablesVerdict(f []string) string {
+	for i := 0; i+1 < len(f); i++ {
+		switch t := f[i+1]; f[i] {
+		case "-g", "--goto":
+			return "goto " + t
+		case "-j", "--jump":
+			switch t {
+			case "ACCEPT", "DROP", "REJECT", "RETURN", "QUEUE", "MASQUERADE", "SNAT", "DNAT":
+				return strings.ToLower(t)
+			case "LOG", "MARK", "CONNMARK", "NFLOG", "TCPMSS":
+				// not terminal
+				return ""
+			}
+			return "jump " + t
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package probe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIptablesSave(t *testing.T) {
+	const in = `# Generated by iptables-save v1.8.9 on Tue Jan  2 15:04:05 2024
+*filter
+:INPUT DROP [120:9600]
+:FORWARD ACCEPT [0:0]
+:DOCKER - [0:0]
+[10:500] -A INPUT -i lo -m comment --comment "loopback traffic" -j ACCEPT
+[3:180] -A INPUT -p tcp -m tcp --dport 22 -m comment --comment ssh -j ACCEPT
+[0:0] -A INPUT -p tcp --dport 23 -j REJECT --reject-with tcp-reset
+[5:300] -A INPUT -j LOG --log-prefix "in: "
+[1:60] -A FORWARD -o docker0 -j DOCKER
+[0:0] -A FORWARD -g DOCKER
+[9:9] -A NOCHAIN -j ACCEPT
+-A INPUT -j ACCEPT
+COMMIT
+*nat
+:POSTROUTING ACCEPT [64:3840]
+[64:3840] -A POSTROUTING -s 172.17.0.0/16 ! -o docker0 -j MASQUERADE
+COMMIT
+`
+	want := []FirewallChain{
+		{Family: "ip", Table: "filter", Name: "INPUT", Hook: "input", Policy: "drop", Rules: []FirewallRule{
+			{Handle: 1, Text: "-i lo -j ACCEPT", Verdict: "accept", Comment: "loopback traffic", Counted: true, Packets: 10, Bytes: 500},
+			{Handle: 2, Text: "-p tcp -m tcp --dport 22 -j ACCEPT", Verdict: "accept", Comment: "ssh", Counted: true, Packets: 3, Bytes: 180},
+			{Handle: 3, Text: "-p tcp --dport 23 -j REJECT --reject-with tcp-reset", Verdict: "reject", Counted: true},
+			{Handle: 4, Text: `-j LOG --log-prefix "in: "`, Counted: true, Packets: 5, Bytes: 300},
+		}},
+		{Family: "ip", Table: "filter", Name: "FORWARD", Hook: "forward", Policy: "accept", Rules: []FirewallRule{
+			{Handle: 1, Text: "-o docker0 -j DOCKER", Verdict: "jump DOCKER", Counted: true, Packets: 1, Bytes: 60},
+			{Handle: 2, Text: "-g DOCKER", Verdict: "goto DOCKER", Counted: true},
+		}},
+		{Family: "ip", Table: "filter", Name: "DOCKER"},
+		{Family: "ip", Table: "nat", Name: "POSTROUTING", Hook: "postrouting", Policy: "accept", Rules: []FirewallRule{
+			{Handle: 1, Text: "-s 172.17.0.0/16 ! -o docker0 -j MASQUERADE", Verdict: "masquerade", Counted: true, Packets: 64, Bytes: 3840},
+		}},
+	}
+	if got := parseIptablesSave("ip", []byte(in)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestIptablesComment(t *testing.T) {
+	tests := []struct {
+		in, rule, comment string
+	}{
+		{`-i lo -m comment --comment "a b" -j ACCEPT`, "-i lo -j ACCEPT", "a b"},
+		{`-m comment --comment ssh -j ACCEPT`, "-j ACCEPT", "ssh"},
+		{`-j ACCEPT -m comment --comment last`, "-j ACCEPT", "last"},
+		{`-j ACCEPT`, "-j ACCEPT", ""},
+	}
+	for _, tt := range tests {
+		rule, comment := iptablesComment(tt.in)
+		if rule != tt.rule || comment != tt.comment {
+			t.Errorf("iptablesComment(%q) = %q, %q; want %q, %q", tt.in, rule, comment, tt.rule, tt.comment)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package probe
+
+import (
+	"fmt"
+	"time"
+)
+
+// GeoInfo is where an address is registered, as far as the database
+// knows; a City or Country database fills Country, an ASN database ASN
+// and Org.
+type GeoInfo struct {
+	Country string // ISO 3166-1 code, e.g. "DE"
+	ASN     uint
+	Org     string // owner of the AS
+}
+
+// IsZero reports whether nothing is known.
+func (g GeoInfo) IsZero() bool {
+	return g.Country == "" && g.ASN == 0
+}
+
+// String is e.g. "DE AS3320 Deutsche Telekom AG", leaving out what is
+// unknown.
+func (g GeoInfo) String() string {
+	s := g.Country
+	if g.ASN != 0 {
+		if s != "" {
+			s += " "
+		}
+		s += fmt.Sprintf("AS%d", g.ASN)
+		if g.Org != "" {
+			s += " " + g.Org
+		}
+	}
+	return s
+}
+
+// GeoLocator looks addresses up in a GeoIP database. There is no live
+// default: the database has to be downloaded or configured first.
+type GeoLocator interface {
+	GeoLookup(ip string) (GeoInfo, error)
+}
+
+// CachedGeo puts the "geoip" Cache in front of loc, since the same
+// addresses are looked up on every refresh; nil stays nil. Failed lookups
+// aren't cached.
+func CachedGeo(loc GeoLocator) GeoLocator {
+	if loc == nil {
+		return nil
+	}
+	return cachedGeo{loc: loc, cache: NewCache[string, GeoInfo]("geoip", CacheConfig{TTL: time.Hour, Size: 4096})}
+}
+
+type cachedGeo struct {
+	loc   GeoLocator
+	cache *Cache[string, GeoInfo]
+}
+
+func (c cachedGeo) GeoLookup(ip string) (GeoInfo, error) {
+	if g, ok := c.cache.Get(ip); ok {
+		return g, nil
+	}
+	g, err := c.loc.GeoLookup(ip)
+	if err == nil {
+		c.cache.Put(ip, g)
+	}
+	return g, err
+}
@@ -0,0 +1,138 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// procRoot is where the Linux procfs is mounted.
+var procRoot = "/proc"
+
+// ICMPCounter is one ICMP/ICMPv6 message counter.
+type ICMPCounter struct {
+	Proto string // "icmp" or "icmp6"
+	Name  string // kernel counter name without the Icmp6 prefix, e.g. "InRedirects"
+	Total int64
+	Rate  float64 // per second since the previous sample
+}
+
+var icmpCounters = []string{
+	"InMsgs", "InEchos", "InEchoReps", "InDestUnreachs", "InRedirects", "InTimeExcds",
+	"OutMsgs", "OutEchos", "OutEchoReps", "OutDestUnreachs",
+}
+
+// icmp6 names differ slightly from the IPv4 ones
+var icmp6Names = map[string]string{
+	"InEchoReps":  "InEchoReplies",
+	"OutEchoReps": "OutEchoReplies",
+}
+
+// ICMPReader reads ICMP message counters.
+type ICMPReader interface {
+	ICMP() ([]ICMPCounter, error)
+}
+
+// ICMPSampler reads ICMP counters (Linux /proc/net/snmp and snmp6) and
+// derives per-second rates between samples.
+type ICMPSampler struct {
+	mu     sync.Mutex
+	last   map[string]int64
+	lastAt time.Time
+}
+
+func NewICMPSampler() *ICMPSampler {
+	return &ICMPSampler{}
+}
+
+// ICMP returns the ICMP then ICMPv6 counters. IPv6 is skipped when the
+// kernel has it disabled.
+func (s *ICMPSampler) ICMP() ([]ICMPCounter, error) {
+	v4, err := readSNMP(procRoot + "/net/snmp")
+	if err != nil {
+		return nil, err
+	}
+	v6, _ := readSNMP6(procRoot + "/net/snmp6")
+
+	out := make([]ICMPCounter, 0, 2*len(icmpCounters))
+	for _, n := range icmpCounters {
+		if v, ok := v4["Icmp:"+n]; ok {
+			out = append(out, ICMPCounter{Proto: "icmp", Name: n, Total: v})
+		}
+	}
+	for _, n := range icmpCounters {
+		n6 := n
+		if alt, ok := icmp6Names[n]; ok {
+			n6 = alt
+		}
+		if v, ok := v6["Icmp6"+n6]; ok {
+			out = append(out, ICMPCounter{Proto: "icmp6", Name: n, Total: v})
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	dt := now.Sub(s.lastAt).Seconds()
+	cur := make(map[string]int64, len(out))
+	for i := range out {
+		k := out[i].Proto + ":" + out[i].Name
+		cur[k] = out[i].Total
+		if prev, ok := s.last[k]; ok && dt > 0 && out[i].Total >= prev {
+			out[i].Rate = float64(out[i].Total-prev) / dt
+		}
+	}
+	s.last, s.lastAt = cur, now
+	return out, nil
+}
+
+// readSNMP parses the header/value line pairs of /proc/net/snmp into
+// "Section:Name" keys.
+func readSNMP(path string) (map[string]int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("icmp stats: %w", err)
+	}
+	out := map[string]int64{}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	for i := 0; i+1 < len(lines); i += 2 {
+		names := strings.Fields(lines[i])
+		vals := strings.Fields(lines[i+1])
+		if len(names) != len(vals) || len(names) == 0 {
+			continue
+		}
+		sec := strings.TrimSuffix(names[0], ":")
+		for j := 1; j < len(names); j++ {
+			if v, err := strconv.ParseInt(vals[j], 10, 64); err == nil {
+				out[sec+":"+names[j]] = v
+			}
+		}
+	}
+	return out, nil
+}
+
+// readSNMP6 parses the "Name value" lines of /proc/net/snmp6.
+func readSNMP6(path string) (map[string]int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]int64{}
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseInt(f[1], 10, 64); err == nil {
+			out[f[0]] = v
+		}
+	}
+	return out, sc.Err()
+}
@@ -0,0 +1,72 @@
+package probe
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IfaceKind is a coarse classification of an interface derived from its name.
+type IfaceKind int
+
+const (
+	IfaceUnknown IfaceKind = iota
+	IfaceLoopback
+	IfaceDockerBridge
+	IfaceLinuxBridge
+	IfaceVeth
+	IfaceTunTap
+	IfaceVirt
+	IfacePhysical
+)
+
+// ClassifyIface guesses the kind of an interface from common Linux naming
+// conventions (lo, docker*, br-*, veth*, tun*/tap*, wg*, en*/eth*/wl*).
+func ClassifyIface(name string) IfaceKind {
+	switch {
+	case name == "lo" || strings.HasPrefix(name, "lo"):
+		return IfaceLoopback
+	case name == "docker0" || strings.HasPrefix(name, "docker"):
+		return IfaceDockerBridge
+	case strings.HasPrefix(name, "br-") || name == "virbr0" || strings.HasPrefix(name, "virbr"):
+		return IfaceLinuxBridge
+	case strings.HasPrefix(name, "veth"):
+		return IfaceVeth
+	case strings.HasPrefix(name, "tun") || strings.HasPrefix(name, "tap"):
+		return IfaceTunTap
+	case strings.HasPrefix(name, "wg"):
+		return IfaceVirt
+	case strings.HasPrefix(name, "en") || strings.HasPrefix(name, "eth") || strings.HasPrefix(name, "wl"):
+		return IfacePhysical
+	default:
+		return IfaceUnknown
+	}
+}
+
+var ifaceKindNames = [...]string{
+	IfaceUnknown:      "unknown",
+	IfaceLoopback:     "loopback",
+	IfaceDockerBridge: "docker",
+	IfaceLinuxBridge:  "bridge",
+	IfaceVeth:         "veth",
+	IfaceTunTap:       "tuntap",
+	IfaceVirt:         "wireguard",
+	IfacePhysical:     "physical",
+}
+
+// String returns a short lowercase name for the kind.
+func (k IfaceKind) String() string {
+	if k < 0 || int(k) >= len(ifaceKindNames) {
+		return ifaceKindNames[IfaceUnknown]
+	}
+	return ifaceKindNames[k]
+}
+
+// ParseIfaceKind is the inverse of IfaceKind.String.
+func ParseIfaceKind(s string) (IfaceKind, error) {
+	for k, n := range ifaceKindNames {
+		if s == n {
+			return IfaceKind(k), nil
+		}
+	}
+	return IfaceUnknown, fmt.Errorf("unknown interface kind %q (want one of %s)", s, strings.Join(ifaceKindNames[:], ", "))
+}
@@ -0,0 +1,100 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LinkInfo is what an interface negotiated with the other end of its
+// cable, and what it could have.
+type LinkInfo struct {
+	Speed          int    // Mbit/s; 0 when unknown, as on virtual interfaces
+	Duplex         string // full, half; "" when unknown
+	Carrier        string // up, down; "" while the interface is down
+	CarrierChanges int    // since boot: a flapping cable or port
+	Driver         string // e1000e, r8169, virtio_net; "" for virtual ones
+
+	// from ethtool, where installed and the driver says
+	Autoneg  string // on, off; ""
+	MaxSpeed int    // the fastest of the NIC's supported link modes, Mbit/s
+}
+
+// LinkReader reads the link details of an interface.
+type LinkReader interface {
+	Link(iface string) (LinkInfo, error)
+}
+
+// Link reads speed, duplex, carrier and driver from sysfs, and what the NIC
+// supports from `ethtool <iface>` when it is installed. Linux only.
+func (Host) Link(iface string) (LinkInfo, error) { return Link(iface) }
+
+func Link(iface string) (LinkInfo, error) {
+	var l LinkInfo
+	dir := filepath.Join(sysRoot, "class/net", iface)
+	if _, err := os.Stat(dir); err != nil {
+		return l, err
+	}
+	l.Speed = linkSpeed(iface)
+	if d := readTrimmed(filepath.Join(dir, "duplex")); d == "full" || d == "half" {
+		l.Duplex = d
+	}
+	switch readTrimmed(filepath.Join(dir, "carrier")) {
+	case "1":
+		l.Carrier = "up"
+	case "0":
+		l.Carrier = "down"
+	}
+	l.CarrierChanges, _ = strconv.Atoi(readTrimmed(filepath.Join(dir, "carrier_changes")))
+	if drv, err := os.Readlink(filepath.Join(dir, "device/driver")); err == nil {
+		l.Driver = filepath.Base(drv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if out, err := exec.CommandContext(ctx, "ethtool", iface).Output(); err == nil {
+		l.parseEthtool(out)
+	}
+	return l, nil
+}
+
+// parseEthtool reads the supported link modes and autonegotiation of
+// ethtool's settings:
+//
+//	Settings for eth0:
+//		Supported link modes:   10baseT/Half 10baseT/Full
+//		                        100baseT/Half 100baseT/Full
+//		                        1000baseT/Full
+//		Supported pause frame use: No
+//		...
+//		Auto-negotiation: on
+func (l *LinkInfo) parseEthtool(out []byte) {
+	supported := false
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		modes := line
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			supported = k == "Supported link modes"
+			modes = v
+			if k == "Auto-negotiation" {
+				l.Autoneg = strings.TrimSpace(v)
+			}
+		}
+		if !supported {
+			continue
+		}
+		for _, m := range strings.Fields(modes) {
+			// 1000baseT/Full, 2500baseX/Full, 10000baseSR/Full
+			if n, err := strconv.Atoi(m[:strings.Index(m+"b", "b")]); err == nil && n > l.MaxSpeed {
+				l.MaxSpeed = n
+			}
+		}
+	}
+}
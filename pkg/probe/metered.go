@@ -0,0 +1,58 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// MeteredChecker reports whether the host's uplink is metered.
+type MeteredChecker interface {
+	Metered() (bool, error)
+}
+
+// Metered asks NetworkManager whether any interface carrying a default route
+// is metered, counting its guesses (e.g. phone hotspots) as metered too. It
+// needs nmcli; without it, or without a default route, the answer is an
+// error rather than a guess.
+func (Host) Metered() (bool, error) { return Metered() }
+
+func Metered() (bool, error) {
+	rs, err := Routes()
+	if err != nil {
+		return false, err
+	}
+	defs := DefaultRoutes(rs)
+	if len(defs) == 0 {
+		return false, fmt.Errorf("metered: no default route")
+	}
+	seen := map[string]bool{}
+	for _, r := range defs {
+		if seen[r.Iface] {
+			continue
+		}
+		seen[r.Iface] = true
+		v, err := nmcliMetered(r.Iface)
+		if err != nil {
+			return false, err
+		}
+		// "yes", "yes (guessed)", "no", "no (guessed)" or "unknown"
+		if strings.HasPrefix(v, "yes") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func nmcliMetered(iface string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "nmcli", "-g", "GENERAL.METERED", "device", "show", iface).Output()
+	if err != nil {
+		return "", fmt.Errorf("nmcli device show %s: %w", iface, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
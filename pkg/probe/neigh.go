@@ -0,0 +1,106 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Neighbor is an entry of the kernel's neighbor cache: ARP for IPv4, NDP
+// for IPv6.
+type Neighbor struct {
+	Family string // "inet" or "inet6"
+	IP     string
+	MAC    string
+	Iface  string
+	State  string // e.g. "REACHABLE", "STALE", "PERMANENT"
+}
+
+// NeighborReader reads the neighbor cache.
+type NeighborReader interface {
+	Neighbors() ([]Neighbor, error)
+}
+
+// Neighbors lists the neighbor cache entries that have a link-layer
+// address, by interface and address. It asks `ip neigh`, which covers both
+// families; without iproute2 it falls back to /proc/net/arp (IPv4 only).
+func (Host) Neighbors() ([]Neighbor, error) { return Neighbors() }
+
+func Neighbors() ([]Neighbor, error) {
+	var raw []ipNeigh
+	err := ipJSON(&raw, "neigh", "show")
+	if errors.Is(err, exec.ErrNotFound) {
+		return arpNeighbors(procRoot + "/net/arp")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Neighbor
+	for _, n := range raw {
+		if n.LLAddr == "" {
+			// INCOMPLETE / FAILED: nobody answered
+			continue
+		}
+		fam := "inet"
+		if strings.Contains(n.Dst, ":") {
+			fam = "inet6"
+		}
+		out = append(out, Neighbor{Family: fam, IP: n.Dst, MAC: n.LLAddr, Iface: n.Dev, State: strings.Join(n.State, ",")})
+	}
+	sortNeighbors(out)
+	return out, nil
+}
+
+type ipNeigh struct {
+	Dst    string   `json:"dst"`
+	Dev    string   `json:"dev"`
+	LLAddr string   `json:"lladdr"`
+	State  []string `json:"state"`
+}
+
+// arpNeighbors parses /proc/net/arp:
+//
+//	IP address       HW type     Flags       HW address            Mask     Device
+//	192.168.1.1      0x1         0x2         52:54:00:00:00:01     *        eth0
+func arpNeighbors(path string) ([]Neighbor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var out []Neighbor
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Scan() // header
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		// flags 0x0: incomplete
+		if len(f) < 6 || f[2] == "0x0" || f[3] == "00:00:00:00:00:00" {
+			continue
+		}
+		state := "REACHABLE"
+		if f[2] == "0x6" {
+			state = "PERMANENT"
+		}
+		out = append(out, Neighbor{Family: "inet", IP: f[0], MAC: f[3], Iface: f[5], State: state})
+	}
+	sortNeighbors(out)
+	return out, sc.Err()
+}
+
+func sortNeighbors(ns []Neighbor) {
+	sort.SliceStable(ns, func(i, j int) bool {
+		a, b := ns[i], ns[j]
+		if a.Iface != b.Iface {
+			return a.Iface < b.Iface
+		}
+		if a.Family != b.Family {
+			return a.Family < b.Family
+		}
+		return bytes.Compare(net.ParseIP(a.IP).To16(), net.ParseIP(b.IP).To16()) < 0
+	})
+}
@@ -0,0 +1,198 @@
+package probe
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/host"
+	gnet "github.com/shirou/gopsutil/v4/net"
+)
+
+// IfaceInfo describes one network interface at sample time.
+type IfaceInfo struct {
+	Name     string
+	MTU      int
+	Hardware string   // MAC address, empty for interfaces without one
+	Addrs    []string // CIDR notation; none for kinds sampled brief, see SetBrief
+	IsUp     bool
+	RxBps    float64 // bytes/sec since the previous sample
+	TxBps    float64
+	RxTotal  uint64 // bytes since the counters were last reset
+	TxTotal  uint64
+	RxPps    float64 // packets/sec since the previous sample
+	TxPps    float64
+	Kind     IfaceKind
+	Speed    int // link speed in Mbit/s, 0 when unknown
+
+	// error and drop counters since the last reset, and their rates in
+	// packets/sec since the previous sample
+	Errin, Errout, Dropin, Dropout                 uint64
+	ErrinRate, ErroutRate, DropinRate, DropoutRate float64
+
+	// Reset is set when the byte counters went back since the previous
+	// sample: the interface was recreated or its driver reset them. The
+	// rates are 0 then.
+	Reset bool
+}
+
+// NetSnapshot is the result of one NetSampler.Sample call.
+type NetSnapshot struct {
+	Hostname string
+	Uptime   time.Duration
+	Ifaces   []IfaceInfo
+	TakenAt  time.Time
+}
+
+// NetSampler samples interfaces and derives rates from the counter deltas
+// between consecutive calls; the first sample reports zero rates. Calls
+// from several goroutines take turns.
+type NetSampler struct {
+	sampling sync.Mutex // held through Sample, over last and lastAt
+	last     map[string]gnet.IOCountersStat
+	lastAt   time.Time
+
+	mu    sync.Mutex
+	brief []IfaceKind
+}
+
+// NewNetSampler returns a sampler with no previous sample.
+func NewNetSampler() *NetSampler {
+	return &NetSampler{
+		last:   map[string]gnet.IOCountersStat{},
+		lastAt: time.Time{},
+	}
+}
+
+// SetBrief makes Sample leave out the addresses and link speed of the
+// interfaces of kinds, reading only their counters. Those lookups are made
+// per interface, which on a node with thousands of veths costs more than
+// the rest of the sample. It is safe to call while Sample runs.
+func (s *NetSampler) SetBrief(kinds ...IfaceKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.brief = slices.Clone(kinds)
+}
+
+// Sample reads the current interface list and counters.
+func (s *NetSampler) Sample() (NetSnapshot, error) {
+	// one at a time: a sample taken between another's reading the
+	// counters and keeping them would leave older ones as the previous
+	s.sampling.Lock()
+	defer s.sampling.Unlock()
+
+	now := time.Now()
+	s.mu.Lock()
+	brief := s.brief
+	s.mu.Unlock()
+
+	hi, _ := host.Info()
+	hostName := ""
+	uptime := time.Duration(0)
+	if hi != nil {
+		hostName = hi.Hostname
+		uptime = time.Duration(hi.Uptime) * time.Second
+	}
+
+	// interface basics from stdlib
+	ifs, err := net.Interfaces()
+	if err != nil {
+		return NetSnapshot{}, err
+	}
+
+	// bytes counters from gopsutil
+	counters, err := gnet.IOCounters(true)
+	if err != nil {
+		return NetSnapshot{}, err
+	}
+	cur := map[string]gnet.IOCountersStat{}
+	for _, c := range counters {
+		cur[c.Name] = c
+	}
+
+	dt := now.Sub(s.lastAt).Seconds()
+	if dt <= 0 {
+		dt = 1
+	}
+
+	out := make([]IfaceInfo, 0, len(ifs))
+	for _, nif := range ifs {
+		ii := IfaceInfo{
+			Name:     nif.Name,
+			MTU:      nif.MTU,
+			Hardware: nif.HardwareAddr.String(),
+			IsUp:     (nif.Flags&net.FlagUp != 0),
+			Kind:     ClassifyIface(nif.Name),
+		}
+		if !slices.Contains(brief, ii.Kind) {
+			addrs, _ := nif.Addrs()
+			for _, a := range addrs {
+				ii.Addrs = append(ii.Addrs, a.String())
+			}
+			ii.Speed = linkSpeed(nif.Name)
+		}
+
+		if c, ok := cur[nif.Name]; ok {
+			ii.RxTotal = c.BytesRecv
+			ii.TxTotal = c.BytesSent
+			ii.Errin, ii.Errout = c.Errin, c.Errout
+			ii.Dropin, ii.Dropout = c.Dropin, c.Dropout
+
+			if prev, ok2 := s.last[nif.Name]; ok2 {
+				ii.Reset = c.BytesRecv < prev.BytesRecv || c.BytesSent < prev.BytesSent
+				ii.RxBps = CounterRate(prev.BytesRecv, c.BytesRecv, dt)
+				ii.TxBps = CounterRate(prev.BytesSent, c.BytesSent, dt)
+				ii.RxPps = CounterRate(prev.PacketsRecv, c.PacketsRecv, dt)
+				ii.TxPps = CounterRate(prev.PacketsSent, c.PacketsSent, dt)
+				ii.ErrinRate = CounterRate(prev.Errin, c.Errin, dt)
+				ii.ErroutRate = CounterRate(prev.Errout, c.Errout, dt)
+				ii.DropinRate = CounterRate(prev.Dropin, c.Dropin, dt)
+				ii.DropoutRate = CounterRate(prev.Dropout, c.Dropout, dt)
+			}
+		}
+		out = append(out, ii)
+	}
+
+	// update sampler state
+	s.last = cur
+	s.lastAt = now
+
+	return NetSnapshot{
+		Hostname: hostName,
+		Uptime:   uptime,
+		Ifaces:   out,
+		TakenAt:  now,
+	}, nil
+}
+
+// sysRoot is where the Linux sysfs is mounted.
+var sysRoot = "/sys"
+
+// linkSpeed reads the negotiated speed of an interface in Mbit/s. Virtual
+// and down interfaces report -1 or fail to read; both come back as 0.
+func linkSpeed(name string) int {
+	b, err := os.ReadFile(filepath.Join(sysRoot, "class/net", name, "speed"))
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || n <= 0 {
+		return 0
+	}
+	return n
+}
+
+// CounterRate is the per-second change from prev to cur, 0 when the
+// counter went back (driver reset or the interface was recreated) or no
+// time passed.
+func CounterRate(prev, cur uint64, dt float64) float64 {
+	if cur < prev || dt <= 0 {
+		return 0
+	}
+	return float64(cur-prev) / dt
+}
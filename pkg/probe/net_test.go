@@ -0,0 +1,43 @@
+package probe
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCounterRate(t *testing.T) {
+	for _, tt := range []struct {
+		prev, cur uint64
+		dt, want  float64
+	}{
+		{100, 300, 2, 100},
+		{100, 100, 1, 0},
+		{300, 100, 1, 0}, // reset
+		{100, 300, 0, 0},
+	} {
+		if got := CounterRate(tt.prev, tt.cur, tt.dt); got != tt.want {
+			t.Errorf("CounterRate(%d, %d, %v) = %v, want %v", tt.prev, tt.cur, tt.dt, got, tt.want)
+		}
+	}
+}
+
+// TestSampleConcurrent is for go test -race: the UI may sample from a key
+// press while the refresh loop samples.
+func TestSampleConcurrent(t *testing.T) {
+	s := NewNetSampler()
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 3 {
+				if _, err := s.Sample(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		s.SetBrief(IfaceVeth)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,194 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// FirewallCounter is an nftables rule with a counter that matches on an
+// interface name.
+type FirewallCounter struct {
+	Family  string
+	Table   string
+	Chain   string
+	Handle  int
+	Dir     string   // "in" (iifname/iif) or "out" (oifname/oif)
+	Ifaces  []string // names as written in the rule; "eth*" is a prefix
+	Negated bool     // the rule matches every interface but these
+	Verdict string   // "accept", "drop", "reject", "jump chain", …; "" to continue
+	Comment string
+	Packets uint64
+	Bytes   uint64
+}
+
+// Key identifies the rule across samples.
+func (c FirewallCounter) Key() string {
+	return fmt.Sprintf("%s %s %s %d", c.Family, c.Table, c.Chain, c.Handle)
+}
+
+// Matches reports whether the rule applies to traffic on iface.
+func (c FirewallCounter) Matches(iface string) bool {
+	hit := false
+	for _, n := range c.Ifaces {
+		if p, ok := strings.CutSuffix(n, "*"); ok && !strings.HasSuffix(p, `\`) {
+			hit = hit || strings.HasPrefix(iface, p)
+			continue
+		}
+		hit = hit || n == iface
+	}
+	return hit != c.Negated
+}
+
+// Dropped reports whether the rule discards what it matches.
+func (c FirewallCounter) Dropped() bool {
+	return c.Verdict == "drop" || c.Verdict == "reject"
+}
+
+// ErrNoNft means the nft command is not installed.
+var ErrNoNft = errors.New("nft not found")
+
+// FirewallReader reads the firewall ruleset: the nftables counters of
+// interface rules, or every chain and rule.
+type FirewallReader interface {
+	FirewallCounters() ([]FirewallCounter, error)
+	ListFirewallRules() ([]FirewallChain, error)
+}
+
+// FirewallCounters lists the counted rules that match on an interface
+// name, in ruleset order. It runs `nft -j list ruleset`, which needs
+// CAP_NET_ADMIN.
+func (Host) FirewallCounters() ([]FirewallCounter, error) { return FirewallCounters() }
+
+func FirewallCounters() ([]FirewallCounter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "nft", "-j", "list", "ruleset")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, ErrNoNft
+		}
+		if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+			return nil, fmt.Errorf("nft: %s", msg)
+		}
+		return nil, fmt.Errorf("nft: %w", err)
+	}
+	return parseNftRuleset(out)
+}
+
+type nftRuleset struct {
+	Nftables []struct {
+		Chain *struct {
+			Family string `json:"family"`
+			Table  string `json:"table"`
+			Name   string `json:"name"`
+			Hook   string `json:"hook"`
+			Prio   int    `json:"prio"`
+			Policy string `json:"policy"`
+		} `json:"chain"`
+		Rule *struct {
+			Family  string            `json:"family"`
+			Table   string            `json:"table"`
+			Chain   string            `json:"chain"`
+			Handle  int               `json:"handle"`
+			Comment string            `json:"comment"`
+			Expr    []json.RawMessage `json:"expr"`
+		} `json:"rule"`
+	} `json:"nftables"`
+}
+
+type nftMatch struct {
+	Op   string `json:"op"`
+	Left struct {
+		Meta *struct {
+			Key string `json:"key"`
+		} `json:"meta"`
+	} `json:"left"`
+	Right json.RawMessage `json:"right"`
+}
+
+func parseNftRuleset(b []byte) ([]FirewallCounter, error) {
+	var rs nftRuleset
+	if err := json.Unmarshal(b, &rs); err != nil {
+		return nil, fmt.Errorf("nft: %w", err)
+	}
+	var out []FirewallCounter
+	for _, o := range rs.Nftables {
+		r := o.Rule
+		if r == nil {
+			continue
+		}
+		c := FirewallCounter{Family: r.Family, Table: r.Table, Chain: r.Chain, Handle: r.Handle, Comment: r.Comment}
+		counted := false
+		for _, raw := range r.Expr {
+			var e map[string]json.RawMessage
+			if json.Unmarshal(raw, &e) != nil {
+				continue
+			}
+			for k, v := range e {
+				switch k {
+				case "match":
+					var mt nftMatch
+					if json.Unmarshal(v, &mt) != nil || mt.Left.Meta == nil || c.Dir != "" {
+						continue
+					}
+					switch mt.Left.Meta.Key {
+					case "iifname", "iif":
+						c.Dir = "in"
+					case "oifname", "oif":
+						c.Dir = "out"
+					default:
+						continue
+					}
+					c.Ifaces = nftNames(mt.Right)
+					c.Negated = mt.Op == "!="
+				case "counter":
+					// anonymous counters only; named ones are a reference string
+					var ctr struct{ Packets, Bytes uint64 }
+					if json.Unmarshal(v, &ctr) == nil {
+						c.Packets, c.Bytes, counted = ctr.Packets, ctr.Bytes, true
+					}
+				case "accept", "drop", "reject", "return", "queue", "masquerade", "snat", "dnat":
+					c.Verdict = k
+				case "jump", "goto":
+					var t struct{ Target string }
+					_ = json.Unmarshal(v, &t)
+					c.Verdict = k + " " + t.Target
+				}
+			}
+		}
+		if counted && c.Dir != "" && len(c.Ifaces) > 0 {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// nftNames reads the right side of an interface match: a name or an
+// anonymous set of names.
+func nftNames(raw json.RawMessage) []string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return []string{s}
+	}
+	var set struct{ Set []json.RawMessage }
+	if json.Unmarshal(raw, &set) != nil {
+		return nil
+	}
+	var out []string
+	for _, e := range set.Set {
+		if json.Unmarshal(e, &s) == nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package probe
+
+import (
+	"reflect"
+	"testing"
+)
+
+// nftRulesetJSON is `nft -j list ruleset` of a small filter table, cut
+// down to what the parsers read.
+const nftRulesetJSON = `{"nftables": [
+ {"metainfo": {"version": "1.0.6", "json_schema_version": 1}},
+ {"table": {"family": "inet", "name": "filter", "handle": 1}},
+ {"chain": {"family": "inet", "table": "filter", "name": "input", "handle": 1, "type": "filter", "hook": "input", "prio": 0, "policy": "drop"}},
+ {"chain": {"family": "inet", "table": "filter", "name": "blocked", "handle": 2}},
+ {"rule": {"family": "inet", "table": "filter", "chain": "input", "handle": 4, "expr": [
+   {"match": {"op": "==", "left": {"ct": {"key": "state"}}, "right": ["established", "related"]}},
+   {"counter": {"packets": 91000, "bytes": 125829120}},
+   {"accept": null}]}},
+ {"rule": {"family": "inet", "table": "filter", "chain": "input", "handle": 5, "comment": "block telnet", "expr": [
+   {"match": {"op": "==", "left": {"meta": {"key": "iifname"}}, "right": "eth0"}},
+   {"match": {"op": "==", "left": {"payload": {"protocol": "tcp", "field": "dport"}}, "right": 23}},
+   {"counter": {"packets": 42, "bytes": 2560}},
+   {"drop": null}]}},
+ {"rule": {"family": "inet", "table": "filter", "chain": "input", "handle": 6, "expr": [
+   {"match": {"op": "!=", "left": {"meta": {"key": "iifname"}}, "right": {"set": ["lo", "docker0"]}}},
+   {"match": {"op": "==", "left": {"payload": {"protocol": "ip", "field": "saddr"}}, "right": {"prefix": {"addr": "10.0.0.0", "len": 8}}}},
+   {"counter": {"packets": 7, "bytes": 420}},
+   {"jump": {"target": "blocked"}}]}},
+ {"rule": {"family": "inet", "table": "filter", "chain": "input", "handle": 7, "expr": [
+   {"match": {"op": "==", "left": {"meta": {"key": "oifname"}}, "right": "eth0"}},
+   {"counter": "named"},
+   {"accept": null}]}},
+ {"rule": {"family": "inet", "table": "filter", "chain": "blocked", "handle": 8, "expr": [
+   {"log": {"prefix": "blocked: "}},
+   {"reject": {"type": "icmpx", "expr": "admin-prohibited"}}]}},
+ {"rule": {"family": "inet", "table": "filter", "chain": "gone", "handle": 9, "expr": [{"accept": null}]}}
+]}`
+
+func TestParseNftRuleset(t *testing.T) {
+	got, err := parseNftRuleset([]byte(nftRulesetJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FirewallCounter{
+		{Family: "inet", Table: "filter", Chain: "input", Handle: 5, Dir: "in", Ifaces: []string{"eth0"}, Verdict: "drop", Comment: "block telnet", Packets: 42, Bytes: 2560},
+		{Family: "inet", Table: "filter", Chain: "input", Handle: 6, Dir: "in", Ifaces: []string{"lo", "docker0"}, Negated: true, Verdict: "jump blocked", Packets: 7, Bytes: 420},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	for _, in := range []string{``, `{"nftables": [`, `[]`} {
+		if _, err := parseNftRuleset([]byte(in)); err == nil {
+			t.Errorf("%q: no error", in)
+		}
+	}
+}
+
+func TestParseNftChains(t *testing.T) {
+	got, err := parseNftChains([]byte(nftRulesetJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FirewallChain{
+		{Family: "inet", Table: "filter", Name: "input", Hook: "input", Policy: "drop", Rules: []FirewallRule{
+			{Handle: 4, Text: "ct state established,related accept", Verdict: "accept", Counted: true, Packets: 91000, Bytes: 125829120},
+			{Handle: 5, Text: `iifname "eth0" tcp dport 23 drop`, Verdict: "drop", Comment: "block telnet", Counted: true, Packets: 42, Bytes: 2560},
+			{Handle: 6, Text: "iifname != { lo, docker0 } ip saddr 10.0.0.0/8 jump blocked", Verdict: "jump blocked", Counted: true, Packets: 7, Bytes: 420},
+			{Handle: 7, Text: `oifname "eth0" counter name "named" accept`, Verdict: "accept"},
+		}},
+		{Family: "inet", Table: "filter", Name: "blocked", Rules: []FirewallRule{
+			{Handle: 8, Text: `log prefix "blocked: " reject with icmpx admin-prohibited`, Verdict: "reject"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	if _, err := parseNftChains([]byte("not json")); err == nil {
+		t.Error("not json: no error")
+	}
+}
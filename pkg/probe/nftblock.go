@@ -0,0 +1,92 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// HostBlocker drops traffic to and from remote hosts.
+type HostBlocker interface {
+	BlockHosts(ips []string) error
+}
+
+func (Host) BlockHosts(ips []string) error { return BlockHosts(ips) }
+
+// BlockTable is the nftables table BlockHosts adds to; deleting it
+// (`nft delete table inet ducknetview`) lifts every block.
+const BlockTable = "inet ducknetview"
+
+// blockRuleset creates BlockTable: a set of addresses per family, dropped
+// on input and output.
+const blockRuleset = `table ` + BlockTable + ` {
+	set blocked4 { type ipv4_addr; }
+	set blocked6 { type ipv6_addr; }
+	chain input {
+		type filter hook input priority 0; policy accept;
+		ip saddr @blocked4 drop
+		ip6 saddr @blocked6 drop
+	}
+	chain output {
+		type filter hook output priority 0; policy accept;
+		ip daddr @blocked4 drop
+		ip6 daddr @blocked6 drop
+	}
+}
+`
+
+// BlockHosts adds ips to the sets of BlockTable, creating it first if
+// needed, with `nft -f -`. Needs root or CAP_NET_ADMIN. The blocks last
+// until the table is deleted or the host reboots.
+func BlockHosts(ips []string) error {
+	var v4, v6 []string
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		switch {
+		case ip == nil:
+			return fmt.Errorf("nft: bad address %q", s)
+		case ip.To4() != nil:
+			v4 = append(v4, ip.String())
+		default:
+			v6 = append(v6, ip.String())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var script strings.Builder
+	// the chains would be added again on every call
+	if exec.CommandContext(ctx, "nft", append([]string{"list", "table"}, strings.Fields(BlockTable)...)...).Run() != nil {
+		script.WriteString(blockRuleset)
+	}
+	if len(v4) > 0 {
+		fmt.Fprintf(&script, "add element %s blocked4 { %s }\n", BlockTable, strings.Join(v4, ", "))
+	}
+	if len(v6) > 0 {
+		fmt.Fprintf(&script, "add element %s blocked6 { %s }\n", BlockTable, strings.Join(v6, ", "))
+	}
+
+	cmd := exec.CommandContext(ctx, "nft", "-f", "-")
+	cmd.Stdin = strings.NewReader(script.String())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrNoNft
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			// "Error: Could not process rule: Operation not permitted", then
+			// the offending line
+			msg, _, _ = strings.Cut(msg, "\n")
+			return fmt.Errorf("nft: %s", msg)
+		}
+		return fmt.Errorf("nft: %w", err)
+	}
+	return nil
+}
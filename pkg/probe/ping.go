@@ -0,0 +1,313 @@
+package probe
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+const (
+	// PingInterval is how often a PingMonitor pings each target.
+	PingInterval = time.Second
+	// PingTimeout is how long a ping waits for its answer.
+	PingTimeout = 2 * time.Second
+	// PingWindow is how many pings per target the statistics cover.
+	PingWindow = 120
+
+	// tcpPingPort is dialled when raw ICMP sockets aren't allowed.
+	tcpPingPort = "443"
+)
+
+// Pinger measures the round trip to a host.
+type Pinger interface {
+	// Ping sends one echo request to host and returns the round-trip time
+	// and how it was measured, "icmp" or "tcp".
+	Ping(ctx context.Context, host string) (time.Duration, string, error)
+}
+
+func (Host) Ping(ctx context.Context, host string) (time.Duration, string, error) {
+	return Ping(ctx, host)
+}
+
+// rawDenied is set once opening a raw ICMP socket failed for lack of
+// privilege, so later pings go straight to TCP.
+var rawDenied atomic.Bool
+
+var pingSeq atomic.Uint32
+
+// Ping sends an ICMP echo request to host, a name or an address (with a
+// zone for link-local IPv6). Raw ICMP sockets need root or CAP_NET_RAW;
+// without them it times a TCP handshake to port 443 instead, where a
+// refused connection counts as an answer: the host is there and replied.
+func Ping(ctx context.Context, host string) (time.Duration, string, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, PingTimeout)
+		defer cancel()
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return 0, "", err
+	}
+	if len(addrs) == 0 {
+		return 0, "", fmt.Errorf("ping: no address for %s", host)
+	}
+	addr := addrs[0]
+
+	if !rawDenied.Load() {
+		rtt, err := icmpEcho(ctx, addr)
+		if !errors.Is(err, os.ErrPermission) {
+			return rtt, "icmp", err
+		}
+		rawDenied.Store(true)
+	}
+	rtt, err := tcpPing(ctx, addr)
+	return rtt, "tcp", err
+}
+
+func icmpEcho(ctx context.Context, addr net.IPAddr) (time.Duration, error) {
+	network, laddr := "ip4:icmp", "0.0.0.0"
+	request, reply := byte(8), byte(0)
+	if addr.IP.To4() == nil {
+		network, laddr = "ip6:ipv6-icmp", "::"
+		request, reply = 128, 129
+	}
+	conn, err := net.ListenPacket(network, laddr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	id, seq := uint16(os.Getpid()), uint16(pingSeq.Add(1))
+	msg := make([]byte, 16)
+	msg[0] = request
+	binary.BigEndian.PutUint16(msg[4:], id)
+	binary.BigEndian.PutUint16(msg[6:], seq)
+	if request == 8 {
+		// the kernel fills in the ICMPv6 checksum, which covers the
+		// IPv6 pseudo-header
+		binary.BigEndian.PutUint16(msg[2:], icmpChecksum(msg))
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(msg, &addr); err != nil {
+		return 0, err
+	}
+	// a raw socket sees every echo reply on the host; wait for ours
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, err
+		}
+		src, _ := from.(*net.IPAddr)
+		if n < 8 || buf[0] != reply || src == nil || !src.IP.Equal(addr.IP) ||
+			binary.BigEndian.Uint16(buf[4:]) != id || binary.BigEndian.Uint16(buf[6:]) != seq {
+			continue
+		}
+		return time.Since(start), nil
+	}
+}
+
+func icmpChecksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+func tcpPing(ctx context.Context, addr net.IPAddr) (time.Duration, error) {
+	var d net.Dialer
+	start := time.Now()
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(addr.String(), tcpPingPort))
+	rtt := time.Since(start)
+	if err == nil {
+		conn.Close()
+		return rtt, nil
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return rtt, nil
+	}
+	return 0, err
+}
+
+// PingTarget is a host watched by a PingMonitor.
+type PingTarget struct {
+	Name string // label, e.g. "gateway"; "" shows Host
+	Host string
+}
+
+// Label is Name, or Host without one.
+func (t PingTarget) Label() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.Host
+}
+
+// PingStats sums up the last PingWindow pings of a target.
+type PingStats struct {
+	PingTarget
+	Method string // of the last answer, "icmp" or "tcp"
+
+	Sent, Lost    int
+	Last          time.Duration // latest answer
+	Min, Avg, Max time.Duration // over the answered pings
+	Err           error         // of the latest ping, nil when it was answered
+
+	// RTTs are the answered round trips in milliseconds, oldest first.
+	RTTs []float64
+}
+
+// Loss is the share of lost pings in percent.
+func (s PingStats) Loss() float64 {
+	if s.Sent == 0 {
+		return 0
+	}
+	return float64(s.Lost) * 100 / float64(s.Sent)
+}
+
+type pingResult struct {
+	rtt time.Duration
+	err error
+}
+
+type pingRun struct {
+	target  PingTarget
+	stop    chan struct{}
+	method  string
+	results []pingResult
+}
+
+// PingMonitor pings a set of targets in the background, each on its own
+// schedule, and keeps the recent results for Stats.
+type PingMonitor struct {
+	pinger Pinger
+	every  time.Duration
+	window int
+
+	mu   sync.Mutex
+	runs []*pingRun
+}
+
+// NewPingMonitor returns a monitor pinging through p every interval and
+// keeping window results per target. Zero values use the defaults above.
+func NewPingMonitor(p Pinger, every time.Duration, window int) *PingMonitor {
+	if every <= 0 {
+		every = PingInterval
+	}
+	if window <= 0 {
+		window = PingWindow
+	}
+	return &PingMonitor{pinger: p, every: every, window: window}
+}
+
+// SetTargets replaces the watched targets. Hosts already watched keep
+// their history, new ones start right away, dropped ones stop.
+func (pm *PingMonitor) SetTargets(targets []PingTarget) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	old := map[string]*pingRun{}
+	for _, r := range pm.runs {
+		old[r.target.Host] = r
+	}
+	runs := make([]*pingRun, 0, len(targets))
+	for _, t := range targets {
+		r, ok := old[t.Host]
+		switch {
+		case t.Host == "" || ok && r == nil:
+			// empty, or listed twice
+			continue
+		case ok:
+			r.target = t
+			old[t.Host] = nil
+		default:
+			r = &pingRun{target: t, stop: make(chan struct{})}
+			old[t.Host] = nil
+			go pm.run(r)
+		}
+		runs = append(runs, r)
+	}
+	for _, r := range old {
+		if r != nil {
+			close(r.stop)
+		}
+	}
+	pm.runs = runs
+}
+
+func (pm *PingMonitor) run(r *pingRun) {
+	t := time.NewTicker(pm.every)
+	defer t.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), PingTimeout)
+		rtt, method, err := pm.pinger.Ping(ctx, r.target.Host)
+		cancel()
+
+		pm.mu.Lock()
+		if err == nil {
+			r.method = method
+		}
+		r.results = append(r.results, pingResult{rtt: rtt, err: err})
+		if len(r.results) > pm.window {
+			r.results = r.results[len(r.results)-pm.window:]
+		}
+		pm.mu.Unlock()
+
+		select {
+		case <-r.stop:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Stats returns the statistics of every target, in the order they were set.
+func (pm *PingMonitor) Stats() []PingStats {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	out := make([]PingStats, 0, len(pm.runs))
+	for _, r := range pm.runs {
+		s := PingStats{PingTarget: r.target, Method: r.method, Sent: len(r.results)}
+		var sum time.Duration
+		for _, res := range r.results {
+			if res.err != nil {
+				s.Lost++
+				continue
+			}
+			if len(s.RTTs) == 0 || res.rtt < s.Min {
+				s.Min = res.rtt
+			}
+			s.Max = max(s.Max, res.rtt)
+			sum += res.rtt
+			s.Last = res.rtt
+			s.RTTs = append(s.RTTs, float64(res.rtt)/float64(time.Millisecond))
+		}
+		if n := len(s.RTTs); n > 0 {
+			s.Avg = sum / time.Duration(n)
+		}
+		if n := len(r.results); n > 0 {
+			s.Err = r.results[n-1].err
+		}
+		out = append(out, s)
+	}
+	return out
+}
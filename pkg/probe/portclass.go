@@ -0,0 +1,97 @@
+package probe
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PortClass is how a local port came to be used: one a service claims by
+// number, or one the kernel handed out to an outgoing connection.
+type PortClass int
+
+const (
+	PortWellKnown  PortClass = iota // below 1024; binding takes privilege
+	PortRegistered                  // 1024 and up, outside the ephemeral range
+	PortEphemeral                   // in the ephemeral range
+)
+
+func (c PortClass) String() string {
+	switch c {
+	case PortWellKnown:
+		return "well-known"
+	case PortRegistered:
+		return "registered"
+	case PortEphemeral:
+		return "ephemeral"
+	}
+	return "?"
+}
+
+// PortRange is a range of ports, both ends included.
+type PortRange struct {
+	Lo, Hi int
+}
+
+// IANAEphemeral is the dynamic range of RFC 6335, taken where the host's
+// own range can't be read.
+var IANAEphemeral = PortRange{Lo: 49152, Hi: 65535}
+
+func (r PortRange) Contains(port int) bool { return port >= r.Lo && port <= r.Hi }
+
+func (r PortRange) String() string { return fmt.Sprintf("%d–%d", r.Lo, r.Hi) }
+
+// Class classifies port, with r as the ephemeral range. Ports below 1024
+// are well-known even where the range reaches down to them.
+func (r PortRange) Class(port int) PortClass {
+	switch {
+	case port < 1024:
+		return PortWellKnown
+	case r.Contains(port):
+		return PortEphemeral
+	}
+	return PortRegistered
+}
+
+// ClassOf classifies the port of a local address "ip:port"; ok is false
+// when it has none, as for raw sockets.
+func (r PortRange) ClassOf(local string) (c PortClass, ok bool) {
+	_, p := SplitLocal(local)
+	n, err := strconv.Atoi(p)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return r.Class(n), true
+}
+
+// EphemeralRangeReader reads the range of ports the kernel picks from for
+// outgoing connections.
+type EphemeralRangeReader interface {
+	EphemeralRange() (PortRange, error)
+}
+
+func (Host) EphemeralRange() (PortRange, error) { return ReadEphemeralRange() }
+
+// ReadEphemeralRange reads net.ipv4.ip_local_port_range, which despite its
+// name is the range of IPv6 sockets too.
+func ReadEphemeralRange() (PortRange, error) {
+	b, err := os.ReadFile("/proc/sys/net/ipv4/ip_local_port_range")
+	if err != nil {
+		return PortRange{}, err
+	}
+	return parsePortRange(string(b))
+}
+
+func parsePortRange(s string) (PortRange, error) {
+	f := strings.Fields(s)
+	if len(f) != 2 {
+		return PortRange{}, fmt.Errorf("ip_local_port_range: unexpected %q", strings.TrimSpace(s))
+	}
+	lo, err1 := strconv.Atoi(f[0])
+	hi, err2 := strconv.Atoi(f[1])
+	if err1 != nil || err2 != nil || lo > hi {
+		return PortRange{}, fmt.Errorf("ip_local_port_range: unexpected %q", strings.TrimSpace(s))
+	}
+	return PortRange{Lo: lo, Hi: hi}, nil
+}
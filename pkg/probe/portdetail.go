@@ -0,0 +1,128 @@
+package probe
+
+import (
+	"bufio"
+	"net"
+	"os/user"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// PortDetail is everything InspectPort finds out about a listener.
+type PortDetail struct {
+	ListenPort
+	Family  string // "IPv4" or "IPv6"
+	UID     int    // owner of the socket, -1 when unknown
+	User    string
+	Cmdline string
+
+	// From ss on Linux, -1 (0 for Inode) when unknown. For a TCP listener
+	// RecvQ is the accept queue waiting for the program and SendQ its
+	// backlog limit; for UDP both are bytes queued.
+	RecvQ, SendQ int
+	Inode        uint64
+	Opts         SockOpts
+}
+
+// PortInspector looks into a single listener.
+type PortInspector interface {
+	InspectPort(lp ListenPort) (PortDetail, error)
+}
+
+func (Host) InspectPort(lp ListenPort) (PortDetail, error) { return InspectPort(lp) }
+
+// InspectPort fills in a PortDetail for lp. Each source is best-effort:
+// the owner and command line of another user's process may need root,
+// and the queues, inode and socket options need Linux's ss.
+func InspectPort(lp ListenPort) (PortDetail, error) {
+	d := PortDetail{ListenPort: lp, Family: "IPv4", UID: -1, RecvQ: -1, SendQ: -1}
+	ip, port := SplitLocal(lp.Local)
+	if strings.Contains(ip, ":") {
+		d.Family = "IPv6"
+	}
+
+	ssSocket(&d, ip, port)
+	if lp.PID > 0 {
+		if p, err := process.NewProcess(lp.PID); err == nil {
+			d.Cmdline, _ = p.Cmdline()
+			if d.UID < 0 {
+				// no ss: the owning process's, which is the same but for
+				// sockets handed over from a privileged parent
+				if uids, err := p.Uids(); err == nil && len(uids) > 1 {
+					d.UID = int(uids[1]) // effective
+				}
+				d.User, _ = p.Username()
+			}
+		}
+	}
+	if d.UID >= 0 && d.User == "" {
+		if u, err := user.LookupId(strconv.Itoa(d.UID)); err == nil {
+			d.User = u.Username
+		}
+	}
+	return d, nil
+}
+
+// ssSocket fills in the queues, owner, inode and options from
+// `ss -Hlnoemi`, e.g.
+//
+//	LISTEN 0 4096 127.0.0.53%lo:53 0.0.0.0:* uid:991 ino:21516 sk:1 <-> skmem:(…) cubic …
+//	UNCONN 0 0 [::]:5353 [::]:* uid:105 ino:23807 sk:5 v6only:1 <-> skmem:(…)
+//	LISTEN 0 128 0.0.0.0:22 0.0.0.0:* ino:662 sk:3 <-> skmem:(…) cubic …
+//
+// Of several sockets on the same address, SO_REUSEPORT, the first is
+// taken.
+func ssSocket(d *PortDetail, ip, port string) {
+	d.Opts = newSockOpts()
+	out, err := ssOpts(d.Proto, "-Hlnoemi", "sport = :"+port)
+	if err != nil {
+		return
+	}
+	want := net.ParseIP(ip)
+	sc := bufio.NewScanner(strings.NewReader(string(out)))
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 5 || !ssAddrMatches(f[3], want) {
+			continue
+		}
+		d.Opts.Shared++
+		if d.Opts.Found {
+			continue
+		}
+		d.Opts.parse(f[5:], d.Family == "IPv6")
+		d.RecvQ, _ = strconv.Atoi(f[1])
+		d.SendQ, _ = strconv.Atoi(f[2])
+		d.UID = 0 // ss leaves out uid:0
+		for _, kv := range f[5:] {
+			k, v, _ := strings.Cut(kv, ":")
+			switch k {
+			case "uid":
+				if uid, err := strconv.Atoi(v); err == nil {
+					d.UID = uid
+				}
+			case "ino":
+				d.Inode, _ = strconv.ParseUint(v, 10, 64)
+			}
+		}
+	}
+}
+
+// ssAddrMatches compares ss's local address ("[::]:53", "127.0.0.53%lo:53",
+// "*:22" for a dual-stack wildcard) with the listener's IP.
+func ssAddrMatches(local string, want net.IP) bool {
+	host := local
+	if i := strings.LastIndex(local, ":"); i >= 0 {
+		host = local[:i]
+	}
+	host = strings.Trim(host, "[]")
+	if i := strings.Index(host, "%"); i >= 0 {
+		host = host[:i]
+	}
+	if host == "*" {
+		return want != nil && want.IsUnspecified()
+	}
+	got := net.ParseIP(host)
+	return got != nil && got.Equal(want)
+}
@@ -0,0 +1,141 @@
+package probe
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"syscall"
+
+	gnet "github.com/shirou/gopsutil/v4/net"
+)
+
+// ListenPort is a listening TCP socket or a bound UDP socket.
+type ListenPort struct {
+	Proto   string
+	Local   string // ip:port
+	PID     int32
+	Process string
+}
+
+func connProto(c gnet.ConnectionStat) string {
+	switch c.Type {
+	case syscall.SOCK_STREAM:
+		return "tcp"
+	case syscall.SOCK_DGRAM:
+		return "udp"
+	default:
+		return "net"
+	}
+}
+
+func isListening(c gnet.ConnectionStat) bool {
+	// TCP: LISTEN
+	if c.Type == syscall.SOCK_STREAM {
+		return c.Status == "LISTEN"
+	}
+
+	// UDP doesn't have LISTEN in the same way; gopsutil often reports empty or "NONE".
+	// We treat any UDP socket with a local port as "listening".
+	if c.Type == syscall.SOCK_DGRAM {
+		return c.Laddr.Port != 0
+	}
+
+	return false
+}
+
+// ListListening returns all listening sockets sorted by protocol, address
+// and PID. Process names are best-effort and may need privileges.
+func ListListening() ([]ListenPort, error) {
+	conns, err := gnet.Connections("all")
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]ListenPort, 0, 128)
+
+	for _, c := range conns {
+		if !isListening(c) {
+			continue
+		}
+
+		lp := ListenPort{
+			Proto: connProto(c),
+			Local: fmt.Sprintf("%s:%d", c.Laddr.IP, c.Laddr.Port),
+			PID:   c.Pid,
+		}
+
+		// Best-effort process name (may require privileges depending on OS)
+		if c.Pid > 0 {
+			lp.Process = procName(c.Pid)
+		}
+
+		out = append(out, lp)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Proto != out[j].Proto {
+			return out[i].Proto < out[j].Proto
+		}
+		if out[i].Local != out[j].Local {
+			return out[i].Local < out[j].Local
+		}
+		return out[i].PID < out[j].PID
+	})
+
+	return out, nil
+}
+
+// SplitLocal splits ListenPort.Local ("ip:port", IPv6 unbracketed) into
+// its address and port.
+func SplitLocal(local string) (ip string, port string) {
+	i := strings.LastIndex(local, ":")
+	if i < 0 {
+		return local, ""
+	}
+	return local[:i], local[i+1:]
+}
+
+// IsWildcard reports whether the listener is bound to all addresses.
+func (lp ListenPort) IsWildcard() bool {
+	ip, _ := SplitLocal(lp.Local)
+	return ip == "0.0.0.0" || ip == "::" || ip == ""
+}
+
+// ReachableAddrs expands a wildcard listener into the concrete addresses of
+// the up interfaces it is reachable on ("192.168.1.10:22", "[fe80::1%eth0]:22").
+// IPv4 wildcards match IPv4 addresses only; "::" matches both families since
+// Linux sockets are dual-stack by default. Returns nil for bound listeners.
+func ReachableAddrs(lp ListenPort, ifaces []IfaceInfo) []string {
+	if !lp.IsWildcard() {
+		return nil
+	}
+	ip, port := SplitLocal(lp.Local)
+	v4only := ip == "0.0.0.0"
+
+	var out []string
+	for _, ii := range ifaces {
+		if !ii.IsUp {
+			continue
+		}
+		for _, a := range ii.Addrs {
+			addr, _, err := net.ParseCIDR(a)
+			if err != nil {
+				continue
+			}
+			if addr.To4() != nil {
+				out = append(out, net.JoinHostPort(addr.String(), port))
+				continue
+			}
+			if v4only {
+				continue
+			}
+			host := addr.String()
+			if addr.IsLinkLocalUnicast() {
+				host += "%" + ii.Name
+			}
+			out = append(out, net.JoinHostPort(host, port))
+		}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+// Package probe collects network state from the local host: per-interface
+// counters and rates, listening sockets and per-process socket counts.
+//
+// The live implementations are NetSampler and Host; the Sampler, PortLister
+// and ProcLister interfaces let callers substitute their own sources.
+package probe
+
+// Sampler takes snapshots of the network interfaces. NetSampler is the live
+// implementation.
+type Sampler interface {
+	Sample() (NetSnapshot, error)
+}
+
+// BriefSampler is a Sampler that can skip the details of some interface
+// kinds; see NetSampler.SetBrief.
+type BriefSampler interface {
+	Sampler
+	SetBrief(kinds ...IfaceKind)
+}
+
+// PortLister lists listening sockets.
+type PortLister interface {
+	ListListening() ([]ListenPort, error)
+}
+
+// ProcLister aggregates sockets per process.
+type ProcLister interface {
+	TopProcsByConnections(limit int) ([]ProcNet, error)
+}
+
+// Host implements PortLister and ProcLister against the running system.
+type Host struct{}
+
+func (Host) ListListening() ([]ListenPort, error) { return ListListening() }
+
+func (Host) TopProcsByConnections(limit int) ([]ProcNet, error) {
+	return TopProcsByConnections(limit)
+}
@@ -0,0 +1,44 @@
+// Package probetest provides canned probe implementations and a fake clock
+// so UI output can be rendered deterministically, e.g. for golden files.
+// The canned data is that of the made-up host in package demo.
+package probetest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nexusriot/ducknetview/pkg/probe/demo"
+)
+
+// Epoch is the fixed instant used by Fixture and NewClock.
+var Epoch = demo.Epoch
+
+// Probes returns canned data; see demo.Probes.
+type Probes = demo.Probes
+
+// Fixture returns a small but representative host; see demo.Fixture.
+func Fixture() *Probes {
+	return demo.Fixture()
+}
+
+// Clock is a manually advanced clock. Pass Clock.Now as ui.Options.Clock.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewClock() *Clock {
+	return &Clock{now: Epoch}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
@@ -0,0 +1,200 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProcBandwidth is the network throughput of a process, or of a remote
+// host for PeerBandwidth, in bytes/sec.
+type ProcBandwidth struct {
+	RxBps float64
+	TxBps float64
+	// bytes so far over the sockets open now, from the first call on;
+	// PeerBandwidth only
+	RxBytes, TxBytes uint64
+}
+
+// ErrNoBandwidth means per-process byte counts are unavailable here (no ss
+// from iproute2, or not Linux); callers fall back to connection counts.
+var ErrNoBandwidth = errors.New("per-process bandwidth needs ss from iproute2")
+
+// ProcBandwidthReader samples per-process throughput.
+type ProcBandwidthReader interface {
+	ProcBandwidth() (map[int32]ProcBandwidth, error)
+}
+
+// ProcBandwidthSampler attributes TCP throughput to processes from the
+// kernel's per-socket byte counters (tcp_info bytes_received and
+// bytes_acked, as printed by `ss -tinp`), the way nethogs does per
+// connection. UDP and loopback traffic are not counted. Rates are deltas
+// between consecutive calls, so the first call reports nothing.
+type ProcBandwidthSampler struct {
+	socks sockDeltas
+}
+
+func NewProcBandwidthSampler() *ProcBandwidthSampler {
+	return &ProcBandwidthSampler{}
+}
+
+func (s *ProcBandwidthSampler) ProcBandwidth() (map[int32]ProcBandwidth, error) {
+	cur, err := readSSBytes()
+	if err != nil {
+		return nil, err
+	}
+	return s.add(cur, time.Now()), nil
+}
+
+func (s *ProcBandwidthSampler) add(cur map[string]sockBytes, now time.Time) map[int32]ProcBandwidth {
+	res := map[int32]ProcBandwidth{}
+	s.socks.rates(cur, now, func(c sockBytes, bw ProcBandwidth) {
+		if c.pid == 0 {
+			return
+		}
+		sum := res[c.pid]
+		sum.RxBps += bw.RxBps
+		sum.TxBps += bw.TxBps
+		res[c.pid] = sum
+	})
+	return res
+}
+
+// PeerBandwidthReader samples throughput per remote address.
+type PeerBandwidthReader interface {
+	PeerBandwidth() (map[string]ProcBandwidth, error)
+}
+
+// PeerBandwidthSampler is ProcBandwidthSampler by remote IP instead of by
+// process, with the same limits. Sockets of other users' processes count
+// too.
+type PeerBandwidthSampler struct {
+	socks sockDeltas
+}
+
+func NewPeerBandwidthSampler() *PeerBandwidthSampler {
+	return &PeerBandwidthSampler{}
+}
+
+func (s *PeerBandwidthSampler) PeerBandwidth() (map[string]ProcBandwidth, error) {
+	cur, err := readSSBytes()
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]ProcBandwidth{}
+	s.socks.rates(cur, time.Now(), func(c sockBytes, bw ProcBandwidth) {
+		if c.peer == "" {
+			return
+		}
+		sum := res[c.peer]
+		sum.RxBps += bw.RxBps
+		sum.TxBps += bw.TxBps
+		res[c.peer] = sum
+	})
+	for _, c := range cur {
+		if c.peer == "" {
+			continue
+		}
+		sum := res[c.peer]
+		sum.RxBytes += c.rx
+		sum.TxBytes += c.tx
+		res[c.peer] = sum
+	}
+	return res, nil
+}
+
+type sockBytes struct {
+	pid    int32  // 0 when not known
+	peer   string // remote IP
+	rx, tx uint64
+}
+
+// sockDeltas turns successive byte counts of sockets into rates.
+type sockDeltas struct {
+	mu     sync.Mutex
+	last   map[string]sockBytes
+	lastAt time.Time
+}
+
+// rates calls add with the rate of every socket in cur that was also in
+// the previous call's counts.
+func (d *sockDeltas) rates(cur map[string]sockBytes, now time.Time, add func(sockBytes, ProcBandwidth)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if dt := now.Sub(d.lastAt).Seconds(); d.last != nil && dt > 0 {
+		for k, c := range cur {
+			p, ok := d.last[k]
+			// counters only grow; a smaller value is a reused 4-tuple
+			if !ok || c.rx < p.rx || c.tx < p.tx {
+				continue
+			}
+			add(c, ProcBandwidth{RxBps: float64(c.rx-p.rx) / dt, TxBps: float64(c.tx-p.tx) / dt})
+		}
+	}
+	d.last, d.lastAt = cur, now
+}
+
+func readSSBytes() (map[string]sockBytes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "ss", "-tinpHO").Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, ErrNoBandwidth
+		}
+		return nil, fmt.Errorf("ss: %w", err)
+	}
+	return parseSSBytes(out), nil
+}
+
+// parseSSBytes reads `ss -tinpHO` lines: state, queues, local, peer,
+// users:(("name",pid=N,fd=M),...), then tcp_info fields. Sockets with a
+// loopback peer are skipped.
+func parseSSBytes(out []byte) map[string]sockBytes {
+	res := map[string]sockBytes{}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 6 {
+			continue
+		}
+		local, peer := f[3], f[4]
+		var sb sockBytes
+		if host, _, err := net.SplitHostPort(peer); err == nil {
+			ip := net.ParseIP(strings.Trim(host, "[]"))
+			if ip != nil && ip.IsLoopback() {
+				continue
+			}
+			if ip != nil {
+				sb.peer = ip.String()
+			}
+		}
+		for _, x := range f[5:] {
+			switch {
+			case strings.HasPrefix(x, "users:"):
+				if _, rest, ok := strings.Cut(x, "pid="); ok {
+					n, _, _ := strings.Cut(rest, ",")
+					pid, _ := strconv.Atoi(n)
+					sb.pid = int32(pid)
+				}
+			case strings.HasPrefix(x, "bytes_received:"):
+				sb.rx, _ = strconv.ParseUint(x[len("bytes_received:"):], 10, 64)
+			case strings.HasPrefix(x, "bytes_acked:"):
+				sb.tx, _ = strconv.ParseUint(x[len("bytes_acked:"):], 10, 64)
+			}
+		}
+		res[local+" "+peer] = sb
+	}
+	return res
+}
@@ -0,0 +1,172 @@
+package probe
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	gnet "github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// procNames caches process names by PID for the listers, which name the
+// same processes on every refresh. A PID reused within the TTL keeps the
+// old name until it runs out.
+var procNames = sync.OnceValue(func() *Cache[int32, string] {
+	return NewCache[int32, string]("procname", CacheConfig{TTL: 30 * time.Second, Size: 4096})
+})
+
+// procName is the name of process pid, "" when it can't be read.
+func procName(pid int32) string {
+	c := procNames()
+	if n, ok := c.Get(pid); ok {
+		return n
+	}
+	var n string
+	if p, err := process.NewProcess(pid); err == nil {
+		n, _ = p.Name()
+	}
+	if n != "" {
+		c.Put(pid, n)
+	}
+	return n
+}
+
+// ProcNet aggregates the sockets owned by one process.
+type ProcNet struct {
+	PID         int32
+	Name        string
+	ConnCount   int
+	ListenCount int
+}
+
+// TopProcsByConnections returns processes ordered by socket count, at most
+// limit entries (all when limit <= 0).
+func TopProcsByConnections(limit int) ([]ProcNet, error) {
+	conns, err := gnet.Connections("all")
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[int32]*ProcNet{}
+	for _, c := range conns {
+		if c.Pid <= 0 {
+			continue
+		}
+		pn := m[c.Pid]
+		if pn == nil {
+			pn = &ProcNet{PID: c.Pid}
+			m[c.Pid] = pn
+		}
+		pn.ConnCount++
+		if c.Status == "LISTEN" {
+			pn.ListenCount++
+		}
+	}
+
+	out := make([]ProcNet, 0, len(m))
+	for pid, pn := range m {
+		if pn.Name == "" {
+			pn.Name = procName(pid)
+		}
+		out = append(out, *pn)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ConnCount != out[j].ConnCount {
+			return out[i].ConnCount > out[j].ConnCount
+		}
+		return out[i].PID < out[j].PID
+	})
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// ProcDetails is what ProcDetail finds out about one process.
+type ProcDetails struct {
+	PID     int32
+	Name    string
+	Cmdline string
+	User    string
+	FDs     int // open file descriptors, handles on Windows; -1 when unreadable
+	Sockets []Conn
+}
+
+// ProcInspector looks into a single process.
+type ProcInspector interface {
+	ProcDetail(pid int32) (ProcDetails, error)
+}
+
+func (Host) ProcDetail(pid int32) (ProcDetails, error) { return ProcDetail(pid) }
+
+// ProcDetail returns the command line, owner, descriptor count and the
+// internet sockets of process pid, listening ones first. Only a missing
+// process is an error; the rest is best-effort, as another user's
+// processes hide most of it without root.
+func ProcDetail(pid int32) (ProcDetails, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return ProcDetails{}, err
+	}
+	d := ProcDetails{PID: pid, FDs: -1}
+	d.Name, _ = p.Name()
+	d.Cmdline, _ = p.Cmdline()
+	d.User, _ = p.Username()
+	if n, err := p.NumFDs(); err == nil {
+		d.FDs = int(n)
+	}
+
+	conns, err := gnet.ConnectionsPid("inet", pid)
+	if err != nil {
+		return d, nil
+	}
+	for _, c := range conns {
+		sc := Conn{
+			Proto:   connProto(c),
+			Local:   fmt.Sprintf("%s:%d", c.Laddr.IP, c.Laddr.Port),
+			Status:  c.Status,
+			PID:     pid,
+			Process: d.Name,
+		}
+		if c.Raddr.Port != 0 {
+			sc.Remote = fmt.Sprintf("%s:%d", c.Raddr.IP, c.Raddr.Port)
+		}
+		d.Sockets = append(d.Sockets, sc)
+	}
+	sort.SliceStable(d.Sockets, func(i, j int) bool {
+		a, b := d.Sockets[i], d.Sockets[j]
+		if (a.Remote == "") != (b.Remote == "") {
+			return a.Remote == ""
+		}
+		if a.Local != b.Local {
+			return a.Local < b.Local
+		}
+		return a.Remote < b.Remote
+	})
+	return d, nil
+}
+
+// ProcSignaler stops processes.
+type ProcSignaler interface {
+	// StopProc asks process pid to exit, or kills it outright with force.
+	StopProc(pid int32, force bool) error
+}
+
+func (Host) StopProc(pid int32, force bool) error { return StopProc(pid, force) }
+
+// StopProc sends SIGTERM to process pid, or SIGKILL with force. Windows has
+// no signals; both terminate the process there.
+func StopProc(pid int32, force bool) error {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return err
+	}
+	if force {
+		return p.Kill()
+	}
+	return p.Terminate()
+}
@@ -0,0 +1,128 @@
+package probe
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NICQueues are the hardware queues of an interface and the interrupts
+// that serve them. A multi-queue NIC spreads its traffic over them, and
+// only as well as their interrupts are spread over the CPUs.
+type NICQueues struct {
+	RX, TX int
+	IRQs   []QueueIRQ // by number
+}
+
+// QueueIRQ is one interrupt of a NIC; most drivers have one per queue or
+// per RX/TX pair, and one more for the link.
+type QueueIRQ struct {
+	IRQ       int
+	Name      string // as in /proc/interrupts: eth0-TxRx-3, mlx5_comp3@pci:0000:3b:00.0
+	Count     uint64 // taken on all CPUs since boot
+	Affinity  string // the CPUs it may run on, as a list: 0-3,8
+	Effective string // the one(s) it does run on; "" on kernels that don't say
+}
+
+// QueueReader reads the queues of an interface.
+type QueueReader interface {
+	Queues(iface string) (NICQueues, error)
+}
+
+// Queues counts the rx-N and tx-N queues in sysfs and finds the interrupts
+// of the interface in /proc/interrupts, by the MSI vectors of its device
+// or by name, with their affinity from /proc/irq. Linux only; virtual
+// interfaces have queues but no interrupts.
+func (Host) Queues(iface string) (NICQueues, error) { return Queues(iface) }
+
+func Queues(iface string) (NICQueues, error) {
+	var q NICQueues
+	dir := filepath.Join(sysRoot, "class/net", iface)
+	ents, err := os.ReadDir(filepath.Join(dir, "queues"))
+	if err != nil {
+		return q, err
+	}
+	for _, e := range ents {
+		switch {
+		case strings.HasPrefix(e.Name(), "rx-"):
+			q.RX++
+		case strings.HasPrefix(e.Name(), "tx-"):
+			q.TX++
+		}
+	}
+
+	msi := map[int]bool{}
+	if ents, err := os.ReadDir(filepath.Join(dir, "device/msi_irqs")); err == nil {
+		for _, e := range ents {
+			if n, err := strconv.Atoi(e.Name()); err == nil {
+				msi[n] = true
+			}
+		}
+	}
+	f, err := os.Open(procRoot + "/interrupts")
+	if err != nil {
+		return q, nil
+	}
+	defer f.Close()
+	q.IRQs = parseInterrupts(f, func(irq int, name string) bool {
+		return msi[irq] || name == iface || strings.HasPrefix(name, iface+"-")
+	})
+	for i := range q.IRQs {
+		base := filepath.Join(procRoot, "irq", strconv.Itoa(q.IRQs[i].IRQ))
+		q.IRQs[i].Affinity = readTrimmed(filepath.Join(base, "smp_affinity_list"))
+		q.IRQs[i].Effective = readTrimmed(filepath.Join(base, "effective_affinity_list"))
+	}
+	return q, nil
+}
+
+// parseInterrupts reads the numbered interrupts of /proc/interrupts that
+// keep says are wanted:
+//
+//	           CPU0       CPU1
+//	 45:    1204551          0  IR-PCI-MSI 524289-edge      eth0-TxRx-0
+//	NMI:          0          0   Non-maskable interrupts
+func parseInterrupts(r io.Reader, keep func(irq int, name string) bool) []QueueIRQ {
+	var out []QueueIRQ
+	cpus := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fs := strings.Fields(sc.Text())
+		if len(fs) == 0 {
+			continue
+		}
+		if cpus == 0 {
+			cpus = len(fs)
+			continue
+		}
+		irq, err := strconv.Atoi(strings.TrimSuffix(fs[0], ":"))
+		if err != nil || len(fs) < 2+cpus {
+			continue
+		}
+		name := fs[len(fs)-1]
+		if !keep(irq, name) {
+			continue
+		}
+		q := QueueIRQ{IRQ: irq, Name: name}
+		for _, c := range fs[1 : 1+cpus] {
+			n, _ := strconv.ParseUint(c, 10, 64)
+			q.Count += n
+		}
+		out = append(out, q)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IRQ < out[j].IRQ })
+	return out
+}
+
+// readTrimmed is the content of a one-line file, or "" when it can't be
+// read.
+func readTrimmed(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
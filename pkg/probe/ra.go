@@ -0,0 +1,156 @@
+package probe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+const icmp6RouterAdvert = 134
+
+// RouterAdvert is a received IPv6 router advertisement.
+type RouterAdvert struct {
+	Router     string // link-local source address
+	Iface      string
+	ReceivedAt time.Time
+
+	HopLimit   uint8
+	Managed    bool // M flag: addresses via DHCPv6
+	Other      bool // O flag: other config via DHCPv6
+	Preference string
+	Lifetime   time.Duration // zero: not a default router
+
+	Prefixes  []RAPrefix
+	MTU       uint32
+	RDNSS     []string
+	SourceMAC string
+}
+
+type RAPrefix struct {
+	Prefix     string
+	OnLink     bool
+	Autonomous bool // usable for SLAAC
+	Valid      time.Duration
+	Preferred  time.Duration
+}
+
+// RAReader delivers router advertisements as they arrive.
+type RAReader interface {
+	// NextRA blocks until the next advertisement is received.
+	NextRA() (RouterAdvert, error)
+}
+
+// RAMonitor passively listens for router advertisements on all interfaces.
+// It needs a raw ICMPv6 socket, i.e. root or CAP_NET_RAW.
+type RAMonitor struct {
+	once sync.Once
+	conn *net.IPConn
+	err  error
+	buf  []byte
+}
+
+func NewRAMonitor() *RAMonitor {
+	return &RAMonitor{buf: make([]byte, 1500)}
+}
+
+func (r *RAMonitor) NextRA() (RouterAdvert, error) {
+	r.once.Do(func() {
+		r.conn, r.err = net.ListenIP("ip6:ipv6-icmp", &net.IPAddr{IP: net.IPv6unspecified})
+		if r.err != nil {
+			r.err = fmt.Errorf("ra monitor: %w", r.err)
+		}
+	})
+	if r.err != nil {
+		return RouterAdvert{}, r.err
+	}
+
+	for {
+		n, src, err := r.conn.ReadFromIP(r.buf)
+		if err != nil {
+			return RouterAdvert{}, err
+		}
+		ra, err := ParseRouterAdvert(r.buf[:n])
+		if err != nil {
+			// other ICMPv6 traffic or a malformed RA
+			continue
+		}
+		ra.Router, ra.Iface = src.IP.String(), src.Zone
+		ra.ReceivedAt = time.Now()
+		return ra, nil
+	}
+}
+
+// Close stops the monitor; a blocked NextRA returns an error.
+func (r *RAMonitor) Close() error {
+	if r.conn == nil {
+		return nil
+	}
+	return r.conn.Close()
+}
+
+// ParseRouterAdvert decodes an ICMPv6 router advertisement (RFC 4861),
+// starting at the ICMPv6 header.
+func ParseRouterAdvert(b []byte) (RouterAdvert, error) {
+	if len(b) < 16 || b[0] != icmp6RouterAdvert || b[1] != 0 {
+		return RouterAdvert{}, errors.New("not a router advertisement")
+	}
+
+	ra := RouterAdvert{
+		HopLimit:   b[4],
+		Managed:    b[5]&0x80 != 0,
+		Other:      b[5]&0x40 != 0,
+		Preference: raPreference(b[5] >> 3 & 0x3),
+		Lifetime:   time.Duration(binary.BigEndian.Uint16(b[6:8])) * time.Second,
+	}
+
+	opts := b[16:]
+	for len(opts) >= 2 {
+		typ, l := opts[0], int(opts[1])*8
+		if l == 0 || l > len(opts) {
+			return RouterAdvert{}, errors.New("router advertisement: bad option length")
+		}
+		o := opts[:l]
+		switch {
+		case typ == 1 && l >= 8: // source link-layer address
+			ra.SourceMAC = net.HardwareAddr(o[2:8]).String()
+		case typ == 3 && l == 32: // prefix information
+			ra.Prefixes = append(ra.Prefixes, RAPrefix{
+				Prefix:     fmt.Sprintf("%s/%d", net.IP(o[16:32]), o[2]),
+				OnLink:     o[3]&0x80 != 0,
+				Autonomous: o[3]&0x40 != 0,
+				Valid:      raLifetime(binary.BigEndian.Uint32(o[4:8])),
+				Preferred:  raLifetime(binary.BigEndian.Uint32(o[8:12])),
+			})
+		case typ == 5 && l == 8: // MTU
+			ra.MTU = binary.BigEndian.Uint32(o[4:8])
+		case typ == 25 && l >= 24: // recursive DNS servers
+			for a := o[8:]; len(a) >= 16; a = a[16:] {
+				ra.RDNSS = append(ra.RDNSS, net.IP(a[:16]).String())
+			}
+		}
+		opts = opts[l:]
+	}
+	return ra, nil
+}
+
+func raPreference(p byte) string {
+	switch p {
+	case 1:
+		return "high"
+	case 3:
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
+// raLifetime maps the all-ones "infinity" value to -1.
+func raLifetime(s uint32) time.Duration {
+	if s == 0xffffffff {
+		return -1
+	}
+	return time.Duration(s) * time.Second
+}
@@ -0,0 +1,167 @@
+package probe
+
+import (
+	"cmp"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ResolverCacheSize is how many addresses a Resolver remembers.
+	ResolverCacheSize = 4096
+	// ResolverTTL is how long an answer is kept before it is looked up
+	// again.
+	ResolverTTL = time.Hour
+	// ResolverWorkers is how many lookups run at once.
+	ResolverWorkers = 8
+	// ResolverTimeout bounds a single reverse lookup.
+	ResolverTimeout = 2 * time.Second
+)
+
+// AddrResolver does reverse DNS lookups.
+type AddrResolver interface {
+	LookupAddr(ctx context.Context, ip string) ([]string, error)
+}
+
+// LookupAddr asks the system resolver for the PTR names of ip.
+func (Host) LookupAddr(ctx context.Context, ip string) ([]string, error) {
+	return net.DefaultResolver.LookupAddr(ctx, ip)
+}
+
+// Resolved is a finished lookup; Names is empty when there was no answer.
+type Resolved struct {
+	IP    string
+	Names []string
+}
+
+// Resolver turns addresses into host names in the background. Name answers
+// from the "rdns" Cache and queues misses for a fixed pool of workers, so
+// the caller never waits on DNS; finished lookups are announced on Done.
+// Failures are cached like answers, so an address is looked up only once
+// while it stays in the cache.
+type Resolver struct {
+	src     AddrResolver
+	workers int
+	cache   *Cache[string, []string]
+
+	mu      sync.Mutex
+	pending map[string]bool // queued or being looked up
+
+	start sync.Once
+	queue chan string
+	done  chan Resolved
+}
+
+// NewResolver returns a Resolver backed by src, keeping up to size
+// addresses for ResolverTTL and running up to workers lookups at once.
+// Zero values use the defaults above, or those set with ConfigureCache.
+func NewResolver(src AddrResolver, size, workers int) *Resolver {
+	if workers <= 0 {
+		workers = ResolverWorkers
+	}
+	cache := NewCache[string, []string]("rdns", CacheConfig{TTL: ResolverTTL, Size: cmp.Or(max(size, 0), ResolverCacheSize)})
+	return &Resolver{
+		src:     src,
+		workers: workers,
+		cache:   cache,
+		pending: map[string]bool{},
+		queue:   make(chan string, 4*workers),
+		done:    make(chan Resolved, cache.size),
+	}
+}
+
+// Cached returns the names found for ip, and whether ip was looked up at
+// all.
+func (r *Resolver) Cached(ip string) ([]string, bool) {
+	return r.cache.Get(ip)
+}
+
+// Name returns the first name of ip from the cache. On a miss it queues a
+// lookup and returns "", false; the answer turns up on Done.
+func (r *Resolver) Name(ip string) (string, bool) {
+	names, ok := r.Cached(ip)
+	if !ok {
+		r.Request(ip)
+		return "", false
+	}
+	if len(names) == 0 {
+		return "", true
+	}
+	return names[0], true
+}
+
+// Request queues a lookup of ip unless it is cached or already queued.
+// When the queue is full the request is dropped; asking again later
+// retries it.
+func (r *Resolver) Request(ip string) {
+	if ip == "" {
+		return
+	}
+	r.start.Do(r.run)
+
+	r.mu.Lock()
+	if r.pending[ip] || r.cache.Contains(ip) {
+		r.mu.Unlock()
+		return
+	}
+	r.pending[ip] = true
+	r.mu.Unlock()
+
+	select {
+	case r.queue <- ip:
+	default:
+		r.mu.Lock()
+		delete(r.pending, ip)
+		r.mu.Unlock()
+	}
+}
+
+// Resolve looks ip up right away unless it is cached, for callers that
+// already run off the UI path.
+func (r *Resolver) Resolve(ip string) []string {
+	if names, ok := r.Cached(ip); ok {
+		return names
+	}
+	return r.lookup(ip).Names
+}
+
+// Done delivers queued lookups as they finish. Answers nobody reads are
+// dropped once the backlog is full; they stay in the cache.
+func (r *Resolver) Done() <-chan Resolved {
+	return r.done
+}
+
+func (r *Resolver) run() {
+	for i := 0; i < r.workers; i++ {
+		go func() {
+			for ip := range r.queue {
+				res := r.lookup(ip)
+				select {
+				case r.done <- res:
+				default:
+				}
+			}
+		}()
+	}
+}
+
+func (r *Resolver) lookup(ip string) Resolved {
+	ctx, cancel := context.WithTimeout(context.Background(), ResolverTimeout)
+	defer cancel()
+	answer, _ := r.src.LookupAddr(ctx, ip)
+	names := make([]string, 0, len(answer))
+	for _, n := range answer {
+		// PTR answers are fully qualified
+		names = append(names, strings.TrimSuffix(n, "."))
+	}
+	res := Resolved{IP: ip, Names: names}
+
+	r.cache.Put(ip, names)
+	r.mu.Lock()
+	delete(r.pending, ip)
+	r.mu.Unlock()
+	return res
+}
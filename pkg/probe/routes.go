@@ -0,0 +1,166 @@
+package probe
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	rtfUp     = 0x0001
+	rtfReject = 0x0200
+)
+
+// Route is an entry of the main routing table.
+type Route struct {
+	Family  string // "inet" or "inet6"
+	Dst     string // CIDR, "0.0.0.0/0" / "::/0" for default routes
+	Gateway string // empty for directly connected routes
+	Iface   string
+	Metric  int
+}
+
+// IsDefault reports whether r is a default route.
+func (r Route) IsDefault() bool {
+	return r.Dst == "0.0.0.0/0" || r.Dst == "::/0"
+}
+
+func (r Route) String() string {
+	s := r.Dst
+	if r.Gateway != "" {
+		s += " via " + r.Gateway
+	}
+	return s + " dev " + r.Iface
+}
+
+// RouteReader reads the routing table.
+type RouteReader interface {
+	Routes() ([]Route, error)
+}
+
+// Routes returns the main IPv4 and IPv6 routing tables (Linux procfs).
+// Rejected and down routes are skipped.
+func (Host) Routes() ([]Route, error) { return Routes() }
+
+func Routes() ([]Route, error) {
+	v4, err := readRoutes4(procRoot + "/net/route")
+	if err != nil {
+		return nil, err
+	}
+	v6, _ := readRoutes6(procRoot + "/net/ipv6_route")
+	out := append(v4, v6...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Family != out[j].Family {
+			return out[i].Family < out[j].Family
+		}
+		if out[i].Metric != out[j].Metric {
+			return out[i].Metric < out[j].Metric
+		}
+		return out[i].Dst < out[j].Dst
+	})
+	return out, nil
+}
+
+// DefaultRoutes filters the default routes out of rs.
+func DefaultRoutes(rs []Route) []Route {
+	var out []Route
+	for _, r := range rs {
+		if r.IsDefault() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func readRoutes4(path string) ([]Route, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("routes: %w", err)
+	}
+	defer f.Close()
+
+	var out []Route
+	sc := bufio.NewScanner(f)
+	sc.Scan() // header
+	for sc.Scan() {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask ...
+		fs := strings.Fields(sc.Text())
+		if len(fs) < 8 {
+			continue
+		}
+		flags, _ := strconv.ParseUint(fs[3], 16, 32)
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+		dst, gw, mask := hexIPv4(fs[1]), hexIPv4(fs[2]), hexIPv4(fs[7])
+		if dst == nil || gw == nil || mask == nil {
+			continue
+		}
+		ones, _ := net.IPMask(mask).Size()
+		r := Route{
+			Family: "inet",
+			Dst:    fmt.Sprintf("%s/%d", dst, ones),
+			Iface:  fs[0],
+		}
+		if !gw.Equal(net.IPv4zero.To4()) {
+			r.Gateway = gw.String()
+		}
+		r.Metric, _ = strconv.Atoi(fs[6])
+		out = append(out, r)
+	}
+	return out, sc.Err()
+}
+
+func readRoutes6(path string) ([]Route, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []Route
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// dst dstlen src srclen nexthop metric refcnt use flags iface
+		fs := strings.Fields(sc.Text())
+		if len(fs) < 10 {
+			continue
+		}
+		flags, _ := strconv.ParseUint(fs[8], 16, 32)
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+		dst, _ := hex.DecodeString(fs[0])
+		nh, _ := hex.DecodeString(fs[4])
+		plen, _ := strconv.ParseUint(fs[1], 16, 8)
+		metric, _ := strconv.ParseUint(fs[5], 16, 32)
+		if len(dst) != net.IPv6len || len(nh) != net.IPv6len {
+			continue
+		}
+		r := Route{
+			Family: "inet6",
+			Dst:    fmt.Sprintf("%s/%d", net.IP(dst), plen),
+			Iface:  fs[9],
+			Metric: int(metric),
+		}
+		if !net.IP(nh).IsUnspecified() {
+			r.Gateway = net.IP(nh).String()
+		}
+		out = append(out, r)
+	}
+	return out, sc.Err()
+}
+
+// hexIPv4 decodes the little-endian hex addresses used by /proc/net/route.
+func hexIPv4(s string) net.IP {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 4 {
+		return nil
+	}
+	return net.IPv4(b[3], b[2], b[1], b[0]).To4()
+}
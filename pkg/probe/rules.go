@@ -0,0 +1,184 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Rule is a policy routing rule (Linux `ip rule`).
+type Rule struct {
+	Family   string // "inet" or "inet6"
+	Priority int
+	Selector string // e.g. "from all fwmark 0xca6c", as printed by ip rule
+	Table    string // table looked up, empty for non-lookup actions
+	Action   string // "lookup", "goto", "blackhole", "unreachable", "prohibit", …
+
+	// summary of the looked up table
+	Routes  int
+	Default string // "via 10.0.0.1 dev wg0", empty if the table has no default route
+}
+
+// RuleReader reads policy routing rules.
+type RuleReader interface {
+	Rules() ([]Rule, error)
+}
+
+// Rules lists policy routing rules for both families, lowest priority first,
+// together with a summary of the table each rule selects. It needs the ip
+// command from iproute2.
+func (Host) Rules() ([]Rule, error) { return Rules() }
+
+func Rules() ([]Rule, error) {
+	var out []Rule
+	for _, fam := range []string{"inet", "inet6"} {
+		flag := "-4"
+		if fam == "inet6" {
+			flag = "-6"
+		}
+
+		var raw []ipRule
+		if err := ipJSON(&raw, flag, "rule", "show"); err != nil {
+			if fam == "inet6" {
+				// IPv6 may be disabled; the v4 rules are still useful
+				continue
+			}
+			return nil, err
+		}
+		var routes []ipRoute
+		_ = ipJSON(&routes, flag, "route", "show", "table", "all")
+
+		for _, r := range raw {
+			rule := Rule{
+				Family:   fam,
+				Priority: r.Priority,
+				Selector: r.selector(),
+				Action:   "lookup",
+				Table:    r.Table,
+			}
+			if r.Action != "" {
+				rule.Action = r.Action
+			}
+			if rule.Action == "goto" {
+				rule.Table = ""
+			}
+			if rule.Table != "" {
+				rule.Routes, rule.Default = summarizeTable(routes, rule.Table)
+			}
+			out = append(out, rule)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Priority != out[j].Priority {
+			return out[i].Priority < out[j].Priority
+		}
+		return out[i].Family < out[j].Family
+	})
+	return out, nil
+}
+
+type ipRule struct {
+	Priority int    `json:"priority"`
+	Not      bool   `json:"not"`
+	Src      string `json:"src"`
+	SrcLen   int    `json:"srclen"`
+	Dst      string `json:"dst"`
+	DstLen   int    `json:"dstlen"`
+	IIf      string `json:"iif"`
+	OIf      string `json:"oif"`
+	FwMark   string `json:"fwmark"`
+	Mask     string `json:"fwmask"`
+	UIDStart *int   `json:"uid_start"`
+	UIDEnd   *int   `json:"uid_end"`
+	IPProto  string `json:"ipproto"`
+	Table    string `json:"table"`
+	Action   string `json:"action"`
+}
+
+func (r ipRule) selector() string {
+	var parts []string
+	if r.Not {
+		parts = append(parts, "not")
+	}
+	parts = append(parts, "from "+prefix(r.Src, r.SrcLen))
+	if r.Dst != "" {
+		parts = append(parts, "to "+prefix(r.Dst, r.DstLen))
+	}
+	if r.IIf != "" {
+		parts = append(parts, "iif "+r.IIf)
+	}
+	if r.OIf != "" {
+		parts = append(parts, "oif "+r.OIf)
+	}
+	if r.FwMark != "" {
+		mark := r.FwMark
+		if r.Mask != "" {
+			mark += "/" + r.Mask
+		}
+		parts = append(parts, "fwmark "+mark)
+	}
+	if r.UIDStart != nil && r.UIDEnd != nil {
+		parts = append(parts, fmt.Sprintf("uidrange %d-%d", *r.UIDStart, *r.UIDEnd))
+	}
+	if r.IPProto != "" {
+		parts = append(parts, "ipproto "+r.IPProto)
+	}
+	return strings.Join(parts, " ")
+}
+
+func prefix(addr string, n int) string {
+	if addr == "" || addr == "all" || n == 0 || strings.Contains(addr, "/") {
+		if addr == "" {
+			return "all"
+		}
+		return addr
+	}
+	return fmt.Sprintf("%s/%d", addr, n)
+}
+
+type ipRoute struct {
+	Type    string `json:"type"`
+	Dst     string `json:"dst"`
+	Gateway string `json:"gateway"`
+	Dev     string `json:"dev"`
+	Table   string `json:"table"`
+}
+
+// summarizeTable counts the routes of table and describes its default route.
+func summarizeTable(routes []ipRoute, table string) (n int, def string) {
+	for _, r := range routes {
+		t := r.Table
+		if t == "" {
+			t = "main"
+		}
+		if t != table {
+			continue
+		}
+		n++
+		if r.Dst == "default" && (r.Type == "" || r.Type == "unicast") && def == "" {
+			if r.Gateway != "" {
+				def = "via " + r.Gateway + " "
+			}
+			def += "dev " + r.Dev
+		}
+	}
+	return n, def
+}
+
+func ipJSON(v any, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "ip", append([]string{"-j"}, args...)...).Output()
+	if err != nil {
+		return fmt.Errorf("ip %s: %w", strings.Join(args, " "), err)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return json.Unmarshal(out, v)
+}
@@ -0,0 +1,192 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SockOpts are the options of a socket that show from outside the process
+// owning it, through Linux's ss; -1 where unknown. SO_REUSEADDR and
+// TCP_NODELAY don't show at all.
+type SockOpts struct {
+	Found bool // ss listed the socket; nothing else is known without it
+
+	RcvBuf, SndBuf int // SO_RCVBUF and SO_SNDBUF, in bytes
+	Drops          int // packets dropped before the program read them
+
+	// Keepalive is whether a keepalive timer runs, which it does on an
+	// idle connection with SO_KEEPALIVE; KeepaliveNext is when it fires.
+	Keepalive     bool
+	KeepaliveNext time.Duration
+	SysKeepalive  KeepaliveTimes
+
+	V6Only int // IPV6_V6ONLY, 0 or 1; -1 on IPv4
+	// Shared counts the listeners bound to the same address and port,
+	// which takes SO_REUSEPORT on all of them; 0 for a connection.
+	Shared int
+
+	// from tcp_info
+	Congestion string   // cubic, bbr, …
+	Flags      []string // negotiated: ts, sack, ecn, wscale:7,7, …
+	MSS        int
+	RTT        time.Duration // smoothed; connections only
+}
+
+// KeepaliveTimes are the system's keepalive defaults, which a socket keeps
+// unless it sets TCP_KEEPIDLE, TCP_KEEPINTVL or TCP_KEEPCNT; zero when
+// unknown.
+type KeepaliveTimes struct {
+	Idle, Interval time.Duration
+	Probes         int
+}
+
+// ConnInspector looks into the socket of a single connection.
+type ConnInspector interface {
+	InspectConn(c Conn) (SockOpts, error)
+}
+
+func (Host) InspectConn(c Conn) (SockOpts, error) { return InspectConn(c) }
+
+// InspectConn reads the options of c's socket from ss. Like InspectPort it
+// is best-effort: a connection ss doesn't list, because it closed since or
+// there is no ss, is not an error, but leaves Found false.
+func InspectConn(c Conn) (SockOpts, error) {
+	o := newSockOpts()
+	lip, lport := SplitLocal(c.Local)
+	rip, rport := SplitLocal(c.Remote)
+	out, err := ssOpts(c.Proto, "-Hanoemi", "sport = :"+lport+" and dport = :"+rport)
+	if err != nil {
+		return o, nil
+	}
+	sc := bufio.NewScanner(strings.NewReader(string(out)))
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 5 || !ssAddrMatches(f[3], net.ParseIP(lip)) || !ssAddrMatches(f[4], net.ParseIP(rip)) {
+			continue
+		}
+		o.parse(f[5:], strings.Contains(lip, ":"))
+		break
+	}
+	return o, nil
+}
+
+func newSockOpts() SockOpts {
+	return SockOpts{RcvBuf: -1, SndBuf: -1, Drops: -1, V6Only: -1, MSS: -1, RTT: -1, SysKeepalive: sysKeepalive()}
+}
+
+// ssOpts runs ss with flags for proto's sockets matching filter, one line
+// per socket.
+func ssOpts(proto, flags, filter string) ([]byte, error) {
+	p := "-t"
+	if strings.HasPrefix(proto, "udp") {
+		p = "-u"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return exec.CommandContext(ctx, "ss", flags+"O", p, filter).Output()
+}
+
+// congestion lists the TCP congestion control names ss may print.
+var congestion = map[string]bool{
+	"reno": true, "cubic": true, "bbr": true, "bbr2": true, "bic": true, "dctcp": true, "highspeed": true, "htcp": true,
+	"hybla": true, "illinois": true, "lp": true, "nv": true, "scalable": true, "vegas": true, "veno": true, "westwood": true, "yeah": true, "cdg": true,
+}
+
+// parse reads the fields ss -eomi prints after the addresses, e.g.
+//
+//	timer:(keepalive,9min59sec,0) uid:1000 ino:4711 sk:9 v6only:0 <->
+//	skmem:(r0,rb131072,t0,tb87040,f0,w0,o0,bl0,d0) ts sack cubic wscale:7,7
+//	rto:204 rtt:3.5/1.2 ato:40 mss:1448 …
+func (o *SockOpts) parse(fields []string, v6 bool) {
+	o.Found = true
+	if v6 {
+		o.V6Only = 0
+	}
+	for _, x := range fields {
+		k, v, _ := strings.Cut(x, ":")
+		switch {
+		case k == "skmem":
+			for _, kv := range strings.Split(strings.Trim(v, "()"), ",") {
+				name := strings.TrimRight(kv, "0123456789")
+				n, err := strconv.Atoi(kv[len(name):])
+				if err != nil {
+					continue
+				}
+				switch name {
+				case "rb":
+					o.RcvBuf = n
+				case "tb":
+					o.SndBuf = n
+				case "d":
+					o.Drops = n
+				}
+			}
+		case k == "timer":
+			parts := strings.Split(strings.Trim(v, "()"), ",")
+			if len(parts) >= 2 && parts[0] == "keepalive" {
+				o.Keepalive = true
+				o.KeepaliveNext = parseSSTimer(parts[1])
+			}
+		case k == "v6only":
+			o.V6Only, _ = strconv.Atoi(v)
+		case k == "mss":
+			o.MSS, _ = strconv.Atoi(v)
+		case k == "rtt":
+			srtt, _, _ := strings.Cut(v, "/")
+			if ms, err := strconv.ParseFloat(srtt, 64); err == nil {
+				o.RTT = time.Duration(ms * float64(time.Millisecond))
+			}
+		case k == "wscale":
+			o.Flags = append(o.Flags, x)
+		case x == "ts" || x == "sack" || x == "ecn" || x == "ecnseen" || x == "fastopen":
+			o.Flags = append(o.Flags, x)
+		case congestion[x]:
+			o.Congestion = x
+		}
+	}
+}
+
+// parseSSTimer reads ss's timer times: "9min59sec", "45sec", "800ms", and
+// "5.200ms" for 5.2 seconds.
+func parseSSTimer(s string) time.Duration {
+	var d time.Duration
+	if m, rest, ok := strings.Cut(s, "min"); ok {
+		n, _ := strconv.Atoi(m)
+		d, s = time.Duration(n)*time.Minute, rest
+	}
+	switch {
+	case strings.HasSuffix(s, "sec"):
+		n, _ := strconv.Atoi(strings.TrimSuffix(s, "sec"))
+		d += time.Duration(n) * time.Second
+	case strings.Contains(s, "."):
+		secs, _ := strconv.ParseFloat(strings.TrimSuffix(s, "ms"), 64)
+		d += time.Duration(secs * float64(time.Second))
+	case strings.HasSuffix(s, "ms"):
+		n, _ := strconv.Atoi(strings.TrimSuffix(s, "ms"))
+		d += time.Duration(n) * time.Millisecond
+	}
+	return d
+}
+
+// sysKeepalive reads the keepalive defaults from /proc/sys.
+func sysKeepalive() KeepaliveTimes {
+	read := func(name string) int {
+		b, err := os.ReadFile("/proc/sys/net/ipv4/tcp_keepalive_" + name)
+		if err != nil {
+			return 0
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(string(b)))
+		return n
+	}
+	return KeepaliveTimes{
+		Idle:     time.Duration(read("time")) * time.Second,
+		Interval: time.Duration(read("intvl")) * time.Second,
+		Probes:   read("probes"),
+	}
+}
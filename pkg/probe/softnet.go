@@ -0,0 +1,106 @@
+package probe
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SoftnetStat is the work one CPU did for the network stack since boot:
+// what the NET_RX softirq took off the NICs' queues, and what it couldn't.
+type SoftnetStat struct {
+	CPU       int
+	Processed uint64 // packets handed up the stack
+	Dropped   uint64 // lost to a full backlog queue (net.core.netdev_max_backlog)
+	Squeezed  uint64 // times a run stopped with work left (net.core.netdev_budget)
+
+	NetRx, NetTx uint64 // softirqs run, from /proc/softirqs
+}
+
+// SoftnetReader reads the per-CPU network stack counters.
+type SoftnetReader interface {
+	Softnet() ([]SoftnetStat, error)
+}
+
+// Softnet reads /proc/net/softnet_stat and the NET_RX and NET_TX rows of
+// /proc/softirqs, one entry per online CPU. Linux only.
+func (Host) Softnet() ([]SoftnetStat, error) { return Softnet() }
+
+func Softnet() ([]SoftnetStat, error) {
+	f, err := os.Open("/proc/net/softnet_stat")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []SoftnetStat
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// hex columns: processed, dropped, time_squeeze, then since 5.10 or
+		// so, in the 14th, the CPU; before, the lines of offline CPUs are
+		// left out and the line number is only a guess
+		c := strings.Fields(sc.Text())
+		if len(c) < 3 {
+			continue
+		}
+		hex := func(i int) uint64 {
+			n, _ := strconv.ParseUint(c[i], 16, 64)
+			return n
+		}
+		s := SoftnetStat{CPU: len(out), Processed: hex(0), Dropped: hex(1), Squeezed: hex(2)}
+		if len(c) >= 14 {
+			s.CPU = int(hex(13))
+		}
+		out = append(out, s)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	rx, tx := softirqs()
+	for i := range out {
+		out[i].NetRx, out[i].NetTx = rx[out[i].CPU], tx[out[i].CPU]
+	}
+	return out, nil
+}
+
+// softirqs reads the NET_RX and NET_TX counts of /proc/softirqs by CPU:
+//
+//	              CPU0       CPU1
+//	NET_TX:        120         96
+//	NET_RX:      81234      64730
+func softirqs() (rx, tx map[int]uint64) {
+	rx, tx = map[int]uint64{}, map[int]uint64{}
+	f, err := os.Open("/proc/softirqs")
+	if err != nil {
+		return rx, tx
+	}
+	defer f.Close()
+
+	var cpus []int
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fs := strings.Fields(sc.Text())
+		if len(fs) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fs[0], "CPU") {
+			for _, h := range fs {
+				n, _ := strconv.Atoi(strings.TrimPrefix(h, "CPU"))
+				cpus = append(cpus, n)
+			}
+			continue
+		}
+		into := map[string]map[int]uint64{"NET_RX:": rx, "NET_TX:": tx}[fs[0]]
+		if into == nil {
+			continue
+		}
+		for i, v := range fs[1:] {
+			if i < len(cpus) {
+				into[cpus[i]], _ = strconv.ParseUint(v, 10, 64)
+			}
+		}
+	}
+	return rx, tx
+}
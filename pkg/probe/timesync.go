@@ -0,0 +1,216 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSync is how well the system clock is kept in time.
+type TimeSync struct {
+	Daemon string // "chrony", "ntpd" or "timesyncd"; "" when only the kernel flag was read
+	Known  bool   // a daemon or the kernel answered, so Synced means something
+	Synced bool   // the daemon, or the kernel, considers the clock synchronized
+	Server string // current time source, if known
+
+	// Offset is the local clock minus true time, estimated by the daemon
+	// or by asking OffsetFrom directly; only valid when HasOffset.
+	Offset     time.Duration
+	HasOffset  bool
+	OffsetFrom string
+}
+
+// TimeSyncChecker reports the clock synchronization status.
+type TimeSyncChecker interface {
+	// TimeSync asks the local time daemons. When none of them knows the
+	// offset and server is set, it asks that NTP server instead.
+	TimeSync(server string) (TimeSync, error)
+}
+
+// TimeSync asks chrony (chronyc), then ntpd (ntpq), then systemd
+// (timedatectl, which covers systemd-timesyncd and otherwise reports the
+// kernel's sync flag), and uses the first that answers.
+func (Host) TimeSync(server string) (TimeSync, error) { return CheckTimeSync(server) }
+
+func CheckTimeSync(server string) (TimeSync, error) {
+	var ts TimeSync
+	var err error
+	found := false
+	for _, check := range []func() (TimeSync, error){chronyTracking, ntpqStatus, timedatectlStatus} {
+		ts, err = check()
+		if err == nil {
+			found = true
+			break
+		}
+	}
+	if !found && server == "" {
+		return TimeSync{}, errors.New("timesync: no chronyc, ntpq or timedatectl")
+	}
+	if !ts.HasOffset && server != "" {
+		off, serr := SNTPOffset(server)
+		if serr != nil && !found {
+			return TimeSync{}, serr
+		}
+		if serr == nil {
+			ts.Offset, ts.HasOffset, ts.OffsetFrom = off, true, server
+		}
+	}
+	return ts, nil
+}
+
+func runTimeCmd(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return string(out), nil
+}
+
+// chronyTracking parses `chronyc -c tracking`:
+//
+//	A29FC87B,ntp1.example.net,3,1700000000.123,0.000012,...,64.4,Normal
+//
+// The fifth field is the correction still to apply (positive: the clock
+// is slow), the last one the leap status.
+func chronyTracking() (TimeSync, error) {
+	out, err := runTimeCmd("chronyc", "-c", "tracking")
+	if err != nil {
+		return TimeSync{}, err
+	}
+	f := strings.Split(strings.TrimSpace(out), ",")
+	if len(f) < 14 {
+		return TimeSync{}, fmt.Errorf("chronyc: unexpected output %q", out)
+	}
+	ts := TimeSync{Daemon: "chrony", Known: true, Server: f[1]}
+	stratum, _ := strconv.Atoi(f[2])
+	ts.Synced = f[len(f)-1] != "Not synchronised" && stratum > 0 && stratum < 16
+	if corr, err := strconv.ParseFloat(f[4], 64); err == nil && ts.Synced {
+		ts.Offset, ts.HasOffset, ts.OffsetFrom = -seconds(corr), true, "chrony"
+	}
+	return ts, nil
+}
+
+// ntpqStatus parses the system variables from `ntpq -c rv`, e.g.
+// "leap=00, stratum=2, refid=192.0.2.1, offset=-0.215, ...". The offset
+// is in milliseconds; leap=11 means unsynchronized.
+func ntpqStatus() (TimeSync, error) {
+	out, err := runTimeCmd("ntpq", "-c", "rv")
+	if err != nil {
+		return TimeSync{}, err
+	}
+	vars := map[string]string{}
+	for _, kv := range strings.FieldsFunc(out, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if k, v, ok := strings.Cut(strings.TrimSpace(kv), "="); ok {
+			vars[k] = strings.Trim(v, `"`)
+		}
+	}
+	leap, ok := vars["leap"]
+	if !ok {
+		return TimeSync{}, fmt.Errorf("ntpq: no leap variable in %q", out)
+	}
+	ts := TimeSync{Daemon: "ntpd", Known: true, Server: vars["refid"], Synced: leap != "11"}
+	if ms, err := strconv.ParseFloat(vars["offset"], 64); err == nil && ts.Synced {
+		ts.Offset, ts.HasOffset, ts.OffsetFrom = seconds(ms/1000), true, "ntpd"
+	}
+	return ts, nil
+}
+
+// timedatectlStatus reads NTPSynchronized from `timedatectl show`, then
+// the offset and server from `timedatectl timesync-status`, which only
+// works while systemd-timesyncd runs:
+//
+//	Server: 192.0.2.1 (ntp.ubuntu.com)
+//	Offset: -1.234ms
+func timedatectlStatus() (TimeSync, error) {
+	out, err := runTimeCmd("timedatectl", "show", "-p", "NTPSynchronized", "--value")
+	if err != nil {
+		return TimeSync{}, err
+	}
+	ts := TimeSync{Known: true, Synced: strings.TrimSpace(out) == "yes"}
+
+	out, err = runTimeCmd("timedatectl", "timesync-status")
+	if err != nil {
+		return ts, nil
+	}
+	ts.Daemon = "timesyncd"
+	sc := bufio.NewScanner(strings.NewReader(out))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		switch strings.TrimSpace(k) {
+		case "Server":
+			if _, name, ok := strings.Cut(v, "("); ok {
+				v = strings.TrimSuffix(name, ")")
+			}
+			ts.Server = v
+		case "Offset":
+			// Go durations don't take a leading "+"
+			if d, err := time.ParseDuration(strings.TrimPrefix(v, "+")); err == nil && ts.Synced {
+				ts.Offset, ts.HasOffset, ts.OffsetFrom = d, true, "timesyncd"
+			}
+		}
+	}
+	return ts, nil
+}
+
+// ntpEpoch is 1900-01-01 in Unix seconds.
+const ntpEpoch = 2208988800
+
+// SNTPOffset asks server (host or host:port) for the time once and
+// returns the local clock minus the server's, corrected for the round
+// trip as in RFC 4330.
+func SNTPOffset(server string) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+	conn, err := net.DialTimeout("udp", server, 2*time.Second)
+	if err != nil {
+		return 0, fmt.Errorf("sntp: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	req := make([]byte, 48)
+	req[0] = 0x23 // LI 0, version 4, mode 3 (client)
+	t1 := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, fmt.Errorf("sntp: %w", err)
+	}
+	resp := make([]byte, 48)
+	n, err := conn.Read(resp)
+	t4 := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("sntp: %w", err)
+	}
+	if n < 48 || resp[0]&0x7 != 4 || resp[1] == 0 {
+		// not a server reply, or stratum 0: a kiss-o'-death
+		return 0, fmt.Errorf("sntp: %s sent no usable answer", server)
+	}
+	t2, t3 := ntpTime(resp[32:40]), ntpTime(resp[40:48])
+	// server minus local, halfway through the round trip
+	theirs := (t2.Sub(t1) + t3.Sub(t4)) / 2
+	return -theirs, nil
+}
+
+func ntpTime(b []byte) time.Time {
+	sec := int64(binary.BigEndian.Uint32(b[:4])) - ntpEpoch
+	frac := int64(binary.BigEndian.Uint32(b[4:]))
+	return time.Unix(sec, frac*1e9>>32)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s * float64(time.Second)))
+}
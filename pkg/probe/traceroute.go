@@ -0,0 +1,131 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TraceMaxHops is how far a trace goes before giving up.
+	TraceMaxHops = 30
+	// TraceProbes is how many probes go out per hop.
+	TraceProbes = 3
+)
+
+// Hop is one TTL step of a traceroute.
+type Hop struct {
+	TTL  int
+	Addr string          // first router that answered; "" when none did
+	RTTs []time.Duration // of the answered probes
+	Sent int
+	Note string // traceroute's annotation, e.g. "!H" (host unreachable)
+}
+
+// Lost is how many probes of the hop went unanswered.
+func (h Hop) Lost() int { return h.Sent - len(h.RTTs) }
+
+// Tracer traces the route to a host.
+type Tracer interface {
+	// Traceroute sends every hop to hops as soon as it is known and
+	// returns when the trace ends or ctx is done.
+	Traceroute(ctx context.Context, host string, hops chan<- Hop) error
+}
+
+func (Host) Traceroute(ctx context.Context, host string, hops chan<- Hop) error {
+	return Traceroute(ctx, host, hops)
+}
+
+// Traceroute runs the system traceroute without name lookups and reads
+// its output a line at a time, so hops arrive as they finish.
+func Traceroute(ctx context.Context, host string, hops chan<- Hop) error {
+	if strings.HasPrefix(host, "-") {
+		return fmt.Errorf("traceroute: bad host %q", host)
+	}
+	cmd := exec.CommandContext(ctx, "traceroute", "-n",
+		"-q", strconv.Itoa(TraceProbes), "-w", "2", "-m", strconv.Itoa(TraceMaxHops), host)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("traceroute: %w", err)
+	}
+
+	sc := bufio.NewScanner(out)
+	for sc.Scan() {
+		h, ok := parseHop(sc.Text())
+		if !ok {
+			continue
+		}
+		select {
+		case hops <- h:
+		case <-ctx.Done():
+		}
+	}
+	err = cmd.Wait()
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// e.g. "example.invalid: Name or service not known"; BSD
+			// traceroute writes its "traceroute to" header there too
+			for _, line := range strings.Split(stderr.String(), "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "traceroute to ") {
+					return errors.New(line)
+				}
+			}
+		}
+		return fmt.Errorf("traceroute: %w", err)
+	}
+	return nil
+}
+
+// parseHop reads a hop line of traceroute -n:
+//
+//	1  192.168.1.1  0.512 ms  0.401 ms  0.389 ms
+//	2  * * *
+//	3  10.0.0.1  5.1 ms 10.0.0.2  6.0 ms !H  5.9 ms
+//
+// Probes may be answered by different routers; the first one is kept.
+func parseHop(line string) (Hop, bool) {
+	f := strings.Fields(line)
+	if len(f) < 2 {
+		return Hop{}, false
+	}
+	ttl, err := strconv.Atoi(f[0])
+	if err != nil {
+		return Hop{}, false
+	}
+	h := Hop{TTL: ttl}
+	for i := 1; i < len(f); i++ {
+		switch tok := f[i]; {
+		case tok == "*":
+			h.Sent++
+		case strings.HasPrefix(tok, "!"):
+			h.Note = tok
+		case i+1 < len(f) && f[i+1] == "ms":
+			ms, err := strconv.ParseFloat(tok, 64)
+			if err != nil {
+				return Hop{}, false
+			}
+			h.RTTs = append(h.RTTs, time.Duration(ms*float64(time.Millisecond)))
+			h.Sent++
+			i++
+		default:
+			if h.Addr == "" {
+				h.Addr = tok
+			}
+		}
+	}
+	return h, true
+}
@@ -0,0 +1,258 @@
+package probe
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+// Tunnel is a port forward or SOCKS proxy run by a local process.
+type Tunnel struct {
+	Kind    string // "local" (ssh -L), "remote" (ssh -R), "dynamic" (ssh -D) or "socks"
+	Listen  string // where connections enter; on the server for "remote"
+	Target  string // where they leave to; "" when the client decides (SOCKS) or unknown
+	Server  string // the ssh destination; for forwards into sshd, the session user
+	PID     int32
+	Process string // "ssh", "autossh" when supervised, "sshd" or the SOCKS daemon
+	Active  bool   // a matching local listener exists; always false for "remote"
+}
+
+// TunnelLister finds tunnels. ports are the current listeners, used to
+// confirm forwards and to catch ones set up outside the command line.
+type TunnelLister interface {
+	Tunnels(ports []ListenPort) ([]Tunnel, error)
+}
+
+func (Host) Tunnels(ports []ListenPort) ([]Tunnel, error) { return Tunnels(ports) }
+
+// socksDaemons are processes whose listeners are SOCKS proxies.
+var socksDaemons = map[string]bool{
+	"microsocks": true, "danted": true, "sockd": true, "3proxy": true,
+	"ss-local": true, "sslocal": true, "gost": true, "tor": true,
+}
+
+// Tunnels reads the command lines of ssh clients (-L, -R, -D), and guesses
+// the rest from listeners: unexplained ssh listeners are forwards from
+// ssh_config, listeners of sshd session processes are clients' -R
+// forwards, and listeners of known SOCKS daemons are proxies.
+func Tunnels(ports []ListenPort) ([]Tunnel, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	byPID := map[int32][]ListenPort{}
+	for _, p := range ports {
+		if p.Proto == "tcp" && p.PID > 0 {
+			byPID[p.PID] = append(byPID[p.PID], p)
+		}
+	}
+
+	var out []Tunnel
+	for _, p := range procs {
+		name, err := p.Name()
+		if err != nil {
+			continue
+		}
+		listens := byPID[p.Pid]
+		switch {
+		case name == "ssh":
+			args, err := p.CmdlineSlice()
+			if err != nil || len(args) == 0 {
+				continue
+			}
+			label := "ssh"
+			if pp, err := p.Parent(); err == nil {
+				if n, _ := pp.Name(); n == "autossh" {
+					label = "autossh"
+				}
+			}
+			out = append(out, sshTunnels(p.Pid, label, args[1:], listens)...)
+		case name == "sshd" || name == "sshd-session":
+			if len(listens) == 0 {
+				continue
+			}
+			// the daemon itself is "sshd ..." or "sshd: /usr/sbin/sshd
+			// [listener]"; sessions are "sshd: user@pts/0" or similar
+			cmd, _ := p.Cmdline()
+			user, ok := strings.CutPrefix(cmd, "sshd: ")
+			if name == "sshd" && (!ok || strings.HasPrefix(user, "/")) {
+				continue
+			}
+			user, _, _ = strings.Cut(user, " ")
+			user, _, _ = strings.Cut(user, "@")
+			for _, l := range listens {
+				out = append(out, Tunnel{Kind: "remote", Listen: l.Local, Server: user, PID: p.Pid, Process: "sshd", Active: true})
+			}
+		case socksDaemons[name]:
+			for _, l := range listens {
+				out = append(out, Tunnel{Kind: "socks", Listen: l.Local, PID: p.Pid, Process: name, Active: true})
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].PID != out[j].PID {
+			return out[i].PID < out[j].PID
+		}
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Listen < out[j].Listen
+	})
+	return out, nil
+}
+
+// sshArgOpts are the ssh options that take an argument.
+const sshArgOpts = "BbcDEeFIiJLlmOoPpQRSWw"
+
+// sshTunnels parses an ssh argument list. Forwards whose local port has a
+// listener are marked active; listeners no option accounts for come from
+// ssh_config or a control-master request and get an unknown target.
+func sshTunnels(pid int32, label string, args []string, listens []ListenPort) []Tunnel {
+	var (
+		out  []Tunnel
+		dest string
+	)
+	add := func(opt byte, spec string) {
+		t, ok := parseForward(opt, spec)
+		if !ok {
+			return
+		}
+		t.PID, t.Process = pid, label
+		out = append(out, t)
+	}
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			if i+1 < len(args) && dest == "" {
+				dest = args[i+1]
+			}
+			break
+		}
+		if !strings.HasPrefix(a, "-") || a == "-" {
+			if dest == "" {
+				dest = a
+			}
+			// the remote command follows the destination
+			break
+		}
+		// flags can be bundled, e.g. -fNL 8080:host:80
+		for j := 1; j < len(a); j++ {
+			c := a[j]
+			if !strings.ContainsRune(sshArgOpts, rune(c)) {
+				continue
+			}
+			val := a[j+1:]
+			if val == "" && i+1 < len(args) {
+				i++
+				val = args[i]
+			}
+			if c == 'L' || c == 'R' || c == 'D' {
+				add(c, val)
+			}
+			break
+		}
+	}
+
+	used := map[string]bool{}
+	for i := range out {
+		out[i].Server = dest
+		if out[i].Kind == "remote" {
+			continue
+		}
+		_, port := SplitLocal(out[i].Listen)
+		for _, l := range listens {
+			if _, lp := SplitLocal(l.Local); lp == port {
+				out[i].Active = true
+				used[l.Local] = true
+			}
+		}
+	}
+	for _, l := range listens {
+		if !used[l.Local] {
+			out = append(out, Tunnel{Kind: "local", Listen: l.Local, Server: dest, PID: pid, Process: label, Active: true})
+		}
+	}
+	return out
+}
+
+// parseForward parses the argument of -L, -R or -D:
+// [bind:]port:host:hostport, [bind:]port:socket, or [bind:]port for -D
+// and the reverse SOCKS form of -R.
+// IPv6 addresses are bracketed; '/' may replace ':' throughout.
+func parseForward(opt byte, spec string) (Tunnel, bool) {
+	f := splitForward(spec)
+	if len(f) == 0 || f[0] == "" {
+		return Tunnel{}, false
+	}
+	t := Tunnel{Kind: map[byte]string{'L': "local", 'R': "remote", 'D': "dynamic"}[opt]}
+
+	bind := "localhost"
+	if opt == 'D' {
+		// [bind:]port
+		if len(f) == 2 {
+			bind, f = f[0], f[1:]
+		}
+		t.Listen = joinForward(bind, f[0])
+		return t, len(f) == 1
+	}
+	switch len(f) {
+	case 4:
+		bind, f = f[0], f[1:]
+	case 3:
+	case 2:
+		// port:socket or socket:host... either way one side is a path
+		t.Listen, t.Target = f[0], f[1]
+		return t, true
+	case 1:
+		// -R port is a SOCKS proxy on the server
+		if opt != 'R' {
+			return Tunnel{}, false
+		}
+		t.Listen = joinForward(bind, f[0])
+		return t, true
+	default:
+		return Tunnel{}, false
+	}
+	t.Listen = joinForward(bind, f[0])
+	t.Target = joinForward(f[1], f[2])
+	return t, true
+}
+
+func joinForward(host, port string) string {
+	if host == "" || host == "*" {
+		host = "0.0.0.0"
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
+
+// splitForward splits on ':' outside brackets, or on '/' for the
+// 8080/::1/80 form; a '/' that starts a socket path is not a separator.
+func splitForward(s string) []string {
+	sep := byte(':')
+	if strings.Contains(s, "/") && !strings.HasPrefix(s, "/") && !strings.Contains(s, ":/") {
+		sep = '/'
+	}
+	var (
+		out   []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case sep:
+			if depth == 0 {
+				out = append(out, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, s[start:])
+}
@@ -0,0 +1,66 @@
+package probe
+
+import (
+	"fmt"
+	"time"
+)
+
+// HumanBytesPerSec formats a byte rate using IEC units, e.g. "1.5 MiB/s".
+func HumanBytesPerSec(bps float64) string {
+	// bps is bytes/sec
+	return humanSize(bps) + "/s"
+}
+
+// HumanBitsPerSec formats a byte rate in bits using SI units, the way
+// links are rated, e.g. "12.6 Mb/s".
+func HumanBitsPerSec(bps float64) string {
+	v := bps * 8
+	if v < 1000 {
+		return fmt.Sprintf("%.0f b/s", v)
+	}
+	div, exp := 1000.0, 0
+	for n := v / 1000; n >= 1000 && exp < 4; n /= 1000 {
+		div *= 1000
+		exp++
+	}
+	suffix := []string{"kb/s", "Mb/s", "Gb/s", "Tb/s", "Pb/s"}[exp]
+	return fmt.Sprintf("%.1f %s", v/div, suffix)
+}
+
+// HumanBytes formats a byte count using IEC units.
+func HumanBytes(n uint64) string {
+	return humanSize(float64(n))
+}
+
+func humanSize(v float64) string {
+	const unit = 1024.0
+	if v < unit {
+		return fmt.Sprintf("%.0f B", v)
+	}
+	div, exp := unit, 0
+	for n := v / unit; n >= unit && exp < 5; n /= unit {
+		div *= unit
+		exp++
+	}
+	suffix := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}[exp]
+	return fmt.Sprintf("%.1f %s", v/div, suffix)
+}
+
+// ClampHistory keeps at most the last max elements of s.
+func ClampHistory[T any](s []T, max int) []T {
+	if max <= 0 {
+		return s[:0]
+	}
+	if len(s) <= max {
+		return s
+	}
+	return s[len(s)-max:]
+}
+
+// Since is time.Since that returns 0 for the zero time.
+func Since(t time.Time) time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	return time.Since(t)
+}
@@ -0,0 +1,169 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WifiInfo is the link a wireless interface has to its access point.
+type WifiInfo struct {
+	Connected bool
+	SSID      string
+	BSSID     string  // the access point's MAC
+	Freq      int     // MHz
+	Channel   int     // from Freq; 0 when it is in no band known
+	Signal    int     // dBm; 0 when the driver doesn't say
+	RxRate    float64 // Mbit/s of the last frames; 0 when unknown
+	TxRate    float64
+}
+
+// WifiReader reads the link of a wireless interface.
+type WifiReader interface {
+	Wifi(iface string) (WifiInfo, error)
+}
+
+// ErrNoWifiTool means neither iw nor iwconfig is installed.
+var ErrNoWifiTool = errors.New("neither iw nor iwconfig found")
+
+// Wifi asks `iw dev <iface> link` (nl80211), or iwconfig where there is no
+// iw, which older systems and some out-of-tree drivers need.
+func (Host) Wifi(iface string) (WifiInfo, error) { return Wifi(iface) }
+
+func Wifi(iface string) (WifiInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "iw", "dev", iface, "link").Output()
+	if err == nil {
+		return parseIwLink(out), nil
+	}
+	if !errors.Is(err, exec.ErrNotFound) {
+		return WifiInfo{}, err
+	}
+	out, err = exec.CommandContext(ctx, "iwconfig", iface).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return WifiInfo{}, ErrNoWifiTool
+	}
+	if err != nil {
+		return WifiInfo{}, err
+	}
+	return parseIwconfig(out), nil
+}
+
+// parseIwLink reads
+//
+//	Connected to 3c:37:86:aa:bb:cc (on wlan0)
+//		SSID: duck
+//		freq: 5180
+//		signal: -52 dBm
+//		rx bitrate: 433.3 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 1
+//		tx bitrate: 390.0 MBit/s VHT-MCS 8 80MHz short GI VHT-NSS 1
+//
+// or "Not connected."
+func parseIwLink(out []byte) WifiInfo {
+	var w WifiInfo
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if rest, ok := strings.CutPrefix(line, "Connected to "); ok {
+			w.Connected = true
+			w.BSSID, _, _ = strings.Cut(rest, " ")
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		switch k {
+		case "SSID":
+			w.SSID = v
+		case "freq":
+			f, _ := strconv.ParseFloat(v, 64) // "5180.0" on newer iw
+			w.Freq = int(f)
+		case "signal":
+			w.Signal, _ = strconv.Atoi(strings.Fields(v + " ")[0])
+		case "rx bitrate":
+			w.RxRate = leadingFloat(v)
+		case "tx bitrate":
+			w.TxRate = leadingFloat(v)
+		}
+	}
+	w.Channel = WifiChannel(w.Freq)
+	return w
+}
+
+// parseIwconfig reads
+//
+//	wlan0     IEEE 802.11  ESSID:"duck"
+//	          Mode:Managed  Frequency:5.18 GHz  Access Point: 3C:37:86:AA:BB:CC
+//	          Bit Rate=433.3 Mb/s   Tx-Power=22 dBm
+//	          Link Quality=58/70  Signal level=-52 dBm
+//
+// which has no receive rate; the bit rate is the one sent at.
+func parseIwconfig(out []byte) WifiInfo {
+	var w WifiInfo
+	s := string(out)
+	field := func(key string) string {
+		_, v, ok := strings.Cut(s, key)
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(v)
+	}
+	if v := field("ESSID:"); strings.HasPrefix(v, `"`) {
+		w.SSID, _, _ = strings.Cut(v[1:], `"`)
+	}
+	if v := field("Access Point:"); v != "" {
+		bssid := strings.Fields(v)[0]
+		if strings.Count(bssid, ":") == 5 {
+			w.Connected, w.BSSID = true, strings.ToLower(bssid)
+		}
+	}
+	if v := field("Frequency:"); v != "" {
+		w.Freq = int(leadingFloat(v)*1000 + 0.5)
+	}
+	for _, key := range []string{"Bit Rate=", "Bit Rate:"} {
+		if v := field(key); v != "" {
+			w.TxRate = leadingFloat(v)
+		}
+	}
+	for _, key := range []string{"Signal level=", "Signal level:"} {
+		if v := field(key); strings.Contains(strings.SplitN(v, "\n", 2)[0], "dBm") {
+			w.Signal = int(leadingFloat(v))
+		}
+	}
+	w.Channel = WifiChannel(w.Freq)
+	return w
+}
+
+// leadingFloat parses the number a string starts with, 0 if none.
+func leadingFloat(s string) float64 {
+	end := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune("-+.0123456789", r) })
+	if end < 0 {
+		end = len(s)
+	}
+	f, _ := strconv.ParseFloat(s[:end], 64)
+	return f
+}
+
+// WifiChannel is the channel number of a frequency in MHz in the 2.4, 5 or
+// 6 GHz band, 0 outside them.
+func WifiChannel(freq int) int {
+	switch {
+	case freq == 2484:
+		return 14
+	case freq >= 2412 && freq <= 2472:
+		return (freq - 2407) / 5
+	case freq >= 5160 && freq <= 5885:
+		return (freq - 5000) / 5
+	case freq >= 5955 && freq <= 7115:
+		return (freq - 5950) / 5
+	}
+	return 0
+}
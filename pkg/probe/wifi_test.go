@@ -0,0 +1,82 @@
+package probe
+
+import "testing"
+
+func TestParseIwLink(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     WifiInfo
+	}{
+		{
+			"5 GHz",
+			`Connected to 3c:37:86:aa:bb:cc (on wlan0)
+	SSID: duck pond
+	freq: 5180
+	RX: 1234567 bytes (8910 packets)
+	TX: 765432 bytes (4321 packets)
+	signal: -52 dBm
+	rx bitrate: 433.3 MBit/s VHT-MCS 9 80MHz short GI VHT-NSS 1
+	tx bitrate: 390.0 MBit/s VHT-MCS 8 80MHz short GI VHT-NSS 1
+`,
+			WifiInfo{Connected: true, SSID: "duck pond", BSSID: "3c:37:86:aa:bb:cc", Freq: 5180, Channel: 36, Signal: -52, RxRate: 433.3, TxRate: 390},
+		},
+		{
+			"newer iw, 2.4 GHz",
+			"Connected to 3c:37:86:aa:bb:cd (on wlan0)\n\tSSID: duck\n\tfreq: 2437.0\n\tsignal: -70 dBm\n\ttx bitrate: 72.2 MBit/s MCS 7 short GI\n",
+			WifiInfo{Connected: true, SSID: "duck", BSSID: "3c:37:86:aa:bb:cd", Freq: 2437, Channel: 6, Signal: -70, TxRate: 72.2},
+		},
+		{"not connected", "Not connected.\n", WifiInfo{}},
+	}
+	for _, tt := range tests {
+		if got := parseIwLink([]byte(tt.in)); got != tt.want {
+			t.Errorf("%s: got %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseIwconfig(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     WifiInfo
+	}{
+		{
+			"connected",
+			`wlan0     IEEE 802.11  ESSID:"duck pond"
+          Mode:Managed  Frequency:5.18 GHz  Access Point: 3C:37:86:AA:BB:CC
+          Bit Rate=433.3 Mb/s   Tx-Power=22 dBm
+          Retry short limit:7   RTS thr:off   Fragment thr:off
+          Link Quality=58/70  Signal level=-52 dBm
+`,
+			WifiInfo{Connected: true, SSID: "duck pond", BSSID: "3c:37:86:aa:bb:cc", Freq: 5180, Channel: 36, Signal: -52, TxRate: 433.3},
+		},
+		{
+			"signal as a quality, colon syntax",
+			`wlan0     IEEE 802.11bgn  ESSID:"duck"
+          Mode:Managed  Frequency:2.412 GHz  Access Point: 3C:37:86:AA:BB:CD
+          Bit Rate:54 Mb/s
+          Link Quality:70/100  Signal level:60/100
+`,
+			WifiInfo{Connected: true, SSID: "duck", BSSID: "3c:37:86:aa:bb:cd", Freq: 2412, Channel: 1, TxRate: 54},
+		},
+		{
+			"not associated",
+			`wlan0     IEEE 802.11  ESSID:off/any
+          Mode:Managed  Access Point: Not-Associated   Tx-Power=22 dBm
+`,
+			WifiInfo{},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseIwconfig([]byte(tt.in)); got != tt.want {
+			t.Errorf("%s: got %+v\nwant %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWifiChannel(t *testing.T) {
+	for freq, want := range map[int]int{2412: 1, 2472: 13, 2484: 14, 5180: 36, 5825: 165, 5955: 1, 6415: 93, 900: 0, 5000: 0} {
+		if got := WifiChannel(freq); got != want {
+			t.Errorf("WifiChannel(%d) = %d, want %d", freq, got, want)
+		}
+	}
+}